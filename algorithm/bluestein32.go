@@ -0,0 +1,57 @@
+package algorithm
+
+import "math"
+
+// Bluestein32 implements the Bluestein (chirp-Z) FFT algorithm for complex64
+// See Bluestein for a description of the algorithm
+type Bluestein32 struct {
+	direction Direction
+	czt       *ChirpZ32
+}
+
+// NewBluestein32 creates a complex64 Bluestein FFT instance for arbitrary size
+func NewBluestein32(length int, direction Direction) *Bluestein32 {
+	// Chirp sequence: w[k] = exp(-i*π*k²/N), computed from the exact integer k²
+	czt := newChirpZ32(length, length, func(k int) complex64 {
+		// angle = -π*k²/N (or +π for inverse)
+		angle := -math.Pi * float64(k*k) / float64(length)
+		if direction == Inverse {
+			angle = -angle
+		}
+		return complex(float32(math.Cos(angle)), float32(math.Sin(angle)))
+	})
+
+	return &Bluestein32{direction: direction, czt: czt}
+}
+
+// WithScale returns a copy of b whose output is multiplied by scale
+// The factor joins the inverse FFT normalization in the final chirp multiply.
+func (b *Bluestein32) WithScale(scale float64) *Bluestein32 {
+	return &Bluestein32{direction: b.direction, czt: b.czt.WithScale(scale)}
+}
+
+func (b *Bluestein32) Len() int                  { return b.czt.Len() }
+func (b *Bluestein32) Direction() Direction      { return b.direction }
+func (b *Bluestein32) InplaceScratchLen() int    { return b.czt.ScratchLen() }
+func (b *Bluestein32) OutOfPlaceScratchLen() int { return b.czt.ScratchLen() }
+func (b *Bluestein32) ImmutableScratchLen() int  { return b.czt.ScratchLen() }
+
+// The convolution always runs in the padded scratch buffer and reads the input
+// exactly once, so in-place, out-of-place and immutable execution are the same
+func (b *Bluestein32) ProcessWithScratch(buffer, scratch []complex64) {
+	b.ProcessImmutable(buffer, buffer, scratch)
+}
+
+func (b *Bluestein32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	b.ProcessImmutable(input, output, scratch)
+}
+
+func (b *Bluestein32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.ImmutableScratchLen())
+
+	// Process each chunk of size b.Len()
+	length := b.Len()
+	for i := 0; i < len(input); i += length {
+		b.czt.Process(input[i:i+length], output[i:i+length], scratch)
+	}
+}
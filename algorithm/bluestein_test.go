@@ -164,3 +164,17 @@ func TestBluesteinRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+// TestBluesteinZeroAllocations checks that the reported scratch covers the inner FFTs
+func TestBluesteinZeroAllocations(t *testing.T) {
+	bluestein := NewBluestein(1031, Forward)
+	buffer := make([]complex128, 1031)
+	scratch := make([]complex128, bluestein.InplaceScratchLen())
+
+	allocs := testing.AllocsPerRun(10, func() {
+		bluestein.ProcessWithScratch(buffer, scratch)
+	})
+	if allocs != 0 {
+		t.Errorf("ProcessWithScratch allocated %.0f times per run", allocs)
+	}
+}
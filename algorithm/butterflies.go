@@ -25,12 +25,24 @@ func (b *Butterfly2) Process(buffer []complex128) {
 }
 
 func (b *Butterfly2) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	if useAVX2 {
+		butterfly2AVX2(buffer, buffer)
+		return
+	}
 	for i := 0; i < len(buffer); i += 2 {
 		b.performFft(buffer[i : i+2])
 	}
 }
 
 func (b *Butterfly2) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	if useAVX2 {
+		butterfly2AVX2(output, input)
+		return
+	}
 	for i := 0; i < len(input); i += 2 {
 		b.performFftOutOfPlace(input[i:i+2], output[i:i+2])
 	}
@@ -77,12 +89,16 @@ func (b *Butterfly3) Process(buffer []complex128) {
 }
 
 func (b *Butterfly3) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 3 {
 		b.performFft(buffer[i : i+3])
 	}
 }
 
 func (b *Butterfly3) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 3 {
 		b.performFftOutOfPlace(input[i:i+3], output[i:i+3])
 	}
@@ -139,12 +155,24 @@ func (b *Butterfly4) Process(buffer []complex128) {
 }
 
 func (b *Butterfly4) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	if useAVX2 {
+		butterfly4AVX2(buffer, buffer, b.direction)
+		return
+	}
 	for i := 0; i < len(buffer); i += 4 {
 		b.performFft(buffer[i : i+4])
 	}
 }
 
 func (b *Butterfly4) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	if useAVX2 {
+		butterfly4AVX2(output, input, b.direction)
+		return
+	}
 	for i := 0; i < len(input); i += 4 {
 		b.performFftOutOfPlace(input[i:i+4], output[i:i+4])
 	}
@@ -242,12 +270,24 @@ func (b *Butterfly8) Process(buffer []complex128) {
 }
 
 func (b *Butterfly8) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	if useAVX2 {
+		butterfly8AVX2(buffer, buffer, b.direction)
+		return
+	}
 	for i := 0; i < len(buffer); i += 8 {
 		b.performFft(buffer[i : i+8])
 	}
 }
 
 func (b *Butterfly8) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	if useAVX2 {
+		butterfly8AVX2(output, input, b.direction)
+		return
+	}
 	for i := 0; i < len(input); i += 8 {
 		b.performFftOutOfPlace(input[i:i+8], output[i:i+8])
 	}
@@ -330,12 +370,24 @@ func (b *Butterfly16) Process(buffer []complex128) {
 }
 
 func (b *Butterfly16) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	if useAVX2 {
+		butterfly16AVX2(buffer, buffer, b.direction)
+		return
+	}
 	for i := 0; i < len(buffer); i += 16 {
 		b.performFft(buffer[i : i+16])
 	}
 }
 
 func (b *Butterfly16) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	if useAVX2 {
+		butterfly16AVX2(output, input, b.direction)
+		return
+	}
 	for i := 0; i < len(input); i += 16 {
 		b.performFftOutOfPlace(input[i:i+16], output[i:i+16])
 	}
@@ -443,12 +495,24 @@ func (b *Butterfly32) Process(buffer []complex128) {
 }
 
 func (b *Butterfly32) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	if useAVX2 {
+		butterfly32AVX2(buffer, buffer, b.direction)
+		return
+	}
 	for i := 0; i < len(buffer); i += 32 {
 		b.performFft(buffer[i : i+32])
 	}
 }
 
 func (b *Butterfly32) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	if useAVX2 {
+		butterfly32AVX2(output, input, b.direction)
+		return
+	}
 	for i := 0; i < len(input); i += 32 {
 		b.performFftOutOfPlace(input[i:i+32], output[i:i+32])
 	}
@@ -560,12 +624,16 @@ func (b *Butterfly5) Process(buffer []complex128) {
 }
 
 func (b *Butterfly5) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 5 {
 		b.performFft(buffer[i : i+5])
 	}
 }
 
 func (b *Butterfly5) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 5 {
 		b.performFftOutOfPlace(input[i:i+5], output[i:i+5])
 	}
@@ -633,12 +701,16 @@ func (b *Butterfly6) Process(buffer []complex128) {
 }
 
 func (b *Butterfly6) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 6 {
 		b.performFft(buffer[i : i+6])
 	}
 }
 
 func (b *Butterfly6) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 6 {
 		b.performFftOutOfPlace(input[i:i+6], output[i:i+6])
 	}
@@ -712,12 +784,16 @@ func (b *Butterfly7) Process(buffer []complex128) {
 }
 
 func (b *Butterfly7) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 7 {
 		b.performFft(buffer[i : i+7])
 	}
 }
 
 func (b *Butterfly7) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 7 {
 		b.performFftOutOfPlace(input[i:i+7], output[i:i+7])
 	}
@@ -807,12 +883,16 @@ func (b *Butterfly9) Process(buffer []complex128) {
 }
 
 func (b *Butterfly9) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 9 {
 		b.performFft(buffer[i : i+9])
 	}
 }
 
 func (b *Butterfly9) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 9 {
 		b.performFftOutOfPlace(input[i:i+9], output[i:i+9])
 	}
@@ -909,12 +989,16 @@ func (b *Butterfly12) Process(buffer []complex128) {
 }
 
 func (b *Butterfly12) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 12 {
 		b.performFft(buffer[i : i+12])
 	}
 }
 
 func (b *Butterfly12) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 12 {
 		b.performFftOutOfPlace(input[i:i+12], output[i:i+12])
 	}
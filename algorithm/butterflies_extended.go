@@ -1,8 +1,11 @@
 package algorithm
 
-import "math"
-
 // This file contains additional butterfly implementations
+//
+// The prime sizes pair x[j] with x[n-j]. W^(jk) and W^(-jk) are conjugates, so
+// with p = x[j] + x[n-j] and q = x[j] - x[n-j], the outputs X[k] and X[n-k]
+// share the sums A = x[0] + Σ Re(W^jk)·p and B = Σ Im(W^jk)·q, and are A ± iB.
+// That is a quarter of the real multiplies of a plain DFT.
 
 // Butterfly11 implements a size-11 FFT (prime size)
 type Butterfly11 struct {
@@ -35,12 +38,16 @@ func (b *Butterfly11) Process(buffer []complex128) {
 }
 
 func (b *Butterfly11) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 11 {
 		b.performFft(buffer[i : i+11])
 	}
 }
 
 func (b *Butterfly11) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 11 {
 		b.performFftOutOfPlace(input[i:i+11], output[i:i+11])
 	}
@@ -51,22 +58,62 @@ func (b *Butterfly11) ProcessImmutable(input []complex128, output, scratch []com
 }
 
 func (b *Butterfly11) performFft(buffer []complex128) {
-	// For size 11 (prime), use DFT for now
-	// TODO: Implement optimized version with symmetry
-	temp := make([]complex128, 11)
-	copy(temp, buffer)
-	for k := 0; k < 11; k++ {
-		sum := complex(0, 0)
-		for j := 0; j < 11; j++ {
-			angle := -2.0 * math.Pi * float64(k*j) / 11.0
-			if b.direction == Inverse {
-				angle = -angle
-			}
-			tw := complex(math.Cos(angle), math.Sin(angle))
-			sum += temp[j] * tw
-		}
-		buffer[k] = sum
-	}
+	// Sums and differences of the symmetric pairs x[j], x[11-j]
+	x0 := buffer[0]
+	x1p, x1n := buffer[1]+buffer[10], buffer[1]-buffer[10]
+	x2p, x2n := buffer[2]+buffer[9], buffer[2]-buffer[9]
+	x3p, x3n := buffer[3]+buffer[8], buffer[3]-buffer[8]
+	x4p, x4n := buffer[4]+buffer[7], buffer[4]-buffer[7]
+	x5p, x5n := buffer[5]+buffer[6], buffer[5]-buffer[6]
+
+	// W^j for j = 1..5; the other twiddles are their conjugates
+	t1r, t1i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t2r, t2i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t3r, t3i := real(b.twiddles[2]), imag(b.twiddles[2])
+	t4r, t4i := real(b.twiddles[3]), imag(b.twiddles[3])
+	t5r, t5i := real(b.twiddles[4]), imag(b.twiddles[4])
+
+	buffer[0] = x0 + x1p + x2p + x3p + x4p + x5p
+
+	// Outputs 1 and 10
+	ar := real(x0) + t1r*real(x1p) + t2r*real(x2p) + t3r*real(x3p) + t4r*real(x4p) + t5r*real(x5p)
+	ai := imag(x0) + t1r*imag(x1p) + t2r*imag(x2p) + t3r*imag(x3p) + t4r*imag(x4p) + t5r*imag(x5p)
+	br := t1i*real(x1n) + t2i*real(x2n) + t3i*real(x3n) + t4i*real(x4n) + t5i*real(x5n)
+	bi := t1i*imag(x1n) + t2i*imag(x2n) + t3i*imag(x3n) + t4i*imag(x4n) + t5i*imag(x5n)
+	buffer[1] = complex(ar-bi, ai+br)
+	buffer[10] = complex(ar+bi, ai-br)
+
+	// Outputs 2 and 9
+	ar = real(x0) + t2r*real(x1p) + t4r*real(x2p) + t5r*real(x3p) + t3r*real(x4p) + t1r*real(x5p)
+	ai = imag(x0) + t2r*imag(x1p) + t4r*imag(x2p) + t5r*imag(x3p) + t3r*imag(x4p) + t1r*imag(x5p)
+	br = t2i*real(x1n) + t4i*real(x2n) - t5i*real(x3n) - t3i*real(x4n) - t1i*real(x5n)
+	bi = t2i*imag(x1n) + t4i*imag(x2n) - t5i*imag(x3n) - t3i*imag(x4n) - t1i*imag(x5n)
+	buffer[2] = complex(ar-bi, ai+br)
+	buffer[9] = complex(ar+bi, ai-br)
+
+	// Outputs 3 and 8
+	ar = real(x0) + t3r*real(x1p) + t5r*real(x2p) + t2r*real(x3p) + t1r*real(x4p) + t4r*real(x5p)
+	ai = imag(x0) + t3r*imag(x1p) + t5r*imag(x2p) + t2r*imag(x3p) + t1r*imag(x4p) + t4r*imag(x5p)
+	br = t3i*real(x1n) - t5i*real(x2n) - t2i*real(x3n) + t1i*real(x4n) + t4i*real(x5n)
+	bi = t3i*imag(x1n) - t5i*imag(x2n) - t2i*imag(x3n) + t1i*imag(x4n) + t4i*imag(x5n)
+	buffer[3] = complex(ar-bi, ai+br)
+	buffer[8] = complex(ar+bi, ai-br)
+
+	// Outputs 4 and 7
+	ar = real(x0) + t4r*real(x1p) + t3r*real(x2p) + t1r*real(x3p) + t5r*real(x4p) + t2r*real(x5p)
+	ai = imag(x0) + t4r*imag(x1p) + t3r*imag(x2p) + t1r*imag(x3p) + t5r*imag(x4p) + t2r*imag(x5p)
+	br = t4i*real(x1n) - t3i*real(x2n) + t1i*real(x3n) + t5i*real(x4n) - t2i*real(x5n)
+	bi = t4i*imag(x1n) - t3i*imag(x2n) + t1i*imag(x3n) + t5i*imag(x4n) - t2i*imag(x5n)
+	buffer[4] = complex(ar-bi, ai+br)
+	buffer[7] = complex(ar+bi, ai-br)
+
+	// Outputs 5 and 6
+	ar = real(x0) + t5r*real(x1p) + t1r*real(x2p) + t4r*real(x3p) + t2r*real(x4p) + t3r*real(x5p)
+	ai = imag(x0) + t5r*imag(x1p) + t1r*imag(x2p) + t4r*imag(x3p) + t2r*imag(x4p) + t3r*imag(x5p)
+	br = t5i*real(x1n) - t1i*real(x2n) + t4i*real(x3n) - t2i*real(x4n) + t3i*real(x5n)
+	bi = t5i*imag(x1n) - t1i*imag(x2n) + t4i*imag(x3n) - t2i*imag(x4n) + t3i*imag(x5n)
+	buffer[5] = complex(ar-bi, ai+br)
+	buffer[6] = complex(ar+bi, ai-br)
 }
 
 func (b *Butterfly11) performFftOutOfPlace(input, output []complex128) {
@@ -106,12 +153,16 @@ func (b *Butterfly13) Process(buffer []complex128) {
 }
 
 func (b *Butterfly13) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += 13 {
 		b.performFft(buffer[i : i+13])
 	}
 }
 
 func (b *Butterfly13) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += 13 {
 		b.performFftOutOfPlace(input[i:i+13], output[i:i+13])
 	}
@@ -122,22 +173,84 @@ func (b *Butterfly13) ProcessImmutable(input []complex128, output, scratch []com
 }
 
 func (b *Butterfly13) performFft(buffer []complex128) {
-	// For size 13 (prime), use DFT
-	// TODO: Implement optimized version with symmetry
-	temp := make([]complex128, 13)
-	copy(temp, buffer)
-	for k := 0; k < 13; k++ {
-		sum := complex(0, 0)
-		for j := 0; j < 13; j++ {
-			angle := -2.0 * math.Pi * float64(k*j) / 13.0
-			if b.direction == Inverse {
-				angle = -angle
-			}
-			tw := complex(math.Cos(angle), math.Sin(angle))
-			sum += temp[j] * tw
-		}
-		buffer[k] = sum
-	}
+	// Sums and differences of the symmetric pairs x[j], x[13-j]
+	x0 := buffer[0]
+	x1p, x1n := buffer[1]+buffer[12], buffer[1]-buffer[12]
+	x2p, x2n := buffer[2]+buffer[11], buffer[2]-buffer[11]
+	x3p, x3n := buffer[3]+buffer[10], buffer[3]-buffer[10]
+	x4p, x4n := buffer[4]+buffer[9], buffer[4]-buffer[9]
+	x5p, x5n := buffer[5]+buffer[8], buffer[5]-buffer[8]
+	x6p, x6n := buffer[6]+buffer[7], buffer[6]-buffer[7]
+
+	// W^j for j = 1..6; the other twiddles are their conjugates
+	t1r, t1i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t2r, t2i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t3r, t3i := real(b.twiddles[2]), imag(b.twiddles[2])
+	t4r, t4i := real(b.twiddles[3]), imag(b.twiddles[3])
+	t5r, t5i := real(b.twiddles[4]), imag(b.twiddles[4])
+	t6r, t6i := real(b.twiddles[5]), imag(b.twiddles[5])
+
+	buffer[0] = x0 + x1p + x2p + x3p + x4p + x5p + x6p
+
+	// Outputs 1 and 12
+	ar := real(x0) + t1r*real(x1p) + t2r*real(x2p) + t3r*real(x3p) + t4r*real(x4p) + t5r*real(x5p) +
+		t6r*real(x6p)
+	ai := imag(x0) + t1r*imag(x1p) + t2r*imag(x2p) + t3r*imag(x3p) + t4r*imag(x4p) + t5r*imag(x5p) +
+		t6r*imag(x6p)
+	br := t1i*real(x1n) + t2i*real(x2n) + t3i*real(x3n) + t4i*real(x4n) + t5i*real(x5n) + t6i*real(x6n)
+	bi := t1i*imag(x1n) + t2i*imag(x2n) + t3i*imag(x3n) + t4i*imag(x4n) + t5i*imag(x5n) + t6i*imag(x6n)
+	buffer[1] = complex(ar-bi, ai+br)
+	buffer[12] = complex(ar+bi, ai-br)
+
+	// Outputs 2 and 11
+	ar = real(x0) + t2r*real(x1p) + t4r*real(x2p) + t6r*real(x3p) + t5r*real(x4p) + t3r*real(x5p) +
+		t1r*real(x6p)
+	ai = imag(x0) + t2r*imag(x1p) + t4r*imag(x2p) + t6r*imag(x3p) + t5r*imag(x4p) + t3r*imag(x5p) +
+		t1r*imag(x6p)
+	br = t2i*real(x1n) + t4i*real(x2n) + t6i*real(x3n) - t5i*real(x4n) - t3i*real(x5n) - t1i*real(x6n)
+	bi = t2i*imag(x1n) + t4i*imag(x2n) + t6i*imag(x3n) - t5i*imag(x4n) - t3i*imag(x5n) - t1i*imag(x6n)
+	buffer[2] = complex(ar-bi, ai+br)
+	buffer[11] = complex(ar+bi, ai-br)
+
+	// Outputs 3 and 10
+	ar = real(x0) + t3r*real(x1p) + t6r*real(x2p) + t4r*real(x3p) + t1r*real(x4p) + t2r*real(x5p) +
+		t5r*real(x6p)
+	ai = imag(x0) + t3r*imag(x1p) + t6r*imag(x2p) + t4r*imag(x3p) + t1r*imag(x4p) + t2r*imag(x5p) +
+		t5r*imag(x6p)
+	br = t3i*real(x1n) + t6i*real(x2n) - t4i*real(x3n) - t1i*real(x4n) + t2i*real(x5n) + t5i*real(x6n)
+	bi = t3i*imag(x1n) + t6i*imag(x2n) - t4i*imag(x3n) - t1i*imag(x4n) + t2i*imag(x5n) + t5i*imag(x6n)
+	buffer[3] = complex(ar-bi, ai+br)
+	buffer[10] = complex(ar+bi, ai-br)
+
+	// Outputs 4 and 9
+	ar = real(x0) + t4r*real(x1p) + t5r*real(x2p) + t1r*real(x3p) + t3r*real(x4p) + t6r*real(x5p) +
+		t2r*real(x6p)
+	ai = imag(x0) + t4r*imag(x1p) + t5r*imag(x2p) + t1r*imag(x3p) + t3r*imag(x4p) + t6r*imag(x5p) +
+		t2r*imag(x6p)
+	br = t4i*real(x1n) - t5i*real(x2n) - t1i*real(x3n) + t3i*real(x4n) - t6i*real(x5n) - t2i*real(x6n)
+	bi = t4i*imag(x1n) - t5i*imag(x2n) - t1i*imag(x3n) + t3i*imag(x4n) - t6i*imag(x5n) - t2i*imag(x6n)
+	buffer[4] = complex(ar-bi, ai+br)
+	buffer[9] = complex(ar+bi, ai-br)
+
+	// Outputs 5 and 8
+	ar = real(x0) + t5r*real(x1p) + t3r*real(x2p) + t2r*real(x3p) + t6r*real(x4p) + t1r*real(x5p) +
+		t4r*real(x6p)
+	ai = imag(x0) + t5r*imag(x1p) + t3r*imag(x2p) + t2r*imag(x3p) + t6r*imag(x4p) + t1r*imag(x5p) +
+		t4r*imag(x6p)
+	br = t5i*real(x1n) - t3i*real(x2n) + t2i*real(x3n) - t6i*real(x4n) - t1i*real(x5n) + t4i*real(x6n)
+	bi = t5i*imag(x1n) - t3i*imag(x2n) + t2i*imag(x3n) - t6i*imag(x4n) - t1i*imag(x5n) + t4i*imag(x6n)
+	buffer[5] = complex(ar-bi, ai+br)
+	buffer[8] = complex(ar+bi, ai-br)
+
+	// Outputs 6 and 7
+	ar = real(x0) + t6r*real(x1p) + t1r*real(x2p) + t5r*real(x3p) + t2r*real(x4p) + t4r*real(x5p) +
+		t3r*real(x6p)
+	ai = imag(x0) + t6r*imag(x1p) + t1r*imag(x2p) + t5r*imag(x3p) + t2r*imag(x4p) + t4r*imag(x5p) +
+		t3r*imag(x6p)
+	br = t6i*real(x1n) - t1i*real(x2n) + t5i*real(x3n) - t2i*real(x4n) + t4i*real(x5n) - t3i*real(x6n)
+	bi = t6i*imag(x1n) - t1i*imag(x2n) + t5i*imag(x3n) - t2i*imag(x4n) + t4i*imag(x5n) - t3i*imag(x6n)
+	buffer[6] = complex(ar-bi, ai+br)
+	buffer[7] = complex(ar+bi, ai-br)
 }
 
 func (b *Butterfly13) performFftOutOfPlace(input, output []complex128) {
@@ -145,198 +258,1268 @@ func (b *Butterfly13) performFftOutOfPlace(input, output []complex128) {
 	b.performFft(output)
 }
 
-// Butterfly24 implements a size-24 FFT
-type Butterfly24 struct {
-	direction  Direction
-	butterfly4 *Butterfly4
-	butterfly6 *Butterfly6
+// Butterfly17 implements a size-17 FFT (prime size)
+type Butterfly17 struct {
+	direction Direction
+	twiddles  [8]complex128 // W1-W8 (W9-W16 are conjugates)
 }
 
-// NewButterfly24 creates a new Butterfly24 instance
-func NewButterfly24(direction Direction) *Butterfly24 {
-	return &Butterfly24{
-		direction:  direction,
-		butterfly4: NewButterfly4(direction),
-		butterfly6: NewButterfly6(direction),
+// NewButterfly17 creates a new Butterfly17 instance
+func NewButterfly17(direction Direction) *Butterfly17 {
+	return &Butterfly17{
+		direction: direction,
+		twiddles: [8]complex128{
+			twiddleFactor(1, 17, direction),
+			twiddleFactor(2, 17, direction),
+			twiddleFactor(3, 17, direction),
+			twiddleFactor(4, 17, direction),
+			twiddleFactor(5, 17, direction),
+			twiddleFactor(6, 17, direction),
+			twiddleFactor(7, 17, direction),
+			twiddleFactor(8, 17, direction),
+		},
 	}
 }
 
-func (b *Butterfly24) Len() int                  { return 24 }
-func (b *Butterfly24) Direction() Direction      { return b.direction }
-func (b *Butterfly24) InplaceScratchLen() int    { return 0 }
-func (b *Butterfly24) OutOfPlaceScratchLen() int { return 0 }
-func (b *Butterfly24) ImmutableScratchLen() int  { return 0 }
+func (b *Butterfly17) Len() int                  { return 17 }
+func (b *Butterfly17) Direction() Direction      { return b.direction }
+func (b *Butterfly17) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly17) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly17) ImmutableScratchLen() int  { return 0 }
 
-func (b *Butterfly24) Process(buffer []complex128) {
+func (b *Butterfly17) Process(buffer []complex128) {
 	b.ProcessWithScratch(buffer, nil)
 }
 
-func (b *Butterfly24) ProcessWithScratch(buffer, scratch []complex128) {
-	for i := 0; i < len(buffer); i += 24 {
-		b.performFft(buffer[i : i+24])
+func (b *Butterfly17) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 17 {
+		b.performFft(buffer[i : i+17])
 	}
 }
 
-func (b *Butterfly24) ProcessOutOfPlace(input, output, scratch []complex128) {
-	for i := 0; i < len(input); i += 24 {
-		b.performFftOutOfPlace(input[i:i+24], output[i:i+24])
+func (b *Butterfly17) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 17 {
+		b.performFftOutOfPlace(input[i:i+17], output[i:i+17])
 	}
 }
 
-func (b *Butterfly24) ProcessImmutable(input []complex128, output, scratch []complex128) {
+func (b *Butterfly17) ProcessImmutable(input []complex128, output, scratch []complex128) {
 	b.ProcessOutOfPlace(input, output, scratch)
 }
 
-func (b *Butterfly24) performFft(buffer []complex128) {
-	// For now, use DFT for size 24
-	// TODO: Implement proper mixed-radix 6x4 algorithm
-	dft := NewDft(24, b.direction)
-	temp := make([]complex128, 24)
-	copy(temp, buffer)
-	dft.performFftImmutable(temp, buffer, nil)
+func (b *Butterfly17) performFft(buffer []complex128) {
+	// Sums and differences of the symmetric pairs x[j], x[17-j]
+	x0 := buffer[0]
+	x1p, x1n := buffer[1]+buffer[16], buffer[1]-buffer[16]
+	x2p, x2n := buffer[2]+buffer[15], buffer[2]-buffer[15]
+	x3p, x3n := buffer[3]+buffer[14], buffer[3]-buffer[14]
+	x4p, x4n := buffer[4]+buffer[13], buffer[4]-buffer[13]
+	x5p, x5n := buffer[5]+buffer[12], buffer[5]-buffer[12]
+	x6p, x6n := buffer[6]+buffer[11], buffer[6]-buffer[11]
+	x7p, x7n := buffer[7]+buffer[10], buffer[7]-buffer[10]
+	x8p, x8n := buffer[8]+buffer[9], buffer[8]-buffer[9]
+
+	// W^j for j = 1..8; the other twiddles are their conjugates
+	t1r, t1i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t2r, t2i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t3r, t3i := real(b.twiddles[2]), imag(b.twiddles[2])
+	t4r, t4i := real(b.twiddles[3]), imag(b.twiddles[3])
+	t5r, t5i := real(b.twiddles[4]), imag(b.twiddles[4])
+	t6r, t6i := real(b.twiddles[5]), imag(b.twiddles[5])
+	t7r, t7i := real(b.twiddles[6]), imag(b.twiddles[6])
+	t8r, t8i := real(b.twiddles[7]), imag(b.twiddles[7])
+
+	buffer[0] = x0 + x1p + x2p + x3p + x4p + x5p + x6p + x7p + x8p
+
+	// Outputs 1 and 16
+	ar := real(x0) + t1r*real(x1p) + t2r*real(x2p) + t3r*real(x3p) + t4r*real(x4p) + t5r*real(x5p) +
+		t6r*real(x6p) + t7r*real(x7p) + t8r*real(x8p)
+	ai := imag(x0) + t1r*imag(x1p) + t2r*imag(x2p) + t3r*imag(x3p) + t4r*imag(x4p) + t5r*imag(x5p) +
+		t6r*imag(x6p) + t7r*imag(x7p) + t8r*imag(x8p)
+	br := t1i*real(x1n) + t2i*real(x2n) + t3i*real(x3n) + t4i*real(x4n) + t5i*real(x5n) + t6i*real(x6n) +
+		t7i*real(x7n) + t8i*real(x8n)
+	bi := t1i*imag(x1n) + t2i*imag(x2n) + t3i*imag(x3n) + t4i*imag(x4n) + t5i*imag(x5n) + t6i*imag(x6n) +
+		t7i*imag(x7n) + t8i*imag(x8n)
+	buffer[1] = complex(ar-bi, ai+br)
+	buffer[16] = complex(ar+bi, ai-br)
+
+	// Outputs 2 and 15
+	ar = real(x0) + t2r*real(x1p) + t4r*real(x2p) + t6r*real(x3p) + t8r*real(x4p) + t7r*real(x5p) +
+		t5r*real(x6p) + t3r*real(x7p) + t1r*real(x8p)
+	ai = imag(x0) + t2r*imag(x1p) + t4r*imag(x2p) + t6r*imag(x3p) + t8r*imag(x4p) + t7r*imag(x5p) +
+		t5r*imag(x6p) + t3r*imag(x7p) + t1r*imag(x8p)
+	br = t2i*real(x1n) + t4i*real(x2n) + t6i*real(x3n) + t8i*real(x4n) - t7i*real(x5n) - t5i*real(x6n) -
+		t3i*real(x7n) - t1i*real(x8n)
+	bi = t2i*imag(x1n) + t4i*imag(x2n) + t6i*imag(x3n) + t8i*imag(x4n) - t7i*imag(x5n) - t5i*imag(x6n) -
+		t3i*imag(x7n) - t1i*imag(x8n)
+	buffer[2] = complex(ar-bi, ai+br)
+	buffer[15] = complex(ar+bi, ai-br)
+
+	// Outputs 3 and 14
+	ar = real(x0) + t3r*real(x1p) + t6r*real(x2p) + t8r*real(x3p) + t5r*real(x4p) + t2r*real(x5p) +
+		t1r*real(x6p) + t4r*real(x7p) + t7r*real(x8p)
+	ai = imag(x0) + t3r*imag(x1p) + t6r*imag(x2p) + t8r*imag(x3p) + t5r*imag(x4p) + t2r*imag(x5p) +
+		t1r*imag(x6p) + t4r*imag(x7p) + t7r*imag(x8p)
+	br = t3i*real(x1n) + t6i*real(x2n) - t8i*real(x3n) - t5i*real(x4n) - t2i*real(x5n) + t1i*real(x6n) +
+		t4i*real(x7n) + t7i*real(x8n)
+	bi = t3i*imag(x1n) + t6i*imag(x2n) - t8i*imag(x3n) - t5i*imag(x4n) - t2i*imag(x5n) + t1i*imag(x6n) +
+		t4i*imag(x7n) + t7i*imag(x8n)
+	buffer[3] = complex(ar-bi, ai+br)
+	buffer[14] = complex(ar+bi, ai-br)
+
+	// Outputs 4 and 13
+	ar = real(x0) + t4r*real(x1p) + t8r*real(x2p) + t5r*real(x3p) + t1r*real(x4p) + t3r*real(x5p) +
+		t7r*real(x6p) + t6r*real(x7p) + t2r*real(x8p)
+	ai = imag(x0) + t4r*imag(x1p) + t8r*imag(x2p) + t5r*imag(x3p) + t1r*imag(x4p) + t3r*imag(x5p) +
+		t7r*imag(x6p) + t6r*imag(x7p) + t2r*imag(x8p)
+	br = t4i*real(x1n) + t8i*real(x2n) - t5i*real(x3n) - t1i*real(x4n) + t3i*real(x5n) + t7i*real(x6n) -
+		t6i*real(x7n) - t2i*real(x8n)
+	bi = t4i*imag(x1n) + t8i*imag(x2n) - t5i*imag(x3n) - t1i*imag(x4n) + t3i*imag(x5n) + t7i*imag(x6n) -
+		t6i*imag(x7n) - t2i*imag(x8n)
+	buffer[4] = complex(ar-bi, ai+br)
+	buffer[13] = complex(ar+bi, ai-br)
+
+	// Outputs 5 and 12
+	ar = real(x0) + t5r*real(x1p) + t7r*real(x2p) + t2r*real(x3p) + t3r*real(x4p) + t8r*real(x5p) +
+		t4r*real(x6p) + t1r*real(x7p) + t6r*real(x8p)
+	ai = imag(x0) + t5r*imag(x1p) + t7r*imag(x2p) + t2r*imag(x3p) + t3r*imag(x4p) + t8r*imag(x5p) +
+		t4r*imag(x6p) + t1r*imag(x7p) + t6r*imag(x8p)
+	br = t5i*real(x1n) - t7i*real(x2n) - t2i*real(x3n) + t3i*real(x4n) + t8i*real(x5n) - t4i*real(x6n) +
+		t1i*real(x7n) + t6i*real(x8n)
+	bi = t5i*imag(x1n) - t7i*imag(x2n) - t2i*imag(x3n) + t3i*imag(x4n) + t8i*imag(x5n) - t4i*imag(x6n) +
+		t1i*imag(x7n) + t6i*imag(x8n)
+	buffer[5] = complex(ar-bi, ai+br)
+	buffer[12] = complex(ar+bi, ai-br)
+
+	// Outputs 6 and 11
+	ar = real(x0) + t6r*real(x1p) + t5r*real(x2p) + t1r*real(x3p) + t7r*real(x4p) + t4r*real(x5p) +
+		t2r*real(x6p) + t8r*real(x7p) + t3r*real(x8p)
+	ai = imag(x0) + t6r*imag(x1p) + t5r*imag(x2p) + t1r*imag(x3p) + t7r*imag(x4p) + t4r*imag(x5p) +
+		t2r*imag(x6p) + t8r*imag(x7p) + t3r*imag(x8p)
+	br = t6i*real(x1n) - t5i*real(x2n) + t1i*real(x3n) + t7i*real(x4n) - t4i*real(x5n) + t2i*real(x6n) +
+		t8i*real(x7n) - t3i*real(x8n)
+	bi = t6i*imag(x1n) - t5i*imag(x2n) + t1i*imag(x3n) + t7i*imag(x4n) - t4i*imag(x5n) + t2i*imag(x6n) +
+		t8i*imag(x7n) - t3i*imag(x8n)
+	buffer[6] = complex(ar-bi, ai+br)
+	buffer[11] = complex(ar+bi, ai-br)
+
+	// Outputs 7 and 10
+	ar = real(x0) + t7r*real(x1p) + t3r*real(x2p) + t4r*real(x3p) + t6r*real(x4p) + t1r*real(x5p) +
+		t8r*real(x6p) + t2r*real(x7p) + t5r*real(x8p)
+	ai = imag(x0) + t7r*imag(x1p) + t3r*imag(x2p) + t4r*imag(x3p) + t6r*imag(x4p) + t1r*imag(x5p) +
+		t8r*imag(x6p) + t2r*imag(x7p) + t5r*imag(x8p)
+	br = t7i*real(x1n) - t3i*real(x2n) + t4i*real(x3n) - t6i*real(x4n) + t1i*real(x5n) + t8i*real(x6n) -
+		t2i*real(x7n) + t5i*real(x8n)
+	bi = t7i*imag(x1n) - t3i*imag(x2n) + t4i*imag(x3n) - t6i*imag(x4n) + t1i*imag(x5n) + t8i*imag(x6n) -
+		t2i*imag(x7n) + t5i*imag(x8n)
+	buffer[7] = complex(ar-bi, ai+br)
+	buffer[10] = complex(ar+bi, ai-br)
+
+	// Outputs 8 and 9
+	ar = real(x0) + t8r*real(x1p) + t1r*real(x2p) + t7r*real(x3p) + t2r*real(x4p) + t6r*real(x5p) +
+		t3r*real(x6p) + t5r*real(x7p) + t4r*real(x8p)
+	ai = imag(x0) + t8r*imag(x1p) + t1r*imag(x2p) + t7r*imag(x3p) + t2r*imag(x4p) + t6r*imag(x5p) +
+		t3r*imag(x6p) + t5r*imag(x7p) + t4r*imag(x8p)
+	br = t8i*real(x1n) - t1i*real(x2n) + t7i*real(x3n) - t2i*real(x4n) + t6i*real(x5n) - t3i*real(x6n) +
+		t5i*real(x7n) - t4i*real(x8n)
+	bi = t8i*imag(x1n) - t1i*imag(x2n) + t7i*imag(x3n) - t2i*imag(x4n) + t6i*imag(x5n) - t3i*imag(x6n) +
+		t5i*imag(x7n) - t4i*imag(x8n)
+	buffer[8] = complex(ar-bi, ai+br)
+	buffer[9] = complex(ar+bi, ai-br)
 }
 
-func (b *Butterfly24) performFftOutOfPlace(input, output []complex128) {
+func (b *Butterfly17) performFftOutOfPlace(input, output []complex128) {
 	copy(output, input)
 	b.performFft(output)
 }
 
-// Butterfly27 implements a size-27 FFT (3^3)
-type Butterfly27 struct {
-	direction  Direction
-	butterfly9 *Butterfly9
+// Butterfly19 implements a size-19 FFT (prime size)
+type Butterfly19 struct {
+	direction Direction
+	twiddles  [9]complex128 // W1-W9 (W10-W18 are conjugates)
 }
 
-// NewButterfly27 creates a new Butterfly27 instance
-func NewButterfly27(direction Direction) *Butterfly27 {
-	return &Butterfly27{
-		direction:  direction,
-		butterfly9: NewButterfly9(direction),
+// NewButterfly19 creates a new Butterfly19 instance
+func NewButterfly19(direction Direction) *Butterfly19 {
+	return &Butterfly19{
+		direction: direction,
+		twiddles: [9]complex128{
+			twiddleFactor(1, 19, direction),
+			twiddleFactor(2, 19, direction),
+			twiddleFactor(3, 19, direction),
+			twiddleFactor(4, 19, direction),
+			twiddleFactor(5, 19, direction),
+			twiddleFactor(6, 19, direction),
+			twiddleFactor(7, 19, direction),
+			twiddleFactor(8, 19, direction),
+			twiddleFactor(9, 19, direction),
+		},
 	}
 }
 
-func (b *Butterfly27) Len() int                  { return 27 }
-func (b *Butterfly27) Direction() Direction      { return b.direction }
-func (b *Butterfly27) InplaceScratchLen() int    { return 0 }
-func (b *Butterfly27) OutOfPlaceScratchLen() int { return 0 }
-func (b *Butterfly27) ImmutableScratchLen() int  { return 0 }
+func (b *Butterfly19) Len() int                  { return 19 }
+func (b *Butterfly19) Direction() Direction      { return b.direction }
+func (b *Butterfly19) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly19) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly19) ImmutableScratchLen() int  { return 0 }
 
-func (b *Butterfly27) Process(buffer []complex128) {
+func (b *Butterfly19) Process(buffer []complex128) {
 	b.ProcessWithScratch(buffer, nil)
 }
 
-func (b *Butterfly27) ProcessWithScratch(buffer, scratch []complex128) {
-	for i := 0; i < len(buffer); i += 27 {
-		b.performFft(buffer[i : i+27])
+func (b *Butterfly19) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 19 {
+		b.performFft(buffer[i : i+19])
 	}
 }
 
-func (b *Butterfly27) ProcessOutOfPlace(input, output, scratch []complex128) {
-	for i := 0; i < len(input); i += 27 {
-		b.performFftOutOfPlace(input[i:i+27], output[i:i+27])
+func (b *Butterfly19) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 19 {
+		b.performFftOutOfPlace(input[i:i+19], output[i:i+19])
 	}
 }
 
-func (b *Butterfly27) ProcessImmutable(input []complex128, output, scratch []complex128) {
+func (b *Butterfly19) ProcessImmutable(input []complex128, output, scratch []complex128) {
 	b.ProcessOutOfPlace(input, output, scratch)
 }
 
-func (b *Butterfly27) performFft(buffer []complex128) {
-	// For now, use DFT for size 27
-	// TODO: Implement proper 9x3 mixed radix
-	dft := NewDft(27, b.direction)
-	temp := make([]complex128, 27)
-	copy(temp, buffer)
-	dft.performFftImmutable(temp, buffer, nil)
+func (b *Butterfly19) performFft(buffer []complex128) {
+	// Sums and differences of the symmetric pairs x[j], x[19-j]
+	x0 := buffer[0]
+	x1p, x1n := buffer[1]+buffer[18], buffer[1]-buffer[18]
+	x2p, x2n := buffer[2]+buffer[17], buffer[2]-buffer[17]
+	x3p, x3n := buffer[3]+buffer[16], buffer[3]-buffer[16]
+	x4p, x4n := buffer[4]+buffer[15], buffer[4]-buffer[15]
+	x5p, x5n := buffer[5]+buffer[14], buffer[5]-buffer[14]
+	x6p, x6n := buffer[6]+buffer[13], buffer[6]-buffer[13]
+	x7p, x7n := buffer[7]+buffer[12], buffer[7]-buffer[12]
+	x8p, x8n := buffer[8]+buffer[11], buffer[8]-buffer[11]
+	x9p, x9n := buffer[9]+buffer[10], buffer[9]-buffer[10]
+
+	// W^j for j = 1..9; the other twiddles are their conjugates
+	t1r, t1i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t2r, t2i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t3r, t3i := real(b.twiddles[2]), imag(b.twiddles[2])
+	t4r, t4i := real(b.twiddles[3]), imag(b.twiddles[3])
+	t5r, t5i := real(b.twiddles[4]), imag(b.twiddles[4])
+	t6r, t6i := real(b.twiddles[5]), imag(b.twiddles[5])
+	t7r, t7i := real(b.twiddles[6]), imag(b.twiddles[6])
+	t8r, t8i := real(b.twiddles[7]), imag(b.twiddles[7])
+	t9r, t9i := real(b.twiddles[8]), imag(b.twiddles[8])
+
+	buffer[0] = x0 + x1p + x2p + x3p + x4p + x5p + x6p + x7p + x8p + x9p
+
+	// Outputs 1 and 18
+	ar := real(x0) + t1r*real(x1p) + t2r*real(x2p) + t3r*real(x3p) + t4r*real(x4p) + t5r*real(x5p) +
+		t6r*real(x6p) + t7r*real(x7p) + t8r*real(x8p) + t9r*real(x9p)
+	ai := imag(x0) + t1r*imag(x1p) + t2r*imag(x2p) + t3r*imag(x3p) + t4r*imag(x4p) + t5r*imag(x5p) +
+		t6r*imag(x6p) + t7r*imag(x7p) + t8r*imag(x8p) + t9r*imag(x9p)
+	br := t1i*real(x1n) + t2i*real(x2n) + t3i*real(x3n) + t4i*real(x4n) + t5i*real(x5n) + t6i*real(x6n) +
+		t7i*real(x7n) + t8i*real(x8n) + t9i*real(x9n)
+	bi := t1i*imag(x1n) + t2i*imag(x2n) + t3i*imag(x3n) + t4i*imag(x4n) + t5i*imag(x5n) + t6i*imag(x6n) +
+		t7i*imag(x7n) + t8i*imag(x8n) + t9i*imag(x9n)
+	buffer[1] = complex(ar-bi, ai+br)
+	buffer[18] = complex(ar+bi, ai-br)
+
+	// Outputs 2 and 17
+	ar = real(x0) + t2r*real(x1p) + t4r*real(x2p) + t6r*real(x3p) + t8r*real(x4p) + t9r*real(x5p) +
+		t7r*real(x6p) + t5r*real(x7p) + t3r*real(x8p) + t1r*real(x9p)
+	ai = imag(x0) + t2r*imag(x1p) + t4r*imag(x2p) + t6r*imag(x3p) + t8r*imag(x4p) + t9r*imag(x5p) +
+		t7r*imag(x6p) + t5r*imag(x7p) + t3r*imag(x8p) + t1r*imag(x9p)
+	br = t2i*real(x1n) + t4i*real(x2n) + t6i*real(x3n) + t8i*real(x4n) - t9i*real(x5n) - t7i*real(x6n) -
+		t5i*real(x7n) - t3i*real(x8n) - t1i*real(x9n)
+	bi = t2i*imag(x1n) + t4i*imag(x2n) + t6i*imag(x3n) + t8i*imag(x4n) - t9i*imag(x5n) - t7i*imag(x6n) -
+		t5i*imag(x7n) - t3i*imag(x8n) - t1i*imag(x9n)
+	buffer[2] = complex(ar-bi, ai+br)
+	buffer[17] = complex(ar+bi, ai-br)
+
+	// Outputs 3 and 16
+	ar = real(x0) + t3r*real(x1p) + t6r*real(x2p) + t9r*real(x3p) + t7r*real(x4p) + t4r*real(x5p) +
+		t1r*real(x6p) + t2r*real(x7p) + t5r*real(x8p) + t8r*real(x9p)
+	ai = imag(x0) + t3r*imag(x1p) + t6r*imag(x2p) + t9r*imag(x3p) + t7r*imag(x4p) + t4r*imag(x5p) +
+		t1r*imag(x6p) + t2r*imag(x7p) + t5r*imag(x8p) + t8r*imag(x9p)
+	br = t3i*real(x1n) + t6i*real(x2n) + t9i*real(x3n) - t7i*real(x4n) - t4i*real(x5n) - t1i*real(x6n) +
+		t2i*real(x7n) + t5i*real(x8n) + t8i*real(x9n)
+	bi = t3i*imag(x1n) + t6i*imag(x2n) + t9i*imag(x3n) - t7i*imag(x4n) - t4i*imag(x5n) - t1i*imag(x6n) +
+		t2i*imag(x7n) + t5i*imag(x8n) + t8i*imag(x9n)
+	buffer[3] = complex(ar-bi, ai+br)
+	buffer[16] = complex(ar+bi, ai-br)
+
+	// Outputs 4 and 15
+	ar = real(x0) + t4r*real(x1p) + t8r*real(x2p) + t7r*real(x3p) + t3r*real(x4p) + t1r*real(x5p) +
+		t5r*real(x6p) + t9r*real(x7p) + t6r*real(x8p) + t2r*real(x9p)
+	ai = imag(x0) + t4r*imag(x1p) + t8r*imag(x2p) + t7r*imag(x3p) + t3r*imag(x4p) + t1r*imag(x5p) +
+		t5r*imag(x6p) + t9r*imag(x7p) + t6r*imag(x8p) + t2r*imag(x9p)
+	br = t4i*real(x1n) + t8i*real(x2n) - t7i*real(x3n) - t3i*real(x4n) + t1i*real(x5n) + t5i*real(x6n) +
+		t9i*real(x7n) - t6i*real(x8n) - t2i*real(x9n)
+	bi = t4i*imag(x1n) + t8i*imag(x2n) - t7i*imag(x3n) - t3i*imag(x4n) + t1i*imag(x5n) + t5i*imag(x6n) +
+		t9i*imag(x7n) - t6i*imag(x8n) - t2i*imag(x9n)
+	buffer[4] = complex(ar-bi, ai+br)
+	buffer[15] = complex(ar+bi, ai-br)
+
+	// Outputs 5 and 14
+	ar = real(x0) + t5r*real(x1p) + t9r*real(x2p) + t4r*real(x3p) + t1r*real(x4p) + t6r*real(x5p) +
+		t8r*real(x6p) + t3r*real(x7p) + t2r*real(x8p) + t7r*real(x9p)
+	ai = imag(x0) + t5r*imag(x1p) + t9r*imag(x2p) + t4r*imag(x3p) + t1r*imag(x4p) + t6r*imag(x5p) +
+		t8r*imag(x6p) + t3r*imag(x7p) + t2r*imag(x8p) + t7r*imag(x9p)
+	br = t5i*real(x1n) - t9i*real(x2n) - t4i*real(x3n) + t1i*real(x4n) + t6i*real(x5n) - t8i*real(x6n) -
+		t3i*real(x7n) + t2i*real(x8n) + t7i*real(x9n)
+	bi = t5i*imag(x1n) - t9i*imag(x2n) - t4i*imag(x3n) + t1i*imag(x4n) + t6i*imag(x5n) - t8i*imag(x6n) -
+		t3i*imag(x7n) + t2i*imag(x8n) + t7i*imag(x9n)
+	buffer[5] = complex(ar-bi, ai+br)
+	buffer[14] = complex(ar+bi, ai-br)
+
+	// Outputs 6 and 13
+	ar = real(x0) + t6r*real(x1p) + t7r*real(x2p) + t1r*real(x3p) + t5r*real(x4p) + t8r*real(x5p) +
+		t2r*real(x6p) + t4r*real(x7p) + t9r*real(x8p) + t3r*real(x9p)
+	ai = imag(x0) + t6r*imag(x1p) + t7r*imag(x2p) + t1r*imag(x3p) + t5r*imag(x4p) + t8r*imag(x5p) +
+		t2r*imag(x6p) + t4r*imag(x7p) + t9r*imag(x8p) + t3r*imag(x9p)
+	br = t6i*real(x1n) - t7i*real(x2n) - t1i*real(x3n) + t5i*real(x4n) - t8i*real(x5n) - t2i*real(x6n) +
+		t4i*real(x7n) - t9i*real(x8n) - t3i*real(x9n)
+	bi = t6i*imag(x1n) - t7i*imag(x2n) - t1i*imag(x3n) + t5i*imag(x4n) - t8i*imag(x5n) - t2i*imag(x6n) +
+		t4i*imag(x7n) - t9i*imag(x8n) - t3i*imag(x9n)
+	buffer[6] = complex(ar-bi, ai+br)
+	buffer[13] = complex(ar+bi, ai-br)
+
+	// Outputs 7 and 12
+	ar = real(x0) + t7r*real(x1p) + t5r*real(x2p) + t2r*real(x3p) + t9r*real(x4p) + t3r*real(x5p) +
+		t4r*real(x6p) + t8r*real(x7p) + t1r*real(x8p) + t6r*real(x9p)
+	ai = imag(x0) + t7r*imag(x1p) + t5r*imag(x2p) + t2r*imag(x3p) + t9r*imag(x4p) + t3r*imag(x5p) +
+		t4r*imag(x6p) + t8r*imag(x7p) + t1r*imag(x8p) + t6r*imag(x9p)
+	br = t7i*real(x1n) - t5i*real(x2n) + t2i*real(x3n) + t9i*real(x4n) - t3i*real(x5n) + t4i*real(x6n) -
+		t8i*real(x7n) - t1i*real(x8n) + t6i*real(x9n)
+	bi = t7i*imag(x1n) - t5i*imag(x2n) + t2i*imag(x3n) + t9i*imag(x4n) - t3i*imag(x5n) + t4i*imag(x6n) -
+		t8i*imag(x7n) - t1i*imag(x8n) + t6i*imag(x9n)
+	buffer[7] = complex(ar-bi, ai+br)
+	buffer[12] = complex(ar+bi, ai-br)
+
+	// Outputs 8 and 11
+	ar = real(x0) + t8r*real(x1p) + t3r*real(x2p) + t5r*real(x3p) + t6r*real(x4p) + t2r*real(x5p) +
+		t9r*real(x6p) + t1r*real(x7p) + t7r*real(x8p) + t4r*real(x9p)
+	ai = imag(x0) + t8r*imag(x1p) + t3r*imag(x2p) + t5r*imag(x3p) + t6r*imag(x4p) + t2r*imag(x5p) +
+		t9r*imag(x6p) + t1r*imag(x7p) + t7r*imag(x8p) + t4r*imag(x9p)
+	br = t8i*real(x1n) - t3i*real(x2n) + t5i*real(x3n) - t6i*real(x4n) + t2i*real(x5n) - t9i*real(x6n) -
+		t1i*real(x7n) + t7i*real(x8n) - t4i*real(x9n)
+	bi = t8i*imag(x1n) - t3i*imag(x2n) + t5i*imag(x3n) - t6i*imag(x4n) + t2i*imag(x5n) - t9i*imag(x6n) -
+		t1i*imag(x7n) + t7i*imag(x8n) - t4i*imag(x9n)
+	buffer[8] = complex(ar-bi, ai+br)
+	buffer[11] = complex(ar+bi, ai-br)
+
+	// Outputs 9 and 10
+	ar = real(x0) + t9r*real(x1p) + t1r*real(x2p) + t8r*real(x3p) + t2r*real(x4p) + t7r*real(x5p) +
+		t3r*real(x6p) + t6r*real(x7p) + t4r*real(x8p) + t5r*real(x9p)
+	ai = imag(x0) + t9r*imag(x1p) + t1r*imag(x2p) + t8r*imag(x3p) + t2r*imag(x4p) + t7r*imag(x5p) +
+		t3r*imag(x6p) + t6r*imag(x7p) + t4r*imag(x8p) + t5r*imag(x9p)
+	br = t9i*real(x1n) - t1i*real(x2n) + t8i*real(x3n) - t2i*real(x4n) + t7i*real(x5n) - t3i*real(x6n) +
+		t6i*real(x7n) - t4i*real(x8n) + t5i*real(x9n)
+	bi = t9i*imag(x1n) - t1i*imag(x2n) + t8i*imag(x3n) - t2i*imag(x4n) + t7i*imag(x5n) - t3i*imag(x6n) +
+		t6i*imag(x7n) - t4i*imag(x8n) + t5i*imag(x9n)
+	buffer[9] = complex(ar-bi, ai+br)
+	buffer[10] = complex(ar+bi, ai-br)
 }
 
-func (b *Butterfly27) performFftOutOfPlace(input, output []complex128) {
+func (b *Butterfly19) performFftOutOfPlace(input, output []complex128) {
 	copy(output, input)
 	b.performFft(output)
 }
 
-// addPrimeButterfly is a helper to create prime-sized butterflies
-// For primes, we use DFT-based computation (RustFFT optimizes with symmetry, but DFT is simpler and correct)
-func createPrimeButterfly(size int, direction Direction) FftInterface {
-	return NewDft(size, direction)
+// Butterfly23 implements a size-23 FFT (prime size)
+type Butterfly23 struct {
+	direction Direction
+	twiddles  [11]complex128 // W1-W11 (W12-W22 are conjugates)
 }
 
-// Butterfly17 implements a size-17 FFT (prime)
-type Butterfly17 struct {
-	inner *Dft
+// NewButterfly23 creates a new Butterfly23 instance
+func NewButterfly23(direction Direction) *Butterfly23 {
+	return &Butterfly23{
+		direction: direction,
+		twiddles: [11]complex128{
+			twiddleFactor(1, 23, direction),
+			twiddleFactor(2, 23, direction),
+			twiddleFactor(3, 23, direction),
+			twiddleFactor(4, 23, direction),
+			twiddleFactor(5, 23, direction),
+			twiddleFactor(6, 23, direction),
+			twiddleFactor(7, 23, direction),
+			twiddleFactor(8, 23, direction),
+			twiddleFactor(9, 23, direction),
+			twiddleFactor(10, 23, direction),
+			twiddleFactor(11, 23, direction),
+		},
+	}
 }
 
-func NewButterfly17(direction Direction) *Butterfly17 {
-	return &Butterfly17{inner: NewDft(17, direction)}
-}
+func (b *Butterfly23) Len() int                  { return 23 }
+func (b *Butterfly23) Direction() Direction      { return b.direction }
+func (b *Butterfly23) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly23) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly23) ImmutableScratchLen() int  { return 0 }
 
-func (b *Butterfly17) Len() int               { return 17 }
-func (b *Butterfly17) Direction() Direction   { return b.inner.Direction() }
-func (b *Butterfly17) InplaceScratchLen() int { return b.inner.InplaceScratchLen() }
-func (b *Butterfly17) ProcessWithScratch(buffer, scratch []complex128) {
-	b.inner.ProcessWithScratch(buffer, scratch)
+func (b *Butterfly23) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
 }
 
-// Butterfly19 implements a size-19 FFT (prime)
-type Butterfly19 struct {
-	inner *Dft
-}
+func (b *Butterfly23) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
 
-func NewButterfly19(direction Direction) *Butterfly19 {
-	return &Butterfly19{inner: NewDft(19, direction)}
+	for i := 0; i < len(buffer); i += 23 {
+		b.performFft(buffer[i : i+23])
+	}
 }
 
-func (b *Butterfly19) Len() int               { return 19 }
-func (b *Butterfly19) Direction() Direction   { return b.inner.Direction() }
-func (b *Butterfly19) InplaceScratchLen() int { return b.inner.InplaceScratchLen() }
-func (b *Butterfly19) ProcessWithScratch(buffer, scratch []complex128) {
-	b.inner.ProcessWithScratch(buffer, scratch)
+func (b *Butterfly23) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 23 {
+		b.performFftOutOfPlace(input[i:i+23], output[i:i+23])
+	}
 }
 
-// Butterfly23 implements a size-23 FFT (prime)
-type Butterfly23 struct {
-	inner *Dft
+func (b *Butterfly23) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
 }
 
-func NewButterfly23(direction Direction) *Butterfly23 {
-	return &Butterfly23{inner: NewDft(23, direction)}
+func (b *Butterfly23) performFft(buffer []complex128) {
+	// Sums and differences of the symmetric pairs x[j], x[23-j]
+	x0 := buffer[0]
+	x1p, x1n := buffer[1]+buffer[22], buffer[1]-buffer[22]
+	x2p, x2n := buffer[2]+buffer[21], buffer[2]-buffer[21]
+	x3p, x3n := buffer[3]+buffer[20], buffer[3]-buffer[20]
+	x4p, x4n := buffer[4]+buffer[19], buffer[4]-buffer[19]
+	x5p, x5n := buffer[5]+buffer[18], buffer[5]-buffer[18]
+	x6p, x6n := buffer[6]+buffer[17], buffer[6]-buffer[17]
+	x7p, x7n := buffer[7]+buffer[16], buffer[7]-buffer[16]
+	x8p, x8n := buffer[8]+buffer[15], buffer[8]-buffer[15]
+	x9p, x9n := buffer[9]+buffer[14], buffer[9]-buffer[14]
+	x10p, x10n := buffer[10]+buffer[13], buffer[10]-buffer[13]
+	x11p, x11n := buffer[11]+buffer[12], buffer[11]-buffer[12]
+
+	// W^j for j = 1..11; the other twiddles are their conjugates
+	t1r, t1i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t2r, t2i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t3r, t3i := real(b.twiddles[2]), imag(b.twiddles[2])
+	t4r, t4i := real(b.twiddles[3]), imag(b.twiddles[3])
+	t5r, t5i := real(b.twiddles[4]), imag(b.twiddles[4])
+	t6r, t6i := real(b.twiddles[5]), imag(b.twiddles[5])
+	t7r, t7i := real(b.twiddles[6]), imag(b.twiddles[6])
+	t8r, t8i := real(b.twiddles[7]), imag(b.twiddles[7])
+	t9r, t9i := real(b.twiddles[8]), imag(b.twiddles[8])
+	t10r, t10i := real(b.twiddles[9]), imag(b.twiddles[9])
+	t11r, t11i := real(b.twiddles[10]), imag(b.twiddles[10])
+
+	buffer[0] = x0 + x1p + x2p + x3p + x4p + x5p + x6p + x7p + x8p + x9p + x10p + x11p
+
+	// Outputs 1 and 22
+	ar := real(x0) + t1r*real(x1p) + t2r*real(x2p) + t3r*real(x3p) + t4r*real(x4p) + t5r*real(x5p) +
+		t6r*real(x6p) + t7r*real(x7p) + t8r*real(x8p) + t9r*real(x9p) + t10r*real(x10p) + t11r*real(x11p)
+	ai := imag(x0) + t1r*imag(x1p) + t2r*imag(x2p) + t3r*imag(x3p) + t4r*imag(x4p) + t5r*imag(x5p) +
+		t6r*imag(x6p) + t7r*imag(x7p) + t8r*imag(x8p) + t9r*imag(x9p) + t10r*imag(x10p) + t11r*imag(x11p)
+	br := t1i*real(x1n) + t2i*real(x2n) + t3i*real(x3n) + t4i*real(x4n) + t5i*real(x5n) + t6i*real(x6n) +
+		t7i*real(x7n) + t8i*real(x8n) + t9i*real(x9n) + t10i*real(x10n) + t11i*real(x11n)
+	bi := t1i*imag(x1n) + t2i*imag(x2n) + t3i*imag(x3n) + t4i*imag(x4n) + t5i*imag(x5n) + t6i*imag(x6n) +
+		t7i*imag(x7n) + t8i*imag(x8n) + t9i*imag(x9n) + t10i*imag(x10n) + t11i*imag(x11n)
+	buffer[1] = complex(ar-bi, ai+br)
+	buffer[22] = complex(ar+bi, ai-br)
+
+	// Outputs 2 and 21
+	ar = real(x0) + t2r*real(x1p) + t4r*real(x2p) + t6r*real(x3p) + t8r*real(x4p) + t10r*real(x5p) +
+		t11r*real(x6p) + t9r*real(x7p) + t7r*real(x8p) + t5r*real(x9p) + t3r*real(x10p) + t1r*real(x11p)
+	ai = imag(x0) + t2r*imag(x1p) + t4r*imag(x2p) + t6r*imag(x3p) + t8r*imag(x4p) + t10r*imag(x5p) +
+		t11r*imag(x6p) + t9r*imag(x7p) + t7r*imag(x8p) + t5r*imag(x9p) + t3r*imag(x10p) + t1r*imag(x11p)
+	br = t2i*real(x1n) + t4i*real(x2n) + t6i*real(x3n) + t8i*real(x4n) + t10i*real(x5n) -
+		t11i*real(x6n) - t9i*real(x7n) - t7i*real(x8n) - t5i*real(x9n) - t3i*real(x10n) - t1i*real(x11n)
+	bi = t2i*imag(x1n) + t4i*imag(x2n) + t6i*imag(x3n) + t8i*imag(x4n) + t10i*imag(x5n) -
+		t11i*imag(x6n) - t9i*imag(x7n) - t7i*imag(x8n) - t5i*imag(x9n) - t3i*imag(x10n) - t1i*imag(x11n)
+	buffer[2] = complex(ar-bi, ai+br)
+	buffer[21] = complex(ar+bi, ai-br)
+
+	// Outputs 3 and 20
+	ar = real(x0) + t3r*real(x1p) + t6r*real(x2p) + t9r*real(x3p) + t11r*real(x4p) + t8r*real(x5p) +
+		t5r*real(x6p) + t2r*real(x7p) + t1r*real(x8p) + t4r*real(x9p) + t7r*real(x10p) + t10r*real(x11p)
+	ai = imag(x0) + t3r*imag(x1p) + t6r*imag(x2p) + t9r*imag(x3p) + t11r*imag(x4p) + t8r*imag(x5p) +
+		t5r*imag(x6p) + t2r*imag(x7p) + t1r*imag(x8p) + t4r*imag(x9p) + t7r*imag(x10p) + t10r*imag(x11p)
+	br = t3i*real(x1n) + t6i*real(x2n) + t9i*real(x3n) - t11i*real(x4n) - t8i*real(x5n) -
+		t5i*real(x6n) - t2i*real(x7n) + t1i*real(x8n) + t4i*real(x9n) + t7i*real(x10n) + t10i*real(x11n)
+	bi = t3i*imag(x1n) + t6i*imag(x2n) + t9i*imag(x3n) - t11i*imag(x4n) - t8i*imag(x5n) -
+		t5i*imag(x6n) - t2i*imag(x7n) + t1i*imag(x8n) + t4i*imag(x9n) + t7i*imag(x10n) + t10i*imag(x11n)
+	buffer[3] = complex(ar-bi, ai+br)
+	buffer[20] = complex(ar+bi, ai-br)
+
+	// Outputs 4 and 19
+	ar = real(x0) + t4r*real(x1p) + t8r*real(x2p) + t11r*real(x3p) + t7r*real(x4p) + t3r*real(x5p) +
+		t1r*real(x6p) + t5r*real(x7p) + t9r*real(x8p) + t10r*real(x9p) + t6r*real(x10p) + t2r*real(x11p)
+	ai = imag(x0) + t4r*imag(x1p) + t8r*imag(x2p) + t11r*imag(x3p) + t7r*imag(x4p) + t3r*imag(x5p) +
+		t1r*imag(x6p) + t5r*imag(x7p) + t9r*imag(x8p) + t10r*imag(x9p) + t6r*imag(x10p) + t2r*imag(x11p)
+	br = t4i*real(x1n) + t8i*real(x2n) - t11i*real(x3n) - t7i*real(x4n) - t3i*real(x5n) +
+		t1i*real(x6n) + t5i*real(x7n) + t9i*real(x8n) - t10i*real(x9n) - t6i*real(x10n) - t2i*real(x11n)
+	bi = t4i*imag(x1n) + t8i*imag(x2n) - t11i*imag(x3n) - t7i*imag(x4n) - t3i*imag(x5n) +
+		t1i*imag(x6n) + t5i*imag(x7n) + t9i*imag(x8n) - t10i*imag(x9n) - t6i*imag(x10n) - t2i*imag(x11n)
+	buffer[4] = complex(ar-bi, ai+br)
+	buffer[19] = complex(ar+bi, ai-br)
+
+	// Outputs 5 and 18
+	ar = real(x0) + t5r*real(x1p) + t10r*real(x2p) + t8r*real(x3p) + t3r*real(x4p) + t2r*real(x5p) +
+		t7r*real(x6p) + t11r*real(x7p) + t6r*real(x8p) + t1r*real(x9p) + t4r*real(x10p) + t9r*real(x11p)
+	ai = imag(x0) + t5r*imag(x1p) + t10r*imag(x2p) + t8r*imag(x3p) + t3r*imag(x4p) + t2r*imag(x5p) +
+		t7r*imag(x6p) + t11r*imag(x7p) + t6r*imag(x8p) + t1r*imag(x9p) + t4r*imag(x10p) + t9r*imag(x11p)
+	br = t5i*real(x1n) + t10i*real(x2n) - t8i*real(x3n) - t3i*real(x4n) + t2i*real(x5n) +
+		t7i*real(x6n) - t11i*real(x7n) - t6i*real(x8n) - t1i*real(x9n) + t4i*real(x10n) + t9i*real(x11n)
+	bi = t5i*imag(x1n) + t10i*imag(x2n) - t8i*imag(x3n) - t3i*imag(x4n) + t2i*imag(x5n) +
+		t7i*imag(x6n) - t11i*imag(x7n) - t6i*imag(x8n) - t1i*imag(x9n) + t4i*imag(x10n) + t9i*imag(x11n)
+	buffer[5] = complex(ar-bi, ai+br)
+	buffer[18] = complex(ar+bi, ai-br)
+
+	// Outputs 6 and 17
+	ar = real(x0) + t6r*real(x1p) + t11r*real(x2p) + t5r*real(x3p) + t1r*real(x4p) + t7r*real(x5p) +
+		t10r*real(x6p) + t4r*real(x7p) + t2r*real(x8p) + t8r*real(x9p) + t9r*real(x10p) + t3r*real(x11p)
+	ai = imag(x0) + t6r*imag(x1p) + t11r*imag(x2p) + t5r*imag(x3p) + t1r*imag(x4p) + t7r*imag(x5p) +
+		t10r*imag(x6p) + t4r*imag(x7p) + t2r*imag(x8p) + t8r*imag(x9p) + t9r*imag(x10p) + t3r*imag(x11p)
+	br = t6i*real(x1n) - t11i*real(x2n) - t5i*real(x3n) + t1i*real(x4n) + t7i*real(x5n) -
+		t10i*real(x6n) - t4i*real(x7n) + t2i*real(x8n) + t8i*real(x9n) - t9i*real(x10n) - t3i*real(x11n)
+	bi = t6i*imag(x1n) - t11i*imag(x2n) - t5i*imag(x3n) + t1i*imag(x4n) + t7i*imag(x5n) -
+		t10i*imag(x6n) - t4i*imag(x7n) + t2i*imag(x8n) + t8i*imag(x9n) - t9i*imag(x10n) - t3i*imag(x11n)
+	buffer[6] = complex(ar-bi, ai+br)
+	buffer[17] = complex(ar+bi, ai-br)
+
+	// Outputs 7 and 16
+	ar = real(x0) + t7r*real(x1p) + t9r*real(x2p) + t2r*real(x3p) + t5r*real(x4p) + t11r*real(x5p) +
+		t4r*real(x6p) + t3r*real(x7p) + t10r*real(x8p) + t6r*real(x9p) + t1r*real(x10p) + t8r*real(x11p)
+	ai = imag(x0) + t7r*imag(x1p) + t9r*imag(x2p) + t2r*imag(x3p) + t5r*imag(x4p) + t11r*imag(x5p) +
+		t4r*imag(x6p) + t3r*imag(x7p) + t10r*imag(x8p) + t6r*imag(x9p) + t1r*imag(x10p) + t8r*imag(x11p)
+	br = t7i*real(x1n) - t9i*real(x2n) - t2i*real(x3n) + t5i*real(x4n) - t11i*real(x5n) -
+		t4i*real(x6n) + t3i*real(x7n) + t10i*real(x8n) - t6i*real(x9n) + t1i*real(x10n) + t8i*real(x11n)
+	bi = t7i*imag(x1n) - t9i*imag(x2n) - t2i*imag(x3n) + t5i*imag(x4n) - t11i*imag(x5n) -
+		t4i*imag(x6n) + t3i*imag(x7n) + t10i*imag(x8n) - t6i*imag(x9n) + t1i*imag(x10n) + t8i*imag(x11n)
+	buffer[7] = complex(ar-bi, ai+br)
+	buffer[16] = complex(ar+bi, ai-br)
+
+	// Outputs 8 and 15
+	ar = real(x0) + t8r*real(x1p) + t7r*real(x2p) + t1r*real(x3p) + t9r*real(x4p) + t6r*real(x5p) +
+		t2r*real(x6p) + t10r*real(x7p) + t5r*real(x8p) + t3r*real(x9p) + t11r*real(x10p) + t4r*real(x11p)
+	ai = imag(x0) + t8r*imag(x1p) + t7r*imag(x2p) + t1r*imag(x3p) + t9r*imag(x4p) + t6r*imag(x5p) +
+		t2r*imag(x6p) + t10r*imag(x7p) + t5r*imag(x8p) + t3r*imag(x9p) + t11r*imag(x10p) + t4r*imag(x11p)
+	br = t8i*real(x1n) - t7i*real(x2n) + t1i*real(x3n) + t9i*real(x4n) - t6i*real(x5n) + t2i*real(x6n) +
+		t10i*real(x7n) - t5i*real(x8n) + t3i*real(x9n) + t11i*real(x10n) - t4i*real(x11n)
+	bi = t8i*imag(x1n) - t7i*imag(x2n) + t1i*imag(x3n) + t9i*imag(x4n) - t6i*imag(x5n) + t2i*imag(x6n) +
+		t10i*imag(x7n) - t5i*imag(x8n) + t3i*imag(x9n) + t11i*imag(x10n) - t4i*imag(x11n)
+	buffer[8] = complex(ar-bi, ai+br)
+	buffer[15] = complex(ar+bi, ai-br)
+
+	// Outputs 9 and 14
+	ar = real(x0) + t9r*real(x1p) + t5r*real(x2p) + t4r*real(x3p) + t10r*real(x4p) + t1r*real(x5p) +
+		t8r*real(x6p) + t6r*real(x7p) + t3r*real(x8p) + t11r*real(x9p) + t2r*real(x10p) + t7r*real(x11p)
+	ai = imag(x0) + t9r*imag(x1p) + t5r*imag(x2p) + t4r*imag(x3p) + t10r*imag(x4p) + t1r*imag(x5p) +
+		t8r*imag(x6p) + t6r*imag(x7p) + t3r*imag(x8p) + t11r*imag(x9p) + t2r*imag(x10p) + t7r*imag(x11p)
+	br = t9i*real(x1n) - t5i*real(x2n) + t4i*real(x3n) - t10i*real(x4n) - t1i*real(x5n) +
+		t8i*real(x6n) - t6i*real(x7n) + t3i*real(x8n) - t11i*real(x9n) - t2i*real(x10n) + t7i*real(x11n)
+	bi = t9i*imag(x1n) - t5i*imag(x2n) + t4i*imag(x3n) - t10i*imag(x4n) - t1i*imag(x5n) +
+		t8i*imag(x6n) - t6i*imag(x7n) + t3i*imag(x8n) - t11i*imag(x9n) - t2i*imag(x10n) + t7i*imag(x11n)
+	buffer[9] = complex(ar-bi, ai+br)
+	buffer[14] = complex(ar+bi, ai-br)
+
+	// Outputs 10 and 13
+	ar = real(x0) + t10r*real(x1p) + t3r*real(x2p) + t7r*real(x3p) + t6r*real(x4p) + t4r*real(x5p) +
+		t9r*real(x6p) + t1r*real(x7p) + t11r*real(x8p) + t2r*real(x9p) + t8r*real(x10p) + t5r*real(x11p)
+	ai = imag(x0) + t10r*imag(x1p) + t3r*imag(x2p) + t7r*imag(x3p) + t6r*imag(x4p) + t4r*imag(x5p) +
+		t9r*imag(x6p) + t1r*imag(x7p) + t11r*imag(x8p) + t2r*imag(x9p) + t8r*imag(x10p) + t5r*imag(x11p)
+	br = t10i*real(x1n) - t3i*real(x2n) + t7i*real(x3n) - t6i*real(x4n) + t4i*real(x5n) -
+		t9i*real(x6n) + t1i*real(x7n) + t11i*real(x8n) - t2i*real(x9n) + t8i*real(x10n) - t5i*real(x11n)
+	bi = t10i*imag(x1n) - t3i*imag(x2n) + t7i*imag(x3n) - t6i*imag(x4n) + t4i*imag(x5n) -
+		t9i*imag(x6n) + t1i*imag(x7n) + t11i*imag(x8n) - t2i*imag(x9n) + t8i*imag(x10n) - t5i*imag(x11n)
+	buffer[10] = complex(ar-bi, ai+br)
+	buffer[13] = complex(ar+bi, ai-br)
+
+	// Outputs 11 and 12
+	ar = real(x0) + t11r*real(x1p) + t1r*real(x2p) + t10r*real(x3p) + t2r*real(x4p) + t9r*real(x5p) +
+		t3r*real(x6p) + t8r*real(x7p) + t4r*real(x8p) + t7r*real(x9p) + t5r*real(x10p) + t6r*real(x11p)
+	ai = imag(x0) + t11r*imag(x1p) + t1r*imag(x2p) + t10r*imag(x3p) + t2r*imag(x4p) + t9r*imag(x5p) +
+		t3r*imag(x6p) + t8r*imag(x7p) + t4r*imag(x8p) + t7r*imag(x9p) + t5r*imag(x10p) + t6r*imag(x11p)
+	br = t11i*real(x1n) - t1i*real(x2n) + t10i*real(x3n) - t2i*real(x4n) + t9i*real(x5n) -
+		t3i*real(x6n) + t8i*real(x7n) - t4i*real(x8n) + t7i*real(x9n) - t5i*real(x10n) + t6i*real(x11n)
+	bi = t11i*imag(x1n) - t1i*imag(x2n) + t10i*imag(x3n) - t2i*imag(x4n) + t9i*imag(x5n) -
+		t3i*imag(x6n) + t8i*imag(x7n) - t4i*imag(x8n) + t7i*imag(x9n) - t5i*imag(x10n) + t6i*imag(x11n)
+	buffer[11] = complex(ar-bi, ai+br)
+	buffer[12] = complex(ar+bi, ai-br)
 }
 
-func (b *Butterfly23) Len() int               { return 23 }
-func (b *Butterfly23) Direction() Direction   { return b.inner.Direction() }
-func (b *Butterfly23) InplaceScratchLen() int { return b.inner.InplaceScratchLen() }
-func (b *Butterfly23) ProcessWithScratch(buffer, scratch []complex128) {
-	b.inner.ProcessWithScratch(buffer, scratch)
+func (b *Butterfly23) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
 }
 
-// Butterfly29 implements a size-29 FFT (prime)
+// Butterfly29 implements a size-29 FFT (prime size)
 type Butterfly29 struct {
-	inner *Dft
+	direction Direction
+	twiddles  [14]complex128 // W1-W14 (W15-W28 are conjugates)
 }
 
+// NewButterfly29 creates a new Butterfly29 instance
 func NewButterfly29(direction Direction) *Butterfly29 {
-	return &Butterfly29{inner: NewDft(29, direction)}
+	return &Butterfly29{
+		direction: direction,
+		twiddles: [14]complex128{
+			twiddleFactor(1, 29, direction),
+			twiddleFactor(2, 29, direction),
+			twiddleFactor(3, 29, direction),
+			twiddleFactor(4, 29, direction),
+			twiddleFactor(5, 29, direction),
+			twiddleFactor(6, 29, direction),
+			twiddleFactor(7, 29, direction),
+			twiddleFactor(8, 29, direction),
+			twiddleFactor(9, 29, direction),
+			twiddleFactor(10, 29, direction),
+			twiddleFactor(11, 29, direction),
+			twiddleFactor(12, 29, direction),
+			twiddleFactor(13, 29, direction),
+			twiddleFactor(14, 29, direction),
+		},
+	}
+}
+
+func (b *Butterfly29) Len() int                  { return 29 }
+func (b *Butterfly29) Direction() Direction      { return b.direction }
+func (b *Butterfly29) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly29) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly29) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly29) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
 }
 
-func (b *Butterfly29) Len() int               { return 29 }
-func (b *Butterfly29) Direction() Direction   { return b.inner.Direction() }
-func (b *Butterfly29) InplaceScratchLen() int { return b.inner.InplaceScratchLen() }
 func (b *Butterfly29) ProcessWithScratch(buffer, scratch []complex128) {
-	b.inner.ProcessWithScratch(buffer, scratch)
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 29 {
+		b.performFft(buffer[i : i+29])
+	}
+}
+
+func (b *Butterfly29) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 29 {
+		b.performFftOutOfPlace(input[i:i+29], output[i:i+29])
+	}
+}
+
+func (b *Butterfly29) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly29) performFft(buffer []complex128) {
+	// Sums and differences of the symmetric pairs x[j], x[29-j]
+	x0 := buffer[0]
+	x1p, x1n := buffer[1]+buffer[28], buffer[1]-buffer[28]
+	x2p, x2n := buffer[2]+buffer[27], buffer[2]-buffer[27]
+	x3p, x3n := buffer[3]+buffer[26], buffer[3]-buffer[26]
+	x4p, x4n := buffer[4]+buffer[25], buffer[4]-buffer[25]
+	x5p, x5n := buffer[5]+buffer[24], buffer[5]-buffer[24]
+	x6p, x6n := buffer[6]+buffer[23], buffer[6]-buffer[23]
+	x7p, x7n := buffer[7]+buffer[22], buffer[7]-buffer[22]
+	x8p, x8n := buffer[8]+buffer[21], buffer[8]-buffer[21]
+	x9p, x9n := buffer[9]+buffer[20], buffer[9]-buffer[20]
+	x10p, x10n := buffer[10]+buffer[19], buffer[10]-buffer[19]
+	x11p, x11n := buffer[11]+buffer[18], buffer[11]-buffer[18]
+	x12p, x12n := buffer[12]+buffer[17], buffer[12]-buffer[17]
+	x13p, x13n := buffer[13]+buffer[16], buffer[13]-buffer[16]
+	x14p, x14n := buffer[14]+buffer[15], buffer[14]-buffer[15]
+
+	// W^j for j = 1..14; the other twiddles are their conjugates
+	t1r, t1i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t2r, t2i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t3r, t3i := real(b.twiddles[2]), imag(b.twiddles[2])
+	t4r, t4i := real(b.twiddles[3]), imag(b.twiddles[3])
+	t5r, t5i := real(b.twiddles[4]), imag(b.twiddles[4])
+	t6r, t6i := real(b.twiddles[5]), imag(b.twiddles[5])
+	t7r, t7i := real(b.twiddles[6]), imag(b.twiddles[6])
+	t8r, t8i := real(b.twiddles[7]), imag(b.twiddles[7])
+	t9r, t9i := real(b.twiddles[8]), imag(b.twiddles[8])
+	t10r, t10i := real(b.twiddles[9]), imag(b.twiddles[9])
+	t11r, t11i := real(b.twiddles[10]), imag(b.twiddles[10])
+	t12r, t12i := real(b.twiddles[11]), imag(b.twiddles[11])
+	t13r, t13i := real(b.twiddles[12]), imag(b.twiddles[12])
+	t14r, t14i := real(b.twiddles[13]), imag(b.twiddles[13])
+
+	buffer[0] = x0 + x1p + x2p + x3p + x4p + x5p + x6p + x7p + x8p + x9p + x10p + x11p + x12p + x13p + x14p
+
+	// Outputs 1 and 28
+	ar := real(x0) + t1r*real(x1p) + t2r*real(x2p) + t3r*real(x3p) + t4r*real(x4p) + t5r*real(x5p) +
+		t6r*real(x6p) + t7r*real(x7p) + t8r*real(x8p) + t9r*real(x9p) + t10r*real(x10p) + t11r*real(x11p) +
+		t12r*real(x12p) + t13r*real(x13p) + t14r*real(x14p)
+	ai := imag(x0) + t1r*imag(x1p) + t2r*imag(x2p) + t3r*imag(x3p) + t4r*imag(x4p) + t5r*imag(x5p) +
+		t6r*imag(x6p) + t7r*imag(x7p) + t8r*imag(x8p) + t9r*imag(x9p) + t10r*imag(x10p) + t11r*imag(x11p) +
+		t12r*imag(x12p) + t13r*imag(x13p) + t14r*imag(x14p)
+	br := t1i*real(x1n) + t2i*real(x2n) + t3i*real(x3n) + t4i*real(x4n) + t5i*real(x5n) + t6i*real(x6n) +
+		t7i*real(x7n) + t8i*real(x8n) + t9i*real(x9n) + t10i*real(x10n) + t11i*real(x11n) +
+		t12i*real(x12n) + t13i*real(x13n) + t14i*real(x14n)
+	bi := t1i*imag(x1n) + t2i*imag(x2n) + t3i*imag(x3n) + t4i*imag(x4n) + t5i*imag(x5n) + t6i*imag(x6n) +
+		t7i*imag(x7n) + t8i*imag(x8n) + t9i*imag(x9n) + t10i*imag(x10n) + t11i*imag(x11n) +
+		t12i*imag(x12n) + t13i*imag(x13n) + t14i*imag(x14n)
+	buffer[1] = complex(ar-bi, ai+br)
+	buffer[28] = complex(ar+bi, ai-br)
+
+	// Outputs 2 and 27
+	ar = real(x0) + t2r*real(x1p) + t4r*real(x2p) + t6r*real(x3p) + t8r*real(x4p) + t10r*real(x5p) +
+		t12r*real(x6p) + t14r*real(x7p) + t13r*real(x8p) + t11r*real(x9p) + t9r*real(x10p) +
+		t7r*real(x11p) + t5r*real(x12p) + t3r*real(x13p) + t1r*real(x14p)
+	ai = imag(x0) + t2r*imag(x1p) + t4r*imag(x2p) + t6r*imag(x3p) + t8r*imag(x4p) + t10r*imag(x5p) +
+		t12r*imag(x6p) + t14r*imag(x7p) + t13r*imag(x8p) + t11r*imag(x9p) + t9r*imag(x10p) +
+		t7r*imag(x11p) + t5r*imag(x12p) + t3r*imag(x13p) + t1r*imag(x14p)
+	br = t2i*real(x1n) + t4i*real(x2n) + t6i*real(x3n) + t8i*real(x4n) + t10i*real(x5n) +
+		t12i*real(x6n) + t14i*real(x7n) - t13i*real(x8n) - t11i*real(x9n) - t9i*real(x10n) -
+		t7i*real(x11n) - t5i*real(x12n) - t3i*real(x13n) - t1i*real(x14n)
+	bi = t2i*imag(x1n) + t4i*imag(x2n) + t6i*imag(x3n) + t8i*imag(x4n) + t10i*imag(x5n) +
+		t12i*imag(x6n) + t14i*imag(x7n) - t13i*imag(x8n) - t11i*imag(x9n) - t9i*imag(x10n) -
+		t7i*imag(x11n) - t5i*imag(x12n) - t3i*imag(x13n) - t1i*imag(x14n)
+	buffer[2] = complex(ar-bi, ai+br)
+	buffer[27] = complex(ar+bi, ai-br)
+
+	// Outputs 3 and 26
+	ar = real(x0) + t3r*real(x1p) + t6r*real(x2p) + t9r*real(x3p) + t12r*real(x4p) + t14r*real(x5p) +
+		t11r*real(x6p) + t8r*real(x7p) + t5r*real(x8p) + t2r*real(x9p) + t1r*real(x10p) + t4r*real(x11p) +
+		t7r*real(x12p) + t10r*real(x13p) + t13r*real(x14p)
+	ai = imag(x0) + t3r*imag(x1p) + t6r*imag(x2p) + t9r*imag(x3p) + t12r*imag(x4p) + t14r*imag(x5p) +
+		t11r*imag(x6p) + t8r*imag(x7p) + t5r*imag(x8p) + t2r*imag(x9p) + t1r*imag(x10p) + t4r*imag(x11p) +
+		t7r*imag(x12p) + t10r*imag(x13p) + t13r*imag(x14p)
+	br = t3i*real(x1n) + t6i*real(x2n) + t9i*real(x3n) + t12i*real(x4n) - t14i*real(x5n) -
+		t11i*real(x6n) - t8i*real(x7n) - t5i*real(x8n) - t2i*real(x9n) + t1i*real(x10n) + t4i*real(x11n) +
+		t7i*real(x12n) + t10i*real(x13n) + t13i*real(x14n)
+	bi = t3i*imag(x1n) + t6i*imag(x2n) + t9i*imag(x3n) + t12i*imag(x4n) - t14i*imag(x5n) -
+		t11i*imag(x6n) - t8i*imag(x7n) - t5i*imag(x8n) - t2i*imag(x9n) + t1i*imag(x10n) + t4i*imag(x11n) +
+		t7i*imag(x12n) + t10i*imag(x13n) + t13i*imag(x14n)
+	buffer[3] = complex(ar-bi, ai+br)
+	buffer[26] = complex(ar+bi, ai-br)
+
+	// Outputs 4 and 25
+	ar = real(x0) + t4r*real(x1p) + t8r*real(x2p) + t12r*real(x3p) + t13r*real(x4p) + t9r*real(x5p) +
+		t5r*real(x6p) + t1r*real(x7p) + t3r*real(x8p) + t7r*real(x9p) + t11r*real(x10p) + t14r*real(x11p) +
+		t10r*real(x12p) + t6r*real(x13p) + t2r*real(x14p)
+	ai = imag(x0) + t4r*imag(x1p) + t8r*imag(x2p) + t12r*imag(x3p) + t13r*imag(x4p) + t9r*imag(x5p) +
+		t5r*imag(x6p) + t1r*imag(x7p) + t3r*imag(x8p) + t7r*imag(x9p) + t11r*imag(x10p) + t14r*imag(x11p) +
+		t10r*imag(x12p) + t6r*imag(x13p) + t2r*imag(x14p)
+	br = t4i*real(x1n) + t8i*real(x2n) + t12i*real(x3n) - t13i*real(x4n) - t9i*real(x5n) -
+		t5i*real(x6n) - t1i*real(x7n) + t3i*real(x8n) + t7i*real(x9n) + t11i*real(x10n) - t14i*real(x11n) -
+		t10i*real(x12n) - t6i*real(x13n) - t2i*real(x14n)
+	bi = t4i*imag(x1n) + t8i*imag(x2n) + t12i*imag(x3n) - t13i*imag(x4n) - t9i*imag(x5n) -
+		t5i*imag(x6n) - t1i*imag(x7n) + t3i*imag(x8n) + t7i*imag(x9n) + t11i*imag(x10n) - t14i*imag(x11n) -
+		t10i*imag(x12n) - t6i*imag(x13n) - t2i*imag(x14n)
+	buffer[4] = complex(ar-bi, ai+br)
+	buffer[25] = complex(ar+bi, ai-br)
+
+	// Outputs 5 and 24
+	ar = real(x0) + t5r*real(x1p) + t10r*real(x2p) + t14r*real(x3p) + t9r*real(x4p) + t4r*real(x5p) +
+		t1r*real(x6p) + t6r*real(x7p) + t11r*real(x8p) + t13r*real(x9p) + t8r*real(x10p) + t3r*real(x11p) +
+		t2r*real(x12p) + t7r*real(x13p) + t12r*real(x14p)
+	ai = imag(x0) + t5r*imag(x1p) + t10r*imag(x2p) + t14r*imag(x3p) + t9r*imag(x4p) + t4r*imag(x5p) +
+		t1r*imag(x6p) + t6r*imag(x7p) + t11r*imag(x8p) + t13r*imag(x9p) + t8r*imag(x10p) + t3r*imag(x11p) +
+		t2r*imag(x12p) + t7r*imag(x13p) + t12r*imag(x14p)
+	br = t5i*real(x1n) + t10i*real(x2n) - t14i*real(x3n) - t9i*real(x4n) - t4i*real(x5n) +
+		t1i*real(x6n) + t6i*real(x7n) + t11i*real(x8n) - t13i*real(x9n) - t8i*real(x10n) - t3i*real(x11n) +
+		t2i*real(x12n) + t7i*real(x13n) + t12i*real(x14n)
+	bi = t5i*imag(x1n) + t10i*imag(x2n) - t14i*imag(x3n) - t9i*imag(x4n) - t4i*imag(x5n) +
+		t1i*imag(x6n) + t6i*imag(x7n) + t11i*imag(x8n) - t13i*imag(x9n) - t8i*imag(x10n) - t3i*imag(x11n) +
+		t2i*imag(x12n) + t7i*imag(x13n) + t12i*imag(x14n)
+	buffer[5] = complex(ar-bi, ai+br)
+	buffer[24] = complex(ar+bi, ai-br)
+
+	// Outputs 6 and 23
+	ar = real(x0) + t6r*real(x1p) + t12r*real(x2p) + t11r*real(x3p) + t5r*real(x4p) + t1r*real(x5p) +
+		t7r*real(x6p) + t13r*real(x7p) + t10r*real(x8p) + t4r*real(x9p) + t2r*real(x10p) + t8r*real(x11p) +
+		t14r*real(x12p) + t9r*real(x13p) + t3r*real(x14p)
+	ai = imag(x0) + t6r*imag(x1p) + t12r*imag(x2p) + t11r*imag(x3p) + t5r*imag(x4p) + t1r*imag(x5p) +
+		t7r*imag(x6p) + t13r*imag(x7p) + t10r*imag(x8p) + t4r*imag(x9p) + t2r*imag(x10p) + t8r*imag(x11p) +
+		t14r*imag(x12p) + t9r*imag(x13p) + t3r*imag(x14p)
+	br = t6i*real(x1n) + t12i*real(x2n) - t11i*real(x3n) - t5i*real(x4n) + t1i*real(x5n) +
+		t7i*real(x6n) + t13i*real(x7n) - t10i*real(x8n) - t4i*real(x9n) + t2i*real(x10n) + t8i*real(x11n) +
+		t14i*real(x12n) - t9i*real(x13n) - t3i*real(x14n)
+	bi = t6i*imag(x1n) + t12i*imag(x2n) - t11i*imag(x3n) - t5i*imag(x4n) + t1i*imag(x5n) +
+		t7i*imag(x6n) + t13i*imag(x7n) - t10i*imag(x8n) - t4i*imag(x9n) + t2i*imag(x10n) + t8i*imag(x11n) +
+		t14i*imag(x12n) - t9i*imag(x13n) - t3i*imag(x14n)
+	buffer[6] = complex(ar-bi, ai+br)
+	buffer[23] = complex(ar+bi, ai-br)
+
+	// Outputs 7 and 22
+	ar = real(x0) + t7r*real(x1p) + t14r*real(x2p) + t8r*real(x3p) + t1r*real(x4p) + t6r*real(x5p) +
+		t13r*real(x6p) + t9r*real(x7p) + t2r*real(x8p) + t5r*real(x9p) + t12r*real(x10p) + t10r*real(x11p) +
+		t3r*real(x12p) + t4r*real(x13p) + t11r*real(x14p)
+	ai = imag(x0) + t7r*imag(x1p) + t14r*imag(x2p) + t8r*imag(x3p) + t1r*imag(x4p) + t6r*imag(x5p) +
+		t13r*imag(x6p) + t9r*imag(x7p) + t2r*imag(x8p) + t5r*imag(x9p) + t12r*imag(x10p) + t10r*imag(x11p) +
+		t3r*imag(x12p) + t4r*imag(x13p) + t11r*imag(x14p)
+	br = t7i*real(x1n) + t14i*real(x2n) - t8i*real(x3n) - t1i*real(x4n) + t6i*real(x5n) +
+		t13i*real(x6n) - t9i*real(x7n) - t2i*real(x8n) + t5i*real(x9n) + t12i*real(x10n) - t10i*real(x11n) -
+		t3i*real(x12n) + t4i*real(x13n) + t11i*real(x14n)
+	bi = t7i*imag(x1n) + t14i*imag(x2n) - t8i*imag(x3n) - t1i*imag(x4n) + t6i*imag(x5n) +
+		t13i*imag(x6n) - t9i*imag(x7n) - t2i*imag(x8n) + t5i*imag(x9n) + t12i*imag(x10n) - t10i*imag(x11n) -
+		t3i*imag(x12n) + t4i*imag(x13n) + t11i*imag(x14n)
+	buffer[7] = complex(ar-bi, ai+br)
+	buffer[22] = complex(ar+bi, ai-br)
+
+	// Outputs 8 and 21
+	ar = real(x0) + t8r*real(x1p) + t13r*real(x2p) + t5r*real(x3p) + t3r*real(x4p) + t11r*real(x5p) +
+		t10r*real(x6p) + t2r*real(x7p) + t6r*real(x8p) + t14r*real(x9p) + t7r*real(x10p) + t1r*real(x11p) +
+		t9r*real(x12p) + t12r*real(x13p) + t4r*real(x14p)
+	ai = imag(x0) + t8r*imag(x1p) + t13r*imag(x2p) + t5r*imag(x3p) + t3r*imag(x4p) + t11r*imag(x5p) +
+		t10r*imag(x6p) + t2r*imag(x7p) + t6r*imag(x8p) + t14r*imag(x9p) + t7r*imag(x10p) + t1r*imag(x11p) +
+		t9r*imag(x12p) + t12r*imag(x13p) + t4r*imag(x14p)
+	br = t8i*real(x1n) - t13i*real(x2n) - t5i*real(x3n) + t3i*real(x4n) + t11i*real(x5n) -
+		t10i*real(x6n) - t2i*real(x7n) + t6i*real(x8n) + t14i*real(x9n) - t7i*real(x10n) + t1i*real(x11n) +
+		t9i*real(x12n) - t12i*real(x13n) - t4i*real(x14n)
+	bi = t8i*imag(x1n) - t13i*imag(x2n) - t5i*imag(x3n) + t3i*imag(x4n) + t11i*imag(x5n) -
+		t10i*imag(x6n) - t2i*imag(x7n) + t6i*imag(x8n) + t14i*imag(x9n) - t7i*imag(x10n) + t1i*imag(x11n) +
+		t9i*imag(x12n) - t12i*imag(x13n) - t4i*imag(x14n)
+	buffer[8] = complex(ar-bi, ai+br)
+	buffer[21] = complex(ar+bi, ai-br)
+
+	// Outputs 9 and 20
+	ar = real(x0) + t9r*real(x1p) + t11r*real(x2p) + t2r*real(x3p) + t7r*real(x4p) + t13r*real(x5p) +
+		t4r*real(x6p) + t5r*real(x7p) + t14r*real(x8p) + t6r*real(x9p) + t3r*real(x10p) + t12r*real(x11p) +
+		t8r*real(x12p) + t1r*real(x13p) + t10r*real(x14p)
+	ai = imag(x0) + t9r*imag(x1p) + t11r*imag(x2p) + t2r*imag(x3p) + t7r*imag(x4p) + t13r*imag(x5p) +
+		t4r*imag(x6p) + t5r*imag(x7p) + t14r*imag(x8p) + t6r*imag(x9p) + t3r*imag(x10p) + t12r*imag(x11p) +
+		t8r*imag(x12p) + t1r*imag(x13p) + t10r*imag(x14p)
+	br = t9i*real(x1n) - t11i*real(x2n) - t2i*real(x3n) + t7i*real(x4n) - t13i*real(x5n) -
+		t4i*real(x6n) + t5i*real(x7n) + t14i*real(x8n) - t6i*real(x9n) + t3i*real(x10n) + t12i*real(x11n) -
+		t8i*real(x12n) + t1i*real(x13n) + t10i*real(x14n)
+	bi = t9i*imag(x1n) - t11i*imag(x2n) - t2i*imag(x3n) + t7i*imag(x4n) - t13i*imag(x5n) -
+		t4i*imag(x6n) + t5i*imag(x7n) + t14i*imag(x8n) - t6i*imag(x9n) + t3i*imag(x10n) + t12i*imag(x11n) -
+		t8i*imag(x12n) + t1i*imag(x13n) + t10i*imag(x14n)
+	buffer[9] = complex(ar-bi, ai+br)
+	buffer[20] = complex(ar+bi, ai-br)
+
+	// Outputs 10 and 19
+	ar = real(x0) + t10r*real(x1p) + t9r*real(x2p) + t1r*real(x3p) + t11r*real(x4p) + t8r*real(x5p) +
+		t2r*real(x6p) + t12r*real(x7p) + t7r*real(x8p) + t3r*real(x9p) + t13r*real(x10p) + t6r*real(x11p) +
+		t4r*real(x12p) + t14r*real(x13p) + t5r*real(x14p)
+	ai = imag(x0) + t10r*imag(x1p) + t9r*imag(x2p) + t1r*imag(x3p) + t11r*imag(x4p) + t8r*imag(x5p) +
+		t2r*imag(x6p) + t12r*imag(x7p) + t7r*imag(x8p) + t3r*imag(x9p) + t13r*imag(x10p) + t6r*imag(x11p) +
+		t4r*imag(x12p) + t14r*imag(x13p) + t5r*imag(x14p)
+	br = t10i*real(x1n) - t9i*real(x2n) + t1i*real(x3n) + t11i*real(x4n) - t8i*real(x5n) +
+		t2i*real(x6n) + t12i*real(x7n) - t7i*real(x8n) + t3i*real(x9n) + t13i*real(x10n) - t6i*real(x11n) +
+		t4i*real(x12n) + t14i*real(x13n) - t5i*real(x14n)
+	bi = t10i*imag(x1n) - t9i*imag(x2n) + t1i*imag(x3n) + t11i*imag(x4n) - t8i*imag(x5n) +
+		t2i*imag(x6n) + t12i*imag(x7n) - t7i*imag(x8n) + t3i*imag(x9n) + t13i*imag(x10n) - t6i*imag(x11n) +
+		t4i*imag(x12n) + t14i*imag(x13n) - t5i*imag(x14n)
+	buffer[10] = complex(ar-bi, ai+br)
+	buffer[19] = complex(ar+bi, ai-br)
+
+	// Outputs 11 and 18
+	ar = real(x0) + t11r*real(x1p) + t7r*real(x2p) + t4r*real(x3p) + t14r*real(x4p) + t3r*real(x5p) +
+		t8r*real(x6p) + t10r*real(x7p) + t1r*real(x8p) + t12r*real(x9p) + t6r*real(x10p) + t5r*real(x11p) +
+		t13r*real(x12p) + t2r*real(x13p) + t9r*real(x14p)
+	ai = imag(x0) + t11r*imag(x1p) + t7r*imag(x2p) + t4r*imag(x3p) + t14r*imag(x4p) + t3r*imag(x5p) +
+		t8r*imag(x6p) + t10r*imag(x7p) + t1r*imag(x8p) + t12r*imag(x9p) + t6r*imag(x10p) + t5r*imag(x11p) +
+		t13r*imag(x12p) + t2r*imag(x13p) + t9r*imag(x14p)
+	br = t11i*real(x1n) - t7i*real(x2n) + t4i*real(x3n) - t14i*real(x4n) - t3i*real(x5n) +
+		t8i*real(x6n) - t10i*real(x7n) + t1i*real(x8n) + t12i*real(x9n) - t6i*real(x10n) + t5i*real(x11n) -
+		t13i*real(x12n) - t2i*real(x13n) + t9i*real(x14n)
+	bi = t11i*imag(x1n) - t7i*imag(x2n) + t4i*imag(x3n) - t14i*imag(x4n) - t3i*imag(x5n) +
+		t8i*imag(x6n) - t10i*imag(x7n) + t1i*imag(x8n) + t12i*imag(x9n) - t6i*imag(x10n) + t5i*imag(x11n) -
+		t13i*imag(x12n) - t2i*imag(x13n) + t9i*imag(x14n)
+	buffer[11] = complex(ar-bi, ai+br)
+	buffer[18] = complex(ar+bi, ai-br)
+
+	// Outputs 12 and 17
+	ar = real(x0) + t12r*real(x1p) + t5r*real(x2p) + t7r*real(x3p) + t10r*real(x4p) + t2r*real(x5p) +
+		t14r*real(x6p) + t3r*real(x7p) + t9r*real(x8p) + t8r*real(x9p) + t4r*real(x10p) + t13r*real(x11p) +
+		t1r*real(x12p) + t11r*real(x13p) + t6r*real(x14p)
+	ai = imag(x0) + t12r*imag(x1p) + t5r*imag(x2p) + t7r*imag(x3p) + t10r*imag(x4p) + t2r*imag(x5p) +
+		t14r*imag(x6p) + t3r*imag(x7p) + t9r*imag(x8p) + t8r*imag(x9p) + t4r*imag(x10p) + t13r*imag(x11p) +
+		t1r*imag(x12p) + t11r*imag(x13p) + t6r*imag(x14p)
+	br = t12i*real(x1n) - t5i*real(x2n) + t7i*real(x3n) - t10i*real(x4n) + t2i*real(x5n) +
+		t14i*real(x6n) - t3i*real(x7n) + t9i*real(x8n) - t8i*real(x9n) + t4i*real(x10n) - t13i*real(x11n) -
+		t1i*real(x12n) + t11i*real(x13n) - t6i*real(x14n)
+	bi = t12i*imag(x1n) - t5i*imag(x2n) + t7i*imag(x3n) - t10i*imag(x4n) + t2i*imag(x5n) +
+		t14i*imag(x6n) - t3i*imag(x7n) + t9i*imag(x8n) - t8i*imag(x9n) + t4i*imag(x10n) - t13i*imag(x11n) -
+		t1i*imag(x12n) + t11i*imag(x13n) - t6i*imag(x14n)
+	buffer[12] = complex(ar-bi, ai+br)
+	buffer[17] = complex(ar+bi, ai-br)
+
+	// Outputs 13 and 16
+	ar = real(x0) + t13r*real(x1p) + t3r*real(x2p) + t10r*real(x3p) + t6r*real(x4p) + t7r*real(x5p) +
+		t9r*real(x6p) + t4r*real(x7p) + t12r*real(x8p) + t1r*real(x9p) + t14r*real(x10p) + t2r*real(x11p) +
+		t11r*real(x12p) + t5r*real(x13p) + t8r*real(x14p)
+	ai = imag(x0) + t13r*imag(x1p) + t3r*imag(x2p) + t10r*imag(x3p) + t6r*imag(x4p) + t7r*imag(x5p) +
+		t9r*imag(x6p) + t4r*imag(x7p) + t12r*imag(x8p) + t1r*imag(x9p) + t14r*imag(x10p) + t2r*imag(x11p) +
+		t11r*imag(x12p) + t5r*imag(x13p) + t8r*imag(x14p)
+	br = t13i*real(x1n) - t3i*real(x2n) + t10i*real(x3n) - t6i*real(x4n) + t7i*real(x5n) -
+		t9i*real(x6n) + t4i*real(x7n) - t12i*real(x8n) + t1i*real(x9n) + t14i*real(x10n) - t2i*real(x11n) +
+		t11i*real(x12n) - t5i*real(x13n) + t8i*real(x14n)
+	bi = t13i*imag(x1n) - t3i*imag(x2n) + t10i*imag(x3n) - t6i*imag(x4n) + t7i*imag(x5n) -
+		t9i*imag(x6n) + t4i*imag(x7n) - t12i*imag(x8n) + t1i*imag(x9n) + t14i*imag(x10n) - t2i*imag(x11n) +
+		t11i*imag(x12n) - t5i*imag(x13n) + t8i*imag(x14n)
+	buffer[13] = complex(ar-bi, ai+br)
+	buffer[16] = complex(ar+bi, ai-br)
+
+	// Outputs 14 and 15
+	ar = real(x0) + t14r*real(x1p) + t1r*real(x2p) + t13r*real(x3p) + t2r*real(x4p) + t12r*real(x5p) +
+		t3r*real(x6p) + t11r*real(x7p) + t4r*real(x8p) + t10r*real(x9p) + t5r*real(x10p) + t9r*real(x11p) +
+		t6r*real(x12p) + t8r*real(x13p) + t7r*real(x14p)
+	ai = imag(x0) + t14r*imag(x1p) + t1r*imag(x2p) + t13r*imag(x3p) + t2r*imag(x4p) + t12r*imag(x5p) +
+		t3r*imag(x6p) + t11r*imag(x7p) + t4r*imag(x8p) + t10r*imag(x9p) + t5r*imag(x10p) + t9r*imag(x11p) +
+		t6r*imag(x12p) + t8r*imag(x13p) + t7r*imag(x14p)
+	br = t14i*real(x1n) - t1i*real(x2n) + t13i*real(x3n) - t2i*real(x4n) + t12i*real(x5n) -
+		t3i*real(x6n) + t11i*real(x7n) - t4i*real(x8n) + t10i*real(x9n) - t5i*real(x10n) + t9i*real(x11n) -
+		t6i*real(x12n) + t8i*real(x13n) - t7i*real(x14n)
+	bi = t14i*imag(x1n) - t1i*imag(x2n) + t13i*imag(x3n) - t2i*imag(x4n) + t12i*imag(x5n) -
+		t3i*imag(x6n) + t11i*imag(x7n) - t4i*imag(x8n) + t10i*imag(x9n) - t5i*imag(x10n) + t9i*imag(x11n) -
+		t6i*imag(x12n) + t8i*imag(x13n) - t7i*imag(x14n)
+	buffer[14] = complex(ar-bi, ai+br)
+	buffer[15] = complex(ar+bi, ai-br)
 }
 
-// Butterfly31 implements a size-31 FFT (prime)
+func (b *Butterfly29) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly31 implements a size-31 FFT (prime size)
 type Butterfly31 struct {
-	inner *Dft
+	direction Direction
+	twiddles  [15]complex128 // W1-W15 (W16-W30 are conjugates)
 }
 
+// NewButterfly31 creates a new Butterfly31 instance
 func NewButterfly31(direction Direction) *Butterfly31 {
-	return &Butterfly31{inner: NewDft(31, direction)}
+	return &Butterfly31{
+		direction: direction,
+		twiddles: [15]complex128{
+			twiddleFactor(1, 31, direction),
+			twiddleFactor(2, 31, direction),
+			twiddleFactor(3, 31, direction),
+			twiddleFactor(4, 31, direction),
+			twiddleFactor(5, 31, direction),
+			twiddleFactor(6, 31, direction),
+			twiddleFactor(7, 31, direction),
+			twiddleFactor(8, 31, direction),
+			twiddleFactor(9, 31, direction),
+			twiddleFactor(10, 31, direction),
+			twiddleFactor(11, 31, direction),
+			twiddleFactor(12, 31, direction),
+			twiddleFactor(13, 31, direction),
+			twiddleFactor(14, 31, direction),
+			twiddleFactor(15, 31, direction),
+		},
+	}
+}
+
+func (b *Butterfly31) Len() int                  { return 31 }
+func (b *Butterfly31) Direction() Direction      { return b.direction }
+func (b *Butterfly31) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly31) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly31) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly31) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
 }
 
-func (b *Butterfly31) Len() int               { return 31 }
-func (b *Butterfly31) Direction() Direction   { return b.inner.Direction() }
-func (b *Butterfly31) InplaceScratchLen() int { return b.inner.InplaceScratchLen() }
 func (b *Butterfly31) ProcessWithScratch(buffer, scratch []complex128) {
-	b.inner.ProcessWithScratch(buffer, scratch)
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 31 {
+		b.performFft(buffer[i : i+31])
+	}
+}
+
+func (b *Butterfly31) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 31 {
+		b.performFftOutOfPlace(input[i:i+31], output[i:i+31])
+	}
+}
+
+func (b *Butterfly31) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly31) performFft(buffer []complex128) {
+	// Sums and differences of the symmetric pairs x[j], x[31-j]
+	x0 := buffer[0]
+	x1p, x1n := buffer[1]+buffer[30], buffer[1]-buffer[30]
+	x2p, x2n := buffer[2]+buffer[29], buffer[2]-buffer[29]
+	x3p, x3n := buffer[3]+buffer[28], buffer[3]-buffer[28]
+	x4p, x4n := buffer[4]+buffer[27], buffer[4]-buffer[27]
+	x5p, x5n := buffer[5]+buffer[26], buffer[5]-buffer[26]
+	x6p, x6n := buffer[6]+buffer[25], buffer[6]-buffer[25]
+	x7p, x7n := buffer[7]+buffer[24], buffer[7]-buffer[24]
+	x8p, x8n := buffer[8]+buffer[23], buffer[8]-buffer[23]
+	x9p, x9n := buffer[9]+buffer[22], buffer[9]-buffer[22]
+	x10p, x10n := buffer[10]+buffer[21], buffer[10]-buffer[21]
+	x11p, x11n := buffer[11]+buffer[20], buffer[11]-buffer[20]
+	x12p, x12n := buffer[12]+buffer[19], buffer[12]-buffer[19]
+	x13p, x13n := buffer[13]+buffer[18], buffer[13]-buffer[18]
+	x14p, x14n := buffer[14]+buffer[17], buffer[14]-buffer[17]
+	x15p, x15n := buffer[15]+buffer[16], buffer[15]-buffer[16]
+
+	// W^j for j = 1..15; the other twiddles are their conjugates
+	t1r, t1i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t2r, t2i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t3r, t3i := real(b.twiddles[2]), imag(b.twiddles[2])
+	t4r, t4i := real(b.twiddles[3]), imag(b.twiddles[3])
+	t5r, t5i := real(b.twiddles[4]), imag(b.twiddles[4])
+	t6r, t6i := real(b.twiddles[5]), imag(b.twiddles[5])
+	t7r, t7i := real(b.twiddles[6]), imag(b.twiddles[6])
+	t8r, t8i := real(b.twiddles[7]), imag(b.twiddles[7])
+	t9r, t9i := real(b.twiddles[8]), imag(b.twiddles[8])
+	t10r, t10i := real(b.twiddles[9]), imag(b.twiddles[9])
+	t11r, t11i := real(b.twiddles[10]), imag(b.twiddles[10])
+	t12r, t12i := real(b.twiddles[11]), imag(b.twiddles[11])
+	t13r, t13i := real(b.twiddles[12]), imag(b.twiddles[12])
+	t14r, t14i := real(b.twiddles[13]), imag(b.twiddles[13])
+	t15r, t15i := real(b.twiddles[14]), imag(b.twiddles[14])
+
+	buffer[0] = x0 + x1p + x2p + x3p + x4p + x5p + x6p + x7p + x8p + x9p + x10p + x11p + x12p + x13p + x14p + x15p
+
+	// Outputs 1 and 30
+	ar := real(x0) + t1r*real(x1p) + t2r*real(x2p) + t3r*real(x3p) + t4r*real(x4p) + t5r*real(x5p) +
+		t6r*real(x6p) + t7r*real(x7p) + t8r*real(x8p) + t9r*real(x9p) + t10r*real(x10p) + t11r*real(x11p) +
+		t12r*real(x12p) + t13r*real(x13p) + t14r*real(x14p) + t15r*real(x15p)
+	ai := imag(x0) + t1r*imag(x1p) + t2r*imag(x2p) + t3r*imag(x3p) + t4r*imag(x4p) + t5r*imag(x5p) +
+		t6r*imag(x6p) + t7r*imag(x7p) + t8r*imag(x8p) + t9r*imag(x9p) + t10r*imag(x10p) + t11r*imag(x11p) +
+		t12r*imag(x12p) + t13r*imag(x13p) + t14r*imag(x14p) + t15r*imag(x15p)
+	br := t1i*real(x1n) + t2i*real(x2n) + t3i*real(x3n) + t4i*real(x4n) + t5i*real(x5n) + t6i*real(x6n) +
+		t7i*real(x7n) + t8i*real(x8n) + t9i*real(x9n) + t10i*real(x10n) + t11i*real(x11n) +
+		t12i*real(x12n) + t13i*real(x13n) + t14i*real(x14n) + t15i*real(x15n)
+	bi := t1i*imag(x1n) + t2i*imag(x2n) + t3i*imag(x3n) + t4i*imag(x4n) + t5i*imag(x5n) + t6i*imag(x6n) +
+		t7i*imag(x7n) + t8i*imag(x8n) + t9i*imag(x9n) + t10i*imag(x10n) + t11i*imag(x11n) +
+		t12i*imag(x12n) + t13i*imag(x13n) + t14i*imag(x14n) + t15i*imag(x15n)
+	buffer[1] = complex(ar-bi, ai+br)
+	buffer[30] = complex(ar+bi, ai-br)
+
+	// Outputs 2 and 29
+	ar = real(x0) + t2r*real(x1p) + t4r*real(x2p) + t6r*real(x3p) + t8r*real(x4p) + t10r*real(x5p) +
+		t12r*real(x6p) + t14r*real(x7p) + t15r*real(x8p) + t13r*real(x9p) + t11r*real(x10p) +
+		t9r*real(x11p) + t7r*real(x12p) + t5r*real(x13p) + t3r*real(x14p) + t1r*real(x15p)
+	ai = imag(x0) + t2r*imag(x1p) + t4r*imag(x2p) + t6r*imag(x3p) + t8r*imag(x4p) + t10r*imag(x5p) +
+		t12r*imag(x6p) + t14r*imag(x7p) + t15r*imag(x8p) + t13r*imag(x9p) + t11r*imag(x10p) +
+		t9r*imag(x11p) + t7r*imag(x12p) + t5r*imag(x13p) + t3r*imag(x14p) + t1r*imag(x15p)
+	br = t2i*real(x1n) + t4i*real(x2n) + t6i*real(x3n) + t8i*real(x4n) + t10i*real(x5n) +
+		t12i*real(x6n) + t14i*real(x7n) - t15i*real(x8n) - t13i*real(x9n) - t11i*real(x10n) -
+		t9i*real(x11n) - t7i*real(x12n) - t5i*real(x13n) - t3i*real(x14n) - t1i*real(x15n)
+	bi = t2i*imag(x1n) + t4i*imag(x2n) + t6i*imag(x3n) + t8i*imag(x4n) + t10i*imag(x5n) +
+		t12i*imag(x6n) + t14i*imag(x7n) - t15i*imag(x8n) - t13i*imag(x9n) - t11i*imag(x10n) -
+		t9i*imag(x11n) - t7i*imag(x12n) - t5i*imag(x13n) - t3i*imag(x14n) - t1i*imag(x15n)
+	buffer[2] = complex(ar-bi, ai+br)
+	buffer[29] = complex(ar+bi, ai-br)
+
+	// Outputs 3 and 28
+	ar = real(x0) + t3r*real(x1p) + t6r*real(x2p) + t9r*real(x3p) + t12r*real(x4p) + t15r*real(x5p) +
+		t13r*real(x6p) + t10r*real(x7p) + t7r*real(x8p) + t4r*real(x9p) + t1r*real(x10p) + t2r*real(x11p) +
+		t5r*real(x12p) + t8r*real(x13p) + t11r*real(x14p) + t14r*real(x15p)
+	ai = imag(x0) + t3r*imag(x1p) + t6r*imag(x2p) + t9r*imag(x3p) + t12r*imag(x4p) + t15r*imag(x5p) +
+		t13r*imag(x6p) + t10r*imag(x7p) + t7r*imag(x8p) + t4r*imag(x9p) + t1r*imag(x10p) + t2r*imag(x11p) +
+		t5r*imag(x12p) + t8r*imag(x13p) + t11r*imag(x14p) + t14r*imag(x15p)
+	br = t3i*real(x1n) + t6i*real(x2n) + t9i*real(x3n) + t12i*real(x4n) + t15i*real(x5n) -
+		t13i*real(x6n) - t10i*real(x7n) - t7i*real(x8n) - t4i*real(x9n) - t1i*real(x10n) + t2i*real(x11n) +
+		t5i*real(x12n) + t8i*real(x13n) + t11i*real(x14n) + t14i*real(x15n)
+	bi = t3i*imag(x1n) + t6i*imag(x2n) + t9i*imag(x3n) + t12i*imag(x4n) + t15i*imag(x5n) -
+		t13i*imag(x6n) - t10i*imag(x7n) - t7i*imag(x8n) - t4i*imag(x9n) - t1i*imag(x10n) + t2i*imag(x11n) +
+		t5i*imag(x12n) + t8i*imag(x13n) + t11i*imag(x14n) + t14i*imag(x15n)
+	buffer[3] = complex(ar-bi, ai+br)
+	buffer[28] = complex(ar+bi, ai-br)
+
+	// Outputs 4 and 27
+	ar = real(x0) + t4r*real(x1p) + t8r*real(x2p) + t12r*real(x3p) + t15r*real(x4p) + t11r*real(x5p) +
+		t7r*real(x6p) + t3r*real(x7p) + t1r*real(x8p) + t5r*real(x9p) + t9r*real(x10p) + t13r*real(x11p) +
+		t14r*real(x12p) + t10r*real(x13p) + t6r*real(x14p) + t2r*real(x15p)
+	ai = imag(x0) + t4r*imag(x1p) + t8r*imag(x2p) + t12r*imag(x3p) + t15r*imag(x4p) + t11r*imag(x5p) +
+		t7r*imag(x6p) + t3r*imag(x7p) + t1r*imag(x8p) + t5r*imag(x9p) + t9r*imag(x10p) + t13r*imag(x11p) +
+		t14r*imag(x12p) + t10r*imag(x13p) + t6r*imag(x14p) + t2r*imag(x15p)
+	br = t4i*real(x1n) + t8i*real(x2n) + t12i*real(x3n) - t15i*real(x4n) - t11i*real(x5n) -
+		t7i*real(x6n) - t3i*real(x7n) + t1i*real(x8n) + t5i*real(x9n) + t9i*real(x10n) + t13i*real(x11n) -
+		t14i*real(x12n) - t10i*real(x13n) - t6i*real(x14n) - t2i*real(x15n)
+	bi = t4i*imag(x1n) + t8i*imag(x2n) + t12i*imag(x3n) - t15i*imag(x4n) - t11i*imag(x5n) -
+		t7i*imag(x6n) - t3i*imag(x7n) + t1i*imag(x8n) + t5i*imag(x9n) + t9i*imag(x10n) + t13i*imag(x11n) -
+		t14i*imag(x12n) - t10i*imag(x13n) - t6i*imag(x14n) - t2i*imag(x15n)
+	buffer[4] = complex(ar-bi, ai+br)
+	buffer[27] = complex(ar+bi, ai-br)
+
+	// Outputs 5 and 26
+	ar = real(x0) + t5r*real(x1p) + t10r*real(x2p) + t15r*real(x3p) + t11r*real(x4p) + t6r*real(x5p) +
+		t1r*real(x6p) + t4r*real(x7p) + t9r*real(x8p) + t14r*real(x9p) + t12r*real(x10p) + t7r*real(x11p) +
+		t2r*real(x12p) + t3r*real(x13p) + t8r*real(x14p) + t13r*real(x15p)
+	ai = imag(x0) + t5r*imag(x1p) + t10r*imag(x2p) + t15r*imag(x3p) + t11r*imag(x4p) + t6r*imag(x5p) +
+		t1r*imag(x6p) + t4r*imag(x7p) + t9r*imag(x8p) + t14r*imag(x9p) + t12r*imag(x10p) + t7r*imag(x11p) +
+		t2r*imag(x12p) + t3r*imag(x13p) + t8r*imag(x14p) + t13r*imag(x15p)
+	br = t5i*real(x1n) + t10i*real(x2n) + t15i*real(x3n) - t11i*real(x4n) - t6i*real(x5n) -
+		t1i*real(x6n) + t4i*real(x7n) + t9i*real(x8n) + t14i*real(x9n) - t12i*real(x10n) - t7i*real(x11n) -
+		t2i*real(x12n) + t3i*real(x13n) + t8i*real(x14n) + t13i*real(x15n)
+	bi = t5i*imag(x1n) + t10i*imag(x2n) + t15i*imag(x3n) - t11i*imag(x4n) - t6i*imag(x5n) -
+		t1i*imag(x6n) + t4i*imag(x7n) + t9i*imag(x8n) + t14i*imag(x9n) - t12i*imag(x10n) - t7i*imag(x11n) -
+		t2i*imag(x12n) + t3i*imag(x13n) + t8i*imag(x14n) + t13i*imag(x15n)
+	buffer[5] = complex(ar-bi, ai+br)
+	buffer[26] = complex(ar+bi, ai-br)
+
+	// Outputs 6 and 25
+	ar = real(x0) + t6r*real(x1p) + t12r*real(x2p) + t13r*real(x3p) + t7r*real(x4p) + t1r*real(x5p) +
+		t5r*real(x6p) + t11r*real(x7p) + t14r*real(x8p) + t8r*real(x9p) + t2r*real(x10p) + t4r*real(x11p) +
+		t10r*real(x12p) + t15r*real(x13p) + t9r*real(x14p) + t3r*real(x15p)
+	ai = imag(x0) + t6r*imag(x1p) + t12r*imag(x2p) + t13r*imag(x3p) + t7r*imag(x4p) + t1r*imag(x5p) +
+		t5r*imag(x6p) + t11r*imag(x7p) + t14r*imag(x8p) + t8r*imag(x9p) + t2r*imag(x10p) + t4r*imag(x11p) +
+		t10r*imag(x12p) + t15r*imag(x13p) + t9r*imag(x14p) + t3r*imag(x15p)
+	br = t6i*real(x1n) + t12i*real(x2n) - t13i*real(x3n) - t7i*real(x4n) - t1i*real(x5n) +
+		t5i*real(x6n) + t11i*real(x7n) - t14i*real(x8n) - t8i*real(x9n) - t2i*real(x10n) + t4i*real(x11n) +
+		t10i*real(x12n) - t15i*real(x13n) - t9i*real(x14n) - t3i*real(x15n)
+	bi = t6i*imag(x1n) + t12i*imag(x2n) - t13i*imag(x3n) - t7i*imag(x4n) - t1i*imag(x5n) +
+		t5i*imag(x6n) + t11i*imag(x7n) - t14i*imag(x8n) - t8i*imag(x9n) - t2i*imag(x10n) + t4i*imag(x11n) +
+		t10i*imag(x12n) - t15i*imag(x13n) - t9i*imag(x14n) - t3i*imag(x15n)
+	buffer[6] = complex(ar-bi, ai+br)
+	buffer[25] = complex(ar+bi, ai-br)
+
+	// Outputs 7 and 24
+	ar = real(x0) + t7r*real(x1p) + t14r*real(x2p) + t10r*real(x3p) + t3r*real(x4p) + t4r*real(x5p) +
+		t11r*real(x6p) + t13r*real(x7p) + t6r*real(x8p) + t1r*real(x9p) + t8r*real(x10p) + t15r*real(x11p) +
+		t9r*real(x12p) + t2r*real(x13p) + t5r*real(x14p) + t12r*real(x15p)
+	ai = imag(x0) + t7r*imag(x1p) + t14r*imag(x2p) + t10r*imag(x3p) + t3r*imag(x4p) + t4r*imag(x5p) +
+		t11r*imag(x6p) + t13r*imag(x7p) + t6r*imag(x8p) + t1r*imag(x9p) + t8r*imag(x10p) + t15r*imag(x11p) +
+		t9r*imag(x12p) + t2r*imag(x13p) + t5r*imag(x14p) + t12r*imag(x15p)
+	br = t7i*real(x1n) + t14i*real(x2n) - t10i*real(x3n) - t3i*real(x4n) + t4i*real(x5n) +
+		t11i*real(x6n) - t13i*real(x7n) - t6i*real(x8n) + t1i*real(x9n) + t8i*real(x10n) + t15i*real(x11n) -
+		t9i*real(x12n) - t2i*real(x13n) + t5i*real(x14n) + t12i*real(x15n)
+	bi = t7i*imag(x1n) + t14i*imag(x2n) - t10i*imag(x3n) - t3i*imag(x4n) + t4i*imag(x5n) +
+		t11i*imag(x6n) - t13i*imag(x7n) - t6i*imag(x8n) + t1i*imag(x9n) + t8i*imag(x10n) + t15i*imag(x11n) -
+		t9i*imag(x12n) - t2i*imag(x13n) + t5i*imag(x14n) + t12i*imag(x15n)
+	buffer[7] = complex(ar-bi, ai+br)
+	buffer[24] = complex(ar+bi, ai-br)
+
+	// Outputs 8 and 23
+	ar = real(x0) + t8r*real(x1p) + t15r*real(x2p) + t7r*real(x3p) + t1r*real(x4p) + t9r*real(x5p) +
+		t14r*real(x6p) + t6r*real(x7p) + t2r*real(x8p) + t10r*real(x9p) + t13r*real(x10p) + t5r*real(x11p) +
+		t3r*real(x12p) + t11r*real(x13p) + t12r*real(x14p) + t4r*real(x15p)
+	ai = imag(x0) + t8r*imag(x1p) + t15r*imag(x2p) + t7r*imag(x3p) + t1r*imag(x4p) + t9r*imag(x5p) +
+		t14r*imag(x6p) + t6r*imag(x7p) + t2r*imag(x8p) + t10r*imag(x9p) + t13r*imag(x10p) + t5r*imag(x11p) +
+		t3r*imag(x12p) + t11r*imag(x13p) + t12r*imag(x14p) + t4r*imag(x15p)
+	br = t8i*real(x1n) - t15i*real(x2n) - t7i*real(x3n) + t1i*real(x4n) + t9i*real(x5n) -
+		t14i*real(x6n) - t6i*real(x7n) + t2i*real(x8n) + t10i*real(x9n) - t13i*real(x10n) - t5i*real(x11n) +
+		t3i*real(x12n) + t11i*real(x13n) - t12i*real(x14n) - t4i*real(x15n)
+	bi = t8i*imag(x1n) - t15i*imag(x2n) - t7i*imag(x3n) + t1i*imag(x4n) + t9i*imag(x5n) -
+		t14i*imag(x6n) - t6i*imag(x7n) + t2i*imag(x8n) + t10i*imag(x9n) - t13i*imag(x10n) - t5i*imag(x11n) +
+		t3i*imag(x12n) + t11i*imag(x13n) - t12i*imag(x14n) - t4i*imag(x15n)
+	buffer[8] = complex(ar-bi, ai+br)
+	buffer[23] = complex(ar+bi, ai-br)
+
+	// Outputs 9 and 22
+	ar = real(x0) + t9r*real(x1p) + t13r*real(x2p) + t4r*real(x3p) + t5r*real(x4p) + t14r*real(x5p) +
+		t8r*real(x6p) + t1r*real(x7p) + t10r*real(x8p) + t12r*real(x9p) + t3r*real(x10p) + t6r*real(x11p) +
+		t15r*real(x12p) + t7r*real(x13p) + t2r*real(x14p) + t11r*real(x15p)
+	ai = imag(x0) + t9r*imag(x1p) + t13r*imag(x2p) + t4r*imag(x3p) + t5r*imag(x4p) + t14r*imag(x5p) +
+		t8r*imag(x6p) + t1r*imag(x7p) + t10r*imag(x8p) + t12r*imag(x9p) + t3r*imag(x10p) + t6r*imag(x11p) +
+		t15r*imag(x12p) + t7r*imag(x13p) + t2r*imag(x14p) + t11r*imag(x15p)
+	br = t9i*real(x1n) - t13i*real(x2n) - t4i*real(x3n) + t5i*real(x4n) + t14i*real(x5n) -
+		t8i*real(x6n) + t1i*real(x7n) + t10i*real(x8n) - t12i*real(x9n) - t3i*real(x10n) + t6i*real(x11n) +
+		t15i*real(x12n) - t7i*real(x13n) + t2i*real(x14n) + t11i*real(x15n)
+	bi = t9i*imag(x1n) - t13i*imag(x2n) - t4i*imag(x3n) + t5i*imag(x4n) + t14i*imag(x5n) -
+		t8i*imag(x6n) + t1i*imag(x7n) + t10i*imag(x8n) - t12i*imag(x9n) - t3i*imag(x10n) + t6i*imag(x11n) +
+		t15i*imag(x12n) - t7i*imag(x13n) + t2i*imag(x14n) + t11i*imag(x15n)
+	buffer[9] = complex(ar-bi, ai+br)
+	buffer[22] = complex(ar+bi, ai-br)
+
+	// Outputs 10 and 21
+	ar = real(x0) + t10r*real(x1p) + t11r*real(x2p) + t1r*real(x3p) + t9r*real(x4p) + t12r*real(x5p) +
+		t2r*real(x6p) + t8r*real(x7p) + t13r*real(x8p) + t3r*real(x9p) + t7r*real(x10p) + t14r*real(x11p) +
+		t4r*real(x12p) + t6r*real(x13p) + t15r*real(x14p) + t5r*real(x15p)
+	ai = imag(x0) + t10r*imag(x1p) + t11r*imag(x2p) + t1r*imag(x3p) + t9r*imag(x4p) + t12r*imag(x5p) +
+		t2r*imag(x6p) + t8r*imag(x7p) + t13r*imag(x8p) + t3r*imag(x9p) + t7r*imag(x10p) + t14r*imag(x11p) +
+		t4r*imag(x12p) + t6r*imag(x13p) + t15r*imag(x14p) + t5r*imag(x15p)
+	br = t10i*real(x1n) - t11i*real(x2n) - t1i*real(x3n) + t9i*real(x4n) - t12i*real(x5n) -
+		t2i*real(x6n) + t8i*real(x7n) - t13i*real(x8n) - t3i*real(x9n) + t7i*real(x10n) - t14i*real(x11n) -
+		t4i*real(x12n) + t6i*real(x13n) - t15i*real(x14n) - t5i*real(x15n)
+	bi = t10i*imag(x1n) - t11i*imag(x2n) - t1i*imag(x3n) + t9i*imag(x4n) - t12i*imag(x5n) -
+		t2i*imag(x6n) + t8i*imag(x7n) - t13i*imag(x8n) - t3i*imag(x9n) + t7i*imag(x10n) - t14i*imag(x11n) -
+		t4i*imag(x12n) + t6i*imag(x13n) - t15i*imag(x14n) - t5i*imag(x15n)
+	buffer[10] = complex(ar-bi, ai+br)
+	buffer[21] = complex(ar+bi, ai-br)
+
+	// Outputs 11 and 20
+	ar = real(x0) + t11r*real(x1p) + t9r*real(x2p) + t2r*real(x3p) + t13r*real(x4p) + t7r*real(x5p) +
+		t4r*real(x6p) + t15r*real(x7p) + t5r*real(x8p) + t6r*real(x9p) + t14r*real(x10p) + t3r*real(x11p) +
+		t8r*real(x12p) + t12r*real(x13p) + t1r*real(x14p) + t10r*real(x15p)
+	ai = imag(x0) + t11r*imag(x1p) + t9r*imag(x2p) + t2r*imag(x3p) + t13r*imag(x4p) + t7r*imag(x5p) +
+		t4r*imag(x6p) + t15r*imag(x7p) + t5r*imag(x8p) + t6r*imag(x9p) + t14r*imag(x10p) + t3r*imag(x11p) +
+		t8r*imag(x12p) + t12r*imag(x13p) + t1r*imag(x14p) + t10r*imag(x15p)
+	br = t11i*real(x1n) - t9i*real(x2n) + t2i*real(x3n) + t13i*real(x4n) - t7i*real(x5n) +
+		t4i*real(x6n) + t15i*real(x7n) - t5i*real(x8n) + t6i*real(x9n) - t14i*real(x10n) - t3i*real(x11n) +
+		t8i*real(x12n) - t12i*real(x13n) - t1i*real(x14n) + t10i*real(x15n)
+	bi = t11i*imag(x1n) - t9i*imag(x2n) + t2i*imag(x3n) + t13i*imag(x4n) - t7i*imag(x5n) +
+		t4i*imag(x6n) + t15i*imag(x7n) - t5i*imag(x8n) + t6i*imag(x9n) - t14i*imag(x10n) - t3i*imag(x11n) +
+		t8i*imag(x12n) - t12i*imag(x13n) - t1i*imag(x14n) + t10i*imag(x15n)
+	buffer[11] = complex(ar-bi, ai+br)
+	buffer[20] = complex(ar+bi, ai-br)
+
+	// Outputs 12 and 19
+	ar = real(x0) + t12r*real(x1p) + t7r*real(x2p) + t5r*real(x3p) + t14r*real(x4p) + t2r*real(x5p) +
+		t10r*real(x6p) + t9r*real(x7p) + t3r*real(x8p) + t15r*real(x9p) + t4r*real(x10p) + t8r*real(x11p) +
+		t11r*real(x12p) + t1r*real(x13p) + t13r*real(x14p) + t6r*real(x15p)
+	ai = imag(x0) + t12r*imag(x1p) + t7r*imag(x2p) + t5r*imag(x3p) + t14r*imag(x4p) + t2r*imag(x5p) +
+		t10r*imag(x6p) + t9r*imag(x7p) + t3r*imag(x8p) + t15r*imag(x9p) + t4r*imag(x10p) + t8r*imag(x11p) +
+		t11r*imag(x12p) + t1r*imag(x13p) + t13r*imag(x14p) + t6r*imag(x15p)
+	br = t12i*real(x1n) - t7i*real(x2n) + t5i*real(x3n) - t14i*real(x4n) - t2i*real(x5n) +
+		t10i*real(x6n) - t9i*real(x7n) + t3i*real(x8n) + t15i*real(x9n) - t4i*real(x10n) + t8i*real(x11n) -
+		t11i*real(x12n) + t1i*real(x13n) + t13i*real(x14n) - t6i*real(x15n)
+	bi = t12i*imag(x1n) - t7i*imag(x2n) + t5i*imag(x3n) - t14i*imag(x4n) - t2i*imag(x5n) +
+		t10i*imag(x6n) - t9i*imag(x7n) + t3i*imag(x8n) + t15i*imag(x9n) - t4i*imag(x10n) + t8i*imag(x11n) -
+		t11i*imag(x12n) + t1i*imag(x13n) + t13i*imag(x14n) - t6i*imag(x15n)
+	buffer[12] = complex(ar-bi, ai+br)
+	buffer[19] = complex(ar+bi, ai-br)
+
+	// Outputs 13 and 18
+	ar = real(x0) + t13r*real(x1p) + t5r*real(x2p) + t8r*real(x3p) + t10r*real(x4p) + t3r*real(x5p) +
+		t15r*real(x6p) + t2r*real(x7p) + t11r*real(x8p) + t7r*real(x9p) + t6r*real(x10p) + t12r*real(x11p) +
+		t1r*real(x12p) + t14r*real(x13p) + t4r*real(x14p) + t9r*real(x15p)
+	ai = imag(x0) + t13r*imag(x1p) + t5r*imag(x2p) + t8r*imag(x3p) + t10r*imag(x4p) + t3r*imag(x5p) +
+		t15r*imag(x6p) + t2r*imag(x7p) + t11r*imag(x8p) + t7r*imag(x9p) + t6r*imag(x10p) + t12r*imag(x11p) +
+		t1r*imag(x12p) + t14r*imag(x13p) + t4r*imag(x14p) + t9r*imag(x15p)
+	br = t13i*real(x1n) - t5i*real(x2n) + t8i*real(x3n) - t10i*real(x4n) + t3i*real(x5n) -
+		t15i*real(x6n) - t2i*real(x7n) + t11i*real(x8n) - t7i*real(x9n) + t6i*real(x10n) - t12i*real(x11n) +
+		t1i*real(x12n) + t14i*real(x13n) - t4i*real(x14n) + t9i*real(x15n)
+	bi = t13i*imag(x1n) - t5i*imag(x2n) + t8i*imag(x3n) - t10i*imag(x4n) + t3i*imag(x5n) -
+		t15i*imag(x6n) - t2i*imag(x7n) + t11i*imag(x8n) - t7i*imag(x9n) + t6i*imag(x10n) - t12i*imag(x11n) +
+		t1i*imag(x12n) + t14i*imag(x13n) - t4i*imag(x14n) + t9i*imag(x15n)
+	buffer[13] = complex(ar-bi, ai+br)
+	buffer[18] = complex(ar+bi, ai-br)
+
+	// Outputs 14 and 17
+	ar = real(x0) + t14r*real(x1p) + t3r*real(x2p) + t11r*real(x3p) + t6r*real(x4p) + t8r*real(x5p) +
+		t9r*real(x6p) + t5r*real(x7p) + t12r*real(x8p) + t2r*real(x9p) + t15r*real(x10p) + t1r*real(x11p) +
+		t13r*real(x12p) + t4r*real(x13p) + t10r*real(x14p) + t7r*real(x15p)
+	ai = imag(x0) + t14r*imag(x1p) + t3r*imag(x2p) + t11r*imag(x3p) + t6r*imag(x4p) + t8r*imag(x5p) +
+		t9r*imag(x6p) + t5r*imag(x7p) + t12r*imag(x8p) + t2r*imag(x9p) + t15r*imag(x10p) + t1r*imag(x11p) +
+		t13r*imag(x12p) + t4r*imag(x13p) + t10r*imag(x14p) + t7r*imag(x15p)
+	br = t14i*real(x1n) - t3i*real(x2n) + t11i*real(x3n) - t6i*real(x4n) + t8i*real(x5n) -
+		t9i*real(x6n) + t5i*real(x7n) - t12i*real(x8n) + t2i*real(x9n) - t15i*real(x10n) - t1i*real(x11n) +
+		t13i*real(x12n) - t4i*real(x13n) + t10i*real(x14n) - t7i*real(x15n)
+	bi = t14i*imag(x1n) - t3i*imag(x2n) + t11i*imag(x3n) - t6i*imag(x4n) + t8i*imag(x5n) -
+		t9i*imag(x6n) + t5i*imag(x7n) - t12i*imag(x8n) + t2i*imag(x9n) - t15i*imag(x10n) - t1i*imag(x11n) +
+		t13i*imag(x12n) - t4i*imag(x13n) + t10i*imag(x14n) - t7i*imag(x15n)
+	buffer[14] = complex(ar-bi, ai+br)
+	buffer[17] = complex(ar+bi, ai-br)
+
+	// Outputs 15 and 16
+	ar = real(x0) + t15r*real(x1p) + t1r*real(x2p) + t14r*real(x3p) + t2r*real(x4p) + t13r*real(x5p) +
+		t3r*real(x6p) + t12r*real(x7p) + t4r*real(x8p) + t11r*real(x9p) + t5r*real(x10p) + t10r*real(x11p) +
+		t6r*real(x12p) + t9r*real(x13p) + t7r*real(x14p) + t8r*real(x15p)
+	ai = imag(x0) + t15r*imag(x1p) + t1r*imag(x2p) + t14r*imag(x3p) + t2r*imag(x4p) + t13r*imag(x5p) +
+		t3r*imag(x6p) + t12r*imag(x7p) + t4r*imag(x8p) + t11r*imag(x9p) + t5r*imag(x10p) + t10r*imag(x11p) +
+		t6r*imag(x12p) + t9r*imag(x13p) + t7r*imag(x14p) + t8r*imag(x15p)
+	br = t15i*real(x1n) - t1i*real(x2n) + t14i*real(x3n) - t2i*real(x4n) + t13i*real(x5n) -
+		t3i*real(x6n) + t12i*real(x7n) - t4i*real(x8n) + t11i*real(x9n) - t5i*real(x10n) + t10i*real(x11n) -
+		t6i*real(x12n) + t9i*real(x13n) - t7i*real(x14n) + t8i*real(x15n)
+	bi = t15i*imag(x1n) - t1i*imag(x2n) + t14i*imag(x3n) - t2i*imag(x4n) + t13i*imag(x5n) -
+		t3i*imag(x6n) + t12i*imag(x7n) - t4i*imag(x8n) + t11i*imag(x9n) - t5i*imag(x10n) + t10i*imag(x11n) -
+		t6i*imag(x12n) + t9i*imag(x13n) - t7i*imag(x14n) + t8i*imag(x15n)
+	buffer[15] = complex(ar-bi, ai+br)
+	buffer[16] = complex(ar+bi, ai-br)
+}
+
+func (b *Butterfly31) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
 }
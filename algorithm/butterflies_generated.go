@@ -0,0 +1,2052 @@
+// Code generated by "genbutterfly -sizes 10,14,15,20,24,25,27,36,64 -o butterflies_generated.go"; DO NOT EDIT.
+
+package algorithm
+
+// Butterfly10 implements a size-10 FFT (Good-Thomas 2x5)
+type Butterfly10 struct {
+	direction Direction
+	twiddles  [2]complex128
+}
+
+// NewButterfly10 creates a new Butterfly10 instance
+func NewButterfly10(direction Direction) *Butterfly10 {
+	return &Butterfly10{
+		direction: direction,
+		twiddles: [2]complex128{
+			twiddleFactor(1, 5, direction),
+			twiddleFactor(2, 5, direction),
+		},
+	}
+}
+
+func (b *Butterfly10) Len() int                  { return 10 }
+func (b *Butterfly10) Direction() Direction      { return b.direction }
+func (b *Butterfly10) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly10) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly10) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly10) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly10) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 10 {
+		b.performFft(buffer[i : i+10])
+	}
+}
+
+func (b *Butterfly10) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 10 {
+		b.performFftOutOfPlace(input[i:i+10], output[i:i+10])
+	}
+}
+
+func (b *Butterfly10) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly10) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9 := buffer[8], buffer[9]
+
+	t0r, t0i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t1r, t1i := real(b.twiddles[1]), imag(b.twiddles[1])
+
+	// 5 FFTs of size 2 over the columns
+	v0, v1 := x0+x5, x0-x5
+	v2, v3 := x2+x7, x2-x7
+	v4, v5 := x4+x9, x4-x9
+	v6, v7 := x6+x1, x6-x1
+	v8, v9 := x8+x3, x8-x3
+
+	// 2 FFTs of size 5 over the rows
+	v10, v11 := v2+v8, v2-v8
+	v12, v13 := v4+v6, v4-v6
+	v14 := v0 + v10 + v12
+	ar15 := real(v0) + t0r*real(v10) + t1r*real(v12)
+	ai15 := imag(v0) + t0r*imag(v10) + t1r*imag(v12)
+	br15 := t0i*real(v11) + t1i*real(v13)
+	bi15 := t0i*imag(v11) + t1i*imag(v13)
+	v16, v17 := complex(ar15-bi15, ai15+br15), complex(ar15+bi15, ai15-br15)
+	ar18 := real(v0) + t1r*real(v10) + t0r*real(v12)
+	ai18 := imag(v0) + t1r*imag(v10) + t0r*imag(v12)
+	br18 := t1i*real(v11) - t0i*real(v13)
+	bi18 := t1i*imag(v11) - t0i*imag(v13)
+	v19, v20 := complex(ar18-bi18, ai18+br18), complex(ar18+bi18, ai18-br18)
+	v21, v22 := v3+v9, v3-v9
+	v23, v24 := v5+v7, v5-v7
+	v25 := v1 + v21 + v23
+	ar26 := real(v1) + t0r*real(v21) + t1r*real(v23)
+	ai26 := imag(v1) + t0r*imag(v21) + t1r*imag(v23)
+	br26 := t0i*real(v22) + t1i*real(v24)
+	bi26 := t0i*imag(v22) + t1i*imag(v24)
+	v27, v28 := complex(ar26-bi26, ai26+br26), complex(ar26+bi26, ai26-br26)
+	ar29 := real(v1) + t1r*real(v21) + t0r*real(v23)
+	ai29 := imag(v1) + t1r*imag(v21) + t0r*imag(v23)
+	br29 := t1i*real(v22) - t0i*real(v24)
+	bi29 := t1i*imag(v22) - t0i*imag(v24)
+	v30, v31 := complex(ar29-bi29, ai29+br29), complex(ar29+bi29, ai29-br29)
+
+	buffer[0] = v14
+	buffer[1] = v27
+	buffer[2] = v19
+	buffer[3] = v31
+	buffer[4] = v17
+	buffer[5] = v25
+	buffer[6] = v16
+	buffer[7] = v30
+	buffer[8] = v20
+	buffer[9] = v28
+}
+
+func (b *Butterfly10) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly14 implements a size-14 FFT (Good-Thomas 2x7)
+type Butterfly14 struct {
+	direction Direction
+	twiddles  [3]complex128
+}
+
+// NewButterfly14 creates a new Butterfly14 instance
+func NewButterfly14(direction Direction) *Butterfly14 {
+	return &Butterfly14{
+		direction: direction,
+		twiddles: [3]complex128{
+			twiddleFactor(1, 7, direction),
+			twiddleFactor(2, 7, direction),
+			twiddleFactor(3, 7, direction),
+		},
+	}
+}
+
+func (b *Butterfly14) Len() int                  { return 14 }
+func (b *Butterfly14) Direction() Direction      { return b.direction }
+func (b *Butterfly14) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly14) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly14) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly14) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly14) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 14 {
+		b.performFft(buffer[i : i+14])
+	}
+}
+
+func (b *Butterfly14) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 14 {
+		b.performFftOutOfPlace(input[i:i+14], output[i:i+14])
+	}
+}
+
+func (b *Butterfly14) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly14) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13 := buffer[12], buffer[13]
+
+	t0r, t0i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t1r, t1i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t2r, t2i := real(b.twiddles[2]), imag(b.twiddles[2])
+
+	// 7 FFTs of size 2 over the columns
+	v0, v1 := x0+x7, x0-x7
+	v2, v3 := x2+x9, x2-x9
+	v4, v5 := x4+x11, x4-x11
+	v6, v7 := x6+x13, x6-x13
+	v8, v9 := x8+x1, x8-x1
+	v10, v11 := x10+x3, x10-x3
+	v12, v13 := x12+x5, x12-x5
+
+	// 2 FFTs of size 7 over the rows
+	v14, v15 := v2+v12, v2-v12
+	v16, v17 := v4+v10, v4-v10
+	v18, v19 := v6+v8, v6-v8
+	v20 := v0 + v14 + v16 + v18
+	ar21 := real(v0) + t0r*real(v14) + t1r*real(v16) + t2r*real(v18)
+	ai21 := imag(v0) + t0r*imag(v14) + t1r*imag(v16) + t2r*imag(v18)
+	br21 := t0i*real(v15) + t1i*real(v17) + t2i*real(v19)
+	bi21 := t0i*imag(v15) + t1i*imag(v17) + t2i*imag(v19)
+	v22, v23 := complex(ar21-bi21, ai21+br21), complex(ar21+bi21, ai21-br21)
+	ar24 := real(v0) + t1r*real(v14) + t2r*real(v16) + t0r*real(v18)
+	ai24 := imag(v0) + t1r*imag(v14) + t2r*imag(v16) + t0r*imag(v18)
+	br24 := t1i*real(v15) - t2i*real(v17) - t0i*real(v19)
+	bi24 := t1i*imag(v15) - t2i*imag(v17) - t0i*imag(v19)
+	v25, v26 := complex(ar24-bi24, ai24+br24), complex(ar24+bi24, ai24-br24)
+	ar27 := real(v0) + t2r*real(v14) + t0r*real(v16) + t1r*real(v18)
+	ai27 := imag(v0) + t2r*imag(v14) + t0r*imag(v16) + t1r*imag(v18)
+	br27 := t2i*real(v15) - t0i*real(v17) + t1i*real(v19)
+	bi27 := t2i*imag(v15) - t0i*imag(v17) + t1i*imag(v19)
+	v28, v29 := complex(ar27-bi27, ai27+br27), complex(ar27+bi27, ai27-br27)
+	v30, v31 := v3+v13, v3-v13
+	v32, v33 := v5+v11, v5-v11
+	v34, v35 := v7+v9, v7-v9
+	v36 := v1 + v30 + v32 + v34
+	ar37 := real(v1) + t0r*real(v30) + t1r*real(v32) + t2r*real(v34)
+	ai37 := imag(v1) + t0r*imag(v30) + t1r*imag(v32) + t2r*imag(v34)
+	br37 := t0i*real(v31) + t1i*real(v33) + t2i*real(v35)
+	bi37 := t0i*imag(v31) + t1i*imag(v33) + t2i*imag(v35)
+	v38, v39 := complex(ar37-bi37, ai37+br37), complex(ar37+bi37, ai37-br37)
+	ar40 := real(v1) + t1r*real(v30) + t2r*real(v32) + t0r*real(v34)
+	ai40 := imag(v1) + t1r*imag(v30) + t2r*imag(v32) + t0r*imag(v34)
+	br40 := t1i*real(v31) - t2i*real(v33) - t0i*real(v35)
+	bi40 := t1i*imag(v31) - t2i*imag(v33) - t0i*imag(v35)
+	v41, v42 := complex(ar40-bi40, ai40+br40), complex(ar40+bi40, ai40-br40)
+	ar43 := real(v1) + t2r*real(v30) + t0r*real(v32) + t1r*real(v34)
+	ai43 := imag(v1) + t2r*imag(v30) + t0r*imag(v32) + t1r*imag(v34)
+	br43 := t2i*real(v31) - t0i*real(v33) + t1i*real(v35)
+	bi43 := t2i*imag(v31) - t0i*imag(v33) + t1i*imag(v35)
+	v44, v45 := complex(ar43-bi43, ai43+br43), complex(ar43+bi43, ai43-br43)
+
+	buffer[0] = v20
+	buffer[1] = v38
+	buffer[2] = v25
+	buffer[3] = v44
+	buffer[4] = v29
+	buffer[5] = v42
+	buffer[6] = v23
+	buffer[7] = v36
+	buffer[8] = v22
+	buffer[9] = v41
+	buffer[10] = v28
+	buffer[11] = v45
+	buffer[12] = v26
+	buffer[13] = v39
+}
+
+func (b *Butterfly14) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly15 implements a size-15 FFT (Good-Thomas 3x5)
+type Butterfly15 struct {
+	direction Direction
+	twiddles  [3]complex128
+}
+
+// NewButterfly15 creates a new Butterfly15 instance
+func NewButterfly15(direction Direction) *Butterfly15 {
+	return &Butterfly15{
+		direction: direction,
+		twiddles: [3]complex128{
+			twiddleFactor(1, 3, direction),
+			twiddleFactor(1, 5, direction),
+			twiddleFactor(2, 5, direction),
+		},
+	}
+}
+
+func (b *Butterfly15) Len() int                  { return 15 }
+func (b *Butterfly15) Direction() Direction      { return b.direction }
+func (b *Butterfly15) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly15) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly15) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly15) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly15) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 15 {
+		b.performFft(buffer[i : i+15])
+	}
+}
+
+func (b *Butterfly15) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 15 {
+		b.performFftOutOfPlace(input[i:i+15], output[i:i+15])
+	}
+}
+
+func (b *Butterfly15) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly15) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13, x14 := buffer[12], buffer[13], buffer[14]
+
+	t0r, t0i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t1r, t1i := real(b.twiddles[1]), imag(b.twiddles[1])
+	t2r, t2i := real(b.twiddles[2]), imag(b.twiddles[2])
+
+	// 5 FFTs of size 3 over the columns
+	v0, v1 := x5+x10, x5-x10
+	v2 := x0 + v0
+	ar3 := real(x0) + t0r*real(v0)
+	ai3 := imag(x0) + t0r*imag(v0)
+	br3 := t0i * real(v1)
+	bi3 := t0i * imag(v1)
+	v4, v5 := complex(ar3-bi3, ai3+br3), complex(ar3+bi3, ai3-br3)
+	v6, v7 := x8+x13, x8-x13
+	v8 := x3 + v6
+	ar9 := real(x3) + t0r*real(v6)
+	ai9 := imag(x3) + t0r*imag(v6)
+	br9 := t0i * real(v7)
+	bi9 := t0i * imag(v7)
+	v10, v11 := complex(ar9-bi9, ai9+br9), complex(ar9+bi9, ai9-br9)
+	v12, v13 := x11+x1, x11-x1
+	v14 := x6 + v12
+	ar15 := real(x6) + t0r*real(v12)
+	ai15 := imag(x6) + t0r*imag(v12)
+	br15 := t0i * real(v13)
+	bi15 := t0i * imag(v13)
+	v16, v17 := complex(ar15-bi15, ai15+br15), complex(ar15+bi15, ai15-br15)
+	v18, v19 := x14+x4, x14-x4
+	v20 := x9 + v18
+	ar21 := real(x9) + t0r*real(v18)
+	ai21 := imag(x9) + t0r*imag(v18)
+	br21 := t0i * real(v19)
+	bi21 := t0i * imag(v19)
+	v22, v23 := complex(ar21-bi21, ai21+br21), complex(ar21+bi21, ai21-br21)
+	v24, v25 := x2+x7, x2-x7
+	v26 := x12 + v24
+	ar27 := real(x12) + t0r*real(v24)
+	ai27 := imag(x12) + t0r*imag(v24)
+	br27 := t0i * real(v25)
+	bi27 := t0i * imag(v25)
+	v28, v29 := complex(ar27-bi27, ai27+br27), complex(ar27+bi27, ai27-br27)
+
+	// 3 FFTs of size 5 over the rows
+	v30, v31 := v8+v26, v8-v26
+	v32, v33 := v14+v20, v14-v20
+	v34 := v2 + v30 + v32
+	ar35 := real(v2) + t1r*real(v30) + t2r*real(v32)
+	ai35 := imag(v2) + t1r*imag(v30) + t2r*imag(v32)
+	br35 := t1i*real(v31) + t2i*real(v33)
+	bi35 := t1i*imag(v31) + t2i*imag(v33)
+	v36, v37 := complex(ar35-bi35, ai35+br35), complex(ar35+bi35, ai35-br35)
+	ar38 := real(v2) + t2r*real(v30) + t1r*real(v32)
+	ai38 := imag(v2) + t2r*imag(v30) + t1r*imag(v32)
+	br38 := t2i*real(v31) - t1i*real(v33)
+	bi38 := t2i*imag(v31) - t1i*imag(v33)
+	v39, v40 := complex(ar38-bi38, ai38+br38), complex(ar38+bi38, ai38-br38)
+	v41, v42 := v10+v28, v10-v28
+	v43, v44 := v16+v22, v16-v22
+	v45 := v4 + v41 + v43
+	ar46 := real(v4) + t1r*real(v41) + t2r*real(v43)
+	ai46 := imag(v4) + t1r*imag(v41) + t2r*imag(v43)
+	br46 := t1i*real(v42) + t2i*real(v44)
+	bi46 := t1i*imag(v42) + t2i*imag(v44)
+	v47, v48 := complex(ar46-bi46, ai46+br46), complex(ar46+bi46, ai46-br46)
+	ar49 := real(v4) + t2r*real(v41) + t1r*real(v43)
+	ai49 := imag(v4) + t2r*imag(v41) + t1r*imag(v43)
+	br49 := t2i*real(v42) - t1i*real(v44)
+	bi49 := t2i*imag(v42) - t1i*imag(v44)
+	v50, v51 := complex(ar49-bi49, ai49+br49), complex(ar49+bi49, ai49-br49)
+	v52, v53 := v11+v29, v11-v29
+	v54, v55 := v17+v23, v17-v23
+	v56 := v5 + v52 + v54
+	ar57 := real(v5) + t1r*real(v52) + t2r*real(v54)
+	ai57 := imag(v5) + t1r*imag(v52) + t2r*imag(v54)
+	br57 := t1i*real(v53) + t2i*real(v55)
+	bi57 := t1i*imag(v53) + t2i*imag(v55)
+	v58, v59 := complex(ar57-bi57, ai57+br57), complex(ar57+bi57, ai57-br57)
+	ar60 := real(v5) + t2r*real(v52) + t1r*real(v54)
+	ai60 := imag(v5) + t2r*imag(v52) + t1r*imag(v54)
+	br60 := t2i*real(v53) - t1i*real(v55)
+	bi60 := t2i*imag(v53) - t1i*imag(v55)
+	v61, v62 := complex(ar60-bi60, ai60+br60), complex(ar60+bi60, ai60-br60)
+
+	buffer[0] = v34
+	buffer[1] = v47
+	buffer[2] = v61
+	buffer[3] = v40
+	buffer[4] = v48
+	buffer[5] = v56
+	buffer[6] = v36
+	buffer[7] = v50
+	buffer[8] = v62
+	buffer[9] = v37
+	buffer[10] = v45
+	buffer[11] = v58
+	buffer[12] = v39
+	buffer[13] = v51
+	buffer[14] = v59
+}
+
+func (b *Butterfly15) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly20 implements a size-20 FFT (Good-Thomas 4x5)
+type Butterfly20 struct {
+	direction Direction
+	twiddles  [2]complex128
+}
+
+// NewButterfly20 creates a new Butterfly20 instance
+func NewButterfly20(direction Direction) *Butterfly20 {
+	return &Butterfly20{
+		direction: direction,
+		twiddles: [2]complex128{
+			twiddleFactor(1, 5, direction),
+			twiddleFactor(2, 5, direction),
+		},
+	}
+}
+
+func (b *Butterfly20) Len() int                  { return 20 }
+func (b *Butterfly20) Direction() Direction      { return b.direction }
+func (b *Butterfly20) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly20) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly20) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly20) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly20) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 20 {
+		b.performFft(buffer[i : i+20])
+	}
+}
+
+func (b *Butterfly20) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 20 {
+		b.performFftOutOfPlace(input[i:i+20], output[i:i+20])
+	}
+}
+
+func (b *Butterfly20) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly20) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13, x14, x15 := buffer[12], buffer[13], buffer[14], buffer[15]
+	x16, x17, x18, x19 := buffer[16], buffer[17], buffer[18], buffer[19]
+
+	t0r, t0i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t1r, t1i := real(b.twiddles[1]), imag(b.twiddles[1])
+
+	// 5 FFTs of size 4 over the columns
+	v0, v1 := x0+x10, x0-x10
+	v2, v3 := x5+x15, rotate90(x5-x15, b.direction)
+	v4, v5, v6, v7 := v0+v2, v1+v3, v0-v2, v1-v3
+	v8, v9 := x4+x14, x4-x14
+	v10, v11 := x9+x19, rotate90(x9-x19, b.direction)
+	v12, v13, v14, v15 := v8+v10, v9+v11, v8-v10, v9-v11
+	v16, v17 := x8+x18, x8-x18
+	v18, v19 := x13+x3, rotate90(x13-x3, b.direction)
+	v20, v21, v22, v23 := v16+v18, v17+v19, v16-v18, v17-v19
+	v24, v25 := x12+x2, x12-x2
+	v26, v27 := x17+x7, rotate90(x17-x7, b.direction)
+	v28, v29, v30, v31 := v24+v26, v25+v27, v24-v26, v25-v27
+	v32, v33 := x16+x6, x16-x6
+	v34, v35 := x1+x11, rotate90(x1-x11, b.direction)
+	v36, v37, v38, v39 := v32+v34, v33+v35, v32-v34, v33-v35
+
+	// 4 FFTs of size 5 over the rows
+	v40, v41 := v12+v36, v12-v36
+	v42, v43 := v20+v28, v20-v28
+	v44 := v4 + v40 + v42
+	ar45 := real(v4) + t0r*real(v40) + t1r*real(v42)
+	ai45 := imag(v4) + t0r*imag(v40) + t1r*imag(v42)
+	br45 := t0i*real(v41) + t1i*real(v43)
+	bi45 := t0i*imag(v41) + t1i*imag(v43)
+	v46, v47 := complex(ar45-bi45, ai45+br45), complex(ar45+bi45, ai45-br45)
+	ar48 := real(v4) + t1r*real(v40) + t0r*real(v42)
+	ai48 := imag(v4) + t1r*imag(v40) + t0r*imag(v42)
+	br48 := t1i*real(v41) - t0i*real(v43)
+	bi48 := t1i*imag(v41) - t0i*imag(v43)
+	v49, v50 := complex(ar48-bi48, ai48+br48), complex(ar48+bi48, ai48-br48)
+	v51, v52 := v13+v37, v13-v37
+	v53, v54 := v21+v29, v21-v29
+	v55 := v5 + v51 + v53
+	ar56 := real(v5) + t0r*real(v51) + t1r*real(v53)
+	ai56 := imag(v5) + t0r*imag(v51) + t1r*imag(v53)
+	br56 := t0i*real(v52) + t1i*real(v54)
+	bi56 := t0i*imag(v52) + t1i*imag(v54)
+	v57, v58 := complex(ar56-bi56, ai56+br56), complex(ar56+bi56, ai56-br56)
+	ar59 := real(v5) + t1r*real(v51) + t0r*real(v53)
+	ai59 := imag(v5) + t1r*imag(v51) + t0r*imag(v53)
+	br59 := t1i*real(v52) - t0i*real(v54)
+	bi59 := t1i*imag(v52) - t0i*imag(v54)
+	v60, v61 := complex(ar59-bi59, ai59+br59), complex(ar59+bi59, ai59-br59)
+	v62, v63 := v14+v38, v14-v38
+	v64, v65 := v22+v30, v22-v30
+	v66 := v6 + v62 + v64
+	ar67 := real(v6) + t0r*real(v62) + t1r*real(v64)
+	ai67 := imag(v6) + t0r*imag(v62) + t1r*imag(v64)
+	br67 := t0i*real(v63) + t1i*real(v65)
+	bi67 := t0i*imag(v63) + t1i*imag(v65)
+	v68, v69 := complex(ar67-bi67, ai67+br67), complex(ar67+bi67, ai67-br67)
+	ar70 := real(v6) + t1r*real(v62) + t0r*real(v64)
+	ai70 := imag(v6) + t1r*imag(v62) + t0r*imag(v64)
+	br70 := t1i*real(v63) - t0i*real(v65)
+	bi70 := t1i*imag(v63) - t0i*imag(v65)
+	v71, v72 := complex(ar70-bi70, ai70+br70), complex(ar70+bi70, ai70-br70)
+	v73, v74 := v15+v39, v15-v39
+	v75, v76 := v23+v31, v23-v31
+	v77 := v7 + v73 + v75
+	ar78 := real(v7) + t0r*real(v73) + t1r*real(v75)
+	ai78 := imag(v7) + t0r*imag(v73) + t1r*imag(v75)
+	br78 := t0i*real(v74) + t1i*real(v76)
+	bi78 := t0i*imag(v74) + t1i*imag(v76)
+	v79, v80 := complex(ar78-bi78, ai78+br78), complex(ar78+bi78, ai78-br78)
+	ar81 := real(v7) + t1r*real(v73) + t0r*real(v75)
+	ai81 := imag(v7) + t1r*imag(v73) + t0r*imag(v75)
+	br81 := t1i*real(v74) - t0i*real(v76)
+	bi81 := t1i*imag(v74) - t0i*imag(v76)
+	v82, v83 := complex(ar81-bi81, ai81+br81), complex(ar81+bi81, ai81-br81)
+
+	buffer[0] = v44
+	buffer[1] = v57
+	buffer[2] = v71
+	buffer[3] = v83
+	buffer[4] = v47
+	buffer[5] = v55
+	buffer[6] = v68
+	buffer[7] = v82
+	buffer[8] = v50
+	buffer[9] = v58
+	buffer[10] = v66
+	buffer[11] = v79
+	buffer[12] = v49
+	buffer[13] = v61
+	buffer[14] = v69
+	buffer[15] = v77
+	buffer[16] = v46
+	buffer[17] = v60
+	buffer[18] = v72
+	buffer[19] = v80
+}
+
+func (b *Butterfly20) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly24 implements a size-24 FFT (Good-Thomas 8x3)
+type Butterfly24 struct {
+	direction Direction
+	twiddles  [3]complex128
+}
+
+// NewButterfly24 creates a new Butterfly24 instance
+func NewButterfly24(direction Direction) *Butterfly24 {
+	return &Butterfly24{
+		direction: direction,
+		twiddles: [3]complex128{
+			twiddleFactor(1, 8, direction),
+			twiddleFactor(3, 8, direction),
+			twiddleFactor(1, 3, direction),
+		},
+	}
+}
+
+func (b *Butterfly24) Len() int                  { return 24 }
+func (b *Butterfly24) Direction() Direction      { return b.direction }
+func (b *Butterfly24) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly24) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly24) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly24) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly24) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 24 {
+		b.performFft(buffer[i : i+24])
+	}
+}
+
+func (b *Butterfly24) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 24 {
+		b.performFftOutOfPlace(input[i:i+24], output[i:i+24])
+	}
+}
+
+func (b *Butterfly24) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly24) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13, x14, x15 := buffer[12], buffer[13], buffer[14], buffer[15]
+	x16, x17, x18, x19 := buffer[16], buffer[17], buffer[18], buffer[19]
+	x20, x21, x22, x23 := buffer[20], buffer[21], buffer[22], buffer[23]
+
+	t2r, t2i := real(b.twiddles[2]), imag(b.twiddles[2])
+
+	// 3 FFTs of size 8 over the columns
+	v0, v1 := x0+x12, x0-x12
+	v2, v3 := x3+x15, x3-x15
+	v4 := v3 * b.twiddles[0]
+	v5, v6 := x6+x18, x6-x18
+	v7 := rotate90(v6, b.direction)
+	v8, v9 := x9+x21, x9-x21
+	v10 := v9 * b.twiddles[1]
+	v11, v12 := v0+v5, v0-v5
+	v13, v14 := v2+v8, rotate90(v2-v8, b.direction)
+	v15, v16, v17, v18 := v11+v13, v12+v14, v11-v13, v12-v14
+	v19, v20 := v1+v7, v1-v7
+	v21, v22 := v4+v10, rotate90(v4-v10, b.direction)
+	v23, v24, v25, v26 := v19+v21, v20+v22, v19-v21, v20-v22
+	v27, v28 := x8+x20, x8-x20
+	v29, v30 := x11+x23, x11-x23
+	v31 := v30 * b.twiddles[0]
+	v32, v33 := x14+x2, x14-x2
+	v34 := rotate90(v33, b.direction)
+	v35, v36 := x17+x5, x17-x5
+	v37 := v36 * b.twiddles[1]
+	v38, v39 := v27+v32, v27-v32
+	v40, v41 := v29+v35, rotate90(v29-v35, b.direction)
+	v42, v43, v44, v45 := v38+v40, v39+v41, v38-v40, v39-v41
+	v46, v47 := v28+v34, v28-v34
+	v48, v49 := v31+v37, rotate90(v31-v37, b.direction)
+	v50, v51, v52, v53 := v46+v48, v47+v49, v46-v48, v47-v49
+	v54, v55 := x16+x4, x16-x4
+	v56, v57 := x19+x7, x19-x7
+	v58 := v57 * b.twiddles[0]
+	v59, v60 := x22+x10, x22-x10
+	v61 := rotate90(v60, b.direction)
+	v62, v63 := x1+x13, x1-x13
+	v64 := v63 * b.twiddles[1]
+	v65, v66 := v54+v59, v54-v59
+	v67, v68 := v56+v62, rotate90(v56-v62, b.direction)
+	v69, v70, v71, v72 := v65+v67, v66+v68, v65-v67, v66-v68
+	v73, v74 := v55+v61, v55-v61
+	v75, v76 := v58+v64, rotate90(v58-v64, b.direction)
+	v77, v78, v79, v80 := v73+v75, v74+v76, v73-v75, v74-v76
+
+	// 8 FFTs of size 3 over the rows
+	v81, v82 := v42+v69, v42-v69
+	v83 := v15 + v81
+	ar84 := real(v15) + t2r*real(v81)
+	ai84 := imag(v15) + t2r*imag(v81)
+	br84 := t2i * real(v82)
+	bi84 := t2i * imag(v82)
+	v85, v86 := complex(ar84-bi84, ai84+br84), complex(ar84+bi84, ai84-br84)
+	v87, v88 := v50+v77, v50-v77
+	v89 := v23 + v87
+	ar90 := real(v23) + t2r*real(v87)
+	ai90 := imag(v23) + t2r*imag(v87)
+	br90 := t2i * real(v88)
+	bi90 := t2i * imag(v88)
+	v91, v92 := complex(ar90-bi90, ai90+br90), complex(ar90+bi90, ai90-br90)
+	v93, v94 := v43+v70, v43-v70
+	v95 := v16 + v93
+	ar96 := real(v16) + t2r*real(v93)
+	ai96 := imag(v16) + t2r*imag(v93)
+	br96 := t2i * real(v94)
+	bi96 := t2i * imag(v94)
+	v97, v98 := complex(ar96-bi96, ai96+br96), complex(ar96+bi96, ai96-br96)
+	v99, v100 := v51+v78, v51-v78
+	v101 := v24 + v99
+	ar102 := real(v24) + t2r*real(v99)
+	ai102 := imag(v24) + t2r*imag(v99)
+	br102 := t2i * real(v100)
+	bi102 := t2i * imag(v100)
+	v103, v104 := complex(ar102-bi102, ai102+br102), complex(ar102+bi102, ai102-br102)
+	v105, v106 := v44+v71, v44-v71
+	v107 := v17 + v105
+	ar108 := real(v17) + t2r*real(v105)
+	ai108 := imag(v17) + t2r*imag(v105)
+	br108 := t2i * real(v106)
+	bi108 := t2i * imag(v106)
+	v109, v110 := complex(ar108-bi108, ai108+br108), complex(ar108+bi108, ai108-br108)
+	v111, v112 := v52+v79, v52-v79
+	v113 := v25 + v111
+	ar114 := real(v25) + t2r*real(v111)
+	ai114 := imag(v25) + t2r*imag(v111)
+	br114 := t2i * real(v112)
+	bi114 := t2i * imag(v112)
+	v115, v116 := complex(ar114-bi114, ai114+br114), complex(ar114+bi114, ai114-br114)
+	v117, v118 := v45+v72, v45-v72
+	v119 := v18 + v117
+	ar120 := real(v18) + t2r*real(v117)
+	ai120 := imag(v18) + t2r*imag(v117)
+	br120 := t2i * real(v118)
+	bi120 := t2i * imag(v118)
+	v121, v122 := complex(ar120-bi120, ai120+br120), complex(ar120+bi120, ai120-br120)
+	v123, v124 := v53+v80, v53-v80
+	v125 := v26 + v123
+	ar126 := real(v26) + t2r*real(v123)
+	ai126 := imag(v26) + t2r*imag(v123)
+	br126 := t2i * real(v124)
+	bi126 := t2i * imag(v124)
+	v127, v128 := complex(ar126-bi126, ai126+br126), complex(ar126+bi126, ai126-br126)
+
+	buffer[0] = v83
+	buffer[1] = v91
+	buffer[2] = v98
+	buffer[3] = v101
+	buffer[4] = v109
+	buffer[5] = v116
+	buffer[6] = v119
+	buffer[7] = v127
+	buffer[8] = v86
+	buffer[9] = v89
+	buffer[10] = v97
+	buffer[11] = v104
+	buffer[12] = v107
+	buffer[13] = v115
+	buffer[14] = v122
+	buffer[15] = v125
+	buffer[16] = v85
+	buffer[17] = v92
+	buffer[18] = v95
+	buffer[19] = v103
+	buffer[20] = v110
+	buffer[21] = v113
+	buffer[22] = v121
+	buffer[23] = v128
+}
+
+func (b *Butterfly24) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly25 implements a size-25 FFT (mixed radix 5x5)
+type Butterfly25 struct {
+	direction Direction
+	twiddles  [11]complex128
+}
+
+// NewButterfly25 creates a new Butterfly25 instance
+func NewButterfly25(direction Direction) *Butterfly25 {
+	return &Butterfly25{
+		direction: direction,
+		twiddles: [11]complex128{
+			twiddleFactor(1, 5, direction),
+			twiddleFactor(2, 5, direction),
+			twiddleFactor(1, 25, direction),
+			twiddleFactor(2, 25, direction),
+			twiddleFactor(3, 25, direction),
+			twiddleFactor(4, 25, direction),
+			twiddleFactor(6, 25, direction),
+			twiddleFactor(8, 25, direction),
+			twiddleFactor(9, 25, direction),
+			twiddleFactor(12, 25, direction),
+			twiddleFactor(16, 25, direction),
+		},
+	}
+}
+
+func (b *Butterfly25) Len() int                  { return 25 }
+func (b *Butterfly25) Direction() Direction      { return b.direction }
+func (b *Butterfly25) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly25) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly25) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly25) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly25) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 25 {
+		b.performFft(buffer[i : i+25])
+	}
+}
+
+func (b *Butterfly25) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 25 {
+		b.performFftOutOfPlace(input[i:i+25], output[i:i+25])
+	}
+}
+
+func (b *Butterfly25) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly25) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13, x14, x15 := buffer[12], buffer[13], buffer[14], buffer[15]
+	x16, x17, x18, x19 := buffer[16], buffer[17], buffer[18], buffer[19]
+	x20, x21, x22, x23 := buffer[20], buffer[21], buffer[22], buffer[23]
+	x24 := buffer[24]
+
+	t0r, t0i := real(b.twiddles[0]), imag(b.twiddles[0])
+	t1r, t1i := real(b.twiddles[1]), imag(b.twiddles[1])
+
+	// 5 FFTs of size 5 over the columns, then twiddles
+	v0, v1 := x5+x20, x5-x20
+	v2, v3 := x10+x15, x10-x15
+	v4 := x0 + v0 + v2
+	ar5 := real(x0) + t0r*real(v0) + t1r*real(v2)
+	ai5 := imag(x0) + t0r*imag(v0) + t1r*imag(v2)
+	br5 := t0i*real(v1) + t1i*real(v3)
+	bi5 := t0i*imag(v1) + t1i*imag(v3)
+	v6, v7 := complex(ar5-bi5, ai5+br5), complex(ar5+bi5, ai5-br5)
+	ar8 := real(x0) + t1r*real(v0) + t0r*real(v2)
+	ai8 := imag(x0) + t1r*imag(v0) + t0r*imag(v2)
+	br8 := t1i*real(v1) - t0i*real(v3)
+	bi8 := t1i*imag(v1) - t0i*imag(v3)
+	v9, v10 := complex(ar8-bi8, ai8+br8), complex(ar8+bi8, ai8-br8)
+	v11, v12 := x6+x21, x6-x21
+	v13, v14 := x11+x16, x11-x16
+	v15 := x1 + v11 + v13
+	ar16 := real(x1) + t0r*real(v11) + t1r*real(v13)
+	ai16 := imag(x1) + t0r*imag(v11) + t1r*imag(v13)
+	br16 := t0i*real(v12) + t1i*real(v14)
+	bi16 := t0i*imag(v12) + t1i*imag(v14)
+	v17, v18 := complex(ar16-bi16, ai16+br16), complex(ar16+bi16, ai16-br16)
+	ar19 := real(x1) + t1r*real(v11) + t0r*real(v13)
+	ai19 := imag(x1) + t1r*imag(v11) + t0r*imag(v13)
+	br19 := t1i*real(v12) - t0i*real(v14)
+	bi19 := t1i*imag(v12) - t0i*imag(v14)
+	v20, v21 := complex(ar19-bi19, ai19+br19), complex(ar19+bi19, ai19-br19)
+	v22 := v17 * b.twiddles[2]
+	v23 := v20 * b.twiddles[3]
+	v24 := v21 * b.twiddles[4]
+	v25 := v18 * b.twiddles[5]
+	v26, v27 := x7+x22, x7-x22
+	v28, v29 := x12+x17, x12-x17
+	v30 := x2 + v26 + v28
+	ar31 := real(x2) + t0r*real(v26) + t1r*real(v28)
+	ai31 := imag(x2) + t0r*imag(v26) + t1r*imag(v28)
+	br31 := t0i*real(v27) + t1i*real(v29)
+	bi31 := t0i*imag(v27) + t1i*imag(v29)
+	v32, v33 := complex(ar31-bi31, ai31+br31), complex(ar31+bi31, ai31-br31)
+	ar34 := real(x2) + t1r*real(v26) + t0r*real(v28)
+	ai34 := imag(x2) + t1r*imag(v26) + t0r*imag(v28)
+	br34 := t1i*real(v27) - t0i*real(v29)
+	bi34 := t1i*imag(v27) - t0i*imag(v29)
+	v35, v36 := complex(ar34-bi34, ai34+br34), complex(ar34+bi34, ai34-br34)
+	v37 := v32 * b.twiddles[3]
+	v38 := v35 * b.twiddles[5]
+	v39 := v36 * b.twiddles[6]
+	v40 := v33 * b.twiddles[7]
+	v41, v42 := x8+x23, x8-x23
+	v43, v44 := x13+x18, x13-x18
+	v45 := x3 + v41 + v43
+	ar46 := real(x3) + t0r*real(v41) + t1r*real(v43)
+	ai46 := imag(x3) + t0r*imag(v41) + t1r*imag(v43)
+	br46 := t0i*real(v42) + t1i*real(v44)
+	bi46 := t0i*imag(v42) + t1i*imag(v44)
+	v47, v48 := complex(ar46-bi46, ai46+br46), complex(ar46+bi46, ai46-br46)
+	ar49 := real(x3) + t1r*real(v41) + t0r*real(v43)
+	ai49 := imag(x3) + t1r*imag(v41) + t0r*imag(v43)
+	br49 := t1i*real(v42) - t0i*real(v44)
+	bi49 := t1i*imag(v42) - t0i*imag(v44)
+	v50, v51 := complex(ar49-bi49, ai49+br49), complex(ar49+bi49, ai49-br49)
+	v52 := v47 * b.twiddles[4]
+	v53 := v50 * b.twiddles[6]
+	v54 := v51 * b.twiddles[8]
+	v55 := v48 * b.twiddles[9]
+	v56, v57 := x9+x24, x9-x24
+	v58, v59 := x14+x19, x14-x19
+	v60 := x4 + v56 + v58
+	ar61 := real(x4) + t0r*real(v56) + t1r*real(v58)
+	ai61 := imag(x4) + t0r*imag(v56) + t1r*imag(v58)
+	br61 := t0i*real(v57) + t1i*real(v59)
+	bi61 := t0i*imag(v57) + t1i*imag(v59)
+	v62, v63 := complex(ar61-bi61, ai61+br61), complex(ar61+bi61, ai61-br61)
+	ar64 := real(x4) + t1r*real(v56) + t0r*real(v58)
+	ai64 := imag(x4) + t1r*imag(v56) + t0r*imag(v58)
+	br64 := t1i*real(v57) - t0i*real(v59)
+	bi64 := t1i*imag(v57) - t0i*imag(v59)
+	v65, v66 := complex(ar64-bi64, ai64+br64), complex(ar64+bi64, ai64-br64)
+	v67 := v62 * b.twiddles[5]
+	v68 := v65 * b.twiddles[7]
+	v69 := v66 * b.twiddles[9]
+	v70 := v63 * b.twiddles[10]
+
+	// 5 FFTs of size 5 over the rows
+	v71, v72 := v15+v60, v15-v60
+	v73, v74 := v30+v45, v30-v45
+	v75 := v4 + v71 + v73
+	ar76 := real(v4) + t0r*real(v71) + t1r*real(v73)
+	ai76 := imag(v4) + t0r*imag(v71) + t1r*imag(v73)
+	br76 := t0i*real(v72) + t1i*real(v74)
+	bi76 := t0i*imag(v72) + t1i*imag(v74)
+	v77, v78 := complex(ar76-bi76, ai76+br76), complex(ar76+bi76, ai76-br76)
+	ar79 := real(v4) + t1r*real(v71) + t0r*real(v73)
+	ai79 := imag(v4) + t1r*imag(v71) + t0r*imag(v73)
+	br79 := t1i*real(v72) - t0i*real(v74)
+	bi79 := t1i*imag(v72) - t0i*imag(v74)
+	v80, v81 := complex(ar79-bi79, ai79+br79), complex(ar79+bi79, ai79-br79)
+	v82, v83 := v22+v67, v22-v67
+	v84, v85 := v37+v52, v37-v52
+	v86 := v6 + v82 + v84
+	ar87 := real(v6) + t0r*real(v82) + t1r*real(v84)
+	ai87 := imag(v6) + t0r*imag(v82) + t1r*imag(v84)
+	br87 := t0i*real(v83) + t1i*real(v85)
+	bi87 := t0i*imag(v83) + t1i*imag(v85)
+	v88, v89 := complex(ar87-bi87, ai87+br87), complex(ar87+bi87, ai87-br87)
+	ar90 := real(v6) + t1r*real(v82) + t0r*real(v84)
+	ai90 := imag(v6) + t1r*imag(v82) + t0r*imag(v84)
+	br90 := t1i*real(v83) - t0i*real(v85)
+	bi90 := t1i*imag(v83) - t0i*imag(v85)
+	v91, v92 := complex(ar90-bi90, ai90+br90), complex(ar90+bi90, ai90-br90)
+	v93, v94 := v23+v68, v23-v68
+	v95, v96 := v38+v53, v38-v53
+	v97 := v9 + v93 + v95
+	ar98 := real(v9) + t0r*real(v93) + t1r*real(v95)
+	ai98 := imag(v9) + t0r*imag(v93) + t1r*imag(v95)
+	br98 := t0i*real(v94) + t1i*real(v96)
+	bi98 := t0i*imag(v94) + t1i*imag(v96)
+	v99, v100 := complex(ar98-bi98, ai98+br98), complex(ar98+bi98, ai98-br98)
+	ar101 := real(v9) + t1r*real(v93) + t0r*real(v95)
+	ai101 := imag(v9) + t1r*imag(v93) + t0r*imag(v95)
+	br101 := t1i*real(v94) - t0i*real(v96)
+	bi101 := t1i*imag(v94) - t0i*imag(v96)
+	v102, v103 := complex(ar101-bi101, ai101+br101), complex(ar101+bi101, ai101-br101)
+	v104, v105 := v24+v69, v24-v69
+	v106, v107 := v39+v54, v39-v54
+	v108 := v10 + v104 + v106
+	ar109 := real(v10) + t0r*real(v104) + t1r*real(v106)
+	ai109 := imag(v10) + t0r*imag(v104) + t1r*imag(v106)
+	br109 := t0i*real(v105) + t1i*real(v107)
+	bi109 := t0i*imag(v105) + t1i*imag(v107)
+	v110, v111 := complex(ar109-bi109, ai109+br109), complex(ar109+bi109, ai109-br109)
+	ar112 := real(v10) + t1r*real(v104) + t0r*real(v106)
+	ai112 := imag(v10) + t1r*imag(v104) + t0r*imag(v106)
+	br112 := t1i*real(v105) - t0i*real(v107)
+	bi112 := t1i*imag(v105) - t0i*imag(v107)
+	v113, v114 := complex(ar112-bi112, ai112+br112), complex(ar112+bi112, ai112-br112)
+	v115, v116 := v25+v70, v25-v70
+	v117, v118 := v40+v55, v40-v55
+	v119 := v7 + v115 + v117
+	ar120 := real(v7) + t0r*real(v115) + t1r*real(v117)
+	ai120 := imag(v7) + t0r*imag(v115) + t1r*imag(v117)
+	br120 := t0i*real(v116) + t1i*real(v118)
+	bi120 := t0i*imag(v116) + t1i*imag(v118)
+	v121, v122 := complex(ar120-bi120, ai120+br120), complex(ar120+bi120, ai120-br120)
+	ar123 := real(v7) + t1r*real(v115) + t0r*real(v117)
+	ai123 := imag(v7) + t1r*imag(v115) + t0r*imag(v117)
+	br123 := t1i*real(v116) - t0i*real(v118)
+	bi123 := t1i*imag(v116) - t0i*imag(v118)
+	v124, v125 := complex(ar123-bi123, ai123+br123), complex(ar123+bi123, ai123-br123)
+
+	buffer[0] = v75
+	buffer[1] = v86
+	buffer[2] = v97
+	buffer[3] = v108
+	buffer[4] = v119
+	buffer[5] = v77
+	buffer[6] = v88
+	buffer[7] = v99
+	buffer[8] = v110
+	buffer[9] = v121
+	buffer[10] = v80
+	buffer[11] = v91
+	buffer[12] = v102
+	buffer[13] = v113
+	buffer[14] = v124
+	buffer[15] = v81
+	buffer[16] = v92
+	buffer[17] = v103
+	buffer[18] = v114
+	buffer[19] = v125
+	buffer[20] = v78
+	buffer[21] = v89
+	buffer[22] = v100
+	buffer[23] = v111
+	buffer[24] = v122
+}
+
+func (b *Butterfly25) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly27 implements a size-27 FFT (mixed radix 3x9)
+type Butterfly27 struct {
+	direction Direction
+	twiddles  [13]complex128
+}
+
+// NewButterfly27 creates a new Butterfly27 instance
+func NewButterfly27(direction Direction) *Butterfly27 {
+	return &Butterfly27{
+		direction: direction,
+		twiddles: [13]complex128{
+			twiddleFactor(1, 3, direction),
+			twiddleFactor(1, 27, direction),
+			twiddleFactor(2, 27, direction),
+			twiddleFactor(4, 27, direction),
+			twiddleFactor(1, 9, direction),
+			twiddleFactor(2, 9, direction),
+			twiddleFactor(8, 27, direction),
+			twiddleFactor(5, 27, direction),
+			twiddleFactor(10, 27, direction),
+			twiddleFactor(4, 9, direction),
+			twiddleFactor(7, 27, direction),
+			twiddleFactor(14, 27, direction),
+			twiddleFactor(16, 27, direction),
+		},
+	}
+}
+
+func (b *Butterfly27) Len() int                  { return 27 }
+func (b *Butterfly27) Direction() Direction      { return b.direction }
+func (b *Butterfly27) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly27) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly27) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly27) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly27) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 27 {
+		b.performFft(buffer[i : i+27])
+	}
+}
+
+func (b *Butterfly27) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 27 {
+		b.performFftOutOfPlace(input[i:i+27], output[i:i+27])
+	}
+}
+
+func (b *Butterfly27) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly27) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13, x14, x15 := buffer[12], buffer[13], buffer[14], buffer[15]
+	x16, x17, x18, x19 := buffer[16], buffer[17], buffer[18], buffer[19]
+	x20, x21, x22, x23 := buffer[20], buffer[21], buffer[22], buffer[23]
+	x24, x25, x26 := buffer[24], buffer[25], buffer[26]
+
+	t0r, t0i := real(b.twiddles[0]), imag(b.twiddles[0])
+
+	// 9 FFTs of size 3 over the columns, then twiddles
+	v0, v1 := x9+x18, x9-x18
+	v2 := x0 + v0
+	ar3 := real(x0) + t0r*real(v0)
+	ai3 := imag(x0) + t0r*imag(v0)
+	br3 := t0i * real(v1)
+	bi3 := t0i * imag(v1)
+	v4, v5 := complex(ar3-bi3, ai3+br3), complex(ar3+bi3, ai3-br3)
+	v6, v7 := x10+x19, x10-x19
+	v8 := x1 + v6
+	ar9 := real(x1) + t0r*real(v6)
+	ai9 := imag(x1) + t0r*imag(v6)
+	br9 := t0i * real(v7)
+	bi9 := t0i * imag(v7)
+	v10, v11 := complex(ar9-bi9, ai9+br9), complex(ar9+bi9, ai9-br9)
+	v12 := v10 * b.twiddles[1]
+	v13 := v11 * b.twiddles[2]
+	v14, v15 := x11+x20, x11-x20
+	v16 := x2 + v14
+	ar17 := real(x2) + t0r*real(v14)
+	ai17 := imag(x2) + t0r*imag(v14)
+	br17 := t0i * real(v15)
+	bi17 := t0i * imag(v15)
+	v18, v19 := complex(ar17-bi17, ai17+br17), complex(ar17+bi17, ai17-br17)
+	v20 := v18 * b.twiddles[2]
+	v21 := v19 * b.twiddles[3]
+	v22, v23 := x12+x21, x12-x21
+	v24 := x3 + v22
+	ar25 := real(x3) + t0r*real(v22)
+	ai25 := imag(x3) + t0r*imag(v22)
+	br25 := t0i * real(v23)
+	bi25 := t0i * imag(v23)
+	v26, v27 := complex(ar25-bi25, ai25+br25), complex(ar25+bi25, ai25-br25)
+	v28 := v26 * b.twiddles[4]
+	v29 := v27 * b.twiddles[5]
+	v30, v31 := x13+x22, x13-x22
+	v32 := x4 + v30
+	ar33 := real(x4) + t0r*real(v30)
+	ai33 := imag(x4) + t0r*imag(v30)
+	br33 := t0i * real(v31)
+	bi33 := t0i * imag(v31)
+	v34, v35 := complex(ar33-bi33, ai33+br33), complex(ar33+bi33, ai33-br33)
+	v36 := v34 * b.twiddles[3]
+	v37 := v35 * b.twiddles[6]
+	v38, v39 := x14+x23, x14-x23
+	v40 := x5 + v38
+	ar41 := real(x5) + t0r*real(v38)
+	ai41 := imag(x5) + t0r*imag(v38)
+	br41 := t0i * real(v39)
+	bi41 := t0i * imag(v39)
+	v42, v43 := complex(ar41-bi41, ai41+br41), complex(ar41+bi41, ai41-br41)
+	v44 := v42 * b.twiddles[7]
+	v45 := v43 * b.twiddles[8]
+	v46, v47 := x15+x24, x15-x24
+	v48 := x6 + v46
+	ar49 := real(x6) + t0r*real(v46)
+	ai49 := imag(x6) + t0r*imag(v46)
+	br49 := t0i * real(v47)
+	bi49 := t0i * imag(v47)
+	v50, v51 := complex(ar49-bi49, ai49+br49), complex(ar49+bi49, ai49-br49)
+	v52 := v50 * b.twiddles[5]
+	v53 := v51 * b.twiddles[9]
+	v54, v55 := x16+x25, x16-x25
+	v56 := x7 + v54
+	ar57 := real(x7) + t0r*real(v54)
+	ai57 := imag(x7) + t0r*imag(v54)
+	br57 := t0i * real(v55)
+	bi57 := t0i * imag(v55)
+	v58, v59 := complex(ar57-bi57, ai57+br57), complex(ar57+bi57, ai57-br57)
+	v60 := v58 * b.twiddles[10]
+	v61 := v59 * b.twiddles[11]
+	v62, v63 := x17+x26, x17-x26
+	v64 := x8 + v62
+	ar65 := real(x8) + t0r*real(v62)
+	ai65 := imag(x8) + t0r*imag(v62)
+	br65 := t0i * real(v63)
+	bi65 := t0i * imag(v63)
+	v66, v67 := complex(ar65-bi65, ai65+br65), complex(ar65+bi65, ai65-br65)
+	v68 := v66 * b.twiddles[6]
+	v69 := v67 * b.twiddles[12]
+
+	// 3 FFTs of size 9 over the rows
+	v70, v71 := v24+v48, v24-v48
+	v72 := v2 + v70
+	ar73 := real(v2) + t0r*real(v70)
+	ai73 := imag(v2) + t0r*imag(v70)
+	br73 := t0i * real(v71)
+	bi73 := t0i * imag(v71)
+	v74, v75 := complex(ar73-bi73, ai73+br73), complex(ar73+bi73, ai73-br73)
+	v76, v77 := v32+v56, v32-v56
+	v78 := v8 + v76
+	ar79 := real(v8) + t0r*real(v76)
+	ai79 := imag(v8) + t0r*imag(v76)
+	br79 := t0i * real(v77)
+	bi79 := t0i * imag(v77)
+	v80, v81 := complex(ar79-bi79, ai79+br79), complex(ar79+bi79, ai79-br79)
+	v82 := v80 * b.twiddles[4]
+	v83 := v81 * b.twiddles[5]
+	v84, v85 := v40+v64, v40-v64
+	v86 := v16 + v84
+	ar87 := real(v16) + t0r*real(v84)
+	ai87 := imag(v16) + t0r*imag(v84)
+	br87 := t0i * real(v85)
+	bi87 := t0i * imag(v85)
+	v88, v89 := complex(ar87-bi87, ai87+br87), complex(ar87+bi87, ai87-br87)
+	v90 := v88 * b.twiddles[5]
+	v91 := v89 * b.twiddles[9]
+	v92, v93 := v78+v86, v78-v86
+	v94 := v72 + v92
+	ar95 := real(v72) + t0r*real(v92)
+	ai95 := imag(v72) + t0r*imag(v92)
+	br95 := t0i * real(v93)
+	bi95 := t0i * imag(v93)
+	v96, v97 := complex(ar95-bi95, ai95+br95), complex(ar95+bi95, ai95-br95)
+	v98, v99 := v82+v90, v82-v90
+	v100 := v74 + v98
+	ar101 := real(v74) + t0r*real(v98)
+	ai101 := imag(v74) + t0r*imag(v98)
+	br101 := t0i * real(v99)
+	bi101 := t0i * imag(v99)
+	v102, v103 := complex(ar101-bi101, ai101+br101), complex(ar101+bi101, ai101-br101)
+	v104, v105 := v83+v91, v83-v91
+	v106 := v75 + v104
+	ar107 := real(v75) + t0r*real(v104)
+	ai107 := imag(v75) + t0r*imag(v104)
+	br107 := t0i * real(v105)
+	bi107 := t0i * imag(v105)
+	v108, v109 := complex(ar107-bi107, ai107+br107), complex(ar107+bi107, ai107-br107)
+	v110, v111 := v28+v52, v28-v52
+	v112 := v4 + v110
+	ar113 := real(v4) + t0r*real(v110)
+	ai113 := imag(v4) + t0r*imag(v110)
+	br113 := t0i * real(v111)
+	bi113 := t0i * imag(v111)
+	v114, v115 := complex(ar113-bi113, ai113+br113), complex(ar113+bi113, ai113-br113)
+	v116, v117 := v36+v60, v36-v60
+	v118 := v12 + v116
+	ar119 := real(v12) + t0r*real(v116)
+	ai119 := imag(v12) + t0r*imag(v116)
+	br119 := t0i * real(v117)
+	bi119 := t0i * imag(v117)
+	v120, v121 := complex(ar119-bi119, ai119+br119), complex(ar119+bi119, ai119-br119)
+	v122 := v120 * b.twiddles[4]
+	v123 := v121 * b.twiddles[5]
+	v124, v125 := v44+v68, v44-v68
+	v126 := v20 + v124
+	ar127 := real(v20) + t0r*real(v124)
+	ai127 := imag(v20) + t0r*imag(v124)
+	br127 := t0i * real(v125)
+	bi127 := t0i * imag(v125)
+	v128, v129 := complex(ar127-bi127, ai127+br127), complex(ar127+bi127, ai127-br127)
+	v130 := v128 * b.twiddles[5]
+	v131 := v129 * b.twiddles[9]
+	v132, v133 := v118+v126, v118-v126
+	v134 := v112 + v132
+	ar135 := real(v112) + t0r*real(v132)
+	ai135 := imag(v112) + t0r*imag(v132)
+	br135 := t0i * real(v133)
+	bi135 := t0i * imag(v133)
+	v136, v137 := complex(ar135-bi135, ai135+br135), complex(ar135+bi135, ai135-br135)
+	v138, v139 := v122+v130, v122-v130
+	v140 := v114 + v138
+	ar141 := real(v114) + t0r*real(v138)
+	ai141 := imag(v114) + t0r*imag(v138)
+	br141 := t0i * real(v139)
+	bi141 := t0i * imag(v139)
+	v142, v143 := complex(ar141-bi141, ai141+br141), complex(ar141+bi141, ai141-br141)
+	v144, v145 := v123+v131, v123-v131
+	v146 := v115 + v144
+	ar147 := real(v115) + t0r*real(v144)
+	ai147 := imag(v115) + t0r*imag(v144)
+	br147 := t0i * real(v145)
+	bi147 := t0i * imag(v145)
+	v148, v149 := complex(ar147-bi147, ai147+br147), complex(ar147+bi147, ai147-br147)
+	v150, v151 := v29+v53, v29-v53
+	v152 := v5 + v150
+	ar153 := real(v5) + t0r*real(v150)
+	ai153 := imag(v5) + t0r*imag(v150)
+	br153 := t0i * real(v151)
+	bi153 := t0i * imag(v151)
+	v154, v155 := complex(ar153-bi153, ai153+br153), complex(ar153+bi153, ai153-br153)
+	v156, v157 := v37+v61, v37-v61
+	v158 := v13 + v156
+	ar159 := real(v13) + t0r*real(v156)
+	ai159 := imag(v13) + t0r*imag(v156)
+	br159 := t0i * real(v157)
+	bi159 := t0i * imag(v157)
+	v160, v161 := complex(ar159-bi159, ai159+br159), complex(ar159+bi159, ai159-br159)
+	v162 := v160 * b.twiddles[4]
+	v163 := v161 * b.twiddles[5]
+	v164, v165 := v45+v69, v45-v69
+	v166 := v21 + v164
+	ar167 := real(v21) + t0r*real(v164)
+	ai167 := imag(v21) + t0r*imag(v164)
+	br167 := t0i * real(v165)
+	bi167 := t0i * imag(v165)
+	v168, v169 := complex(ar167-bi167, ai167+br167), complex(ar167+bi167, ai167-br167)
+	v170 := v168 * b.twiddles[5]
+	v171 := v169 * b.twiddles[9]
+	v172, v173 := v158+v166, v158-v166
+	v174 := v152 + v172
+	ar175 := real(v152) + t0r*real(v172)
+	ai175 := imag(v152) + t0r*imag(v172)
+	br175 := t0i * real(v173)
+	bi175 := t0i * imag(v173)
+	v176, v177 := complex(ar175-bi175, ai175+br175), complex(ar175+bi175, ai175-br175)
+	v178, v179 := v162+v170, v162-v170
+	v180 := v154 + v178
+	ar181 := real(v154) + t0r*real(v178)
+	ai181 := imag(v154) + t0r*imag(v178)
+	br181 := t0i * real(v179)
+	bi181 := t0i * imag(v179)
+	v182, v183 := complex(ar181-bi181, ai181+br181), complex(ar181+bi181, ai181-br181)
+	v184, v185 := v163+v171, v163-v171
+	v186 := v155 + v184
+	ar187 := real(v155) + t0r*real(v184)
+	ai187 := imag(v155) + t0r*imag(v184)
+	br187 := t0i * real(v185)
+	bi187 := t0i * imag(v185)
+	v188, v189 := complex(ar187-bi187, ai187+br187), complex(ar187+bi187, ai187-br187)
+
+	buffer[0] = v94
+	buffer[1] = v134
+	buffer[2] = v174
+	buffer[3] = v100
+	buffer[4] = v140
+	buffer[5] = v180
+	buffer[6] = v106
+	buffer[7] = v146
+	buffer[8] = v186
+	buffer[9] = v96
+	buffer[10] = v136
+	buffer[11] = v176
+	buffer[12] = v102
+	buffer[13] = v142
+	buffer[14] = v182
+	buffer[15] = v108
+	buffer[16] = v148
+	buffer[17] = v188
+	buffer[18] = v97
+	buffer[19] = v137
+	buffer[20] = v177
+	buffer[21] = v103
+	buffer[22] = v143
+	buffer[23] = v183
+	buffer[24] = v109
+	buffer[25] = v149
+	buffer[26] = v189
+}
+
+func (b *Butterfly27) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly36 implements a size-36 FFT (Good-Thomas 4x9)
+type Butterfly36 struct {
+	direction Direction
+	twiddles  [4]complex128
+}
+
+// NewButterfly36 creates a new Butterfly36 instance
+func NewButterfly36(direction Direction) *Butterfly36 {
+	return &Butterfly36{
+		direction: direction,
+		twiddles: [4]complex128{
+			twiddleFactor(1, 3, direction),
+			twiddleFactor(1, 9, direction),
+			twiddleFactor(2, 9, direction),
+			twiddleFactor(4, 9, direction),
+		},
+	}
+}
+
+func (b *Butterfly36) Len() int                  { return 36 }
+func (b *Butterfly36) Direction() Direction      { return b.direction }
+func (b *Butterfly36) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly36) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly36) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly36) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly36) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 36 {
+		b.performFft(buffer[i : i+36])
+	}
+}
+
+func (b *Butterfly36) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 36 {
+		b.performFftOutOfPlace(input[i:i+36], output[i:i+36])
+	}
+}
+
+func (b *Butterfly36) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly36) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13, x14, x15 := buffer[12], buffer[13], buffer[14], buffer[15]
+	x16, x17, x18, x19 := buffer[16], buffer[17], buffer[18], buffer[19]
+	x20, x21, x22, x23 := buffer[20], buffer[21], buffer[22], buffer[23]
+	x24, x25, x26, x27 := buffer[24], buffer[25], buffer[26], buffer[27]
+	x28, x29, x30, x31 := buffer[28], buffer[29], buffer[30], buffer[31]
+	x32, x33, x34, x35 := buffer[32], buffer[33], buffer[34], buffer[35]
+
+	t0r, t0i := real(b.twiddles[0]), imag(b.twiddles[0])
+
+	// 9 FFTs of size 4 over the columns
+	v0, v1 := x0+x18, x0-x18
+	v2, v3 := x9+x27, rotate90(x9-x27, b.direction)
+	v4, v5, v6, v7 := v0+v2, v1+v3, v0-v2, v1-v3
+	v8, v9 := x4+x22, x4-x22
+	v10, v11 := x13+x31, rotate90(x13-x31, b.direction)
+	v12, v13, v14, v15 := v8+v10, v9+v11, v8-v10, v9-v11
+	v16, v17 := x8+x26, x8-x26
+	v18, v19 := x17+x35, rotate90(x17-x35, b.direction)
+	v20, v21, v22, v23 := v16+v18, v17+v19, v16-v18, v17-v19
+	v24, v25 := x12+x30, x12-x30
+	v26, v27 := x21+x3, rotate90(x21-x3, b.direction)
+	v28, v29, v30, v31 := v24+v26, v25+v27, v24-v26, v25-v27
+	v32, v33 := x16+x34, x16-x34
+	v34, v35 := x25+x7, rotate90(x25-x7, b.direction)
+	v36, v37, v38, v39 := v32+v34, v33+v35, v32-v34, v33-v35
+	v40, v41 := x20+x2, x20-x2
+	v42, v43 := x29+x11, rotate90(x29-x11, b.direction)
+	v44, v45, v46, v47 := v40+v42, v41+v43, v40-v42, v41-v43
+	v48, v49 := x24+x6, x24-x6
+	v50, v51 := x33+x15, rotate90(x33-x15, b.direction)
+	v52, v53, v54, v55 := v48+v50, v49+v51, v48-v50, v49-v51
+	v56, v57 := x28+x10, x28-x10
+	v58, v59 := x1+x19, rotate90(x1-x19, b.direction)
+	v60, v61, v62, v63 := v56+v58, v57+v59, v56-v58, v57-v59
+	v64, v65 := x32+x14, x32-x14
+	v66, v67 := x5+x23, rotate90(x5-x23, b.direction)
+	v68, v69, v70, v71 := v64+v66, v65+v67, v64-v66, v65-v67
+
+	// 4 FFTs of size 9 over the rows
+	v72, v73 := v28+v52, v28-v52
+	v74 := v4 + v72
+	ar75 := real(v4) + t0r*real(v72)
+	ai75 := imag(v4) + t0r*imag(v72)
+	br75 := t0i * real(v73)
+	bi75 := t0i * imag(v73)
+	v76, v77 := complex(ar75-bi75, ai75+br75), complex(ar75+bi75, ai75-br75)
+	v78, v79 := v36+v60, v36-v60
+	v80 := v12 + v78
+	ar81 := real(v12) + t0r*real(v78)
+	ai81 := imag(v12) + t0r*imag(v78)
+	br81 := t0i * real(v79)
+	bi81 := t0i * imag(v79)
+	v82, v83 := complex(ar81-bi81, ai81+br81), complex(ar81+bi81, ai81-br81)
+	v84 := v82 * b.twiddles[1]
+	v85 := v83 * b.twiddles[2]
+	v86, v87 := v44+v68, v44-v68
+	v88 := v20 + v86
+	ar89 := real(v20) + t0r*real(v86)
+	ai89 := imag(v20) + t0r*imag(v86)
+	br89 := t0i * real(v87)
+	bi89 := t0i * imag(v87)
+	v90, v91 := complex(ar89-bi89, ai89+br89), complex(ar89+bi89, ai89-br89)
+	v92 := v90 * b.twiddles[2]
+	v93 := v91 * b.twiddles[3]
+	v94, v95 := v80+v88, v80-v88
+	v96 := v74 + v94
+	ar97 := real(v74) + t0r*real(v94)
+	ai97 := imag(v74) + t0r*imag(v94)
+	br97 := t0i * real(v95)
+	bi97 := t0i * imag(v95)
+	v98, v99 := complex(ar97-bi97, ai97+br97), complex(ar97+bi97, ai97-br97)
+	v100, v101 := v84+v92, v84-v92
+	v102 := v76 + v100
+	ar103 := real(v76) + t0r*real(v100)
+	ai103 := imag(v76) + t0r*imag(v100)
+	br103 := t0i * real(v101)
+	bi103 := t0i * imag(v101)
+	v104, v105 := complex(ar103-bi103, ai103+br103), complex(ar103+bi103, ai103-br103)
+	v106, v107 := v85+v93, v85-v93
+	v108 := v77 + v106
+	ar109 := real(v77) + t0r*real(v106)
+	ai109 := imag(v77) + t0r*imag(v106)
+	br109 := t0i * real(v107)
+	bi109 := t0i * imag(v107)
+	v110, v111 := complex(ar109-bi109, ai109+br109), complex(ar109+bi109, ai109-br109)
+	v112, v113 := v29+v53, v29-v53
+	v114 := v5 + v112
+	ar115 := real(v5) + t0r*real(v112)
+	ai115 := imag(v5) + t0r*imag(v112)
+	br115 := t0i * real(v113)
+	bi115 := t0i * imag(v113)
+	v116, v117 := complex(ar115-bi115, ai115+br115), complex(ar115+bi115, ai115-br115)
+	v118, v119 := v37+v61, v37-v61
+	v120 := v13 + v118
+	ar121 := real(v13) + t0r*real(v118)
+	ai121 := imag(v13) + t0r*imag(v118)
+	br121 := t0i * real(v119)
+	bi121 := t0i * imag(v119)
+	v122, v123 := complex(ar121-bi121, ai121+br121), complex(ar121+bi121, ai121-br121)
+	v124 := v122 * b.twiddles[1]
+	v125 := v123 * b.twiddles[2]
+	v126, v127 := v45+v69, v45-v69
+	v128 := v21 + v126
+	ar129 := real(v21) + t0r*real(v126)
+	ai129 := imag(v21) + t0r*imag(v126)
+	br129 := t0i * real(v127)
+	bi129 := t0i * imag(v127)
+	v130, v131 := complex(ar129-bi129, ai129+br129), complex(ar129+bi129, ai129-br129)
+	v132 := v130 * b.twiddles[2]
+	v133 := v131 * b.twiddles[3]
+	v134, v135 := v120+v128, v120-v128
+	v136 := v114 + v134
+	ar137 := real(v114) + t0r*real(v134)
+	ai137 := imag(v114) + t0r*imag(v134)
+	br137 := t0i * real(v135)
+	bi137 := t0i * imag(v135)
+	v138, v139 := complex(ar137-bi137, ai137+br137), complex(ar137+bi137, ai137-br137)
+	v140, v141 := v124+v132, v124-v132
+	v142 := v116 + v140
+	ar143 := real(v116) + t0r*real(v140)
+	ai143 := imag(v116) + t0r*imag(v140)
+	br143 := t0i * real(v141)
+	bi143 := t0i * imag(v141)
+	v144, v145 := complex(ar143-bi143, ai143+br143), complex(ar143+bi143, ai143-br143)
+	v146, v147 := v125+v133, v125-v133
+	v148 := v117 + v146
+	ar149 := real(v117) + t0r*real(v146)
+	ai149 := imag(v117) + t0r*imag(v146)
+	br149 := t0i * real(v147)
+	bi149 := t0i * imag(v147)
+	v150, v151 := complex(ar149-bi149, ai149+br149), complex(ar149+bi149, ai149-br149)
+	v152, v153 := v30+v54, v30-v54
+	v154 := v6 + v152
+	ar155 := real(v6) + t0r*real(v152)
+	ai155 := imag(v6) + t0r*imag(v152)
+	br155 := t0i * real(v153)
+	bi155 := t0i * imag(v153)
+	v156, v157 := complex(ar155-bi155, ai155+br155), complex(ar155+bi155, ai155-br155)
+	v158, v159 := v38+v62, v38-v62
+	v160 := v14 + v158
+	ar161 := real(v14) + t0r*real(v158)
+	ai161 := imag(v14) + t0r*imag(v158)
+	br161 := t0i * real(v159)
+	bi161 := t0i * imag(v159)
+	v162, v163 := complex(ar161-bi161, ai161+br161), complex(ar161+bi161, ai161-br161)
+	v164 := v162 * b.twiddles[1]
+	v165 := v163 * b.twiddles[2]
+	v166, v167 := v46+v70, v46-v70
+	v168 := v22 + v166
+	ar169 := real(v22) + t0r*real(v166)
+	ai169 := imag(v22) + t0r*imag(v166)
+	br169 := t0i * real(v167)
+	bi169 := t0i * imag(v167)
+	v170, v171 := complex(ar169-bi169, ai169+br169), complex(ar169+bi169, ai169-br169)
+	v172 := v170 * b.twiddles[2]
+	v173 := v171 * b.twiddles[3]
+	v174, v175 := v160+v168, v160-v168
+	v176 := v154 + v174
+	ar177 := real(v154) + t0r*real(v174)
+	ai177 := imag(v154) + t0r*imag(v174)
+	br177 := t0i * real(v175)
+	bi177 := t0i * imag(v175)
+	v178, v179 := complex(ar177-bi177, ai177+br177), complex(ar177+bi177, ai177-br177)
+	v180, v181 := v164+v172, v164-v172
+	v182 := v156 + v180
+	ar183 := real(v156) + t0r*real(v180)
+	ai183 := imag(v156) + t0r*imag(v180)
+	br183 := t0i * real(v181)
+	bi183 := t0i * imag(v181)
+	v184, v185 := complex(ar183-bi183, ai183+br183), complex(ar183+bi183, ai183-br183)
+	v186, v187 := v165+v173, v165-v173
+	v188 := v157 + v186
+	ar189 := real(v157) + t0r*real(v186)
+	ai189 := imag(v157) + t0r*imag(v186)
+	br189 := t0i * real(v187)
+	bi189 := t0i * imag(v187)
+	v190, v191 := complex(ar189-bi189, ai189+br189), complex(ar189+bi189, ai189-br189)
+	v192, v193 := v31+v55, v31-v55
+	v194 := v7 + v192
+	ar195 := real(v7) + t0r*real(v192)
+	ai195 := imag(v7) + t0r*imag(v192)
+	br195 := t0i * real(v193)
+	bi195 := t0i * imag(v193)
+	v196, v197 := complex(ar195-bi195, ai195+br195), complex(ar195+bi195, ai195-br195)
+	v198, v199 := v39+v63, v39-v63
+	v200 := v15 + v198
+	ar201 := real(v15) + t0r*real(v198)
+	ai201 := imag(v15) + t0r*imag(v198)
+	br201 := t0i * real(v199)
+	bi201 := t0i * imag(v199)
+	v202, v203 := complex(ar201-bi201, ai201+br201), complex(ar201+bi201, ai201-br201)
+	v204 := v202 * b.twiddles[1]
+	v205 := v203 * b.twiddles[2]
+	v206, v207 := v47+v71, v47-v71
+	v208 := v23 + v206
+	ar209 := real(v23) + t0r*real(v206)
+	ai209 := imag(v23) + t0r*imag(v206)
+	br209 := t0i * real(v207)
+	bi209 := t0i * imag(v207)
+	v210, v211 := complex(ar209-bi209, ai209+br209), complex(ar209+bi209, ai209-br209)
+	v212 := v210 * b.twiddles[2]
+	v213 := v211 * b.twiddles[3]
+	v214, v215 := v200+v208, v200-v208
+	v216 := v194 + v214
+	ar217 := real(v194) + t0r*real(v214)
+	ai217 := imag(v194) + t0r*imag(v214)
+	br217 := t0i * real(v215)
+	bi217 := t0i * imag(v215)
+	v218, v219 := complex(ar217-bi217, ai217+br217), complex(ar217+bi217, ai217-br217)
+	v220, v221 := v204+v212, v204-v212
+	v222 := v196 + v220
+	ar223 := real(v196) + t0r*real(v220)
+	ai223 := imag(v196) + t0r*imag(v220)
+	br223 := t0i * real(v221)
+	bi223 := t0i * imag(v221)
+	v224, v225 := complex(ar223-bi223, ai223+br223), complex(ar223+bi223, ai223-br223)
+	v226, v227 := v205+v213, v205-v213
+	v228 := v197 + v226
+	ar229 := real(v197) + t0r*real(v226)
+	ai229 := imag(v197) + t0r*imag(v226)
+	br229 := t0i * real(v227)
+	bi229 := t0i * imag(v227)
+	v230, v231 := complex(ar229-bi229, ai229+br229), complex(ar229+bi229, ai229-br229)
+
+	buffer[0] = v96
+	buffer[1] = v142
+	buffer[2] = v188
+	buffer[3] = v218
+	buffer[4] = v104
+	buffer[5] = v150
+	buffer[6] = v179
+	buffer[7] = v225
+	buffer[8] = v111
+	buffer[9] = v136
+	buffer[10] = v182
+	buffer[11] = v228
+	buffer[12] = v98
+	buffer[13] = v144
+	buffer[14] = v190
+	buffer[15] = v219
+	buffer[16] = v105
+	buffer[17] = v151
+	buffer[18] = v176
+	buffer[19] = v222
+	buffer[20] = v108
+	buffer[21] = v138
+	buffer[22] = v184
+	buffer[23] = v230
+	buffer[24] = v99
+	buffer[25] = v145
+	buffer[26] = v191
+	buffer[27] = v216
+	buffer[28] = v102
+	buffer[29] = v148
+	buffer[30] = v178
+	buffer[31] = v224
+	buffer[32] = v110
+	buffer[33] = v139
+	buffer[34] = v185
+	buffer[35] = v231
+}
+
+func (b *Butterfly36) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
+
+// Butterfly64 implements a size-64 FFT (mixed radix 8x8)
+type Butterfly64 struct {
+	direction Direction
+	twiddles  [24]complex128
+}
+
+// NewButterfly64 creates a new Butterfly64 instance
+func NewButterfly64(direction Direction) *Butterfly64 {
+	return &Butterfly64{
+		direction: direction,
+		twiddles: [24]complex128{
+			twiddleFactor(1, 8, direction),
+			twiddleFactor(3, 8, direction),
+			twiddleFactor(1, 64, direction),
+			twiddleFactor(1, 32, direction),
+			twiddleFactor(3, 64, direction),
+			twiddleFactor(1, 16, direction),
+			twiddleFactor(5, 64, direction),
+			twiddleFactor(3, 32, direction),
+			twiddleFactor(7, 64, direction),
+			twiddleFactor(5, 32, direction),
+			twiddleFactor(3, 16, direction),
+			twiddleFactor(7, 32, direction),
+			twiddleFactor(9, 64, direction),
+			twiddleFactor(15, 64, direction),
+			twiddleFactor(9, 32, direction),
+			twiddleFactor(21, 64, direction),
+			twiddleFactor(5, 16, direction),
+			twiddleFactor(7, 16, direction),
+			twiddleFactor(25, 64, direction),
+			twiddleFactor(15, 32, direction),
+			twiddleFactor(35, 64, direction),
+			twiddleFactor(9, 16, direction),
+			twiddleFactor(21, 32, direction),
+			twiddleFactor(49, 64, direction),
+		},
+	}
+}
+
+func (b *Butterfly64) Len() int                  { return 64 }
+func (b *Butterfly64) Direction() Direction      { return b.direction }
+func (b *Butterfly64) InplaceScratchLen() int    { return 0 }
+func (b *Butterfly64) OutOfPlaceScratchLen() int { return 0 }
+func (b *Butterfly64) ImmutableScratchLen() int  { return 0 }
+
+func (b *Butterfly64) Process(buffer []complex128) {
+	b.ProcessWithScratch(buffer, nil)
+}
+
+func (b *Butterfly64) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += 64 {
+		b.performFft(buffer[i : i+64])
+	}
+}
+
+func (b *Butterfly64) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += 64 {
+		b.performFftOutOfPlace(input[i:i+64], output[i:i+64])
+	}
+}
+
+func (b *Butterfly64) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	b.ProcessOutOfPlace(input, output, scratch)
+}
+
+func (b *Butterfly64) performFft(buffer []complex128) {
+	x0, x1, x2, x3 := buffer[0], buffer[1], buffer[2], buffer[3]
+	x4, x5, x6, x7 := buffer[4], buffer[5], buffer[6], buffer[7]
+	x8, x9, x10, x11 := buffer[8], buffer[9], buffer[10], buffer[11]
+	x12, x13, x14, x15 := buffer[12], buffer[13], buffer[14], buffer[15]
+	x16, x17, x18, x19 := buffer[16], buffer[17], buffer[18], buffer[19]
+	x20, x21, x22, x23 := buffer[20], buffer[21], buffer[22], buffer[23]
+	x24, x25, x26, x27 := buffer[24], buffer[25], buffer[26], buffer[27]
+	x28, x29, x30, x31 := buffer[28], buffer[29], buffer[30], buffer[31]
+	x32, x33, x34, x35 := buffer[32], buffer[33], buffer[34], buffer[35]
+	x36, x37, x38, x39 := buffer[36], buffer[37], buffer[38], buffer[39]
+	x40, x41, x42, x43 := buffer[40], buffer[41], buffer[42], buffer[43]
+	x44, x45, x46, x47 := buffer[44], buffer[45], buffer[46], buffer[47]
+	x48, x49, x50, x51 := buffer[48], buffer[49], buffer[50], buffer[51]
+	x52, x53, x54, x55 := buffer[52], buffer[53], buffer[54], buffer[55]
+	x56, x57, x58, x59 := buffer[56], buffer[57], buffer[58], buffer[59]
+	x60, x61, x62, x63 := buffer[60], buffer[61], buffer[62], buffer[63]
+
+	// 8 FFTs of size 8 over the columns, then twiddles
+	v0, v1 := x0+x32, x0-x32
+	v2, v3 := x8+x40, x8-x40
+	v4 := v3 * b.twiddles[0]
+	v5, v6 := x16+x48, x16-x48
+	v7 := rotate90(v6, b.direction)
+	v8, v9 := x24+x56, x24-x56
+	v10 := v9 * b.twiddles[1]
+	v11, v12 := v0+v5, v0-v5
+	v13, v14 := v2+v8, rotate90(v2-v8, b.direction)
+	v15, v16, v17, v18 := v11+v13, v12+v14, v11-v13, v12-v14
+	v19, v20 := v1+v7, v1-v7
+	v21, v22 := v4+v10, rotate90(v4-v10, b.direction)
+	v23, v24, v25, v26 := v19+v21, v20+v22, v19-v21, v20-v22
+	v27, v28 := x1+x33, x1-x33
+	v29, v30 := x9+x41, x9-x41
+	v31 := v30 * b.twiddles[0]
+	v32, v33 := x17+x49, x17-x49
+	v34 := rotate90(v33, b.direction)
+	v35, v36 := x25+x57, x25-x57
+	v37 := v36 * b.twiddles[1]
+	v38, v39 := v27+v32, v27-v32
+	v40, v41 := v29+v35, rotate90(v29-v35, b.direction)
+	v42, v43, v44, v45 := v38+v40, v39+v41, v38-v40, v39-v41
+	v46, v47 := v28+v34, v28-v34
+	v48, v49 := v31+v37, rotate90(v31-v37, b.direction)
+	v50, v51, v52, v53 := v46+v48, v47+v49, v46-v48, v47-v49
+	v54 := v50 * b.twiddles[2]
+	v55 := v43 * b.twiddles[3]
+	v56 := v51 * b.twiddles[4]
+	v57 := v44 * b.twiddles[5]
+	v58 := v52 * b.twiddles[6]
+	v59 := v45 * b.twiddles[7]
+	v60 := v53 * b.twiddles[8]
+	v61, v62 := x2+x34, x2-x34
+	v63, v64 := x10+x42, x10-x42
+	v65 := v64 * b.twiddles[0]
+	v66, v67 := x18+x50, x18-x50
+	v68 := rotate90(v67, b.direction)
+	v69, v70 := x26+x58, x26-x58
+	v71 := v70 * b.twiddles[1]
+	v72, v73 := v61+v66, v61-v66
+	v74, v75 := v63+v69, rotate90(v63-v69, b.direction)
+	v76, v77, v78, v79 := v72+v74, v73+v75, v72-v74, v73-v75
+	v80, v81 := v62+v68, v62-v68
+	v82, v83 := v65+v71, rotate90(v65-v71, b.direction)
+	v84, v85, v86, v87 := v80+v82, v81+v83, v80-v82, v81-v83
+	v88 := v84 * b.twiddles[3]
+	v89 := v77 * b.twiddles[5]
+	v90 := v85 * b.twiddles[7]
+	v91 := v78 * b.twiddles[0]
+	v92 := v86 * b.twiddles[9]
+	v93 := v79 * b.twiddles[10]
+	v94 := v87 * b.twiddles[11]
+	v95, v96 := x3+x35, x3-x35
+	v97, v98 := x11+x43, x11-x43
+	v99 := v98 * b.twiddles[0]
+	v100, v101 := x19+x51, x19-x51
+	v102 := rotate90(v101, b.direction)
+	v103, v104 := x27+x59, x27-x59
+	v105 := v104 * b.twiddles[1]
+	v106, v107 := v95+v100, v95-v100
+	v108, v109 := v97+v103, rotate90(v97-v103, b.direction)
+	v110, v111, v112, v113 := v106+v108, v107+v109, v106-v108, v107-v109
+	v114, v115 := v96+v102, v96-v102
+	v116, v117 := v99+v105, rotate90(v99-v105, b.direction)
+	v118, v119, v120, v121 := v114+v116, v115+v117, v114-v116, v115-v117
+	v122 := v118 * b.twiddles[4]
+	v123 := v111 * b.twiddles[7]
+	v124 := v119 * b.twiddles[12]
+	v125 := v112 * b.twiddles[10]
+	v126 := v120 * b.twiddles[13]
+	v127 := v113 * b.twiddles[14]
+	v128 := v121 * b.twiddles[15]
+	v129, v130 := x4+x36, x4-x36
+	v131, v132 := x12+x44, x12-x44
+	v133 := v132 * b.twiddles[0]
+	v134, v135 := x20+x52, x20-x52
+	v136 := rotate90(v135, b.direction)
+	v137, v138 := x28+x60, x28-x60
+	v139 := v138 * b.twiddles[1]
+	v140, v141 := v129+v134, v129-v134
+	v142, v143 := v131+v137, rotate90(v131-v137, b.direction)
+	v144, v145, v146, v147 := v140+v142, v141+v143, v140-v142, v141-v143
+	v148, v149 := v130+v136, v130-v136
+	v150, v151 := v133+v139, rotate90(v133-v139, b.direction)
+	v152, v153, v154, v155 := v148+v150, v149+v151, v148-v150, v149-v151
+	v156 := v152 * b.twiddles[5]
+	v157 := v145 * b.twiddles[0]
+	v158 := v153 * b.twiddles[10]
+	v159 := rotate90(v146, b.direction)
+	v160 := v154 * b.twiddles[16]
+	v161 := v147 * b.twiddles[1]
+	v162 := v155 * b.twiddles[17]
+	v163, v164 := x5+x37, x5-x37
+	v165, v166 := x13+x45, x13-x45
+	v167 := v166 * b.twiddles[0]
+	v168, v169 := x21+x53, x21-x53
+	v170 := rotate90(v169, b.direction)
+	v171, v172 := x29+x61, x29-x61
+	v173 := v172 * b.twiddles[1]
+	v174, v175 := v163+v168, v163-v168
+	v176, v177 := v165+v171, rotate90(v165-v171, b.direction)
+	v178, v179, v180, v181 := v174+v176, v175+v177, v174-v176, v175-v177
+	v182, v183 := v164+v170, v164-v170
+	v184, v185 := v167+v173, rotate90(v167-v173, b.direction)
+	v186, v187, v188, v189 := v182+v184, v183+v185, v182-v184, v183-v185
+	v190 := v186 * b.twiddles[6]
+	v191 := v179 * b.twiddles[9]
+	v192 := v187 * b.twiddles[13]
+	v193 := v180 * b.twiddles[16]
+	v194 := v188 * b.twiddles[18]
+	v195 := v181 * b.twiddles[19]
+	v196 := v189 * b.twiddles[20]
+	v197, v198 := x6+x38, x6-x38
+	v199, v200 := x14+x46, x14-x46
+	v201 := v200 * b.twiddles[0]
+	v202, v203 := x22+x54, x22-x54
+	v204 := rotate90(v203, b.direction)
+	v205, v206 := x30+x62, x30-x62
+	v207 := v206 * b.twiddles[1]
+	v208, v209 := v197+v202, v197-v202
+	v210, v211 := v199+v205, rotate90(v199-v205, b.direction)
+	v212, v213, v214, v215 := v208+v210, v209+v211, v208-v210, v209-v211
+	v216, v217 := v198+v204, v198-v204
+	v218, v219 := v201+v207, rotate90(v201-v207, b.direction)
+	v220, v221, v222, v223 := v216+v218, v217+v219, v216-v218, v217-v219
+	v224 := v220 * b.twiddles[7]
+	v225 := v213 * b.twiddles[10]
+	v226 := v221 * b.twiddles[14]
+	v227 := v214 * b.twiddles[1]
+	v228 := v222 * b.twiddles[19]
+	v229 := v215 * b.twiddles[21]
+	v230 := v223 * b.twiddles[22]
+	v231, v232 := x7+x39, x7-x39
+	v233, v234 := x15+x47, x15-x47
+	v235 := v234 * b.twiddles[0]
+	v236, v237 := x23+x55, x23-x55
+	v238 := rotate90(v237, b.direction)
+	v239, v240 := x31+x63, x31-x63
+	v241 := v240 * b.twiddles[1]
+	v242, v243 := v231+v236, v231-v236
+	v244, v245 := v233+v239, rotate90(v233-v239, b.direction)
+	v246, v247, v248, v249 := v242+v244, v243+v245, v242-v244, v243-v245
+	v250, v251 := v232+v238, v232-v238
+	v252, v253 := v235+v241, rotate90(v235-v241, b.direction)
+	v254, v255, v256, v257 := v250+v252, v251+v253, v250-v252, v251-v253
+	v258 := v254 * b.twiddles[8]
+	v259 := v247 * b.twiddles[11]
+	v260 := v255 * b.twiddles[15]
+	v261 := v248 * b.twiddles[17]
+	v262 := v256 * b.twiddles[20]
+	v263 := v249 * b.twiddles[22]
+	v264 := v257 * b.twiddles[23]
+
+	// 8 FFTs of size 8 over the rows
+	v265, v266 := v15+v144, v15-v144
+	v267, v268 := v42+v178, v42-v178
+	v269 := v268 * b.twiddles[0]
+	v270, v271 := v76+v212, v76-v212
+	v272 := rotate90(v271, b.direction)
+	v273, v274 := v110+v246, v110-v246
+	v275 := v274 * b.twiddles[1]
+	v276, v277 := v265+v270, v265-v270
+	v278, v279 := v267+v273, rotate90(v267-v273, b.direction)
+	v280, v281, v282, v283 := v276+v278, v277+v279, v276-v278, v277-v279
+	v284, v285 := v266+v272, v266-v272
+	v286, v287 := v269+v275, rotate90(v269-v275, b.direction)
+	v288, v289, v290, v291 := v284+v286, v285+v287, v284-v286, v285-v287
+	v292, v293 := v23+v156, v23-v156
+	v294, v295 := v54+v190, v54-v190
+	v296 := v295 * b.twiddles[0]
+	v297, v298 := v88+v224, v88-v224
+	v299 := rotate90(v298, b.direction)
+	v300, v301 := v122+v258, v122-v258
+	v302 := v301 * b.twiddles[1]
+	v303, v304 := v292+v297, v292-v297
+	v305, v306 := v294+v300, rotate90(v294-v300, b.direction)
+	v307, v308, v309, v310 := v303+v305, v304+v306, v303-v305, v304-v306
+	v311, v312 := v293+v299, v293-v299
+	v313, v314 := v296+v302, rotate90(v296-v302, b.direction)
+	v315, v316, v317, v318 := v311+v313, v312+v314, v311-v313, v312-v314
+	v319, v320 := v16+v157, v16-v157
+	v321, v322 := v55+v191, v55-v191
+	v323 := v322 * b.twiddles[0]
+	v324, v325 := v89+v225, v89-v225
+	v326 := rotate90(v325, b.direction)
+	v327, v328 := v123+v259, v123-v259
+	v329 := v328 * b.twiddles[1]
+	v330, v331 := v319+v324, v319-v324
+	v332, v333 := v321+v327, rotate90(v321-v327, b.direction)
+	v334, v335, v336, v337 := v330+v332, v331+v333, v330-v332, v331-v333
+	v338, v339 := v320+v326, v320-v326
+	v340, v341 := v323+v329, rotate90(v323-v329, b.direction)
+	v342, v343, v344, v345 := v338+v340, v339+v341, v338-v340, v339-v341
+	v346, v347 := v24+v158, v24-v158
+	v348, v349 := v56+v192, v56-v192
+	v350 := v349 * b.twiddles[0]
+	v351, v352 := v90+v226, v90-v226
+	v353 := rotate90(v352, b.direction)
+	v354, v355 := v124+v260, v124-v260
+	v356 := v355 * b.twiddles[1]
+	v357, v358 := v346+v351, v346-v351
+	v359, v360 := v348+v354, rotate90(v348-v354, b.direction)
+	v361, v362, v363, v364 := v357+v359, v358+v360, v357-v359, v358-v360
+	v365, v366 := v347+v353, v347-v353
+	v367, v368 := v350+v356, rotate90(v350-v356, b.direction)
+	v369, v370, v371, v372 := v365+v367, v366+v368, v365-v367, v366-v368
+	v373, v374 := v17+v159, v17-v159
+	v375, v376 := v57+v193, v57-v193
+	v377 := v376 * b.twiddles[0]
+	v378, v379 := v91+v227, v91-v227
+	v380 := rotate90(v379, b.direction)
+	v381, v382 := v125+v261, v125-v261
+	v383 := v382 * b.twiddles[1]
+	v384, v385 := v373+v378, v373-v378
+	v386, v387 := v375+v381, rotate90(v375-v381, b.direction)
+	v388, v389, v390, v391 := v384+v386, v385+v387, v384-v386, v385-v387
+	v392, v393 := v374+v380, v374-v380
+	v394, v395 := v377+v383, rotate90(v377-v383, b.direction)
+	v396, v397, v398, v399 := v392+v394, v393+v395, v392-v394, v393-v395
+	v400, v401 := v25+v160, v25-v160
+	v402, v403 := v58+v194, v58-v194
+	v404 := v403 * b.twiddles[0]
+	v405, v406 := v92+v228, v92-v228
+	v407 := rotate90(v406, b.direction)
+	v408, v409 := v126+v262, v126-v262
+	v410 := v409 * b.twiddles[1]
+	v411, v412 := v400+v405, v400-v405
+	v413, v414 := v402+v408, rotate90(v402-v408, b.direction)
+	v415, v416, v417, v418 := v411+v413, v412+v414, v411-v413, v412-v414
+	v419, v420 := v401+v407, v401-v407
+	v421, v422 := v404+v410, rotate90(v404-v410, b.direction)
+	v423, v424, v425, v426 := v419+v421, v420+v422, v419-v421, v420-v422
+	v427, v428 := v18+v161, v18-v161
+	v429, v430 := v59+v195, v59-v195
+	v431 := v430 * b.twiddles[0]
+	v432, v433 := v93+v229, v93-v229
+	v434 := rotate90(v433, b.direction)
+	v435, v436 := v127+v263, v127-v263
+	v437 := v436 * b.twiddles[1]
+	v438, v439 := v427+v432, v427-v432
+	v440, v441 := v429+v435, rotate90(v429-v435, b.direction)
+	v442, v443, v444, v445 := v438+v440, v439+v441, v438-v440, v439-v441
+	v446, v447 := v428+v434, v428-v434
+	v448, v449 := v431+v437, rotate90(v431-v437, b.direction)
+	v450, v451, v452, v453 := v446+v448, v447+v449, v446-v448, v447-v449
+	v454, v455 := v26+v162, v26-v162
+	v456, v457 := v60+v196, v60-v196
+	v458 := v457 * b.twiddles[0]
+	v459, v460 := v94+v230, v94-v230
+	v461 := rotate90(v460, b.direction)
+	v462, v463 := v128+v264, v128-v264
+	v464 := v463 * b.twiddles[1]
+	v465, v466 := v454+v459, v454-v459
+	v467, v468 := v456+v462, rotate90(v456-v462, b.direction)
+	v469, v470, v471, v472 := v465+v467, v466+v468, v465-v467, v466-v468
+	v473, v474 := v455+v461, v455-v461
+	v475, v476 := v458+v464, rotate90(v458-v464, b.direction)
+	v477, v478, v479, v480 := v473+v475, v474+v476, v473-v475, v474-v476
+
+	buffer[0] = v280
+	buffer[1] = v307
+	buffer[2] = v334
+	buffer[3] = v361
+	buffer[4] = v388
+	buffer[5] = v415
+	buffer[6] = v442
+	buffer[7] = v469
+	buffer[8] = v288
+	buffer[9] = v315
+	buffer[10] = v342
+	buffer[11] = v369
+	buffer[12] = v396
+	buffer[13] = v423
+	buffer[14] = v450
+	buffer[15] = v477
+	buffer[16] = v281
+	buffer[17] = v308
+	buffer[18] = v335
+	buffer[19] = v362
+	buffer[20] = v389
+	buffer[21] = v416
+	buffer[22] = v443
+	buffer[23] = v470
+	buffer[24] = v289
+	buffer[25] = v316
+	buffer[26] = v343
+	buffer[27] = v370
+	buffer[28] = v397
+	buffer[29] = v424
+	buffer[30] = v451
+	buffer[31] = v478
+	buffer[32] = v282
+	buffer[33] = v309
+	buffer[34] = v336
+	buffer[35] = v363
+	buffer[36] = v390
+	buffer[37] = v417
+	buffer[38] = v444
+	buffer[39] = v471
+	buffer[40] = v290
+	buffer[41] = v317
+	buffer[42] = v344
+	buffer[43] = v371
+	buffer[44] = v398
+	buffer[45] = v425
+	buffer[46] = v452
+	buffer[47] = v479
+	buffer[48] = v283
+	buffer[49] = v310
+	buffer[50] = v337
+	buffer[51] = v364
+	buffer[52] = v391
+	buffer[53] = v418
+	buffer[54] = v445
+	buffer[55] = v472
+	buffer[56] = v291
+	buffer[57] = v318
+	buffer[58] = v345
+	buffer[59] = v372
+	buffer[60] = v399
+	buffer[61] = v426
+	buffer[62] = v453
+	buffer[63] = v480
+}
+
+func (b *Butterfly64) performFftOutOfPlace(input, output []complex128) {
+	copy(output, input)
+	b.performFft(output)
+}
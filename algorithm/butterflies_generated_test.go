@@ -0,0 +1,109 @@
+package algorithm
+
+import (
+	"fmt"
+	"math/cmplx"
+	"testing"
+)
+
+// generatedButterflies lists the sizes in generate.go, each with the general
+// algorithm the planner would otherwise use for it
+var generatedButterflies = []struct {
+	n       int
+	new     func(Direction) FftInterface
+	new32   func(Direction) FftInterface32
+	general func(Direction) FftInterface
+}{
+	{10, func(d Direction) FftInterface { return NewButterfly10(d) },
+		func(d Direction) FftInterface32 { return NewButterfly10_32(d) },
+		func(d Direction) FftInterface { return NewRadixN([]RadixFactor{Factor2, Factor5}, NewDft(1, d)) }},
+	{14, func(d Direction) FftInterface { return NewButterfly14(d) },
+		func(d Direction) FftInterface32 { return NewButterfly14_32(d) },
+		func(d Direction) FftInterface { return NewRadixN([]RadixFactor{Factor2, Factor7}, NewDft(1, d)) }},
+	{15, func(d Direction) FftInterface { return NewButterfly15(d) },
+		func(d Direction) FftInterface32 { return NewButterfly15_32(d) },
+		func(d Direction) FftInterface { return NewRadixN([]RadixFactor{Factor3, Factor5}, NewDft(1, d)) }},
+	{20, func(d Direction) FftInterface { return NewButterfly20(d) },
+		func(d Direction) FftInterface32 { return NewButterfly20_32(d) },
+		func(d Direction) FftInterface { return NewRadixN([]RadixFactor{Factor4, Factor5}, NewDft(1, d)) }},
+	{24, func(d Direction) FftInterface { return NewButterfly24(d) },
+		func(d Direction) FftInterface32 { return NewButterfly24_32(d) },
+		func(d Direction) FftInterface { return NewRadixN([]RadixFactor{Factor4, Factor6}, NewDft(1, d)) }},
+	{25, func(d Direction) FftInterface { return NewButterfly25(d) },
+		func(d Direction) FftInterface32 { return NewButterfly25_32(d) },
+		func(d Direction) FftInterface { return NewRadixN([]RadixFactor{Factor5, Factor5}, NewDft(1, d)) }},
+	{27, func(d Direction) FftInterface { return NewButterfly27(d) },
+		func(d Direction) FftInterface32 { return NewButterfly27_32(d) },
+		func(d Direction) FftInterface {
+			return NewRadixN([]RadixFactor{Factor3, Factor3, Factor3}, NewDft(1, d))
+		}},
+	{36, func(d Direction) FftInterface { return NewButterfly36(d) },
+		func(d Direction) FftInterface32 { return NewButterfly36_32(d) },
+		func(d Direction) FftInterface { return NewRadixN([]RadixFactor{Factor6, Factor6}, NewDft(1, d)) }},
+	{64, func(d Direction) FftInterface { return NewButterfly64(d) },
+		func(d Direction) FftInterface32 { return NewButterfly64_32(d) },
+		func(d Direction) FftInterface { return NewRadix4(64, d) }},
+}
+
+// TestGeneratedButterfliesMatchDft checks the generated butterflies against Dft
+// in both directions, in place and out of place, on several chunks
+func TestGeneratedButterfliesMatchDft(t *testing.T) {
+	for _, g := range generatedButterflies {
+		for _, dir := range []Direction{Forward, Inverse} {
+			t.Run(fmt.Sprintf("Size%d/Dir%d", g.n, dir), func(t *testing.T) {
+				bf := g.new(dir)
+				if bf.Len() != g.n || bf.Direction() != dir {
+					t.Fatalf("got Len %d and Direction %d", bf.Len(), bf.Direction())
+				}
+
+				input := make([]complex128, 3*g.n)
+				for i := range input {
+					input[i] = complex(float64(i%7)-2.5, float64(i%4)*0.75)
+				}
+
+				expected := make([]complex128, len(input))
+				NewDft(g.n, dir).ProcessImmutable(input, expected, nil)
+
+				inplace := append([]complex128(nil), input...)
+				bf.ProcessWithScratch(inplace, nil)
+				outOfPlace := make([]complex128, len(input))
+				bf.ProcessOutOfPlace(append([]complex128(nil), input...), outOfPlace, nil)
+
+				for i := range expected {
+					if cmplx.Abs(inplace[i]-expected[i]) > 1e-12 || cmplx.Abs(outOfPlace[i]-expected[i]) > 1e-12 {
+						t.Fatalf("[%d] got %v in place and %v out of place, want %v", i, inplace[i], outOfPlace[i], expected[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGeneratedButterflies32(t *testing.T) {
+	for _, g := range generatedButterflies {
+		for _, dir := range []Direction{Forward, Inverse} {
+			t.Run(fmt.Sprintf("Size%d/Dir%d", g.n, dir), func(t *testing.T) {
+				if err := compareWith64(g.new(dir), g.new32(dir)); err > 1e-5 {
+					t.Errorf("max error %g", err)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkGeneratedButterflies compares the generated butterflies with the
+// general algorithms for the same sizes
+func BenchmarkGeneratedButterflies(b *testing.B) {
+	for _, g := range generatedButterflies {
+		for _, fft := range []FftInterface{g.new(Forward), g.general(Forward)} {
+			b.Run(fmt.Sprintf("%T/Size%d", fft, g.n), func(b *testing.B) {
+				buffer := make([]complex128, 64*g.n)
+				scratch := make([]complex128, fft.InplaceScratchLen())
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					fft.ProcessWithScratch(buffer, scratch)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package algorithm
+
+import "fmt"
+
+// checkInplace panics unless buffer holds a whole number of length-sized
+// FFTs and scratch holds at least expectedScratch elements
+// It mirrors the gofft package's checks, so a kernel called directly fails
+// with a description of the problem instead of an index out of range.
+func checkInplace(bufferLen, length, scratchLen, expectedScratch int) {
+	if bufferLen < length {
+		panic(fmt.Sprintf("provided FFT buffer was too small: expected len = %d, got len = %d", length, bufferLen))
+	}
+	if (length == 0 && bufferLen != 0) || (length != 0 && bufferLen%length != 0) {
+		panic(fmt.Sprintf("FFT buffer length must be a multiple of FFT length: expected multiple of %d, got len = %d", length, bufferLen))
+	}
+	if scratchLen < expectedScratch {
+		panic(fmt.Sprintf("not enough scratch space was provided: expected scratch len >= %d, got scratch len = %d", expectedScratch, scratchLen))
+	}
+}
+
+// checkOutOfPlace is checkInplace for an input and output of the same length
+func checkOutOfPlace(inputLen, outputLen, length, scratchLen, expectedScratch int) {
+	if inputLen != outputLen {
+		panic(fmt.Sprintf("FFT input and output buffer lengths don't match: got input len = %d, output len = %d", inputLen, outputLen))
+	}
+	checkInplace(inputLen, length, scratchLen, expectedScratch)
+}
+
+// checkFixedLen panics unless input and output have exactly the expected
+// lengths and scratch holds at least expectedScratch elements
+func checkFixedLen(inputLen, expectedInputLen, outputLen, expectedOutputLen, scratchLen, expectedScratch int) {
+	if inputLen != expectedInputLen {
+		panic(fmt.Sprintf("FFT input and output buffer lengths don't match: expected input len = %d, got input len = %d", expectedInputLen, inputLen))
+	}
+	if outputLen != expectedOutputLen {
+		panic(fmt.Sprintf("FFT input and output buffer lengths don't match: expected output len = %d, got output len = %d", expectedOutputLen, outputLen))
+	}
+	if scratchLen < expectedScratch {
+		panic(fmt.Sprintf("not enough scratch space was provided: expected scratch len >= %d, got scratch len = %d", expectedScratch, scratchLen))
+	}
+}
@@ -0,0 +1,81 @@
+package algorithm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// expectPanic fails t unless fn panics with a message containing want
+func expectPanic(t *testing.T, want string, fn func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected panic containing %q, got %q", want, msg)
+		}
+	}()
+	fn()
+}
+
+// TestMalformedBuffersPanic checks that kernels called directly reject short
+// buffers and scratch with a description rather than an index out of range
+func TestMalformedBuffersPanic(t *testing.T) {
+	testCases := []struct {
+		name string
+		fft  FftInterface
+	}{
+		{"Dft/10", NewDft(10, Forward)},
+		{"Butterfly4", NewButterfly4(Forward)},
+		{"Butterfly11", NewButterfly11(Forward)},
+		{"Butterfly36", NewButterfly36(Forward)},
+		{"Radix4/64", NewRadix4(64, Forward)},
+		{"Radix8/512", NewRadix8(512, Forward)},
+		{"Stockham/1024", NewStockham(1024, Forward)},
+		{"RadixN/60", NewRadixN([]RadixFactor{Factor3, Factor4, Factor5}, NewDft(1, Forward))},
+		{"Raders/13", NewRaders(NewButterfly12(Forward))},
+		{"Bluestein/37", NewBluestein(37, Forward)},
+		{"MixedRadix/Radix4", NewMixedRadix(NewRadix4(16, Forward), NewButterfly3(Forward))},
+		{"GoodThomas/Butterflies", NewGoodThomas(NewButterfly5(Forward), NewButterfly7(Forward))},
+		{"FourStep/Radix4", NewFourStep(NewRadix4(64, Forward), NewRadix4(32, Forward))},
+		{"Scaled/Bluestein", NewScaled(NewBluestein(37, Forward), 0.5)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := tc.fft.Len()
+			buffer := make([]complex128, n)
+
+			expectPanic(t, "buffer was too small", func() {
+				tc.fft.ProcessWithScratch(buffer[:n-1], make([]complex128, tc.fft.InplaceScratchLen()))
+			})
+			expectPanic(t, "must be a multiple of FFT length", func() {
+				tc.fft.ProcessOutOfPlace(make([]complex128, n+1), make([]complex128, n+1), make([]complex128, tc.fft.OutOfPlaceScratchLen()))
+			})
+			expectPanic(t, "lengths don't match", func() {
+				tc.fft.ProcessImmutable(buffer, make([]complex128, 2*n), make([]complex128, tc.fft.ImmutableScratchLen()))
+			})
+			if scratchLen := tc.fft.InplaceScratchLen(); scratchLen > 0 {
+				expectPanic(t, "not enough scratch space", func() {
+					tc.fft.ProcessWithScratch(buffer, make([]complex128, scratchLen-1))
+				})
+			}
+		})
+	}
+}
+
+func TestChirpZMalformedBuffersPanic(t *testing.T) {
+	c := NewChirpZ(10, 12, twiddleFactor(1, 12, Forward), 1)
+	scratch := make([]complex128, c.ScratchLen())
+
+	expectPanic(t, "expected input len = 10", func() {
+		c.Process(make([]complex128, 9), make([]complex128, 12), scratch)
+	})
+	expectPanic(t, "expected output len = 12", func() {
+		c.Process(make([]complex128, 10), make([]complex128, 10), scratch)
+	})
+	expectPanic(t, "not enough scratch space", func() {
+		c.Process(make([]complex128, 10), make([]complex128, 12), scratch[:len(scratch)-1])
+	})
+}
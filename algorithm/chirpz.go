@@ -0,0 +1,149 @@
+package algorithm
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// ChirpZ implements the chirp-z transform, the z-transform of inputLen samples
+// evaluated at outputLen points along the spiral A·W^-k:
+//
+//	X[k] = Σ x[n] A^-n W^(nk)
+//
+// Bluestein's identity nk = (n² + k² - (k-n)²)/2 turns the sum into a
+// convolution with the chirp W^(-j²/2), computed with power-of-two FFTs:
+// 1. Multiply the input by A^-n W^(n²/2) and zero-pad it
+// 2. Convolve with the chirp via FFT, using its precomputed spectrum
+// 3. Multiply the first outputLen results by W^(k²/2)
+//
+// The DFT is the special case A = 1, W = e^(-2πi/N), which Bluestein uses.
+type ChirpZ struct {
+	inputLen      int
+	outputLen     int
+	fftSize       int          // Power-of-two size >= inputLen+outputLen-1
+	fft           *Radix4      // Power-of-two FFT
+	invFft        *Radix4      // Inverse FFT
+	inputChirp    []complex128 // A^-n W^(n²/2)
+	outputChirp   []complex128 // W^(k²/2)
+	chirpSpectrum []complex128 // FFT of the wrapped chirp W^(-j²/2)
+	scratchLen    int          // Padded work buffer plus inner FFT scratch
+	outputScale   complex128   // Inverse FFT normalization times any WithScale factor
+}
+
+// NewChirpZ creates a chirp-z transform of inputLen samples at the outputLen points A·W^-k
+func NewChirpZ(inputLen, outputLen int, w, a complex128) *ChirpZ {
+	wAbs, wArg := cmplx.Abs(w), cmplx.Phase(w)
+	c := newChirpZ(inputLen, outputLen, func(k int) complex128 {
+		return spiralPower(wAbs, wArg, float64(k)*float64(k)/2)
+	})
+
+	aAbs, aArg := cmplx.Abs(a), cmplx.Phase(a)
+	for n := range c.inputChirp {
+		c.inputChirp[n] *= spiralPower(aAbs, aArg, -float64(n))
+	}
+	return c
+}
+
+// spiralPower returns (r·e^(iθ))^e on the principal branch
+func spiralPower(r, theta, e float64) complex128 {
+	magnitude := math.Pow(r, e)
+	return complex(magnitude*math.Cos(theta*e), magnitude*math.Sin(theta*e))
+}
+
+// newChirpZ creates a chirp-z transform with A = 1 whose chirp(k) returns W^(k²/2)
+func newChirpZ(inputLen, outputLen int, chirp func(k int) complex128) *ChirpZ {
+	// Find next power of two >= inputLen+outputLen-1
+	minSize := inputLen + outputLen - 1
+	fftSize := 1
+	for fftSize < minSize {
+		fftSize *= 2
+	}
+
+	// Create power-of-two FFTs
+	fft := NewRadix4(fftSize, Forward)
+	invFft := NewRadix4(fftSize, Inverse)
+
+	inputChirp := make([]complex128, inputLen)
+	outputChirp := make([]complex128, outputLen)
+	for k := range inputChirp {
+		inputChirp[k] = chirp(k)
+	}
+	for k := range outputChirp {
+		if k < inputLen {
+			outputChirp[k] = inputChirp[k]
+		} else {
+			outputChirp[k] = chirp(k)
+		}
+	}
+
+	// The convolution needs W^(-j²/2) for j in (-inputLen, outputLen); the
+	// negative lags wrap around to the end of the buffer
+	chirpSpectrum := make([]complex128, fftSize)
+	for j := 0; j < outputLen; j++ {
+		chirpSpectrum[j] = 1 / outputChirp[j]
+	}
+	for j := 1; j < inputLen; j++ {
+		chirpSpectrum[fftSize-j] = 1 / inputChirp[j]
+	}
+	scratch := make([]complex128, fft.InplaceScratchLen())
+	fft.ProcessWithScratch(chirpSpectrum, scratch)
+
+	// The work buffer is followed by scratch for the inner FFTs, so that
+	// processing never allocates
+	innerScratch := fft.InplaceScratchLen()
+	if invFft.InplaceScratchLen() > innerScratch {
+		innerScratch = invFft.InplaceScratchLen()
+	}
+
+	return &ChirpZ{
+		inputLen:      inputLen,
+		outputLen:     outputLen,
+		fftSize:       fftSize,
+		fft:           fft,
+		invFft:        invFft,
+		inputChirp:    inputChirp,
+		outputChirp:   outputChirp,
+		chirpSpectrum: chirpSpectrum,
+		scratchLen:    fftSize + innerScratch,
+		outputScale:   complex(1/float64(fftSize), 0),
+	}
+}
+
+// WithScale returns a copy of c whose output is multiplied by scale
+// The factor joins the inverse FFT normalization in the final chirp multiply.
+func (c *ChirpZ) WithScale(scale float64) *ChirpZ {
+	scaled := *c
+	scaled.outputScale = c.outputScale * complex(scale, 0)
+	return &scaled
+}
+
+func (c *ChirpZ) Len() int        { return c.inputLen }
+func (c *ChirpZ) OutputLen() int  { return c.outputLen }
+func (c *ChirpZ) ScratchLen() int { return c.scratchLen }
+
+// Process computes the transform of Len() input samples into OutputLen() outputs
+// The convolution runs in the padded scratch buffer and reads the input
+// exactly once, so input and output may be the same slice when the lengths match.
+func (c *ChirpZ) Process(input, output, scratch []complex128) {
+	checkFixedLen(len(input), c.inputLen, len(output), c.outputLen, len(scratch), c.scratchLen)
+
+	x := scratch[:c.fftSize] // Input padded to fftSize
+	innerScratch := scratch[c.fftSize:c.scratchLen]
+
+	// Step 1: Multiply input by chirp and pad
+	pointwiseMultiply(x[:c.inputLen], input, c.inputChirp)
+	for k := c.inputLen; k < c.fftSize; k++ {
+		x[k] = 0
+	}
+
+	// Step 2: Convolve with the chirp in the frequency domain
+	c.fft.ProcessWithScratch(x, innerScratch)
+	pointwiseMultiply(x, x, c.chirpSpectrum)
+	c.invFft.ProcessWithScratch(x, innerScratch)
+
+	// Step 3: Normalize (inverse FFT doesn't auto-normalize), multiply by
+	// chirp and extract result. Only the first outputLen outputs are needed.
+	for k := 0; k < c.outputLen; k++ {
+		output[k] = x[k] * c.outputScale * c.outputChirp[k]
+	}
+}
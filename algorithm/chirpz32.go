@@ -0,0 +1,139 @@
+package algorithm
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// ChirpZ32 implements the chirp-z transform for complex64
+// See ChirpZ for a description of the algorithm
+type ChirpZ32 struct {
+	inputLen      int
+	outputLen     int
+	fftSize       int         // Power-of-two size >= inputLen+outputLen-1
+	fft           *Radix4_32  // Power-of-two FFT
+	invFft        *Radix4_32  // Inverse FFT
+	inputChirp    []complex64 // A^-n W^(n²/2)
+	outputChirp   []complex64 // W^(k²/2)
+	chirpSpectrum []complex64 // FFT of the wrapped chirp W^(-j²/2)
+	scratchLen    int         // Padded work buffer plus inner FFT scratch
+	outputScale   complex64   // Inverse FFT normalization times any WithScale factor
+}
+
+// NewChirpZ32 creates a chirp-z transform of inputLen samples at the outputLen points A·W^-k
+func NewChirpZ32(inputLen, outputLen int, w, a complex64) *ChirpZ32 {
+	wAbs, wArg := cmplx.Abs(complex128(w)), cmplx.Phase(complex128(w))
+	c := newChirpZ32(inputLen, outputLen, func(k int) complex64 {
+		return spiralPower32(wAbs, wArg, float64(k)*float64(k)/2)
+	})
+
+	aAbs, aArg := cmplx.Abs(complex128(a)), cmplx.Phase(complex128(a))
+	for n := range c.inputChirp {
+		c.inputChirp[n] *= spiralPower32(aAbs, aArg, -float64(n))
+	}
+	return c
+}
+
+// spiralPower32 returns (r·e^(iθ))^e on the principal branch
+func spiralPower32(r, theta, e float64) complex64 {
+	magnitude := math.Pow(r, e)
+	return complex(float32(magnitude*math.Cos(theta*e)), float32(magnitude*math.Sin(theta*e)))
+}
+
+// newChirpZ32 creates a chirp-z transform with A = 1 whose chirp(k) returns W^(k²/2)
+func newChirpZ32(inputLen, outputLen int, chirp func(k int) complex64) *ChirpZ32 {
+	// Find next power of two >= inputLen+outputLen-1
+	minSize := inputLen + outputLen - 1
+	fftSize := 1
+	for fftSize < minSize {
+		fftSize *= 2
+	}
+
+	// Create power-of-two FFTs
+	fft := NewRadix4_32(fftSize, Forward)
+	invFft := NewRadix4_32(fftSize, Inverse)
+
+	inputChirp := make([]complex64, inputLen)
+	outputChirp := make([]complex64, outputLen)
+	for k := range inputChirp {
+		inputChirp[k] = chirp(k)
+	}
+	for k := range outputChirp {
+		if k < inputLen {
+			outputChirp[k] = inputChirp[k]
+		} else {
+			outputChirp[k] = chirp(k)
+		}
+	}
+
+	// The convolution needs W^(-j²/2) for j in (-inputLen, outputLen); the
+	// negative lags wrap around to the end of the buffer
+	chirpSpectrum := make([]complex64, fftSize)
+	for j := 0; j < outputLen; j++ {
+		chirpSpectrum[j] = 1 / outputChirp[j]
+	}
+	for j := 1; j < inputLen; j++ {
+		chirpSpectrum[fftSize-j] = 1 / inputChirp[j]
+	}
+	scratch := make([]complex64, fft.InplaceScratchLen())
+	fft.ProcessWithScratch(chirpSpectrum, scratch)
+
+	// The work buffer is followed by scratch for the inner FFTs, so that
+	// processing never allocates
+	innerScratch := fft.InplaceScratchLen()
+	if invFft.InplaceScratchLen() > innerScratch {
+		innerScratch = invFft.InplaceScratchLen()
+	}
+
+	return &ChirpZ32{
+		inputLen:      inputLen,
+		outputLen:     outputLen,
+		fftSize:       fftSize,
+		fft:           fft,
+		invFft:        invFft,
+		inputChirp:    inputChirp,
+		outputChirp:   outputChirp,
+		chirpSpectrum: chirpSpectrum,
+		scratchLen:    fftSize + innerScratch,
+		outputScale:   complex(float32(1/float64(fftSize)), 0),
+	}
+}
+
+// WithScale returns a copy of c whose output is multiplied by scale
+// The factor joins the inverse FFT normalization in the final chirp multiply.
+func (c *ChirpZ32) WithScale(scale float64) *ChirpZ32 {
+	scaled := *c
+	scaled.outputScale = c.outputScale * complex(float32(scale), 0)
+	return &scaled
+}
+
+func (c *ChirpZ32) Len() int        { return c.inputLen }
+func (c *ChirpZ32) OutputLen() int  { return c.outputLen }
+func (c *ChirpZ32) ScratchLen() int { return c.scratchLen }
+
+// Process computes the transform of Len() input samples into OutputLen() outputs
+// The convolution runs in the padded scratch buffer and reads the input
+// exactly once, so input and output may be the same slice when the lengths match.
+func (c *ChirpZ32) Process(input, output, scratch []complex64) {
+	checkFixedLen(len(input), c.inputLen, len(output), c.outputLen, len(scratch), c.scratchLen)
+
+	x := scratch[:c.fftSize] // Input padded to fftSize
+	innerScratch := scratch[c.fftSize:c.scratchLen]
+
+	// Step 1: Multiply input by chirp and pad
+	pointwiseMultiply32(x[:c.inputLen], input, c.inputChirp)
+	for k := c.inputLen; k < c.fftSize; k++ {
+		x[k] = 0
+	}
+
+	// Step 2: Convolve with the chirp in the frequency domain
+	c.fft.ProcessWithScratch(x, innerScratch)
+	pointwiseMultiply32(x, x, c.chirpSpectrum)
+	c.invFft.ProcessWithScratch(x, innerScratch)
+
+	// Step 3: Normalize (inverse FFT doesn't auto-normalize), multiply by
+	// chirp and extract result. Only the first outputLen outputs are needed.
+	for k := 0; k < c.outputLen; k++ {
+		output[k] = x[k] * c.outputScale * c.outputChirp[k]
+	}
+}
@@ -0,0 +1,55 @@
+package algorithm
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestChirpZMatchesBluestein checks that the chirp-z transform along the unit
+// circle is the DFT Bluestein computes
+func TestChirpZMatchesBluestein(t *testing.T) {
+	for _, n := range []int{5, 31, 100} {
+		input := make([]complex128, n)
+		for i := range input {
+			input[i] = complex(math.Sin(float64(i)*0.3), math.Cos(float64(i)*0.8))
+		}
+
+		czt := NewChirpZ(n, n, cmplx.Rect(1, -2*math.Pi/float64(n)), 1)
+		got := make([]complex128, n)
+		czt.Process(input, got, make([]complex128, czt.ScratchLen()))
+
+		bluestein := NewBluestein(n, Forward)
+		want := make([]complex128, n)
+		bluestein.ProcessImmutable(input, want, make([]complex128, bluestein.ImmutableScratchLen()))
+
+		for i := range got {
+			if cmplx.Abs(got[i]-want[i]) > 1e-9 {
+				t.Fatalf("size %d: [%d] got %v, want %v", n, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestChirpZ32(t *testing.T) {
+	w, a := cmplx.Rect(0.999, -0.02), cmplx.Rect(1.01, 0.4)
+	czt64 := NewChirpZ(40, 25, w, a)
+	czt32 := NewChirpZ32(40, 25, complex64(w), complex64(a))
+
+	input64 := make([]complex128, 40)
+	input32 := make([]complex64, 40)
+	for i := range input64 {
+		input64[i] = complex(math.Cos(float64(i)*0.9), 0)
+		input32[i] = complex64(input64[i])
+	}
+	output64 := make([]complex128, 25)
+	output32 := make([]complex64, 25)
+	czt64.Process(input64, output64, make([]complex128, czt64.ScratchLen()))
+	czt32.Process(input32, output32, make([]complex64, czt32.ScratchLen()))
+
+	for i := range output64 {
+		if err := cmplx.Abs(output64[i] - complex128(output32[i])); err > 1e-3*(1+cmplx.Abs(output64[i])) {
+			t.Errorf("[%d] complex64 result %v differs from %v", i, output32[i], output64[i])
+		}
+	}
+}
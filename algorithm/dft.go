@@ -31,6 +31,17 @@ func NewDft(length int, direction Direction) *Dft {
 	}
 }
 
+// WithScale returns a copy of d whose output is multiplied by scale
+// The factor is folded into the twiddles, so it costs nothing at run time.
+func (d *Dft) WithScale(scale float64) *Dft {
+	s := complex(scale, 0)
+	twiddles := make([]complex128, len(d.twiddles))
+	for i, tw := range d.twiddles {
+		twiddles[i] = tw * s
+	}
+	return &Dft{twiddles: twiddles, direction: d.direction}
+}
+
 // Len returns the FFT size
 func (d *Dft) Len() int {
 	return len(d.twiddles)
@@ -77,6 +88,8 @@ func (d *Dft) Process(buffer []complex128) {
 
 // ProcessWithScratch computes the FFT in-place using provided scratch space
 func (d *Dft) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), d.Len(), len(scratch), d.InplaceScratchLen())
+
 	// For in-place operation, we use scratch as temporary output space
 	for i := 0; i < len(buffer); i += d.Len() {
 		chunk := buffer[i : i+d.Len()]
@@ -88,6 +101,8 @@ func (d *Dft) ProcessWithScratch(buffer, scratch []complex128) {
 
 // ProcessOutOfPlace computes the FFT from input to output
 func (d *Dft) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), d.Len(), len(scratch), d.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += d.Len() {
 		inChunk := input[i : i+d.Len()]
 		outChunk := output[i : i+d.Len()]
@@ -97,6 +112,8 @@ func (d *Dft) ProcessOutOfPlace(input, output, scratch []complex128) {
 
 // ProcessImmutable computes the FFT without modifying the input
 func (d *Dft) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), d.Len(), len(scratch), d.ImmutableScratchLen())
+
 	for i := 0; i < len(input); i += d.Len() {
 		inChunk := input[i : i+d.Len()]
 		outChunk := output[i : i+d.Len()]
@@ -159,6 +176,16 @@ func NewDft32(length int, direction Direction) *Dft32 {
 	}
 }
 
+// WithScale returns a copy of d whose output is multiplied by scale
+func (d *Dft32) WithScale(scale float64) *Dft32 {
+	s := complex(float32(scale), 0)
+	twiddles := make([]complex64, len(d.twiddles))
+	for i, tw := range d.twiddles {
+		twiddles[i] = tw * s
+	}
+	return &Dft32{twiddles: twiddles, direction: d.direction}
+}
+
 // Len returns the FFT size
 func (d *Dft32) Len() int {
 	return len(d.twiddles)
@@ -192,6 +219,8 @@ func (d *Dft32) Process(buffer []complex64) {
 
 // ProcessWithScratch computes the FFT in-place using provided scratch space
 func (d *Dft32) ProcessWithScratch(buffer, scratch []complex64) {
+	checkInplace(len(buffer), d.Len(), len(scratch), d.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += d.Len() {
 		chunk := buffer[i : i+d.Len()]
 		selfScratch := scratch[:d.Len()]
@@ -202,6 +231,8 @@ func (d *Dft32) ProcessWithScratch(buffer, scratch []complex64) {
 
 // ProcessOutOfPlace computes the FFT from input to output
 func (d *Dft32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), d.Len(), len(scratch), d.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += d.Len() {
 		inChunk := input[i : i+d.Len()]
 		outChunk := output[i : i+d.Len()]
@@ -211,6 +242,8 @@ func (d *Dft32) ProcessOutOfPlace(input, output, scratch []complex64) {
 
 // ProcessImmutable computes the FFT without modifying the input
 func (d *Dft32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), d.Len(), len(scratch), d.ImmutableScratchLen())
+
 	for i := 0; i < len(input); i += d.Len() {
 		inChunk := input[i : i+d.Len()]
 		outChunk := output[i : i+d.Len()]
@@ -0,0 +1,109 @@
+package algorithm
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// compareWith64 runs a complex128 FFT and its complex64 port on the same input
+// and returns the maximum absolute difference between the two outputs
+func compareWith64(fft64 FftInterface, fft32 FftInterface32) float64 {
+	n := fft64.Len()
+	buffer64 := make([]complex128, n)
+	buffer32 := make([]complex64, n)
+	for i := range buffer64 {
+		buffer64[i] = complex(math.Sin(float64(i)*0.37), math.Cos(float64(i)*0.11))
+		buffer32[i] = complex64(buffer64[i])
+	}
+
+	fft64.ProcessWithScratch(buffer64, make([]complex128, fft64.InplaceScratchLen()))
+	fft32.ProcessWithScratch(buffer32, make([]complex64, fft32.InplaceScratchLen()))
+
+	maxErr := 0.0
+	for i := range buffer64 {
+		if err := cmplx.Abs(buffer64[i] - complex128(buffer32[i])); err > maxErr {
+			maxErr = err
+		}
+	}
+	return maxErr
+}
+
+// TestFloat32Ports checks each complex64 algorithm against its complex128 original
+func TestFloat32Ports(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		testCases := []struct {
+			name  string
+			fft64 FftInterface
+			fft32 FftInterface32
+		}{
+			{"Butterfly2", NewButterfly2(dir), NewButterfly2_32(dir)},
+			{"Butterfly3", NewButterfly3(dir), NewButterfly3_32(dir)},
+			{"Butterfly4", NewButterfly4(dir), NewButterfly4_32(dir)},
+			{"Butterfly5", NewButterfly5(dir), NewButterfly5_32(dir)},
+			{"Butterfly6", NewButterfly6(dir), NewButterfly6_32(dir)},
+			{"Butterfly7", NewButterfly7(dir), NewButterfly7_32(dir)},
+			{"Butterfly8", NewButterfly8(dir), NewButterfly8_32(dir)},
+			{"Butterfly9", NewButterfly9(dir), NewButterfly9_32(dir)},
+			{"Butterfly11", NewButterfly11(dir), NewButterfly11_32(dir)},
+			{"Butterfly12", NewButterfly12(dir), NewButterfly12_32(dir)},
+			{"Butterfly13", NewButterfly13(dir), NewButterfly13_32(dir)},
+			{"Butterfly16", NewButterfly16(dir), NewButterfly16_32(dir)},
+			{"Butterfly17", NewButterfly17(dir), NewButterfly17_32(dir)},
+			{"Butterfly19", NewButterfly19(dir), NewButterfly19_32(dir)},
+			{"Butterfly23", NewButterfly23(dir), NewButterfly23_32(dir)},
+			{"Butterfly24", NewButterfly24(dir), NewButterfly24_32(dir)},
+			{"Butterfly27", NewButterfly27(dir), NewButterfly27_32(dir)},
+			{"Butterfly29", NewButterfly29(dir), NewButterfly29_32(dir)},
+			{"Butterfly31", NewButterfly31(dir), NewButterfly31_32(dir)},
+			{"Butterfly32", NewButterfly32(dir), NewButterfly32_32(dir)},
+			{"Radix4/64", NewRadix4(64, dir), NewRadix4_32(64, dir)},
+			{"Radix4/2048", NewRadix4(2048, dir), NewRadix4_32(2048, dir)},
+			{"Radix8/4096", NewRadix8(4096, dir), NewRadix8_32(4096, dir)},
+			{"Stockham/2048", NewStockham(2048, dir), NewStockham32(2048, dir)},
+			{"FourStep/4096", NewFourStep(NewRadix4(64, dir), NewStockham(64, dir)),
+				NewFourStep32(NewRadix4_32(64, dir), NewStockham32(64, dir))},
+			{"RadixN/60", NewRadixN([]RadixFactor{Factor5, Factor3, Factor4}, NewDft(1, dir)),
+				NewRadixN32([]RadixFactor{Factor5, Factor3, Factor4}, NewDft32(1, dir))},
+			{"Raders/37", NewRaders(NewDft(36, dir)), NewRaders32(NewDft32(36, dir))},
+			{"Raders/97", NewRaders(NewRadixN([]RadixFactor{Factor3, Factor2, Factor4, Factor4}, NewDft(1, dir))),
+				NewRaders32(NewRadixN32([]RadixFactor{Factor3, Factor2, Factor4, Factor4}, NewDft32(1, dir)))},
+			{"Bluestein/101", NewBluestein(101, dir), NewBluestein32(101, dir)},
+			{"MixedRadix/35", NewMixedRadix(NewButterfly5(dir), NewButterfly7(dir)),
+				NewMixedRadix32(NewButterfly5_32(dir), NewButterfly7_32(dir))},
+			{"GoodThomas/143", NewGoodThomas(NewButterfly11(dir), NewButterfly13(dir)),
+				NewGoodThomas32(NewButterfly11_32(dir), NewButterfly13_32(dir))},
+			{"Radix4/Parallel", NewRadix4(4096, dir).WithWorkers(4), NewRadix4_32(4096, dir).WithWorkers(4)},
+			{"MixedRadix/Parallel", NewMixedRadix(NewRadix4(64, dir), NewButterfly9(dir)).WithWorkers(4),
+				NewMixedRadix32(NewRadix4_32(64, dir), NewButterfly9_32(dir)).WithWorkers(4)},
+			{"GoodThomas/Parallel", NewGoodThomas(NewRadix4(64, dir), NewButterfly9(dir)).WithWorkers(4),
+				NewGoodThomas32(NewRadix4_32(64, dir), NewButterfly9_32(dir)).WithWorkers(4)},
+			{"Radix4/Scaled", NewRadix4(256, dir).WithScale(0.5), NewRadix4_32(256, dir).WithScale(0.5)},
+			{"Radix8/Scaled", NewRadix8(512, dir).WithScale(0.5), NewRadix8_32(512, dir).WithScale(0.5)},
+			{"Stockham/Parallel", NewStockham(4096, dir).WithScale(0.5).WithWorkers(4),
+				NewStockham32(4096, dir).WithScale(0.5).WithWorkers(4)},
+			{"FourStep/Parallel", NewFourStep(NewRadix8(128, dir), NewButterfly9(dir)).WithScale(0.5).WithWorkers(4),
+				NewFourStep32(NewRadix8_32(128, dir), NewButterfly9_32(dir)).WithScale(0.5).WithWorkers(4)},
+			{"Raders/Scaled", NewRaders(NewDft(36, dir)).WithScale(0.5), NewRaders32(NewDft32(36, dir)).WithScale(0.5)},
+			{"Bluestein/Scaled", NewBluestein(101, dir).WithScale(0.5), NewBluestein32(101, dir).WithScale(0.5)},
+			{"Scaled/Butterfly8", NewScaled(NewButterfly8(dir), 0.5), NewScaled32(NewButterfly8_32(dir), 0.5)},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				if tc.fft32.Len() != tc.fft64.Len() {
+					t.Fatalf("Len mismatch: got %d, want %d", tc.fft32.Len(), tc.fft64.Len())
+				}
+				if tc.fft32.Direction() != dir {
+					t.Fatalf("Direction mismatch: got %v, want %v", tc.fft32.Direction(), dir)
+				}
+
+				maxErr := compareWith64(tc.fft64, tc.fft32)
+				tolerance := 1e-5 * float64(tc.fft64.Len())
+				if maxErr > tolerance {
+					t.Errorf("%s: complex64 differs from complex128 by %.3e", tc.name, maxErr)
+				}
+			})
+		}
+	}
+}
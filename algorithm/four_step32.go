@@ -0,0 +1,233 @@
+package algorithm
+
+// FourStep32 implements Bailey's four-step FFT for complex64
+// Like MixedRadix32 it splits a size n FFT into FFTs of size width and height,
+// but it makes just two passes over memory. The first gathers a block of
+// columns at a time into a buffer that fits in cache, runs their FFTs and
+// scatters them back multiplied by the twiddles. The second runs the FFTs of
+// a block of rows into a buffer and transposes it into the output. The
+// twiddles come from two tables of about sqrt(n) entries rather than one of n.
+type FourStep32 struct {
+	widthFft          FftInterface32
+	width             int
+	heightFft         FftInterface32
+	height            int
+	length            int
+	direction         Direction
+	blockColumns      int         // Columns per block of the first pass
+	blockRows         int         // Rows per block of the second pass
+	twiddlesLow       []complex64 // W^k for k below 1<<twiddleShift
+	twiddlesHigh      []complex64 // W^(k<<twiddleShift), times the output scale
+	twiddleShift      uint
+	blockScratch      int // Block buffers plus the inner FFTs' scratch
+	inplaceScratch    int
+	outofplaceScratch int
+	workers           int
+}
+
+// NewFourStep32 creates a complex64 FourStep FFT instance
+// The FFT size will be widthFft.Len() * heightFft.Len(); the two should be
+// close to its square root.
+func NewFourStep32(widthFft, heightFft FftInterface32) *FourStep32 {
+	if widthFft.Direction() != heightFft.Direction() {
+		panic("width and height FFTs must have the same direction")
+	}
+
+	direction := widthFft.Direction()
+	width := widthFft.Len()
+	height := heightFft.Len()
+	length := width * height
+
+	blockColumns := min(max(fourStepBlockLen/height, fourStepMinBlock), width)
+	blockRows := min(max(fourStepBlockLen/width, fourStepMinBlock), height)
+
+	// W^k = W^(k>>shift<<shift) · W^(k&mask), with both tables near sqrt(length)
+	shift := uint(0)
+	for 1<<(2*shift) < length {
+		shift++
+	}
+	twiddlesLow := make([]complex64, 1<<shift)
+	for k := range twiddlesLow {
+		twiddlesLow[k] = twiddleFactor32(k, length, direction)
+	}
+	twiddlesHigh := make([]complex64, (length-1)>>shift+1)
+	for k := range twiddlesHigh {
+		twiddlesHigh[k] = twiddleFactor32(k<<shift, length, direction)
+	}
+
+	// The first pass needs the gathered columns and their FFTs, the second
+	// the FFTs of the rows
+	blockScratch := max(2*blockColumns*(height+fourStepPad), blockRows*(width+fourStepPad)) +
+		max(heightFft.OutOfPlaceScratchLen(), widthFft.OutOfPlaceScratchLen())
+
+	return &FourStep32{
+		widthFft:          widthFft,
+		width:             width,
+		heightFft:         heightFft,
+		height:            height,
+		length:            length,
+		direction:         direction,
+		blockColumns:      blockColumns,
+		blockRows:         blockRows,
+		twiddlesLow:       twiddlesLow,
+		twiddlesHigh:      twiddlesHigh,
+		twiddleShift:      shift,
+		blockScratch:      blockScratch,
+		inplaceScratch:    length + blockScratch,
+		outofplaceScratch: blockScratch,
+	}
+}
+
+func (f *FourStep32) Len() int                  { return f.length }
+func (f *FourStep32) Direction() Direction      { return f.direction }
+func (f *FourStep32) InplaceScratchLen() int    { return f.inplaceScratch }
+func (f *FourStep32) OutOfPlaceScratchLen() int { return f.outofplaceScratch }
+func (f *FourStep32) ImmutableScratchLen() int  { return f.inplaceScratch }
+
+// WithWorkers returns a copy of f that splits both passes across up to
+// workers goroutines. Every call then allocates, including block buffers for
+// the workers beyond the first.
+func (f *FourStep32) WithWorkers(workers int) *FourStep32 {
+	parallel := *f
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of f whose output is multiplied by scale
+// The factor is folded into the twiddles, which every element passes through.
+func (f *FourStep32) WithScale(scale float64) *FourStep32 {
+	s := complex(float32(scale), 0)
+	scaled := *f
+	scaled.twiddlesHigh = make([]complex64, len(f.twiddlesHigh))
+	for i, tw := range f.twiddlesHigh {
+		scaled.twiddlesHigh[i] = tw * s
+	}
+	return &scaled
+}
+
+func (f *FourStep32) Process(buffer []complex64) {
+	scratch := make([]complex64, f.InplaceScratchLen())
+	f.ProcessWithScratch(buffer, scratch)
+}
+
+func (f *FourStep32) ProcessWithScratch(buffer, scratch []complex64) {
+	checkInplace(len(buffer), f.Len(), len(scratch), f.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += f.length {
+		chunk := buffer[i : i+f.length]
+		f.performFft(chunk, scratch[:f.length], chunk, scratch[f.length:f.inplaceScratch])
+	}
+}
+
+func (f *FourStep32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), f.Len(), len(scratch), f.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += f.length {
+		in := input[i : i+f.length]
+		f.performFft(in, in, output[i:i+f.length], scratch[:f.blockScratch])
+	}
+}
+
+func (f *FourStep32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), f.Len(), len(scratch), f.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += f.length {
+		f.performFft(input[i:i+f.length], scratch[:f.length], output[i:i+f.length], scratch[f.length:f.inplaceScratch])
+	}
+}
+
+// performFft computes the FFT of input into output by way of work, which may
+// be input but not output. blockScratch holds f.blockScratch elements.
+func (f *FourStep32) performFft(input, work, output, blockScratch []complex64) {
+	numColumnBlocks := (f.width + f.blockColumns - 1) / f.blockColumns
+	numRowBlocks := (f.height + f.blockRows - 1) / f.blockRows
+	if f.workers > 1 {
+		f.performFftParallel(input, work, output, blockScratch, numColumnBlocks, numRowBlocks)
+		return
+	}
+
+	for b := 0; b < numColumnBlocks; b++ {
+		f.columnBlock(input, work, blockScratch, b)
+	}
+	for b := 0; b < numRowBlocks; b++ {
+		f.rowBlock(work, output, blockScratch, b)
+	}
+}
+
+// performFftParallel is performFft with both passes split across f.workers goroutines
+// The first worker uses blockScratch and the others get buffers of their own.
+func (f *FourStep32) performFftParallel(input, work, output, blockScratch []complex64, numColumnBlocks, numRowBlocks int) {
+	buffers := make([][]complex64, min(f.workers, max(numColumnBlocks, numRowBlocks)))
+	buffers[0] = blockScratch
+	for i := 1; i < len(buffers); i++ {
+		buffers[i] = make([]complex64, f.blockScratch)
+	}
+
+	ParallelFor(len(buffers), numColumnBlocks, func(worker, start, end int) {
+		for b := start; b < end; b++ {
+			f.columnBlock(input, work, buffers[worker], b)
+		}
+	})
+	ParallelFor(len(buffers), numRowBlocks, func(worker, start, end int) {
+		for b := start; b < end; b++ {
+			f.rowBlock(work, output, buffers[worker], b)
+		}
+	})
+}
+
+// columnBlock is the first pass for column block b: height-sized FFTs of the
+// columns of input, written back to the same columns of work multiplied by
+// the twiddles
+func (f *FourStep32) columnBlock(input, work, scratch []complex64, b int) {
+	start := b * f.blockColumns
+	columns := min(f.blockColumns, f.width-start)
+	stride := f.height + fourStepPad
+	gathered := scratch[:columns*stride]
+	transformed := scratch[columns*stride : 2*columns*stride]
+	innerScratch := scratch[2*columns*stride:]
+
+	// Column start+j becomes row j of gathered
+	for r := 0; r < f.height; r++ {
+		row := input[r*f.width+start : r*f.width+start+columns]
+		for j, v := range row {
+			gathered[j*stride+r] = v
+		}
+	}
+
+	for j := 0; j < columns; j++ {
+		f.heightFft.ProcessOutOfPlace(gathered[j*stride:j*stride+f.height], transformed[j*stride:j*stride+f.height], innerScratch)
+	}
+
+	// Element k of column start+j is multiplied by W^(k·(start+j))
+	mask := 1<<f.twiddleShift - 1
+	for k := 0; k < f.height; k++ {
+		row := work[k*f.width+start : k*f.width+start+columns]
+		for j := range row {
+			e := k * (start + j)
+			row[j] = transformed[j*stride+k] * f.twiddlesHigh[e>>f.twiddleShift] * f.twiddlesLow[e&mask]
+		}
+	}
+}
+
+// rowBlock is the second pass for row block b: width-sized FFTs of the rows
+// of work, transposed into output. It leaves the rows of work clobbered.
+func (f *FourStep32) rowBlock(work, output, scratch []complex64, b int) {
+	start := b * f.blockRows
+	rows := min(f.blockRows, f.height-start)
+	stride := f.width + fourStepPad
+	transformed := scratch[:rows*stride]
+	innerScratch := scratch[rows*stride:]
+
+	for i := 0; i < rows; i++ {
+		row := work[(start+i)*f.width : (start+i+1)*f.width]
+		f.widthFft.ProcessOutOfPlace(row, transformed[i*stride:i*stride+f.width], innerScratch)
+	}
+
+	// Row start+i of the FFTs lands in column start+i of the width x height output
+	for c := 0; c < f.width; c++ {
+		column := output[c*f.height+start : c*f.height+start+rows]
+		for i := range column {
+			column[i] = transformed[i*stride+c]
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestFourStepMatchesMixedRadix(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		testCases := []struct {
+			name          string
+			width, height FftInterface
+		}{
+			{"Butterflies", NewButterfly4(dir), NewButterfly3(dir)},
+			{"Square", NewRadix4(64, dir), NewRadix4(64, dir)},
+			{"Radix8", NewRadix8(512, dir), NewRadix8(1024, dir)},
+			{"Stockham", NewStockham(2048, dir), NewStockham(256, dir)},
+			// Blocks of 32 columns with a short last one, and a single row block
+			{"PartialColumnBlock", NewDft(40, dir), NewRadix4(1024, dir)},
+			{"PartialRowBlock", NewRadix4(1024, dir), NewDft(40, dir)},
+			{"Bluestein", NewBluestein(37, dir), NewRadixN([]RadixFactor{Factor3, Factor4}, NewDft(5, dir))},
+		}
+
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("Dir%d/%s", dir, tc.name), func(t *testing.T) {
+				fft := NewFourStep(tc.width, tc.height)
+				reference := NewMixedRadix(tc.width, tc.height)
+				n := fft.Len()
+
+				input := make([]complex128, 2*n)
+				for i := range input {
+					input[i] = complex(math.Sin(float64(i)*0.7), math.Cos(float64(i)*0.3))
+				}
+				expected := append([]complex128(nil), input...)
+				reference.ProcessWithScratch(expected, make([]complex128, reference.InplaceScratchLen()))
+
+				got := append([]complex128(nil), input...)
+				fft.ProcessWithScratch(got, make([]complex128, fft.InplaceScratchLen()))
+				tolerance := 1e-12 * float64(n)
+				for i := range got {
+					if err := cmplx.Abs(got[i] - expected[i]); err > tolerance {
+						t.Fatalf("[%d] got %v, want %v, err %g", i, got[i], expected[i], err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestFourStepMatchesDft(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		t.Run(fmt.Sprintf("Dir%d", dir), func(t *testing.T) {
+			checkMatchesDft(t, NewFourStep(NewButterfly16(dir), NewButterfly32(dir)))
+			checkMatchesDft(t, NewFourStep(NewDft(1, dir), NewButterfly7(dir)))
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package algorithm
+
+// The straight-line butterflies for the composite sizes without a hand-written
+// kernel are generated by cmd/genbutterfly. To add a size, append it to both
+// lists and run go generate.
+
+//go:generate go run ../cmd/genbutterfly -sizes 10,14,15,20,24,25,27,36,64 -o butterflies_generated.go
+//go:generate go run ../cmd/genbutterfly -sizes 10,14,15,20,24,25,27,36,64 -complex64 -o butterflies_generated32.go
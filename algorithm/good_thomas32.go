@@ -0,0 +1,280 @@
+package algorithm
+
+// GoodThomas32 implements the Good-Thomas (prime-factor) FFT algorithm for complex64
+// See GoodThomas for a description of the algorithm
+type GoodThomas32 struct {
+	widthFft          FftInterface32
+	width             int
+	heightFft         FftInterface32
+	height            int
+	length            int
+	direction         Direction
+	inputStep         int // Step between consecutive width indices in the input map
+	outputStep        int // Step between consecutive height indices in the output map
+	widthCrt          int // Output index contribution of one width frequency
+	inplaceScratch    int
+	outofplaceScratch int
+	immutableScratch  int
+	workers           int
+	scale             complex64 // Output scale, applied by the output reindexing
+}
+
+// NewGoodThomas32 creates a complex64 GoodThomas FFT instance
+// The FFT size will be widthFft.Len() * heightFft.Len(), which must be coprime
+func NewGoodThomas32(widthFft, heightFft FftInterface32) *GoodThomas32 {
+	if widthFft.Direction() != heightFft.Direction() {
+		panic("width and height FFTs must have the same direction")
+	}
+
+	direction := widthFft.Direction()
+	width := widthFft.Len()
+	height := heightFft.Len()
+	length := width * height
+
+	if gcd(width, height) != 1 {
+		panic("Good-Thomas algorithm requires coprime width and height")
+	}
+
+	// Input index (height*x + width*y) mod length goes to row y, column x.
+	// Output (kx, ky) goes to the index congruent to kx mod width and ky mod height.
+	widthCrt := height * modInverse(height, width) % length
+	heightCrt := width * modInverse(width, height) % length
+
+	// Calculate scratch space requirements
+	// As in MixedRadix, whichever length-sized buffer is idle doubles as
+	// inner scratch, so extra space is only needed beyond that
+	heightInplace := heightFft.InplaceScratchLen()
+	widthInplace := widthFft.InplaceScratchLen()
+	heightOutOfPlace := heightFft.OutOfPlaceScratchLen()
+
+	innerScratch := heightOutOfPlace
+	if widthInplace > length && widthInplace > innerScratch {
+		innerScratch = widthInplace
+	}
+	inplaceScratch := length + innerScratch
+
+	maxInnerInplace := heightInplace
+	if widthInplace > maxInnerInplace {
+		maxInnerInplace = widthInplace
+	}
+	outofplaceScratch := 0
+	if maxInnerInplace > length {
+		outofplaceScratch = maxInnerInplace
+	}
+
+	immutableScratch := length + heightInplace
+	if widthInplace > immutableScratch {
+		immutableScratch = widthInplace
+	}
+
+	return &GoodThomas32{
+		widthFft:          widthFft,
+		width:             width,
+		heightFft:         heightFft,
+		height:            height,
+		length:            length,
+		direction:         direction,
+		inputStep:         height,
+		outputStep:        heightCrt,
+		widthCrt:          widthCrt,
+		inplaceScratch:    inplaceScratch,
+		outofplaceScratch: outofplaceScratch,
+		immutableScratch:  immutableScratch,
+		scale:             1,
+	}
+}
+
+func (g *GoodThomas32) Len() int                  { return g.length }
+func (g *GoodThomas32) Direction() Direction      { return g.direction }
+func (g *GoodThomas32) InplaceScratchLen() int    { return g.inplaceScratch }
+func (g *GoodThomas32) OutOfPlaceScratchLen() int { return g.outofplaceScratch }
+func (g *GoodThomas32) ImmutableScratchLen() int  { return g.immutableScratch }
+
+// WithWorkers returns a copy of g that splits each pass of ProcessWithScratch
+// across up to workers goroutines. Scratch requirements don't change, but
+// every call then allocates, so this only pays off for large lengths.
+func (g *GoodThomas32) WithWorkers(workers int) *GoodThomas32 {
+	parallel := *g
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of g whose output is multiplied by scale
+// The factor is applied while reindexing the output, so it costs one
+// multiply per element rather than a separate pass.
+func (g *GoodThomas32) WithScale(scale float64) *GoodThomas32 {
+	scaled := *g
+	scaled.scale = g.scale * complex(float32(scale), 0)
+	return &scaled
+}
+
+func (g *GoodThomas32) ProcessWithScratch(buffer, scratch []complex64) {
+	checkInplace(len(buffer), g.Len(), len(scratch), g.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += g.length {
+		g.processOne(buffer[i:i+g.length], scratch[:g.inplaceScratch])
+	}
+}
+
+func (g *GoodThomas32) processOne(buffer, scratch []complex64) {
+	if g.workers > 1 {
+		g.processOneParallel(buffer, scratch)
+		return
+	}
+
+	selfScratch := scratch[:g.length]
+	innerScratch := scratch[g.length:]
+
+	// STEP 1: Reorder input into height rows of width
+	g.reindexInput(buffer, selfScratch)
+
+	// STEP 2: Perform width-sized FFTs, borrowing the buffer as scratch
+	widthScratch := buffer
+	if len(innerScratch) >= g.widthFft.InplaceScratchLen() {
+		widthScratch = innerScratch
+	}
+	g.widthFft.ProcessWithScratch(selfScratch, widthScratch)
+
+	// STEP 3: Transpose to width rows of height
+	transpose32(g.height, g.width, selfScratch, buffer)
+
+	// STEP 4: Perform height-sized FFTs out-of-place (buffer → selfScratch)
+	g.heightFft.ProcessOutOfPlace(buffer, selfScratch, innerScratch)
+
+	// STEP 5: Reorder output back into natural order
+	g.reindexOutput(selfScratch, buffer)
+}
+
+// processOneParallel is processOne with every step split across g.workers goroutines
+func (g *GoodThomas32) processOneParallel(buffer, scratch []complex64) {
+	selfScratch := scratch[:g.length]
+	innerScratch := scratch[g.length:]
+
+	ParallelFor(g.workers, g.height, func(_, start, end int) {
+		g.reindexInputRows(buffer, selfScratch, start, end)
+	})
+
+	if !parallelInplace32(g.workers, g.widthFft, selfScratch, buffer) {
+		widthScratch := buffer
+		if len(innerScratch) >= g.widthFft.InplaceScratchLen() {
+			widthScratch = innerScratch
+		}
+		g.widthFft.ProcessWithScratch(selfScratch, widthScratch)
+	}
+
+	parallelTranspose32(g.workers, g.height, g.width, selfScratch, buffer)
+
+	if !parallelOutOfPlace32(g.workers, g.heightFft, buffer, selfScratch) {
+		g.heightFft.ProcessOutOfPlace(buffer, selfScratch, innerScratch)
+	}
+
+	ParallelFor(g.workers, g.width, func(_, start, end int) {
+		g.reindexOutputRows(selfScratch, buffer, start, end)
+	})
+}
+
+func (g *GoodThomas32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), g.Len(), len(scratch), g.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += g.length {
+		g.performFftOutOfPlace(input[i:i+g.length], output[i:i+g.length], scratch)
+	}
+}
+
+func (g *GoodThomas32) performFftOutOfPlace(input, output, scratch []complex64) {
+	// Same steps as processOne, ping-ponging between input and output
+	g.reindexInput(input, output)
+
+	widthScratch := input
+	if len(scratch) >= g.widthFft.InplaceScratchLen() {
+		widthScratch = scratch
+	}
+	g.widthFft.ProcessWithScratch(output, widthScratch)
+
+	transpose32(g.height, g.width, output, input)
+
+	heightScratch := output
+	if len(scratch) >= g.heightFft.InplaceScratchLen() {
+		heightScratch = scratch
+	}
+	g.heightFft.ProcessWithScratch(input, heightScratch)
+
+	g.reindexOutput(input, output)
+}
+
+func (g *GoodThomas32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), g.Len(), len(scratch), g.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += g.length {
+		g.performFftImmutable(input[i:i+g.length], output[i:i+g.length], scratch[:g.immutableScratch])
+	}
+}
+
+func (g *GoodThomas32) performFftImmutable(input, output, scratch []complex64) {
+	// Input is read once by the reindex, after which scratch takes its place
+	g.reindexInput(input, output)
+	g.widthFft.ProcessWithScratch(output, scratch)
+
+	selfScratch := scratch[:g.length]
+	transpose32(g.height, g.width, output, selfScratch)
+	g.heightFft.ProcessWithScratch(selfScratch, scratch[g.length:])
+
+	g.reindexOutput(selfScratch, output)
+}
+
+// reindexInput gathers input[(height*x + width*y) mod length] into row y, column x
+func (g *GoodThomas32) reindexInput(input, output []complex64) {
+	g.reindexInputRows(input, output, 0, g.height)
+}
+
+// reindexInputRows is reindexInput for rows [start, end) only
+func (g *GoodThomas32) reindexInputRows(input, output []complex64, start, end int) {
+	rowStart := start * g.width % g.length
+	for y := start; y < end; y++ {
+		idx := rowStart
+		row := output[y*g.width : (y+1)*g.width]
+		for x := range row {
+			row[x] = input[idx]
+			idx += g.inputStep
+			if idx >= g.length {
+				idx -= g.length
+			}
+		}
+		rowStart += g.width
+	}
+}
+
+// reindexOutput scatters row kx, column ky to the index congruent to kx mod width and ky mod height
+func (g *GoodThomas32) reindexOutput(input, output []complex64) {
+	g.reindexOutputRows(input, output, 0, g.width)
+}
+
+// reindexOutputRows is reindexOutput for rows [start, end) only
+func (g *GoodThomas32) reindexOutputRows(input, output []complex64, start, end int) {
+	rowStart := start * g.widthCrt % g.length
+	for kx := start; kx < end; kx++ {
+		idx := rowStart
+		row := input[kx*g.height : (kx+1)*g.height]
+		if g.scale == 1 {
+			for _, v := range row {
+				output[idx] = v
+				idx += g.outputStep
+				if idx >= g.length {
+					idx -= g.length
+				}
+			}
+		} else {
+			for _, v := range row {
+				output[idx] = v * g.scale
+				idx += g.outputStep
+				if idx >= g.length {
+					idx -= g.length
+				}
+			}
+		}
+		rowStart += g.widthCrt
+		if rowStart >= g.length {
+			rowStart -= g.length
+		}
+	}
+}
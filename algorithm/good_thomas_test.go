@@ -0,0 +1,63 @@
+package algorithm
+
+import (
+	"fmt"
+	"math/cmplx"
+	"testing"
+)
+
+// TestGoodThomasCorrectness tests GoodThomas against DFT
+func TestGoodThomasCorrectness(t *testing.T) {
+	testCases := []struct {
+		n1, n2 int
+	}{
+		{2, 3},   // 6
+		{3, 2},   // 6, swapped
+		{4, 5},   // 20
+		{5, 7},   // 35
+		{8, 9},   // 72
+		{11, 13}, // 143
+		{16, 17}, // 272
+	}
+
+	for _, dir := range []Direction{Forward, Inverse} {
+		for _, tc := range testCases {
+			n := tc.n1 * tc.n2
+			t.Run(fmt.Sprintf("Dir%d/%dx%d", dir, tc.n1, tc.n2), func(t *testing.T) {
+				gt := NewGoodThomas(NewDft(tc.n1, dir), NewDft(tc.n2, dir))
+
+				input := make([]complex128, n)
+				for i := range input {
+					input[i] = complex(float64(i%11), float64(i%7)*0.5)
+				}
+
+				result := make([]complex128, n)
+				copy(result, input)
+				gt.ProcessWithScratch(result, make([]complex128, gt.InplaceScratchLen()))
+
+				expected := make([]complex128, n)
+				copy(expected, input)
+				NewDft(n, dir).ProcessWithScratch(expected, make([]complex128, n))
+
+				maxErr := 0.0
+				for i := range result {
+					if err := cmplx.Abs(result[i] - expected[i]); err > maxErr {
+						maxErr = err
+					}
+				}
+				if maxErr > 1e-9 {
+					t.Errorf("Size %d (%dx%d) failed with error %.6e", n, tc.n1, tc.n2, maxErr)
+				}
+			})
+		}
+	}
+}
+
+func TestGoodThomasRejectsNonCoprime(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for non-coprime width and height")
+		}
+	}()
+	NewGoodThomas(NewDft(4, Forward), NewDft(6, Forward))
+}
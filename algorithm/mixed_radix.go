@@ -14,6 +14,8 @@ type MixedRadix struct {
 	direction         Direction
 	inplaceScratch    int
 	outofplaceScratch int
+	immutableScratch  int
+	workers           int
 }
 
 // NewMixedRadix creates a MixedRadix FFT instance
@@ -42,9 +44,12 @@ func NewMixedRadix(widthFft, heightFft FftInterface) *MixedRadix {
 	}
 
 	// Calculate scratch space requirements
+	// Whichever of the two length-sized buffers is idle during an inner FFT
+	// doubles as its scratch, so extra space is only needed when an inner
+	// FFT wants more than that.
 	heightInplace := heightFft.InplaceScratchLen()
 	widthInplace := widthFft.InplaceScratchLen()
-	widthOutofplace := 0 // We'll use in-place for width FFT
+	widthOutOfPlace := widthFft.OutOfPlaceScratchLen()
 
 	maxInnerInplace := heightInplace
 	if widthInplace > maxInnerInplace {
@@ -56,12 +61,15 @@ func NewMixedRadix(widthFft, heightFft FftInterface) *MixedRadix {
 		outofplaceScratch = maxInnerInplace
 	}
 
-	inplaceScratch := length
-	if heightInplace > length {
-		inplaceScratch = length + (heightInplace - length)
+	innerScratch := widthOutOfPlace
+	if heightInplace > length && heightInplace > innerScratch {
+		innerScratch = heightInplace
 	}
-	if widthOutofplace > 0 && widthOutofplace > inplaceScratch-length {
-		inplaceScratch = length + widthOutofplace
+	inplaceScratch := length + innerScratch
+
+	immutableScratch := length + widthInplace
+	if heightInplace > immutableScratch {
+		immutableScratch = heightInplace
 	}
 
 	return &MixedRadix{
@@ -74,6 +82,7 @@ func NewMixedRadix(widthFft, heightFft FftInterface) *MixedRadix {
 		direction:         direction,
 		inplaceScratch:    inplaceScratch,
 		outofplaceScratch: outofplaceScratch,
+		immutableScratch:  immutableScratch,
 	}
 }
 
@@ -81,7 +90,29 @@ func (m *MixedRadix) Len() int                  { return m.length }
 func (m *MixedRadix) Direction() Direction      { return m.direction }
 func (m *MixedRadix) InplaceScratchLen() int    { return m.inplaceScratch }
 func (m *MixedRadix) OutOfPlaceScratchLen() int { return m.outofplaceScratch }
-func (m *MixedRadix) ImmutableScratchLen() int  { return m.inplaceScratch }
+func (m *MixedRadix) ImmutableScratchLen() int  { return m.immutableScratch }
+
+// WithWorkers returns a copy of m that splits each pass of ProcessWithScratch
+// across up to workers goroutines. Scratch requirements don't change, but
+// every call then allocates, so this only pays off for large lengths.
+func (m *MixedRadix) WithWorkers(workers int) *MixedRadix {
+	parallel := *m
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of m whose output is multiplied by scale
+// The factor is folded into the twiddles, which every element passes through
+// before the last FFTs, so it costs nothing at run time.
+func (m *MixedRadix) WithScale(scale float64) *MixedRadix {
+	s := complex(scale, 0)
+	scaled := *m
+	scaled.twiddles = make([]complex128, len(m.twiddles))
+	for i, tw := range m.twiddles {
+		scaled.twiddles[i] = tw * s
+	}
+	return &scaled
+}
 
 func (m *MixedRadix) Process(buffer []complex128) {
 	scratch := make([]complex128, m.InplaceScratchLen())
@@ -89,20 +120,30 @@ func (m *MixedRadix) Process(buffer []complex128) {
 }
 
 func (m *MixedRadix) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), m.Len(), len(scratch), m.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += m.length {
+		m.processOne(buffer[i:i+m.length], scratch[:m.inplaceScratch])
+	}
+}
+
+func (m *MixedRadix) processOne(buffer, scratch []complex128) {
+	if m.workers > 1 {
+		m.processOneParallel(buffer, scratch)
+		return
+	}
+
 	// Six-step FFT algorithm (based on RustFFT)
 	selfScratch := scratch[:m.length]
-	var innerScratch []complex128
-	if len(scratch) > m.length {
-		innerScratch = scratch[m.length:]
-	}
+	innerScratch := scratch[m.length:]
 
-	// STEP 1: Transpose input (width x height) to (height x width)
-	transpose(m.width, m.height, buffer, selfScratch)
+	// STEP 1: Transpose input (height rows of width) to (width rows of height)
+	transpose(m.height, m.width, buffer, selfScratch)
 
 	// STEP 2: Perform height-sized FFTs
 	// The heightFft will process multiple FFTs of size height
 	heightScratch := buffer // Use buffer as scratch since we've copied data to selfScratch
-	if len(innerScratch) >= len(buffer) {
+	if len(innerScratch) >= m.heightFft.InplaceScratchLen() {
 		heightScratch = innerScratch
 	}
 	m.heightFft.ProcessWithScratch(selfScratch, heightScratch)
@@ -112,26 +153,101 @@ func (m *MixedRadix) ProcessWithScratch(buffer, scratch []complex128) {
 		selfScratch[i] = selfScratch[i] * m.twiddles[i]
 	}
 
-	// STEP 4: Transpose back to (width x height)
-	transpose(m.height, m.width, selfScratch, buffer)
+	// STEP 4: Transpose back to (height rows of width)
+	transpose(m.width, m.height, selfScratch, buffer)
 
 	// STEP 5: Perform width-sized FFTs out-of-place (buffer → selfScratch)
-	// Copy buffer to selfScratch, process there
-	copy(selfScratch, buffer)
-	m.widthFft.ProcessWithScratch(selfScratch, innerScratch)
+	m.widthFft.ProcessOutOfPlace(buffer, selfScratch, innerScratch)
 
-	// STEP 6: Transpose final result (width x height) → buffer
-	transpose(m.width, m.height, selfScratch, buffer)
+	// STEP 6: Transpose final result (height rows of width) → buffer
+	transpose(m.height, m.width, selfScratch, buffer)
+}
+
+// processOneParallel is processOne with every step split across m.workers goroutines
+// The inner FFTs borrow the idle buffer as scratch one slice per worker,
+// falling back to a single goroutine when they need more than that.
+func (m *MixedRadix) processOneParallel(buffer, scratch []complex128) {
+	selfScratch := scratch[:m.length]
+	innerScratch := scratch[m.length:]
+
+	parallelTranspose(m.workers, m.height, m.width, buffer, selfScratch)
+
+	if !parallelInplace(m.workers, m.heightFft, selfScratch, buffer) {
+		heightScratch := buffer
+		if len(innerScratch) >= m.heightFft.InplaceScratchLen() {
+			heightScratch = innerScratch
+		}
+		m.heightFft.ProcessWithScratch(selfScratch, heightScratch)
+	}
+
+	parallelMultiply(m.workers, selfScratch, m.twiddles)
+
+	parallelTranspose(m.workers, m.width, m.height, selfScratch, buffer)
+
+	if !parallelOutOfPlace(m.workers, m.widthFft, buffer, selfScratch) {
+		m.widthFft.ProcessOutOfPlace(buffer, selfScratch, innerScratch)
+	}
+
+	parallelTranspose(m.workers, m.height, m.width, selfScratch, buffer)
 }
 
 func (m *MixedRadix) ProcessOutOfPlace(input, output, scratch []complex128) {
-	copy(output, input)
-	m.ProcessWithScratch(output, scratch)
+	checkOutOfPlace(len(input), len(output), m.Len(), len(scratch), m.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += m.length {
+		m.performFftOutOfPlace(input[i:i+m.length], output[i:i+m.length], scratch)
+	}
+}
+
+func (m *MixedRadix) performFftOutOfPlace(input, output, scratch []complex128) {
+	// Same six steps as processOne, ping-ponging between input and output
+	// so no extra buffer is needed
+	transpose(m.height, m.width, input, output)
+
+	heightScratch := input
+	if len(scratch) >= m.heightFft.InplaceScratchLen() {
+		heightScratch = scratch
+	}
+	m.heightFft.ProcessWithScratch(output, heightScratch)
+
+	for i := range output {
+		output[i] = output[i] * m.twiddles[i]
+	}
+
+	transpose(m.width, m.height, output, input)
+
+	widthScratch := output
+	if len(scratch) >= m.widthFft.InplaceScratchLen() {
+		widthScratch = scratch
+	}
+	m.widthFft.ProcessWithScratch(input, widthScratch)
+
+	transpose(m.height, m.width, input, output)
 }
 
 func (m *MixedRadix) ProcessImmutable(input []complex128, output, scratch []complex128) {
-	copy(output, input)
-	m.ProcessWithScratch(output, scratch)
+	checkOutOfPlace(len(input), len(output), m.Len(), len(scratch), m.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += m.length {
+		m.performFftImmutable(input[i:i+m.length], output[i:i+m.length], scratch[:m.immutableScratch])
+	}
+}
+
+func (m *MixedRadix) performFftImmutable(input, output, scratch []complex128) {
+	// Input is read exactly once by the first transpose, after which the
+	// scratch buffer takes its place as the second work buffer
+	transpose(m.height, m.width, input, output)
+	m.heightFft.ProcessWithScratch(output, scratch)
+
+	for i := range output {
+		output[i] = output[i] * m.twiddles[i]
+	}
+
+	selfScratch := scratch[:m.length]
+	transpose(m.width, m.height, output, selfScratch)
+	m.widthFft.ProcessWithScratch(selfScratch, scratch[m.length:])
+
+	transpose(m.height, m.width, selfScratch, output)
 }
 
 // transpose performs a matrix transpose
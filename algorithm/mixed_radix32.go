@@ -0,0 +1,263 @@
+package algorithm
+
+import "math"
+
+// MixedRadix32 implements the Mixed-Radix FFT algorithm for complex64
+// It factors a size n FFT into n1 * n2, computes several inner FFTs, then combines results
+type MixedRadix32 struct {
+	twiddles          []complex64
+	widthFft          FftInterface32
+	width             int
+	heightFft         FftInterface32
+	height            int
+	length            int
+	direction         Direction
+	inplaceScratch    int
+	outofplaceScratch int
+	immutableScratch  int
+	workers           int
+}
+
+// NewMixedRadix32 creates a complex64 MixedRadix FFT instance
+// The FFT size will be widthFft.Len() * heightFft.Len()
+func NewMixedRadix32(widthFft, heightFft FftInterface32) *MixedRadix32 {
+	if widthFft.Direction() != heightFft.Direction() {
+		panic("width and height FFTs must have the same direction")
+	}
+
+	direction := widthFft.Direction()
+	width := widthFft.Len()
+	height := heightFft.Len()
+	length := width * height
+
+	// Precompute twiddle factors
+	twiddles := make([]complex64, length)
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			idx := x*height + y
+			angle := -2.0 * math.Pi * float64(x*y) / float64(length)
+			if direction == Inverse {
+				angle = -angle
+			}
+			twiddles[idx] = complex(float32(math.Cos(angle)), float32(math.Sin(angle)))
+		}
+	}
+
+	// Calculate scratch space requirements
+	// Whichever of the two length-sized buffers is idle during an inner FFT
+	// doubles as its scratch, so extra space is only needed when an inner
+	// FFT wants more than that.
+	heightInplace := heightFft.InplaceScratchLen()
+	widthInplace := widthFft.InplaceScratchLen()
+	widthOutOfPlace := widthFft.OutOfPlaceScratchLen()
+
+	maxInnerInplace := heightInplace
+	if widthInplace > maxInnerInplace {
+		maxInnerInplace = widthInplace
+	}
+
+	outofplaceScratch := 0
+	if maxInnerInplace > length {
+		outofplaceScratch = maxInnerInplace
+	}
+
+	innerScratch := widthOutOfPlace
+	if heightInplace > length && heightInplace > innerScratch {
+		innerScratch = heightInplace
+	}
+	inplaceScratch := length + innerScratch
+
+	immutableScratch := length + widthInplace
+	if heightInplace > immutableScratch {
+		immutableScratch = heightInplace
+	}
+
+	return &MixedRadix32{
+		twiddles:          twiddles,
+		widthFft:          widthFft,
+		width:             width,
+		heightFft:         heightFft,
+		height:            height,
+		length:            length,
+		direction:         direction,
+		inplaceScratch:    inplaceScratch,
+		outofplaceScratch: outofplaceScratch,
+		immutableScratch:  immutableScratch,
+	}
+}
+
+func (m *MixedRadix32) Len() int                  { return m.length }
+func (m *MixedRadix32) Direction() Direction      { return m.direction }
+func (m *MixedRadix32) InplaceScratchLen() int    { return m.inplaceScratch }
+func (m *MixedRadix32) OutOfPlaceScratchLen() int { return m.outofplaceScratch }
+func (m *MixedRadix32) ImmutableScratchLen() int  { return m.immutableScratch }
+
+// WithWorkers returns a copy of m that splits each pass of ProcessWithScratch
+// across up to workers goroutines. Scratch requirements don't change, but
+// every call then allocates, so this only pays off for large lengths.
+func (m *MixedRadix32) WithWorkers(workers int) *MixedRadix32 {
+	parallel := *m
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of m whose output is multiplied by scale
+// The factor is folded into the twiddles, which every element passes through
+// before the last FFTs, so it costs nothing at run time.
+func (m *MixedRadix32) WithScale(scale float64) *MixedRadix32 {
+	s := complex(float32(scale), 0)
+	scaled := *m
+	scaled.twiddles = make([]complex64, len(m.twiddles))
+	for i, tw := range m.twiddles {
+		scaled.twiddles[i] = tw * s
+	}
+	return &scaled
+}
+
+func (m *MixedRadix32) Process(buffer []complex64) {
+	scratch := make([]complex64, m.InplaceScratchLen())
+	m.ProcessWithScratch(buffer, scratch)
+}
+
+func (m *MixedRadix32) ProcessWithScratch(buffer, scratch []complex64) {
+	checkInplace(len(buffer), m.Len(), len(scratch), m.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += m.length {
+		m.processOne(buffer[i:i+m.length], scratch[:m.inplaceScratch])
+	}
+}
+
+func (m *MixedRadix32) processOne(buffer, scratch []complex64) {
+	if m.workers > 1 {
+		m.processOneParallel(buffer, scratch)
+		return
+	}
+
+	// Six-step FFT algorithm (based on RustFFT)
+	selfScratch := scratch[:m.length]
+	innerScratch := scratch[m.length:]
+
+	// STEP 1: Transpose input (height rows of width) to (width rows of height)
+	transpose32(m.height, m.width, buffer, selfScratch)
+
+	// STEP 2: Perform height-sized FFTs
+	// The heightFft will process multiple FFTs of size height
+	heightScratch := buffer // Use buffer as scratch since we've copied data to selfScratch
+	if len(innerScratch) >= m.heightFft.InplaceScratchLen() {
+		heightScratch = innerScratch
+	}
+	m.heightFft.ProcessWithScratch(selfScratch, heightScratch)
+
+	// STEP 3: Apply twiddle factors
+	for i := range selfScratch {
+		selfScratch[i] = selfScratch[i] * m.twiddles[i]
+	}
+
+	// STEP 4: Transpose back to (height rows of width)
+	transpose32(m.width, m.height, selfScratch, buffer)
+
+	// STEP 5: Perform width-sized FFTs out-of-place (buffer → selfScratch)
+	m.widthFft.ProcessOutOfPlace(buffer, selfScratch, innerScratch)
+
+	// STEP 6: Transpose final result (height rows of width) → buffer
+	transpose32(m.height, m.width, selfScratch, buffer)
+}
+
+// processOneParallel is processOne with every step split across m.workers goroutines
+// The inner FFTs borrow the idle buffer as scratch one slice per worker,
+// falling back to a single goroutine when they need more than that.
+func (m *MixedRadix32) processOneParallel(buffer, scratch []complex64) {
+	selfScratch := scratch[:m.length]
+	innerScratch := scratch[m.length:]
+
+	parallelTranspose32(m.workers, m.height, m.width, buffer, selfScratch)
+
+	if !parallelInplace32(m.workers, m.heightFft, selfScratch, buffer) {
+		heightScratch := buffer
+		if len(innerScratch) >= m.heightFft.InplaceScratchLen() {
+			heightScratch = innerScratch
+		}
+		m.heightFft.ProcessWithScratch(selfScratch, heightScratch)
+	}
+
+	parallelMultiply32(m.workers, selfScratch, m.twiddles)
+
+	parallelTranspose32(m.workers, m.width, m.height, selfScratch, buffer)
+
+	if !parallelOutOfPlace32(m.workers, m.widthFft, buffer, selfScratch) {
+		m.widthFft.ProcessOutOfPlace(buffer, selfScratch, innerScratch)
+	}
+
+	parallelTranspose32(m.workers, m.height, m.width, selfScratch, buffer)
+}
+
+func (m *MixedRadix32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), m.Len(), len(scratch), m.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += m.length {
+		m.performFftOutOfPlace(input[i:i+m.length], output[i:i+m.length], scratch)
+	}
+}
+
+func (m *MixedRadix32) performFftOutOfPlace(input, output, scratch []complex64) {
+	// Same six steps as processOne, ping-ponging between input and output
+	// so no extra buffer is needed
+	transpose32(m.height, m.width, input, output)
+
+	heightScratch := input
+	if len(scratch) >= m.heightFft.InplaceScratchLen() {
+		heightScratch = scratch
+	}
+	m.heightFft.ProcessWithScratch(output, heightScratch)
+
+	for i := range output {
+		output[i] = output[i] * m.twiddles[i]
+	}
+
+	transpose32(m.width, m.height, output, input)
+
+	widthScratch := output
+	if len(scratch) >= m.widthFft.InplaceScratchLen() {
+		widthScratch = scratch
+	}
+	m.widthFft.ProcessWithScratch(input, widthScratch)
+
+	transpose32(m.height, m.width, input, output)
+}
+
+func (m *MixedRadix32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), m.Len(), len(scratch), m.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += m.length {
+		m.performFftImmutable(input[i:i+m.length], output[i:i+m.length], scratch[:m.immutableScratch])
+	}
+}
+
+func (m *MixedRadix32) performFftImmutable(input, output, scratch []complex64) {
+	// Input is read exactly once by the first transpose, after which the
+	// scratch buffer takes its place as the second work buffer
+	transpose32(m.height, m.width, input, output)
+	m.heightFft.ProcessWithScratch(output, scratch)
+
+	for i := range output {
+		output[i] = output[i] * m.twiddles[i]
+	}
+
+	selfScratch := scratch[:m.length]
+	transpose32(m.width, m.height, output, selfScratch)
+	m.widthFft.ProcessWithScratch(selfScratch, scratch[m.length:])
+
+	transpose32(m.height, m.width, selfScratch, output)
+}
+
+// transpose32 performs a matrix transpose for complex64
+// Treats input as a rows x cols matrix and transposes to output
+func transpose32(rows, cols int, input, output []complex64) {
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			inputIdx := r*cols + c
+			outputIdx := c*rows + r
+			output[outputIdx] = input[inputIdx]
+		}
+	}
+}
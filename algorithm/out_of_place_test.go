@@ -0,0 +1,83 @@
+package algorithm
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestOutOfPlaceMatchesInplace checks that ProcessOutOfPlace and ProcessImmutable
+// agree with ProcessWithScratch when given exactly the scratch they report
+func TestOutOfPlaceMatchesInplace(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		testCases := []struct {
+			name string
+			fft  FftInterface
+		}{
+			{"Dft/10", NewDft(10, dir)},
+			{"Butterfly7", NewButterfly7(dir)},
+			{"Butterfly17", NewButterfly17(dir)},
+			{"Radix4/64", NewRadix4(64, dir)},
+			{"Radix4/Dft", NewRadix4WithBase(2, NewDft(3, dir))},
+			{"Radix8/512", NewRadix8(512, dir)},
+			{"Radix8/Dft", NewRadix8WithBase(2, NewDft(3, dir))},
+			{"Stockham/1", NewStockham(1, dir)},
+			{"Stockham/2", NewStockham(2, dir)},
+			{"Stockham/512", NewStockham(512, dir)},
+			{"Stockham/1024", NewStockham(1024, dir)},
+			{"FourStep/Butterflies", NewFourStep(NewButterfly5(dir), NewButterfly7(dir))},
+			{"FourStep/Radix4", NewFourStep(NewRadix4(64, dir), NewRadix4(32, dir))},
+			{"FourStep/Bluestein", NewFourStep(NewBluestein(5, dir), NewBluestein(3, dir))},
+			{"RadixN/60", NewRadixN([]RadixFactor{Factor3, Factor4, Factor5}, NewDft(1, dir))},
+			{"RadixN/Dft", NewRadixN([]RadixFactor{Factor2, Factor3}, NewDft(5, dir))},
+			{"RadixN/Bluestein", NewRadixN([]RadixFactor{Factor2}, NewBluestein(5, dir))},
+			{"Raders/13", NewRaders(NewButterfly12(dir))},
+			{"Raders/Dft", NewRaders(NewDft(10, dir))},
+			{"Raders/Bluestein", NewRaders(NewBluestein(10, dir))},
+			{"Bluestein/37", NewBluestein(37, dir)},
+			{"MixedRadix/Butterflies", NewMixedRadix(NewButterfly5(dir), NewButterfly7(dir))},
+			{"MixedRadix/Radix4", NewMixedRadix(NewRadix4(16, dir), NewButterfly3(dir))},
+			{"MixedRadix/Dft", NewMixedRadix(NewDft(6, dir), NewDft(4, dir))},
+			{"MixedRadix/Bluestein", NewMixedRadix(NewBluestein(5, dir), NewBluestein(3, dir))},
+			{"GoodThomas/Butterflies", NewGoodThomas(NewButterfly5(dir), NewButterfly7(dir))},
+			{"GoodThomas/Radix4", NewGoodThomas(NewRadix4(16, dir), NewButterfly3(dir))},
+			{"GoodThomas/Dft", NewGoodThomas(NewDft(4, dir), NewDft(9, dir))},
+			{"GoodThomas/Bluestein", NewGoodThomas(NewBluestein(5, dir), NewBluestein(3, dir))},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				n := tc.fft.Len() * 2 // Two chunks, to exercise batching
+				input := make([]complex128, n)
+				for i := range input {
+					input[i] = complex(math.Sin(float64(i)*0.7), math.Cos(float64(i)*0.3))
+				}
+
+				expected := append([]complex128(nil), input...)
+				tc.fft.ProcessWithScratch(expected, make([]complex128, tc.fft.InplaceScratchLen()))
+
+				// Immutable must leave its input untouched
+				immutableInput := append([]complex128(nil), input...)
+				output := make([]complex128, n)
+				tc.fft.ProcessImmutable(immutableInput, output, make([]complex128, tc.fft.ImmutableScratchLen()))
+				for i := range output {
+					if cmplx.Abs(output[i]-expected[i]) > 1e-9 {
+						t.Fatalf("ProcessImmutable[%d]: got %v, want %v", i, output[i], expected[i])
+					}
+					if immutableInput[i] != input[i] {
+						t.Fatalf("ProcessImmutable modified input[%d]", i)
+					}
+				}
+
+				outOfPlaceInput := append([]complex128(nil), input...)
+				output = make([]complex128, n)
+				tc.fft.ProcessOutOfPlace(outOfPlaceInput, output, make([]complex128, tc.fft.OutOfPlaceScratchLen()))
+				for i := range output {
+					if cmplx.Abs(output[i]-expected[i]) > 1e-9 {
+						t.Fatalf("ProcessOutOfPlace[%d]: got %v, want %v", i, output[i], expected[i])
+					}
+				}
+			})
+		}
+	}
+}
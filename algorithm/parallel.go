@@ -0,0 +1,75 @@
+package algorithm
+
+import "sync"
+
+// ParallelFor splits [0, count) into one contiguous range per worker and runs
+// fn on each range in its own goroutine, returning once all of them are done
+// With a single worker (or a single item) fn runs on the calling goroutine.
+func ParallelFor(workers, count int, fn func(worker, start, end int)) {
+	if workers > count {
+		workers = count
+	}
+	if workers <= 1 {
+		fn(0, 0, count)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		start := w * count / workers
+		end := (w + 1) * count / workers
+		go func(w int) {
+			defer wg.Done()
+			fn(w, start, end)
+		}(w)
+	}
+	wg.Wait()
+}
+
+// parallelInplace runs fft over the fft.Len()-sized chunks of buffer, split
+// across workers. Each worker borrows the matching part of idle as scratch,
+// so it reports false without doing anything if one FFT needs more scratch
+// than its own length.
+func parallelInplace(workers int, fft FftInterface, buffer, idle []complex128) bool {
+	n := fft.Len()
+	if fft.InplaceScratchLen() > n {
+		return false
+	}
+	ParallelFor(workers, len(buffer)/n, func(_, start, end int) {
+		fft.ProcessWithScratch(buffer[start*n:end*n], idle[start*n:end*n])
+	})
+	return true
+}
+
+// parallelOutOfPlace runs fft from the chunks of input into output, split
+// across workers. It reports false without doing anything if fft needs
+// out-of-place scratch, since there is none to share out.
+func parallelOutOfPlace(workers int, fft FftInterface, input, output []complex128) bool {
+	n := fft.Len()
+	if fft.OutOfPlaceScratchLen() > 0 {
+		return false
+	}
+	ParallelFor(workers, len(input)/n, func(_, start, end int) {
+		fft.ProcessOutOfPlace(input[start*n:end*n], output[start*n:end*n], nil)
+	})
+	return true
+}
+
+// parallelTranspose is transpose split across workers by input rows
+func parallelTranspose(workers, rows, cols int, input, output []complex128) {
+	ParallelFor(workers, rows, func(_, start, end int) {
+		for r := start; r < end; r++ {
+			for c := 0; c < cols; c++ {
+				output[c*rows+r] = input[r*cols+c]
+			}
+		}
+	})
+}
+
+// parallelMultiply multiplies data by twiddles element-wise, split across workers
+func parallelMultiply(workers int, data, twiddles []complex128) {
+	ParallelFor(workers, len(data), func(_, start, end int) {
+		pointwiseMultiply(data[start:end], data[start:end], twiddles[start:end])
+	})
+}
@@ -0,0 +1,49 @@
+package algorithm
+
+// parallelInplace32 is the complex64 version of parallelInplace
+// It runs fft over the fft.Len()-sized chunks of buffer, split
+// across workers. Each worker borrows the matching part of idle as scratch,
+// so it reports false without doing anything if one FFT needs more scratch
+// than its own length.
+func parallelInplace32(workers int, fft FftInterface32, buffer, idle []complex64) bool {
+	n := fft.Len()
+	if fft.InplaceScratchLen() > n {
+		return false
+	}
+	ParallelFor(workers, len(buffer)/n, func(_, start, end int) {
+		fft.ProcessWithScratch(buffer[start*n:end*n], idle[start*n:end*n])
+	})
+	return true
+}
+
+// parallelOutOfPlace32 runs fft from the chunks of input into output, split
+// across workers. It reports false without doing anything if fft needs
+// out-of-place scratch, since there is none to share out.
+func parallelOutOfPlace32(workers int, fft FftInterface32, input, output []complex64) bool {
+	n := fft.Len()
+	if fft.OutOfPlaceScratchLen() > 0 {
+		return false
+	}
+	ParallelFor(workers, len(input)/n, func(_, start, end int) {
+		fft.ProcessOutOfPlace(input[start*n:end*n], output[start*n:end*n], nil)
+	})
+	return true
+}
+
+// parallelTranspose32 is transpose split across workers by input rows
+func parallelTranspose32(workers, rows, cols int, input, output []complex64) {
+	ParallelFor(workers, rows, func(_, start, end int) {
+		for r := start; r < end; r++ {
+			for c := 0; c < cols; c++ {
+				output[c*rows+r] = input[r*cols+c]
+			}
+		}
+	})
+}
+
+// parallelMultiply32 multiplies data by twiddles element-wise, split across workers
+func parallelMultiply32(workers int, data, twiddles []complex64) {
+	ParallelFor(workers, len(data), func(_, start, end int) {
+		pointwiseMultiply32(data[start:end], data[start:end], twiddles[start:end])
+	})
+}
@@ -0,0 +1,86 @@
+package algorithm
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+// TestWithWorkersMatchesSequential checks that the parallel passes compute
+// exactly what the single-goroutine versions do
+func TestWithWorkersMatchesSequential(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		testCases := []struct {
+			name     string
+			fft      FftInterface
+			parallel FftInterface
+		}{
+			{"Radix4/4096", NewRadix4(4096, dir), NewRadix4(4096, dir).WithWorkers(4)},
+			{"Radix4/8192", NewRadix4(8192, dir), NewRadix4(8192, dir).WithWorkers(3)},
+			{"Radix4/Dft", NewRadix4WithBase(3, NewDft(5, dir)), NewRadix4WithBase(3, NewDft(5, dir)).WithWorkers(4)},
+			{"Radix8/32768", NewRadix8(32768, dir), NewRadix8(32768, dir).WithWorkers(4)},
+			{"Radix8/Dft", NewRadix8WithBase(3, NewDft(5, dir)), NewRadix8WithBase(3, NewDft(5, dir)).WithWorkers(3)},
+			{"Stockham/4096", NewStockham(4096, dir), NewStockham(4096, dir).WithWorkers(4)},
+			{"Stockham/8192", NewStockham(8192, dir), NewStockham(8192, dir).WithWorkers(3)},
+			{"FourStep/Radix8", NewFourStep(NewRadix8(256, dir), NewRadix8(512, dir)),
+				NewFourStep(NewRadix8(256, dir), NewRadix8(512, dir)).WithWorkers(4)},
+			{"FourStep/Dft", NewFourStep(NewDft(40, dir), NewRadix4(1024, dir)),
+				NewFourStep(NewDft(40, dir), NewRadix4(1024, dir)).WithWorkers(3)},
+			{"MixedRadix/Radix4", NewMixedRadix(NewRadix4(64, dir), NewRadix4(32, dir)),
+				NewMixedRadix(NewRadix4(64, dir), NewRadix4(32, dir)).WithWorkers(4)},
+			{"MixedRadix/Bluestein", NewMixedRadix(NewBluestein(11, dir), NewButterfly13(dir)),
+				NewMixedRadix(NewBluestein(11, dir), NewButterfly13(dir)).WithWorkers(4)},
+			{"GoodThomas/Radix4", NewGoodThomas(NewRadix4(256, dir), NewButterfly17(dir)),
+				NewGoodThomas(NewRadix4(256, dir), NewButterfly17(dir)).WithWorkers(4)},
+			{"GoodThomas/Bluestein", NewGoodThomas(NewBluestein(7, dir), NewBluestein(9, dir)),
+				NewGoodThomas(NewBluestein(7, dir), NewBluestein(9, dir)).WithWorkers(2)},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				n := tc.fft.Len()
+				if tc.parallel.InplaceScratchLen() != tc.fft.InplaceScratchLen() {
+					t.Fatalf("scratch length changed: got %d, want %d", tc.parallel.InplaceScratchLen(), tc.fft.InplaceScratchLen())
+				}
+
+				// Two transforms back to back
+				input := make([]complex128, 2*n)
+				for i := range input {
+					input[i] = complex(float64(i%13)-6, float64(i%7)*0.25)
+				}
+
+				expected := make([]complex128, len(input))
+				copy(expected, input)
+				tc.fft.ProcessWithScratch(expected, make([]complex128, tc.fft.InplaceScratchLen()))
+
+				got := make([]complex128, len(input))
+				copy(got, input)
+				tc.parallel.ProcessWithScratch(got, make([]complex128, tc.parallel.InplaceScratchLen()))
+
+				for i := range got {
+					if cmplx.Abs(got[i]-expected[i]) > 1e-9 {
+						t.Fatalf("[%d] got %v, want %v", i, got[i], expected[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestParallelForCoversRange(t *testing.T) {
+	for _, tc := range []struct{ workers, count int }{{1, 10}, {4, 10}, {16, 5}, {3, 0}, {8, 1000}} {
+		seen := make([]int, tc.count)
+		ParallelFor(tc.workers, tc.count, func(w, start, end int) {
+			if w < 0 || w >= tc.workers {
+				t.Errorf("worker index %d out of range", w)
+			}
+			for i := start; i < end; i++ {
+				seen[i]++
+			}
+		})
+		for i, n := range seen {
+			if n != 1 {
+				t.Errorf("workers=%d count=%d: index %d visited %d times", tc.workers, tc.count, i, n)
+			}
+		}
+	}
+}
@@ -1,10 +1,32 @@
 package algorithm
 
 import (
+	"fmt"
 	"math/cmplx"
 	"testing"
 )
 
+// newPrimeButterfly returns the hardcoded butterfly for one of the primes 11-31
+func newPrimeButterfly(n int, direction Direction) FftInterface {
+	switch n {
+	case 11:
+		return NewButterfly11(direction)
+	case 13:
+		return NewButterfly13(direction)
+	case 17:
+		return NewButterfly17(direction)
+	case 19:
+		return NewButterfly19(direction)
+	case 23:
+		return NewButterfly23(direction)
+	case 29:
+		return NewButterfly29(direction)
+	case 31:
+		return NewButterfly31(direction)
+	}
+	panic(fmt.Sprintf("no prime butterfly of size %d", n))
+}
+
 // TestPrimeButterflies tests all prime-sized butterflies
 func TestPrimeButterflies(t *testing.T) {
 	primes := []int{3, 5, 7, 11, 13, 17, 19, 23, 29, 31}
@@ -160,3 +182,50 @@ func TestPrimeButterfliesRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+// TestPrimeButterfliesMatchDft checks the symmetric-pair butterflies against
+// Dft in both directions, in place and out of place, on several chunks
+func TestPrimeButterfliesMatchDft(t *testing.T) {
+	for _, n := range []int{11, 13, 17, 19, 23, 29, 31} {
+		for _, dir := range []Direction{Forward, Inverse} {
+			t.Run(fmt.Sprintf("Size%d/Dir%d", n, dir), func(t *testing.T) {
+				bf := newPrimeButterfly(n, dir)
+				input := make([]complex128, 3*n)
+				for i := range input {
+					input[i] = complex(float64(i%7)-2.5, float64(i%4)*0.75)
+				}
+
+				expected := make([]complex128, len(input))
+				NewDft(n, dir).ProcessImmutable(input, expected, nil)
+
+				inplace := append([]complex128(nil), input...)
+				bf.ProcessWithScratch(inplace, nil)
+				outOfPlace := make([]complex128, len(input))
+				bf.ProcessOutOfPlace(append([]complex128(nil), input...), outOfPlace, nil)
+
+				for i := range expected {
+					if cmplx.Abs(inplace[i]-expected[i]) > 1e-12 || cmplx.Abs(outOfPlace[i]-expected[i]) > 1e-12 {
+						t.Fatalf("[%d] got %v in place and %v out of place, want %v", i, inplace[i], outOfPlace[i], expected[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkPrimeButterflies compares the hardcoded prime butterflies with Dft
+func BenchmarkPrimeButterflies(b *testing.B) {
+	for _, n := range []int{11, 13, 17, 19, 23, 29, 31} {
+		algorithms := []FftInterface{newPrimeButterfly(n, Forward), NewDft(n, Forward)}
+		for _, fft := range algorithms {
+			b.Run(fmt.Sprintf("%T/Size%d", fft, n), func(b *testing.B) {
+				buffer := make([]complex128, 64*n)
+				scratch := make([]complex128, fft.InplaceScratchLen())
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					fft.ProcessWithScratch(buffer, scratch)
+				}
+			})
+		}
+	}
+}
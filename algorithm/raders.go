@@ -14,6 +14,7 @@ type Raders struct {
 	primitiveRootInv     int
 	inplaceScratchLen    int
 	outofplaceScratchLen int
+	scale                complex128 // Output scale, folded into innerFftData
 }
 
 // NewRaders creates a Rader's algorithm instance for a prime size
@@ -60,8 +61,12 @@ func NewRaders(innerFft FftInterface) *Raders {
 	innerFft.ProcessWithScratch(innerFftData, innerScratch)
 
 	// Calculate scratch requirements
+	// Out-of-place, the inner FFTs borrow whichever of input/output is idle
 	inplaceScratch := innerLen + innerFft.InplaceScratchLen()
-	outofplaceScratch := innerFft.InplaceScratchLen()
+	outofplaceScratch := 0
+	if innerFft.InplaceScratchLen() > innerLen {
+		outofplaceScratch = innerFft.InplaceScratchLen()
+	}
 
 	return &Raders{
 		length:               length,
@@ -72,14 +77,33 @@ func NewRaders(innerFft FftInterface) *Raders {
 		primitiveRootInv:     gInv,
 		inplaceScratchLen:    inplaceScratch,
 		outofplaceScratchLen: outofplaceScratch,
+		scale:                1,
+	}
+}
+
+// WithScale returns a copy of r whose output is multiplied by scale
+// The factor is folded into the convolution kernel; only the DC bin and
+// the first element's contribution need an extra multiply.
+func (r *Raders) WithScale(scale float64) *Raders {
+	s := complex(scale, 0)
+	scaled := *r
+	scaled.scale = r.scale * s
+	scaled.innerFftData = make([]complex128, len(r.innerFftData))
+	for i, v := range r.innerFftData {
+		scaled.innerFftData[i] = v * s
 	}
+	return &scaled
 }
 
-func (r *Raders) Len() int               { return r.length }
-func (r *Raders) Direction() Direction   { return r.direction }
-func (r *Raders) InplaceScratchLen() int { return r.inplaceScratchLen }
+func (r *Raders) Len() int                  { return r.length }
+func (r *Raders) Direction() Direction      { return r.direction }
+func (r *Raders) InplaceScratchLen() int    { return r.inplaceScratchLen }
+func (r *Raders) OutOfPlaceScratchLen() int { return r.outofplaceScratchLen }
+func (r *Raders) ImmutableScratchLen() int  { return r.inplaceScratchLen }
 
 func (r *Raders) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), r.Len(), len(scratch), r.InplaceScratchLen())
+
 	// Process each chunk of size r.length
 	for i := 0; i < len(buffer); i += r.length {
 		chunk := buffer[i : i+r.length]
@@ -108,7 +132,7 @@ func (r *Raders) processOne(buffer, scratch []complex128) {
 	r.innerFft.ProcessWithScratch(innerScratch, extraScratch)
 
 	// innerScratch[0] is sum of buffer[1:], add buffer[0] for DC component
-	buffer[0] = first + innerScratch[0]
+	buffer[0] = (first + innerScratch[0]) * r.scale
 
 	// Multiply with precomputed data and conjugate (sets up for inverse FFT)
 	for i := range innerScratch {
@@ -116,7 +140,7 @@ func (r *Raders) processOne(buffer, scratch []complex128) {
 	}
 
 	// Add first element (conjugated) to DC bin
-	innerScratch[0] = innerScratch[0] + complexConj(first)
+	innerScratch[0] = innerScratch[0] + complexConj(first)*r.scale
 
 	// Second FFT (effectively inverse due to conjugation)
 	r.innerFft.ProcessWithScratch(innerScratch, extraScratch)
@@ -130,6 +154,99 @@ func (r *Raders) processOne(buffer, scratch []complex128) {
 	}
 }
 
+func (r *Raders) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftOutOfPlace(input[i:i+r.length], output[i:i+r.length], scratch)
+	}
+}
+
+func (r *Raders) performFftOutOfPlace(input, output, scratch []complex128) {
+	// Same steps as processOne, with output[1:] and input[1:] taking turns
+	// as the inner FFT buffer and its scratch
+	innerLen := r.length - 1
+	first := input[0]
+	innerInput := input[1:]
+	innerOutput := output[1:]
+
+	// Reorder input[1:] into output[1:] using primitive root
+	idx := 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRoot) % r.length
+		innerOutput[i] = input[idx]
+	}
+
+	// First inner FFT
+	innerScratch := scratch
+	if len(scratch) < r.innerFft.InplaceScratchLen() {
+		innerScratch = innerInput
+	}
+	r.innerFft.ProcessWithScratch(innerOutput, innerScratch)
+
+	// innerOutput[0] is sum of input[1:], add input[0] for DC component
+	output[0] = (first + innerOutput[0]) * r.scale
+
+	// Multiply with precomputed data and conjugate, moving back into input
+	for i := range innerOutput {
+		innerInput[i] = complexConj(innerOutput[i] * r.innerFftData[i])
+	}
+	innerInput[0] = innerInput[0] + complexConj(first)*r.scale
+
+	// Second FFT (effectively inverse due to conjugation)
+	if len(scratch) < r.innerFft.InplaceScratchLen() {
+		innerScratch = innerOutput
+	}
+	r.innerFft.ProcessWithScratch(innerInput, innerScratch)
+
+	// Reorder into output using inverse primitive root
+	idx = 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRootInv) % r.length
+		output[idx] = complexConj(innerInput[i])
+	}
+}
+
+func (r *Raders) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftImmutable(input[i:i+r.length], output[i:i+r.length], scratch[:r.inplaceScratchLen])
+	}
+}
+
+func (r *Raders) performFftImmutable(input, output, scratch []complex128) {
+	// Same steps as processOne, with scratch standing in for the input
+	// during the second inner FFT
+	innerLen := r.length - 1
+	innerOutput := output[1:]
+	workScratch := scratch[:innerLen]
+	extraScratch := scratch[innerLen:]
+
+	idx := 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRoot) % r.length
+		innerOutput[i] = input[idx]
+	}
+
+	r.innerFft.ProcessWithScratch(innerOutput, extraScratch)
+
+	output[0] = (input[0] + innerOutput[0]) * r.scale
+
+	for i := range innerOutput {
+		workScratch[i] = complexConj(innerOutput[i] * r.innerFftData[i])
+	}
+	workScratch[0] = workScratch[0] + complexConj(input[0])*r.scale
+
+	r.innerFft.ProcessWithScratch(workScratch, extraScratch)
+
+	idx = 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRootInv) % r.length
+		output[idx] = complexConj(workScratch[i])
+	}
+}
+
 // Helper functions for Rader's algorithm
 
 // isPrime checks if n is prime using trial division
@@ -0,0 +1,246 @@
+package algorithm
+
+import "math"
+
+// Raders32 implements Rader's Algorithm for prime-sized complex64 FFTs
+// This converts a prime-size p FFT into a size p-1 FFT via convolution
+type Raders32 struct {
+	length               int
+	direction            Direction
+	innerFft             FftInterface32 // FFT of size p-1
+	innerFftData         []complex64    // Precomputed FFT for convolution
+	primitiveRoot        int
+	primitiveRootInv     int
+	inplaceScratchLen    int
+	outofplaceScratchLen int
+	scale                complex64 // Output scale, folded into innerFftData
+}
+
+// NewRaders32 creates a complex64 Rader's algorithm instance for a prime size
+// innerFft must have length p-1 where p is prime
+func NewRaders32(innerFft FftInterface32) *Raders32 {
+	innerLen := innerFft.Len()
+	length := innerLen + 1
+
+	// Verify p is prime
+	if !isPrime(length) {
+		panic("Rader's algorithm requires prime size")
+	}
+
+	direction := innerFft.Direction()
+
+	// Find primitive root g mod p
+	g := findPrimitiveRoot(length)
+
+	// Find modular inverse of g mod p
+	gInv := modInverse(g, length)
+
+	// Precompute the convolution kernel
+	// h[k] = (1/innerLen) * W^(g^(-k)) where W = exp(-2πi/p)
+	// Use primitive_root_inverse to iterate
+	scale := 1.0 / float64(innerLen)
+	innerFftInput := make([]complex64, innerLen)
+	twiddleIdx := 1
+	for i := range innerFftInput {
+		angle := -2.0 * math.Pi * float64(twiddleIdx) / float64(length)
+		if direction == Inverse {
+			angle = -angle
+		}
+		innerFftInput[i] = complex(float32(math.Cos(angle)*scale), float32(math.Sin(angle)*scale))
+
+		// Use inverse root to iterate
+		twiddleIdx = (twiddleIdx * gInv) % length
+	}
+
+	// FFT the kernel for convolution
+	innerFftData := make([]complex64, innerLen)
+	copy(innerFftData, innerFftInput)
+	innerScratch := make([]complex64, innerFft.InplaceScratchLen())
+	innerFft.ProcessWithScratch(innerFftData, innerScratch)
+
+	// Calculate scratch requirements
+	// Out-of-place, the inner FFTs borrow whichever of input/output is idle
+	inplaceScratch := innerLen + innerFft.InplaceScratchLen()
+	outofplaceScratch := 0
+	if innerFft.InplaceScratchLen() > innerLen {
+		outofplaceScratch = innerFft.InplaceScratchLen()
+	}
+
+	return &Raders32{
+		length:               length,
+		direction:            direction,
+		innerFft:             innerFft,
+		innerFftData:         innerFftData,
+		primitiveRoot:        g,
+		primitiveRootInv:     gInv,
+		inplaceScratchLen:    inplaceScratch,
+		outofplaceScratchLen: outofplaceScratch,
+		scale:                1,
+	}
+}
+
+// WithScale returns a copy of r whose output is multiplied by scale
+// The factor is folded into the convolution kernel; only the DC bin and
+// the first element's contribution need an extra multiply.
+func (r *Raders32) WithScale(scale float64) *Raders32 {
+	s := complex(float32(scale), 0)
+	scaled := *r
+	scaled.scale = r.scale * s
+	scaled.innerFftData = make([]complex64, len(r.innerFftData))
+	for i, v := range r.innerFftData {
+		scaled.innerFftData[i] = v * s
+	}
+	return &scaled
+}
+
+func (r *Raders32) Len() int                  { return r.length }
+func (r *Raders32) Direction() Direction      { return r.direction }
+func (r *Raders32) InplaceScratchLen() int    { return r.inplaceScratchLen }
+func (r *Raders32) OutOfPlaceScratchLen() int { return r.outofplaceScratchLen }
+func (r *Raders32) ImmutableScratchLen() int  { return r.inplaceScratchLen }
+
+func (r *Raders32) ProcessWithScratch(buffer, scratch []complex64) {
+	checkInplace(len(buffer), r.Len(), len(scratch), r.InplaceScratchLen())
+
+	// Process each chunk of size r.length
+	for i := 0; i < len(buffer); i += r.length {
+		chunk := buffer[i : i+r.length]
+		workScratch := scratch[:r.inplaceScratchLen]
+		r.processOne(chunk, workScratch)
+	}
+}
+
+func (r *Raders32) processOne(buffer, scratch []complex64) {
+	innerLen := r.length - 1
+	innerScratch := scratch[:innerLen]
+	extraScratch := scratch[innerLen:]
+
+	// Save first element
+	first := buffer[0]
+
+	// Reorder buffer[1:] into scratch using primitive root
+	// scratch[k] = buffer[g^k mod p]
+	idx := 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRoot) % r.length
+		innerScratch[i] = buffer[idx]
+	}
+
+	// First inner FFT
+	r.innerFft.ProcessWithScratch(innerScratch, extraScratch)
+
+	// innerScratch[0] is sum of buffer[1:], add buffer[0] for DC component
+	buffer[0] = (first + innerScratch[0]) * r.scale
+
+	// Multiply with precomputed data and conjugate (sets up for inverse FFT)
+	for i := range innerScratch {
+		innerScratch[i] = complexConj32(innerScratch[i] * r.innerFftData[i])
+	}
+
+	// Add first element (conjugated) to DC bin
+	innerScratch[0] = innerScratch[0] + complexConj32(first)*r.scale
+
+	// Second FFT (effectively inverse due to conjugation)
+	r.innerFft.ProcessWithScratch(innerScratch, extraScratch)
+
+	// Reorder output using inverse primitive root
+	// buffer[g^(-k) mod p] = conj(scratch[k])
+	idx = 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRootInv) % r.length
+		buffer[idx] = complexConj32(innerScratch[i])
+	}
+}
+
+func (r *Raders32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftOutOfPlace(input[i:i+r.length], output[i:i+r.length], scratch)
+	}
+}
+
+func (r *Raders32) performFftOutOfPlace(input, output, scratch []complex64) {
+	// Same steps as processOne, with output[1:] and input[1:] taking turns
+	// as the inner FFT buffer and its scratch
+	innerLen := r.length - 1
+	first := input[0]
+	innerInput := input[1:]
+	innerOutput := output[1:]
+
+	// Reorder input[1:] into output[1:] using primitive root
+	idx := 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRoot) % r.length
+		innerOutput[i] = input[idx]
+	}
+
+	// First inner FFT
+	innerScratch := scratch
+	if len(scratch) < r.innerFft.InplaceScratchLen() {
+		innerScratch = innerInput
+	}
+	r.innerFft.ProcessWithScratch(innerOutput, innerScratch)
+
+	// innerOutput[0] is sum of input[1:], add input[0] for DC component
+	output[0] = (first + innerOutput[0]) * r.scale
+
+	// Multiply with precomputed data and conjugate, moving back into input
+	for i := range innerOutput {
+		innerInput[i] = complexConj32(innerOutput[i] * r.innerFftData[i])
+	}
+	innerInput[0] = innerInput[0] + complexConj32(first)*r.scale
+
+	// Second FFT (effectively inverse due to conjugation)
+	if len(scratch) < r.innerFft.InplaceScratchLen() {
+		innerScratch = innerOutput
+	}
+	r.innerFft.ProcessWithScratch(innerInput, innerScratch)
+
+	// Reorder into output using inverse primitive root
+	idx = 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRootInv) % r.length
+		output[idx] = complexConj32(innerInput[i])
+	}
+}
+
+func (r *Raders32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftImmutable(input[i:i+r.length], output[i:i+r.length], scratch[:r.inplaceScratchLen])
+	}
+}
+
+func (r *Raders32) performFftImmutable(input, output, scratch []complex64) {
+	// Same steps as processOne, with scratch standing in for the input
+	// during the second inner FFT
+	innerLen := r.length - 1
+	innerOutput := output[1:]
+	workScratch := scratch[:innerLen]
+	extraScratch := scratch[innerLen:]
+
+	idx := 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRoot) % r.length
+		innerOutput[i] = input[idx]
+	}
+
+	r.innerFft.ProcessWithScratch(innerOutput, extraScratch)
+
+	output[0] = (input[0] + innerOutput[0]) * r.scale
+
+	for i := range innerOutput {
+		workScratch[i] = complexConj32(innerOutput[i] * r.innerFftData[i])
+	}
+	workScratch[0] = workScratch[0] + complexConj32(input[0])*r.scale
+
+	r.innerFft.ProcessWithScratch(workScratch, extraScratch)
+
+	idx = 1
+	for i := 0; i < innerLen; i++ {
+		idx = (idx * r.primitiveRootInv) % r.length
+		output[idx] = complexConj32(workScratch[i])
+	}
+}
@@ -4,12 +4,24 @@ import (
 	"math"
 )
 
-// FftInterface is a minimal interface for FFT algorithms
+// FftInterface is the interface implemented by every FFT algorithm
+//
+// ProcessOutOfPlace may use input as extra scratch space and leaves it in an
+// unspecified state. ProcessImmutable never modifies input. All three process
+// methods accept buffers whose length is a multiple of Len().
+//
+// Every exported process method panics with a description of the problem
+// when given a short buffer or scratch, or input and output of different
+// lengths, instead of indexing out of range.
 type FftInterface interface {
 	Len() int
 	Direction() Direction
 	InplaceScratchLen() int
+	OutOfPlaceScratchLen() int
+	ImmutableScratchLen() int
 	ProcessWithScratch(buffer, scratch []complex128)
+	ProcessOutOfPlace(input, output, scratch []complex128)
+	ProcessImmutable(input []complex128, output, scratch []complex128)
 }
 
 // Radix4 implements an FFT algorithm optimized for power-of-two sizes
@@ -22,6 +34,8 @@ type Radix4 struct {
 	direction         Direction
 	inplaceScratch    int
 	outofplaceScratch int
+	workers           int
+	scale             complex128 // Output scale, folded into the last cross-FFT layer
 }
 
 // NewRadix4 creates a new Radix4 FFT instance for the given power-of-two length
@@ -125,6 +139,7 @@ func NewRadix4WithBase(k int, baseFft FftInterface) *Radix4 {
 		direction:         direction,
 		inplaceScratch:    inplaceScratch,
 		outofplaceScratch: outofplaceScratch,
+		scale:             1,
 	}
 }
 
@@ -134,12 +149,42 @@ func (r *Radix4) InplaceScratchLen() int    { return r.inplaceScratch }
 func (r *Radix4) OutOfPlaceScratchLen() int { return r.outofplaceScratch }
 func (r *Radix4) ImmutableScratchLen() int  { return r.baseFft.InplaceScratchLen() }
 
+// WithWorkers returns a copy of r that splits the transpose, base FFTs and
+// cross-FFT layers of ProcessWithScratch and ProcessOutOfPlace across up to
+// workers goroutines. Every call then allocates, so this only pays off for
+// large lengths.
+func (r *Radix4) WithWorkers(workers int) *Radix4 {
+	parallel := *r
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of r whose output is multiplied by scale
+// The factor is folded into the twiddles of the last cross-FFT layer, so
+// it costs one multiply per column of that layer rather than a separate pass.
+func (r *Radix4) WithScale(scale float64) *Radix4 {
+	s := complex(scale, 0)
+	scaled := *r
+	scaled.scale = r.scale * s
+	scaled.twiddles = make([]complex128, len(r.twiddles))
+	copy(scaled.twiddles, r.twiddles)
+	if r.length > r.baseLen {
+		lastLayer := scaled.twiddles[len(scaled.twiddles)-(r.length/4)*3:]
+		for i := range lastLayer {
+			lastLayer[i] *= s
+		}
+	}
+	return &scaled
+}
+
 func (r *Radix4) Process(buffer []complex128) {
 	scratch := make([]complex128, r.InplaceScratchLen())
 	r.ProcessWithScratch(buffer, scratch)
 }
 
 func (r *Radix4) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), r.Len(), len(scratch), r.InplaceScratchLen())
+
 	for i := 0; i < len(buffer); i += r.length {
 		chunk := buffer[i : i+r.length]
 		selfScratch := scratch[:r.length]
@@ -149,6 +194,8 @@ func (r *Radix4) ProcessWithScratch(buffer, scratch []complex128) {
 }
 
 func (r *Radix4) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.OutOfPlaceScratchLen())
+
 	for i := 0; i < len(input); i += r.length {
 		inChunk := input[i : i+r.length]
 		outChunk := output[i : i+r.length]
@@ -157,6 +204,8 @@ func (r *Radix4) ProcessOutOfPlace(input, output, scratch []complex128) {
 }
 
 func (r *Radix4) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.ImmutableScratchLen())
+
 	for i := 0; i < len(input); i += r.length {
 		inChunk := input[i : i+r.length]
 		outChunk := output[i : i+r.length]
@@ -180,6 +229,11 @@ func (r *Radix4) performFftImmutable(input []complex128, output []complex128, sc
 }
 
 func (r *Radix4) performFftOutOfPlace(input []complex128, output []complex128, scratch []complex128) {
+	if r.workers > 1 {
+		r.performFftParallel(input, output, scratch)
+		return
+	}
+
 	// Copy data with bit-reversed transpose
 	if r.length == r.baseLen {
 		copy(output, input)
@@ -189,7 +243,7 @@ func (r *Radix4) performFftOutOfPlace(input []complex128, output []complex128, s
 
 	// Base-level FFTs
 	baseScratch := scratch
-	if len(scratch) == 0 {
+	if len(scratch) < r.baseFft.InplaceScratchLen() {
 		baseScratch = input
 	}
 	r.baseFft.ProcessWithScratch(output, baseScratch)
@@ -198,6 +252,64 @@ func (r *Radix4) performFftOutOfPlace(input []complex128, output []complex128, s
 	r.performCrossFfts(output)
 }
 
+// performFftParallel is performFftOutOfPlace with every step split across r.workers goroutines
+func (r *Radix4) performFftParallel(input, output, scratch []complex128) {
+	if r.length == r.baseLen {
+		copy(output, input)
+	} else {
+		parallelBitReversedTranspose4(r.workers, r.baseLen, input, output)
+	}
+
+	// The transposed input is idle, so each worker borrows its slice of it
+	if !parallelInplace(r.workers, r.baseFft, output, input) {
+		baseScratch := scratch
+		if len(scratch) < r.baseFft.InplaceScratchLen() {
+			baseScratch = input
+		}
+		r.baseFft.ProcessWithScratch(output, baseScratch)
+	}
+
+	const rowCount = 4
+	crossFftLen := r.baseLen
+	layerTwiddles := r.twiddles
+	butterfly4 := NewButterfly4(r.direction)
+
+	for crossFftLen < len(output) {
+		numColumns := crossFftLen
+		crossFftLen *= rowCount
+		numChunks := len(output) / crossFftLen
+
+		scale := complex128(1)
+		if crossFftLen == r.length {
+			scale = r.scale
+		}
+
+		if numChunks >= r.workers {
+			// Early layers: plenty of independent chunks to share out
+			ParallelFor(r.workers, numChunks, func(_, start, end int) {
+				for c := start; c < end; c++ {
+					data := output[c*crossFftLen : (c+1)*crossFftLen]
+					butterfly4Columns(data, layerTwiddles, numColumns, 0, numColumns, scale, butterfly4)
+				}
+			})
+		} else {
+			// Late layers: few chunks, so split the columns of each instead
+			for offset := 0; offset < len(output); offset += crossFftLen {
+				data := output[offset : offset+crossFftLen]
+				ParallelFor(r.workers, numColumns, func(_, start, end int) {
+					butterfly4Columns(data, layerTwiddles, numColumns, start, end, scale, butterfly4)
+				})
+			}
+		}
+
+		layerTwiddles = layerTwiddles[numColumns*(rowCount-1):]
+	}
+
+	if r.length == r.baseLen {
+		scaleBuffer(output, r.scale)
+	}
+}
+
 func (r *Radix4) performCrossFfts(output []complex128) {
 	const rowCount = 4
 	crossFftLen := r.baseLen
@@ -209,21 +321,46 @@ func (r *Radix4) performCrossFfts(output []complex128) {
 		crossFftLen *= rowCount
 
 		// Process each chunk
-		for offset := 0; offset < len(output); offset += crossFftLen {
-			data := output[offset : offset+crossFftLen]
-			butterfly4Stage(data, layerTwiddles, numColumns, butterfly4)
+		if crossFftLen == r.length {
+			// The last layer also applies the output scale
+			butterfly4Columns(output, layerTwiddles, numColumns, 0, numColumns, r.scale, butterfly4)
+		} else {
+			for offset := 0; offset < len(output); offset += crossFftLen {
+				data := output[offset : offset+crossFftLen]
+				butterfly4Stage(data, layerTwiddles, numColumns, butterfly4)
+			}
 		}
 
 		// Skip past twiddle factors used in this layer
 		twiddleOffset := numColumns * (rowCount - 1)
 		layerTwiddles = layerTwiddles[twiddleOffset:]
 	}
+
+	if r.length == r.baseLen {
+		// No cross-FFT layer to fold the scale into
+		scaleBuffer(output, r.scale)
+	}
 }
 
 // butterfly4Stage applies a radix-4 butterfly stage
 func butterfly4Stage(data []complex128, twiddles []complex128, numColumns int, butterfly4 *Butterfly4) {
+	butterfly4Columns(data, twiddles, numColumns, 0, numColumns, 1, butterfly4)
+}
+
+// butterfly4Columns applies the radix-4 butterflies of columns [start, end) of a stage
+// The first row is multiplied by scale; the other rows expect it in their twiddles.
+func butterfly4Columns(data []complex128, twiddles []complex128, numColumns, start, end int, scale complex128, butterfly4 *Butterfly4) {
+	if useAVX2 && end-start >= 2 {
+		// The kernel takes columns in pairs and leaves the first row's scale to us
+		scaleBuffer(data[start:end], scale)
+		scale = 1
+		pairsEnd := start + (end-start)&^1
+		radix4ColumnsAVX2(data, twiddles, numColumns, start, pairsEnd, butterfly4.direction)
+		start = pairsEnd
+	}
+
 	// Apply twiddle factors and perform radix-4 butterflies
-	for col := 0; col < numColumns; col++ {
+	for col := start; col < end; col++ {
 		// Get the four values for this column
 		idx0 := col
 		idx1 := col + numColumns
@@ -232,8 +369,12 @@ func butterfly4Stage(data []complex128, twiddles []complex128, numColumns int, b
 
 		// Load values and apply twiddle factors (first row doesn't need twiddles)
 		twIdx := col * 3
+		first := data[idx0]
+		if scale != 1 {
+			first *= scale
+		}
 		scratch := [4]complex128{
-			data[idx0],
+			first,
 			data[idx1] * twiddles[twIdx+0],
 			data[idx2] * twiddles[twIdx+1],
 			data[idx3] * twiddles[twIdx+2],
@@ -253,6 +394,18 @@ func butterfly4Stage(data []complex128, twiddles []complex128, numColumns int, b
 // bitReversedTranspose4 performs a bit-reversed transpose with divisor 4
 // This is a port of RustFFT's bitreversed_transpose::<T, 4>
 func bitReversedTranspose4(height int, input, output []complex128) {
+	bitReversedTranspose4Columns(height, input, output, 0, len(input)/height/4)
+}
+
+// parallelBitReversedTranspose4 is bitReversedTranspose4 split across workers by column groups
+func parallelBitReversedTranspose4(workers, height int, input, output []complex128) {
+	ParallelFor(workers, len(input)/height/4, func(_, start, end int) {
+		bitReversedTranspose4Columns(height, input, output, start, end)
+	})
+}
+
+// bitReversedTranspose4Columns transposes the groups of 4 columns [start, end)
+func bitReversedTranspose4Columns(height int, input, output []complex128, start, end int) {
 	const D = 4 // Divisor for bit reversal
 	width := len(input) / height
 
@@ -260,8 +413,6 @@ func bitReversedTranspose4(height int, input, output []complex128) {
 		panic("invalid dimensions for bitreversed_transpose")
 	}
 
-	stridedWidth := width / D
-
 	// Compute how many "digits" we need for base-D bit reversal
 	revDigits := 0
 	temp := width
@@ -273,7 +424,7 @@ func bitReversedTranspose4(height int, input, output []complex128) {
 		revDigits++
 	}
 
-	for x := 0; x < stridedWidth; x++ {
+	for x := start; x < end; x++ {
 		// Create forward and reversed indices
 		xFwd := [D]int{}
 		xRev := [D]int{}
@@ -0,0 +1,443 @@
+package algorithm
+
+import (
+	"math"
+)
+
+// FftInterface32 is the complex64 counterpart of FftInterface
+//
+// ProcessOutOfPlace may use input as extra scratch space and leaves it in an
+// unspecified state. ProcessImmutable never modifies input. All three process
+// methods accept buffers whose length is a multiple of Len().
+//
+// Every exported process method panics with a description of the problem
+// when given a short buffer or scratch, or input and output of different
+// lengths, instead of indexing out of range.
+type FftInterface32 interface {
+	Len() int
+	Direction() Direction
+	InplaceScratchLen() int
+	OutOfPlaceScratchLen() int
+	ImmutableScratchLen() int
+	ProcessWithScratch(buffer, scratch []complex64)
+	ProcessOutOfPlace(input, output, scratch []complex64)
+	ProcessImmutable(input []complex64, output, scratch []complex64)
+}
+
+// Radix4_32 implements the Radix4 algorithm for complex64
+// It uses a radix-4 decimation-in-frequency algorithm
+type Radix4_32 struct {
+	twiddles          []complex64
+	baseFft           FftInterface32
+	baseLen           int
+	length            int
+	direction         Direction
+	inplaceScratch    int
+	outofplaceScratch int
+	workers           int
+	scale             complex64 // Output scale, folded into the last cross-FFT layer
+}
+
+// NewRadix4_32 creates a new complex64 Radix4 FFT instance for the given power-of-two length
+func NewRadix4_32(length int, direction Direction) *Radix4_32 {
+	if !isPowerOfTwo(length) {
+		panic("Radix4_32 algorithm requires a power-of-two input size")
+	}
+
+	// Figure out which base length to use (match RustFFT's selection logic exactly)
+	exponent := trailingZeros(length)
+	var baseFft FftInterface32
+	var baseExponent int
+
+	switch exponent {
+	case 0:
+		// Length 1 - trivial case
+		baseExponent = 0
+		baseFft = &trivialFft32{direction: direction}
+	case 1:
+		baseExponent = 1
+		baseFft = NewButterfly2_32(direction)
+	case 2:
+		baseExponent = 2
+		baseFft = NewButterfly4_32(direction)
+	case 3:
+		baseExponent = 3
+		baseFft = NewButterfly8_32(direction)
+	default:
+		// For larger sizes, match RustFFT's choice:
+		// - Odd exponent: use Butterfly32_32 (base_exponent=5)
+		// - Even exponent: use Butterfly16_32 (base_exponent=4)
+		if exponent%2 == 1 {
+			baseExponent = 5
+			baseFft = NewButterfly32_32(direction)
+		} else {
+			baseExponent = 4
+			baseFft = NewButterfly16_32(direction)
+		}
+	}
+
+	k := (exponent - baseExponent) / 2
+	return NewRadix4WithBase32(k, baseFft)
+}
+
+// NewRadix4WithBase32 creates a complex64 Radix4 instance that computes FFTs of length 4^k * baseFft.Len()
+func NewRadix4WithBase32(k int, baseFft FftInterface32) *Radix4_32 {
+	baseLen := baseFft.Len()
+	length := baseLen * (1 << (k * 2))
+	direction := baseFft.Direction()
+
+	// Precompute twiddle factors
+	const rowCount = 4
+	crossFftLen := baseLen
+	twiddleFactors := make([]complex64, 0, length*2)
+
+	for crossFftLen < length {
+		numColumns := crossFftLen
+		crossFftLen *= rowCount
+
+		for i := 0; i < numColumns; i++ {
+			for k := 1; k < rowCount; k++ {
+				angle := 2.0 * math.Pi * float64(i*k) / float64(crossFftLen)
+				if direction == Forward {
+					angle = -angle
+				}
+				twiddle := complex(float32(math.Cos(angle)), float32(math.Sin(angle)))
+				twiddleFactors = append(twiddleFactors, twiddle)
+			}
+		}
+	}
+
+	baseInplaceScratch := baseFft.InplaceScratchLen()
+	inplaceScratch := crossFftLen
+	if baseInplaceScratch > crossFftLen {
+		inplaceScratch = crossFftLen + baseInplaceScratch
+	}
+
+	outofplaceScratch := 0
+	if baseInplaceScratch > length {
+		outofplaceScratch = baseInplaceScratch
+	}
+
+	return &Radix4_32{
+		twiddles:          twiddleFactors,
+		baseFft:           baseFft,
+		baseLen:           baseLen,
+		length:            length,
+		direction:         direction,
+		inplaceScratch:    inplaceScratch,
+		outofplaceScratch: outofplaceScratch,
+		scale:             1,
+	}
+}
+
+func (r *Radix4_32) Len() int                  { return r.length }
+func (r *Radix4_32) Direction() Direction      { return r.direction }
+func (r *Radix4_32) InplaceScratchLen() int    { return r.inplaceScratch }
+func (r *Radix4_32) OutOfPlaceScratchLen() int { return r.outofplaceScratch }
+func (r *Radix4_32) ImmutableScratchLen() int  { return r.baseFft.InplaceScratchLen() }
+
+// WithWorkers returns a copy of r that splits the transpose, base FFTs and
+// cross-FFT layers of ProcessWithScratch and ProcessOutOfPlace across up to
+// workers goroutines. Every call then allocates, so this only pays off for
+// large lengths.
+func (r *Radix4_32) WithWorkers(workers int) *Radix4_32 {
+	parallel := *r
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of r whose output is multiplied by scale
+// The factor is folded into the twiddles of the last cross-FFT layer, so
+// it costs one multiply per column of that layer rather than a separate pass.
+func (r *Radix4_32) WithScale(scale float64) *Radix4_32 {
+	s := complex(float32(scale), 0)
+	scaled := *r
+	scaled.scale = r.scale * s
+	scaled.twiddles = make([]complex64, len(r.twiddles))
+	copy(scaled.twiddles, r.twiddles)
+	if r.length > r.baseLen {
+		lastLayer := scaled.twiddles[len(scaled.twiddles)-(r.length/4)*3:]
+		for i := range lastLayer {
+			lastLayer[i] *= s
+		}
+	}
+	return &scaled
+}
+
+func (r *Radix4_32) Process(buffer []complex64) {
+	scratch := make([]complex64, r.InplaceScratchLen())
+	r.ProcessWithScratch(buffer, scratch)
+}
+
+func (r *Radix4_32) ProcessWithScratch(buffer, scratch []complex64) {
+	checkInplace(len(buffer), r.Len(), len(scratch), r.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += r.length {
+		chunk := buffer[i : i+r.length]
+		selfScratch := scratch[:r.length]
+		r.performFftOutOfPlace(chunk, selfScratch, scratch[r.length:])
+		copy(chunk, selfScratch)
+	}
+}
+
+func (r *Radix4_32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		inChunk := input[i : i+r.length]
+		outChunk := output[i : i+r.length]
+		r.performFftOutOfPlace(inChunk, outChunk, scratch)
+	}
+}
+
+func (r *Radix4_32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		inChunk := input[i : i+r.length]
+		outChunk := output[i : i+r.length]
+		r.performFftImmutable(inChunk, outChunk, scratch)
+	}
+}
+
+func (r *Radix4_32) performFftImmutable(input []complex64, output []complex64, scratch []complex64) {
+	// Copy data with bit-reversed transpose
+	if r.length == r.baseLen {
+		copy(output, input)
+	} else {
+		bitReversedTranspose4_32(r.baseLen, input, output)
+	}
+
+	// Base-level FFTs
+	r.baseFft.ProcessWithScratch(output, scratch)
+
+	// Cross FFTs
+	r.performCrossFfts(output)
+}
+
+func (r *Radix4_32) performFftOutOfPlace(input []complex64, output []complex64, scratch []complex64) {
+	if r.workers > 1 {
+		r.performFftParallel(input, output, scratch)
+		return
+	}
+
+	// Copy data with bit-reversed transpose
+	if r.length == r.baseLen {
+		copy(output, input)
+	} else {
+		bitReversedTranspose4_32(r.baseLen, input, output)
+	}
+
+	// Base-level FFTs
+	baseScratch := scratch
+	if len(scratch) < r.baseFft.InplaceScratchLen() {
+		baseScratch = input
+	}
+	r.baseFft.ProcessWithScratch(output, baseScratch)
+
+	// Cross FFTs
+	r.performCrossFfts(output)
+}
+
+// performFftParallel is performFftOutOfPlace with every step split across r.workers goroutines
+func (r *Radix4_32) performFftParallel(input, output, scratch []complex64) {
+	if r.length == r.baseLen {
+		copy(output, input)
+	} else {
+		parallelBitReversedTranspose4_32(r.workers, r.baseLen, input, output)
+	}
+
+	// The transposed input is idle, so each worker borrows its slice of it
+	if !parallelInplace32(r.workers, r.baseFft, output, input) {
+		baseScratch := scratch
+		if len(scratch) < r.baseFft.InplaceScratchLen() {
+			baseScratch = input
+		}
+		r.baseFft.ProcessWithScratch(output, baseScratch)
+	}
+
+	const rowCount = 4
+	crossFftLen := r.baseLen
+	layerTwiddles := r.twiddles
+	butterfly4 := NewButterfly4_32(r.direction)
+
+	for crossFftLen < len(output) {
+		numColumns := crossFftLen
+		crossFftLen *= rowCount
+		numChunks := len(output) / crossFftLen
+
+		scale := complex64(1)
+		if crossFftLen == r.length {
+			scale = r.scale
+		}
+
+		if numChunks >= r.workers {
+			// Early layers: plenty of independent chunks to share out
+			ParallelFor(r.workers, numChunks, func(_, start, end int) {
+				for c := start; c < end; c++ {
+					data := output[c*crossFftLen : (c+1)*crossFftLen]
+					butterfly4Columns32(data, layerTwiddles, numColumns, 0, numColumns, scale, butterfly4)
+				}
+			})
+		} else {
+			// Late layers: few chunks, so split the columns of each instead
+			for offset := 0; offset < len(output); offset += crossFftLen {
+				data := output[offset : offset+crossFftLen]
+				ParallelFor(r.workers, numColumns, func(_, start, end int) {
+					butterfly4Columns32(data, layerTwiddles, numColumns, start, end, scale, butterfly4)
+				})
+			}
+		}
+
+		layerTwiddles = layerTwiddles[numColumns*(rowCount-1):]
+	}
+
+	if r.length == r.baseLen {
+		scaleBuffer32(output, r.scale)
+	}
+}
+
+func (r *Radix4_32) performCrossFfts(output []complex64) {
+	const rowCount = 4
+	crossFftLen := r.baseLen
+	layerTwiddles := r.twiddles
+	butterfly4 := NewButterfly4_32(r.direction)
+
+	for crossFftLen < len(output) {
+		numColumns := crossFftLen
+		crossFftLen *= rowCount
+
+		// Process each chunk
+		if crossFftLen == r.length {
+			// The last layer also applies the output scale
+			butterfly4Columns32(output, layerTwiddles, numColumns, 0, numColumns, r.scale, butterfly4)
+		} else {
+			for offset := 0; offset < len(output); offset += crossFftLen {
+				data := output[offset : offset+crossFftLen]
+				butterfly4Stage32(data, layerTwiddles, numColumns, butterfly4)
+			}
+		}
+
+		// Skip past twiddle factors used in this layer
+		twiddleOffset := numColumns * (rowCount - 1)
+		layerTwiddles = layerTwiddles[twiddleOffset:]
+	}
+
+	if r.length == r.baseLen {
+		// No cross-FFT layer to fold the scale into
+		scaleBuffer32(output, r.scale)
+	}
+}
+
+// butterfly4Stage32 applies a radix-4 butterfly stage
+func butterfly4Stage32(data []complex64, twiddles []complex64, numColumns int, butterfly4 *Butterfly4_32) {
+	butterfly4Columns32(data, twiddles, numColumns, 0, numColumns, 1, butterfly4)
+}
+
+// butterfly4Columns32 applies the radix-4 butterflies of columns [start, end) of a stage
+// The first row is multiplied by scale; the other rows expect it in their twiddles.
+func butterfly4Columns32(data []complex64, twiddles []complex64, numColumns, start, end int, scale complex64, butterfly4 *Butterfly4_32) {
+	// Apply twiddle factors and perform radix-4 butterflies
+	for col := start; col < end; col++ {
+		// Get the four values for this column
+		idx0 := col
+		idx1 := col + numColumns
+		idx2 := col + 2*numColumns
+		idx3 := col + 3*numColumns
+
+		// Load values and apply twiddle factors (first row doesn't need twiddles)
+		twIdx := col * 3
+		first := data[idx0]
+		if scale != 1 {
+			first *= scale
+		}
+		scratch := [4]complex64{
+			first,
+			data[idx1] * twiddles[twIdx+0],
+			data[idx2] * twiddles[twIdx+1],
+			data[idx3] * twiddles[twIdx+2],
+		}
+
+		// Perform 4-point butterfly FFT on scratch array
+		butterfly4.performFftOutOfPlace(scratch[:], scratch[:])
+
+		// Store results back
+		data[idx0] = scratch[0]
+		data[idx1] = scratch[1]
+		data[idx2] = scratch[2]
+		data[idx3] = scratch[3]
+	}
+}
+
+// bitReversedTranspose4_32 performs a bit-reversed transpose with divisor 4
+// This is a port of RustFFT's bitreversed_transpose::<T, 4>
+func bitReversedTranspose4_32(height int, input, output []complex64) {
+	bitReversedTranspose4Columns32(height, input, output, 0, len(input)/height/4)
+}
+
+// parallelBitReversedTranspose4_32 is bitReversedTranspose4_32 split across workers by column groups
+func parallelBitReversedTranspose4_32(workers, height int, input, output []complex64) {
+	ParallelFor(workers, len(input)/height/4, func(_, start, end int) {
+		bitReversedTranspose4Columns32(height, input, output, start, end)
+	})
+}
+
+// bitReversedTranspose4Columns32 transposes the groups of 4 columns [start, end)
+func bitReversedTranspose4Columns32(height int, input, output []complex64, start, end int) {
+	const D = 4 // Divisor for bit reversal
+	width := len(input) / height
+
+	if len(input)%height != 0 || len(input) != len(output) {
+		panic("invalid dimensions for bitreversed_transpose")
+	}
+
+	// Compute how many "digits" we need for base-D bit reversal
+	revDigits := 0
+	temp := width
+	for temp > 1 {
+		if temp%D != 0 {
+			panic("width must be a power of D")
+		}
+		temp /= D
+		revDigits++
+	}
+
+	for x := start; x < end; x++ {
+		// Create forward and reversed indices
+		xFwd := [D]int{}
+		xRev := [D]int{}
+
+		for i := 0; i < D; i++ {
+			xFwd[i] = D*x + i
+			xRev[i] = reverseBitsBaseD(xFwd[i], revDigits, D)
+		}
+
+		// Transpose with bit-reversed columns
+		for y := 0; y < height; y++ {
+			for i := 0; i < D; i++ {
+				inputIndex := xFwd[i] + y*width
+				outputIndex := y + xRev[i]*height
+				output[outputIndex] = input[inputIndex]
+			}
+		}
+	}
+}
+
+// trivialFft32 is a trivial complex64 FFT for length 1 (does nothing)
+type trivialFft32 struct {
+	direction Direction
+}
+
+func (t *trivialFft32) Len() int                                       { return 1 }
+func (t *trivialFft32) Direction() Direction                           { return t.direction }
+func (t *trivialFft32) InplaceScratchLen() int                         { return 0 }
+func (t *trivialFft32) OutOfPlaceScratchLen() int                      { return 0 }
+func (t *trivialFft32) ImmutableScratchLen() int                       { return 0 }
+func (t *trivialFft32) Process(buffer []complex64)                     {}
+func (t *trivialFft32) ProcessWithScratch(buffer, scratch []complex64) {}
+func (t *trivialFft32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	copy(output, input)
+}
+func (t *trivialFft32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	copy(output, input)
+}
@@ -0,0 +1,352 @@
+package algorithm
+
+import "math"
+
+// Radix8 implements an FFT algorithm for power-of-two sizes
+// It has the same structure as Radix4, a digit-reversed transpose, base
+// butterflies, then cross-FFT layers, but its layers are radix-8, so a large
+// FFT makes a third fewer passes over memory.
+type Radix8 struct {
+	twiddles          []complex128
+	baseFft           FftInterface
+	baseLen           int
+	length            int
+	direction         Direction
+	inplaceScratch    int
+	outofplaceScratch int
+	workers           int
+	scale             complex128 // Output scale, folded into the last cross-FFT layer
+}
+
+// NewRadix8 creates a new Radix8 FFT instance for the given power-of-two length
+func NewRadix8(length int, direction Direction) *Radix8 {
+	if !isPowerOfTwo(length) {
+		panic("Radix8 algorithm requires a power-of-two input size")
+	}
+
+	// The base takes whatever the radix-8 layers leave over: 8, 16 or 32
+	exponent := trailingZeros(length)
+	var baseFft FftInterface
+	switch {
+	case exponent == 0:
+		baseFft = &trivialFft{direction: direction}
+	case exponent == 1:
+		baseFft = NewButterfly2(direction)
+	case exponent == 2:
+		baseFft = NewButterfly4(direction)
+	case exponent%3 == 0:
+		baseFft = NewButterfly8(direction)
+	case exponent%3 == 1:
+		baseFft = NewButterfly16(direction)
+	default:
+		baseFft = NewButterfly32(direction)
+	}
+
+	k := (exponent - trailingZeros(baseFft.Len())) / 3
+	return NewRadix8WithBase(k, baseFft)
+}
+
+// NewRadix8WithBase creates a Radix8 instance that computes FFTs of length 8^k * baseFft.Len()
+func NewRadix8WithBase(k int, baseFft FftInterface) *Radix8 {
+	baseLen := baseFft.Len()
+	length := baseLen << (k * 3)
+	direction := baseFft.Direction()
+
+	// Each layer stores its twiddles row by row, W^(col·row) for rows 1-7, so
+	// that neighbouring columns have neighbouring twiddles
+	const rowCount = 8
+	crossFftLen := baseLen
+	twiddleFactors := make([]complex128, 0, length)
+
+	for crossFftLen < length {
+		numColumns := crossFftLen
+		crossFftLen *= rowCount
+
+		for k := 1; k < rowCount; k++ {
+			for i := 0; i < numColumns; i++ {
+				angle := 2.0 * math.Pi * float64(i*k) / float64(crossFftLen)
+				if direction == Forward {
+					angle = -angle
+				}
+				twiddleFactors = append(twiddleFactors, complex(math.Cos(angle), math.Sin(angle)))
+			}
+		}
+	}
+
+	baseInplaceScratch := baseFft.InplaceScratchLen()
+	inplaceScratch := length
+	if baseInplaceScratch > length {
+		inplaceScratch = length + baseInplaceScratch
+	}
+
+	outofplaceScratch := 0
+	if baseInplaceScratch > length {
+		outofplaceScratch = baseInplaceScratch
+	}
+
+	return &Radix8{
+		twiddles:          twiddleFactors,
+		baseFft:           baseFft,
+		baseLen:           baseLen,
+		length:            length,
+		direction:         direction,
+		inplaceScratch:    inplaceScratch,
+		outofplaceScratch: outofplaceScratch,
+		scale:             1,
+	}
+}
+
+func (r *Radix8) Len() int                  { return r.length }
+func (r *Radix8) Direction() Direction      { return r.direction }
+func (r *Radix8) InplaceScratchLen() int    { return r.inplaceScratch }
+func (r *Radix8) OutOfPlaceScratchLen() int { return r.outofplaceScratch }
+func (r *Radix8) ImmutableScratchLen() int  { return r.baseFft.InplaceScratchLen() }
+
+// WithWorkers returns a copy of r that splits the transpose, base FFTs and
+// cross-FFT layers of ProcessWithScratch and ProcessOutOfPlace across up to
+// workers goroutines, as Radix4.WithWorkers does
+func (r *Radix8) WithWorkers(workers int) *Radix8 {
+	parallel := *r
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of r whose output is multiplied by scale
+// As in Radix4, the factor is folded into the last cross-FFT layer.
+func (r *Radix8) WithScale(scale float64) *Radix8 {
+	s := complex(scale, 0)
+	scaled := *r
+	scaled.scale = r.scale * s
+	scaled.twiddles = make([]complex128, len(r.twiddles))
+	copy(scaled.twiddles, r.twiddles)
+	if r.length > r.baseLen {
+		lastLayer := scaled.twiddles[len(scaled.twiddles)-(r.length/8)*7:]
+		for i := range lastLayer {
+			lastLayer[i] *= s
+		}
+	}
+	return &scaled
+}
+
+func (r *Radix8) Process(buffer []complex128) {
+	scratch := make([]complex128, r.InplaceScratchLen())
+	r.ProcessWithScratch(buffer, scratch)
+}
+
+func (r *Radix8) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), r.Len(), len(scratch), r.InplaceScratchLen())
+
+	for i := 0; i < len(buffer); i += r.length {
+		chunk := buffer[i : i+r.length]
+		selfScratch := scratch[:r.length]
+		r.performFftOutOfPlace(chunk, selfScratch, scratch[r.length:])
+		copy(chunk, selfScratch)
+	}
+}
+
+func (r *Radix8) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftOutOfPlace(input[i:i+r.length], output[i:i+r.length], scratch)
+	}
+}
+
+func (r *Radix8) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		inChunk := input[i : i+r.length]
+		outChunk := output[i : i+r.length]
+		r.transpose(inChunk, outChunk)
+		r.baseFft.ProcessWithScratch(outChunk, scratch)
+		r.performCrossFfts(outChunk)
+	}
+}
+
+// transpose copies input to output in the digit-reversed order the base FFTs expect
+func (r *Radix8) transpose(input, output []complex128) {
+	if r.length == r.baseLen {
+		copy(output, input)
+	} else {
+		bitReversedTranspose8Columns(r.baseLen, input, output, 0, len(input)/r.baseLen/8)
+	}
+}
+
+func (r *Radix8) performFftOutOfPlace(input []complex128, output []complex128, scratch []complex128) {
+	if r.workers > 1 {
+		r.performFftParallel(input, output, scratch)
+		return
+	}
+
+	r.transpose(input, output)
+
+	// Base-level FFTs
+	baseScratch := scratch
+	if len(scratch) < r.baseFft.InplaceScratchLen() {
+		baseScratch = input
+	}
+	r.baseFft.ProcessWithScratch(output, baseScratch)
+
+	r.performCrossFfts(output)
+}
+
+// performFftParallel is performFftOutOfPlace with every step split across r.workers goroutines
+func (r *Radix8) performFftParallel(input, output, scratch []complex128) {
+	if r.length == r.baseLen {
+		copy(output, input)
+	} else {
+		ParallelFor(r.workers, len(input)/r.baseLen/8, func(_, start, end int) {
+			bitReversedTranspose8Columns(r.baseLen, input, output, start, end)
+		})
+	}
+
+	// The transposed input is idle, so each worker borrows its slice of it
+	if !parallelInplace(r.workers, r.baseFft, output, input) {
+		baseScratch := scratch
+		if len(scratch) < r.baseFft.InplaceScratchLen() {
+			baseScratch = input
+		}
+		r.baseFft.ProcessWithScratch(output, baseScratch)
+	}
+
+	r.forEachLayer(func(layerLen, numColumns int, twiddles []complex128, scale complex128) {
+		if numChunks := len(output) / layerLen; numChunks >= r.workers {
+			// Early layers: plenty of independent chunks to share out
+			ParallelFor(r.workers, numChunks, func(_, start, end int) {
+				for c := start; c < end; c++ {
+					data := output[c*layerLen : (c+1)*layerLen]
+					butterfly8Columns(data, twiddles, numColumns, 0, numColumns, scale, r.direction)
+				}
+			})
+			return
+		}
+		// Late layers: few chunks, so split the columns of each instead
+		for offset := 0; offset < len(output); offset += layerLen {
+			data := output[offset : offset+layerLen]
+			ParallelFor(r.workers, numColumns, func(_, start, end int) {
+				butterfly8Columns(data, twiddles, numColumns, start, end, scale, r.direction)
+			})
+		}
+	})
+}
+
+func (r *Radix8) performCrossFfts(output []complex128) {
+	r.forEachLayer(func(layerLen, numColumns int, twiddles []complex128, scale complex128) {
+		for offset := 0; offset < len(output); offset += layerLen {
+			data := output[offset : offset+layerLen]
+			butterfly8Columns(data, twiddles, numColumns, 0, numColumns, scale, r.direction)
+		}
+	})
+
+	if r.length == r.baseLen {
+		// No cross-FFT layer to fold the scale into
+		scaleBuffer(output, r.scale)
+	}
+}
+
+// forEachLayer calls fn for each cross-FFT layer with its chunk length,
+// column count, twiddles and the scale of its first row
+func (r *Radix8) forEachLayer(fn func(layerLen, numColumns int, twiddles []complex128, scale complex128)) {
+	const rowCount = 8
+	crossFftLen := r.baseLen
+	layerTwiddles := r.twiddles
+
+	for crossFftLen < r.length {
+		numColumns := crossFftLen
+		crossFftLen *= rowCount
+
+		// The last layer also applies the output scale
+		scale := complex128(1)
+		if crossFftLen == r.length {
+			scale = r.scale
+		}
+		fn(crossFftLen, numColumns, layerTwiddles, scale)
+
+		layerTwiddles = layerTwiddles[numColumns*(rowCount-1):]
+	}
+}
+
+// butterfly8Columns applies the radix-8 butterflies of columns [start, end) of a layer
+// Row r of column col is multiplied by twiddles[(r-1)*numColumns+col] first.
+// The first row is multiplied by scale; the other rows expect it in their twiddles.
+func butterfly8Columns(data, twiddles []complex128, numColumns, start, end int, scale complex128, direction Direction) {
+	if useAVX2 && end-start >= 2 {
+		// The kernel takes columns in pairs and leaves the first row's scale to us
+		scaleBuffer(data[start:end], scale)
+		scale = 1
+		pairsEnd := start + (end-start)&^1
+		radix8ColumnsAVX2(data, twiddles, numColumns, start, pairsEnd, direction)
+		start = pairsEnd
+	}
+
+	root2 := math.Sqrt(0.5)
+	rows := [8][]complex128{}
+	for i := range rows {
+		rows[i] = data[i*numColumns : (i+1)*numColumns]
+	}
+	tw := [7][]complex128{}
+	for i := range tw {
+		tw[i] = twiddles[i*numColumns : (i+1)*numColumns]
+	}
+
+	for col := start; col < end; col++ {
+		x0 := rows[0][col]
+		if scale != 1 {
+			x0 *= scale
+		}
+		x1 := rows[1][col] * tw[0][col]
+		x2 := rows[2][col] * tw[1][col]
+		x3 := rows[3][col] * tw[2][col]
+		x4 := rows[4][col] * tw[3][col]
+		x5 := rows[5][col] * tw[4][col]
+		x6 := rows[6][col] * tw[5][col]
+		x7 := rows[7][col] * tw[6][col]
+
+		// 4-point FFTs of the even and odd rows
+		e0, e1 := x0+x4, x0-x4
+		e2, e3 := x2+x6, rotate90(x2-x6, direction)
+		e0, e2, e1, e3 = e0+e2, e0-e2, e1+e3, e1-e3
+		o0, o1 := x1+x5, x1-x5
+		o2, o3 := x3+x7, rotate90(x3-x7, direction)
+		o0, o2, o1, o3 = o0+o2, o0-o2, o1+o3, o1-o3
+
+		// Twiddles W8^1, W8^2 and W8^3 of the odd half
+		r1 := rotate90(o1, direction)
+		o1 = complex(root2*(real(o1)+real(r1)), root2*(imag(o1)+imag(r1)))
+		o2 = rotate90(o2, direction)
+		r3 := rotate90(o3, direction)
+		o3 = complex(root2*(real(r3)-real(o3)), root2*(imag(r3)-imag(o3)))
+
+		rows[0][col], rows[4][col] = e0+o0, e0-o0
+		rows[1][col], rows[5][col] = e1+o1, e1-o1
+		rows[2][col], rows[6][col] = e2+o2, e2-o2
+		rows[3][col], rows[7][col] = e3+o3, e3-o3
+	}
+}
+
+// bitReversedTranspose8Columns is bitReversedTranspose4Columns with divisor 8,
+// for the groups of 8 columns [start, end)
+func bitReversedTranspose8Columns(height int, input, output []complex128, start, end int) {
+	const D = 8
+	width := len(input) / height
+
+	revDigits := 0
+	for temp := width; temp > 1; temp /= D {
+		revDigits++
+	}
+
+	for x := start; x < end; x++ {
+		var xFwd, xRev [D]int
+		for i := 0; i < D; i++ {
+			xFwd[i] = D*x + i
+			xRev[i] = reverseBitsBaseD(xFwd[i], revDigits, D)
+		}
+
+		for y := 0; y < height; y++ {
+			for i := 0; i < D; i++ {
+				output[y+xRev[i]*height] = input[xFwd[i]+y*width]
+			}
+		}
+	}
+}
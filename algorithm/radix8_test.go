@@ -0,0 +1,51 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// checkMatchesDft compares fft with a DFT of the same length on two chunks
+func checkMatchesDft(t *testing.T, fft FftInterface) {
+	t.Helper()
+	n := fft.Len()
+	input := make([]complex128, 2*n)
+	for i := range input {
+		input[i] = complex(math.Sin(float64(i)*0.7), math.Cos(float64(i)*0.3))
+	}
+
+	expected := make([]complex128, len(input))
+	NewDft(n, fft.Direction()).ProcessImmutable(input, expected, nil)
+
+	got := append([]complex128(nil), input...)
+	fft.ProcessWithScratch(got, make([]complex128, fft.InplaceScratchLen()))
+	tolerance := 1e-12 * float64(n)
+	for i := range got {
+		if err := cmplx.Abs(got[i] - expected[i]); err > tolerance {
+			t.Fatalf("[%d] got %v, want %v, err %g", i, got[i], expected[i], err)
+		}
+	}
+}
+
+func TestRadix8MatchesDft(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		// Every base: trivial, 2, 4, 8, 16 and 32
+		for exponent := 0; exponent <= 13; exponent++ {
+			n := 1 << exponent
+			t.Run(fmt.Sprintf("Size%d/Dir%d", n, dir), func(t *testing.T) {
+				checkMatchesDft(t, NewRadix8(n, dir))
+			})
+		}
+	}
+}
+
+func TestRadix8WithBase(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		t.Run(fmt.Sprintf("Dir%d", dir), func(t *testing.T) {
+			checkMatchesDft(t, NewRadix8WithBase(2, NewButterfly3(dir)))
+			checkMatchesDft(t, NewRadix8WithBase(1, NewDft(5, dir)))
+		})
+	}
+}
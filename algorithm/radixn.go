@@ -23,15 +23,17 @@ type TransposeFactor struct {
 // RadixN implements multi-factor FFT decomposition
 // For sizes like 24 = 2³×3, 60 = 2²×3×5, 120 = 2³×3×5
 type RadixN struct {
-	length           int
-	direction        Direction
-	baseFft          FftInterface
-	baseLen          int
-	factors          []RadixFactor     // Original factors
-	transposeFactors []TransposeFactor // Collapsed for transpose
-	butterflies      []FftInterface    // Butterfly for each factor
-	twiddles         []complex128      // All twiddle factors
-	inplaceScratch   int
+	length            int
+	direction         Direction
+	baseFft           FftInterface
+	baseLen           int
+	factors           []RadixFactor     // Original factors
+	transposeFactors  []TransposeFactor // Collapsed for transpose
+	butterflies       []FftInterface    // Butterfly for each factor
+	twiddles          []complex128      // All twiddle factors
+	inplaceScratch    int
+	outofplaceScratch int
+	scale             complex128 // Output scale, folded into the last cross-FFT layer
 }
 
 // NewRadixN creates a RadixN FFT instance
@@ -118,46 +120,106 @@ func NewRadixN(factors []RadixFactor, baseFft FftInterface) *RadixN {
 		inplaceScratch = length + baseScratch
 	}
 
+	outofplaceScratch := 0
+	if baseScratch > length {
+		outofplaceScratch = baseScratch
+	}
+
 	return &RadixN{
-		length:           length,
-		direction:        direction,
-		baseFft:          baseFft,
-		baseLen:          baseLen,
-		factors:          factors,
-		transposeFactors: transposeFactors,
-		butterflies:      butterflies,
-		twiddles:         twiddles,
-		inplaceScratch:   inplaceScratch,
+		length:            length,
+		direction:         direction,
+		baseFft:           baseFft,
+		baseLen:           baseLen,
+		factors:           factors,
+		transposeFactors:  transposeFactors,
+		butterflies:       butterflies,
+		twiddles:          twiddles,
+		inplaceScratch:    inplaceScratch,
+		outofplaceScratch: outofplaceScratch,
+		scale:             1,
 	}
 }
 
-func (r *RadixN) Len() int               { return r.length }
-func (r *RadixN) Direction() Direction   { return r.direction }
-func (r *RadixN) InplaceScratchLen() int { return r.inplaceScratch }
+// WithScale returns a copy of r whose output is multiplied by scale
+// As in Radix4, the factor is folded into the last cross-FFT layer.
+func (r *RadixN) WithScale(scale float64) *RadixN {
+	s := complex(scale, 0)
+	scaled := *r
+	scaled.scale = r.scale * s
+	scaled.twiddles = make([]complex128, len(r.twiddles))
+	copy(scaled.twiddles, r.twiddles)
+	if len(r.factors) > 0 {
+		radix := int(r.factors[len(r.factors)-1])
+		lastLayer := scaled.twiddles[len(scaled.twiddles)-(r.length/radix)*(radix-1):]
+		for i := range lastLayer {
+			lastLayer[i] *= s
+		}
+	}
+	return &scaled
+}
+
+func (r *RadixN) Len() int                  { return r.length }
+func (r *RadixN) Direction() Direction      { return r.direction }
+func (r *RadixN) InplaceScratchLen() int    { return r.inplaceScratch }
+func (r *RadixN) OutOfPlaceScratchLen() int { return r.outofplaceScratch }
+func (r *RadixN) ImmutableScratchLen() int  { return r.baseFft.InplaceScratchLen() }
 
 func (r *RadixN) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), r.Len(), len(scratch), r.InplaceScratchLen())
+
 	// Process each chunk
 	for i := 0; i < len(buffer); i += r.length {
 		chunk := buffer[i : i+r.length]
-		workScratch := scratch[:r.inplaceScratch]
-		r.processOne(chunk, workScratch)
+		selfScratch := scratch[:r.length]
+		r.performFftOutOfPlace(chunk, selfScratch, scratch[r.length:])
+		copy(chunk, selfScratch)
 	}
 }
 
-func (r *RadixN) processOne(buffer, scratch []complex128) {
-	output := scratch[:r.length]
-	innerScratch := make([]complex128, r.baseFft.InplaceScratchLen())
-	if len(scratch) > r.length {
-		innerScratch = scratch[r.length:]
+func (r *RadixN) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftOutOfPlace(input[i:i+r.length], output[i:i+r.length], scratch)
 	}
+}
+
+func (r *RadixN) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftImmutable(input[i:i+r.length], output[i:i+r.length], scratch)
+	}
+}
 
+func (r *RadixN) performFftImmutable(input, output, scratch []complex128) {
 	// Step 1: Factor transpose (reorders data based on factors)
-	factorTranspose(r.baseLen, buffer, output, r.transposeFactors)
+	factorTranspose(r.baseLen, input, output, r.transposeFactors)
 
 	// Step 2: Base FFTs
-	r.baseFft.ProcessWithScratch(output, innerScratch)
+	r.baseFft.ProcessWithScratch(output, scratch)
+
+	// Step 3: Cross-FFTs
+	r.performCrossFfts(output)
+}
+
+func (r *RadixN) performFftOutOfPlace(input, output, scratch []complex128) {
+	// Step 1: Factor transpose (reorders data based on factors)
+	factorTranspose(r.baseLen, input, output, r.transposeFactors)
+
+	// Step 2: Base FFTs, borrowing the input as scratch when none was provided
+	baseScratch := scratch
+	if len(scratch) < r.baseFft.InplaceScratchLen() {
+		baseScratch = input
+	}
+	r.baseFft.ProcessWithScratch(output, baseScratch)
+
+	// Step 3: Cross-FFTs
+	r.performCrossFfts(output)
+}
 
-	// Step 3: Cross-FFTs with twiddles for each factor layer
+// performCrossFfts applies the cross-FFT butterflies with twiddles for each factor layer
+func (r *RadixN) performCrossFfts(output []complex128) {
 	crossFftLen := r.baseLen
 	twiddleOffset := 0
 
@@ -169,16 +231,24 @@ func (r *RadixN) processOne(buffer, scratch []complex128) {
 		// Apply cross-FFT butterflies on chunks
 		layerTwiddles := r.twiddles[twiddleOffset : twiddleOffset+crossFftColumns*(radix-1)]
 
+		// The last layer also applies the output scale
+		scale := complex128(1)
+		if crossFftLen == r.length {
+			scale = r.scale
+		}
+
 		for chunkStart := 0; chunkStart < r.length; chunkStart += crossFftLen {
 			chunk := output[chunkStart : chunkStart+crossFftLen]
-			applyCrossFft(chunk, layerTwiddles, crossFftColumns, radix, butterfly)
+			applyCrossFft(chunk, layerTwiddles, crossFftColumns, radix, scale, butterfly)
 		}
 
 		twiddleOffset += crossFftColumns * (radix - 1)
 	}
 
-	// Copy result back to buffer
-	copy(buffer, output)
+	if len(r.butterflies) == 0 {
+		// No cross-FFT layer to fold the scale into
+		scaleBuffer(output, r.scale)
+	}
 }
 
 // factorTranspose performs a transpose with remainder-reversal on column indices
@@ -213,16 +283,38 @@ func reverseRemainders(value int, factors []TransposeFactor) int {
 	return result
 }
 
+// maxRadixFactor is the largest RadixFactor, which bounds the column size in applyCrossFft
+const maxRadixFactor = 7
+
 // applyCrossFft applies a cross-FFT butterfly with twiddles
-// This performs radix-point butterflies on strided data
-func applyCrossFft(data []complex128, twiddles []complex128, columns, radix int, butterfly FftInterface) {
-	// For each column
-	for col := 0; col < columns; col++ {
+// This performs radix-point butterflies on strided data. The first row is
+// multiplied by scale; the other rows expect it in their twiddles.
+func applyCrossFft(data []complex128, twiddles []complex128, columns, radix int, scale complex128, butterfly FftInterface) {
+	var column [maxRadixFactor]complex128
+
+	first := 0
+	if useAVX2 && columns >= 2 && (radix == 2 || radix == 4) {
+		// The kernels take columns in pairs and leave the first row's scale to us
+		scaleBuffer(data[:columns], scale)
+		scale = 1
+		first = columns &^ 1
+		if radix == 2 {
+			radix2ColumnsAVX2(data, twiddles, columns, 0, first)
+		} else {
+			radix4ColumnsAVX2(data, twiddles, columns, 0, first, butterfly.Direction())
+		}
+	}
+
+	// For each remaining column
+	for col := first; col < columns; col++ {
 		// Extract radix elements (strided by columns)
-		chunk := make([]complex128, radix)
+		chunk := column[:radix]
 
 		// First element (no twiddle)
 		chunk[0] = data[col]
+		if scale != 1 {
+			chunk[0] *= scale
+		}
 
 		// Remaining elements with twiddles
 		// Twiddles are laid out: [col0_tw1, col0_tw2, ..., col1_tw1, col1_tw2, ...]
@@ -233,8 +325,7 @@ func applyCrossFft(data []complex128, twiddles []complex128, columns, radix int,
 		}
 
 		// Apply butterfly
-		scratch := make([]complex128, butterfly.InplaceScratchLen())
-		butterfly.ProcessWithScratch(chunk, scratch)
+		performColumnFft(butterfly, chunk)
 
 		// Write back
 		for r := 0; r < radix; r++ {
@@ -243,3 +334,25 @@ func applyCrossFft(data []complex128, twiddles []complex128, columns, radix int,
 		}
 	}
 }
+
+// performColumnFft runs a factor butterfly on one gathered column
+// Calling through the concrete type lets the column stay on the stack,
+// where an interface call would move it to the heap on every column
+func performColumnFft(butterfly FftInterface, chunk []complex128) {
+	switch b := butterfly.(type) {
+	case *Butterfly2:
+		b.performFft(chunk)
+	case *Butterfly3:
+		b.performFft(chunk)
+	case *Butterfly4:
+		b.performFft(chunk)
+	case *Butterfly5:
+		b.performFft(chunk)
+	case *Butterfly6:
+		b.performFft(chunk)
+	case *Butterfly7:
+		b.performFft(chunk)
+	default:
+		panic("unsupported radix factor")
+	}
+}
@@ -0,0 +1,308 @@
+package algorithm
+
+import "math"
+
+// RadixN32 implements multi-factor FFT decomposition for complex64
+// For sizes like 24 = 2³×3, 60 = 2²×3×5, 120 = 2³×3×5
+type RadixN32 struct {
+	length            int
+	direction         Direction
+	baseFft           FftInterface32
+	baseLen           int
+	factors           []RadixFactor     // Original factors
+	transposeFactors  []TransposeFactor // Collapsed for transpose
+	butterflies       []FftInterface32  // Butterfly for each factor
+	twiddles          []complex64       // All twiddle factors
+	inplaceScratch    int
+	outofplaceScratch int
+	scale             complex64 // Output scale, folded into the last cross-FFT layer
+}
+
+// NewRadixN32 creates a complex64 RadixN FFT instance
+// factors: array of radices (2-7) to decompose
+// baseFft: FFT to use for base (often size 1)
+func NewRadixN32(factors []RadixFactor, baseFft FftInterface32) *RadixN32 {
+	baseLen := baseFft.Len()
+	direction := baseFft.Direction()
+
+	// Create butterflies for each factor
+	butterflies := make([]FftInterface32, len(factors))
+	crossFftLen := baseLen
+	twiddleCount := 0
+
+	for i, factor := range factors {
+		crossFftRows := int(factor)
+		crossFftColumns := crossFftLen
+
+		// Twiddles needed: columns × (rows - 1)
+		twiddleCount += crossFftColumns * (crossFftRows - 1)
+
+		// Create butterfly for this factor
+		switch factor {
+		case Factor2:
+			butterflies[i] = NewButterfly2_32(direction)
+		case Factor3:
+			butterflies[i] = NewButterfly3_32(direction)
+		case Factor4:
+			butterflies[i] = NewButterfly4_32(direction)
+		case Factor5:
+			butterflies[i] = NewButterfly5_32(direction)
+		case Factor6:
+			butterflies[i] = NewButterfly6_32(direction)
+		case Factor7:
+			butterflies[i] = NewButterfly7_32(direction)
+		default:
+			panic("unsupported radix factor")
+		}
+
+		crossFftLen *= crossFftRows
+	}
+
+	length := crossFftLen
+
+	// Build transpose factors (reversed and collapsed)
+	transposeFactors := make([]TransposeFactor, 0, len(factors))
+	for i := len(factors) - 1; i >= 0; i-- {
+		f := factors[i]
+
+		// Try to collapse with last factor
+		if len(transposeFactors) > 0 && transposeFactors[len(transposeFactors)-1].factor == f {
+			transposeFactors[len(transposeFactors)-1].count++
+		} else {
+			transposeFactors = append(transposeFactors, TransposeFactor{factor: f, count: 1})
+		}
+	}
+
+	// Precompute all twiddle factors
+	twiddles := make([]complex64, twiddleCount)
+	twiddleIdx := 0
+	crossFftLen = baseLen
+
+	for _, factor := range factors {
+		crossFftColumns := crossFftLen
+		crossFftLen *= int(factor)
+
+		// Twiddles for this layer
+		for i := 0; i < crossFftColumns; i++ {
+			for k := 1; k < int(factor); k++ {
+				angle := -2.0 * math.Pi * float64(i*k) / float64(crossFftLen)
+				if direction == Inverse {
+					angle = -angle
+				}
+				twiddles[twiddleIdx] = complex(float32(math.Cos(angle)), float32(math.Sin(angle)))
+				twiddleIdx++
+			}
+		}
+	}
+
+	// Calculate scratch space
+	baseScratch := baseFft.InplaceScratchLen()
+	inplaceScratch := length
+	if baseScratch > length {
+		inplaceScratch = length + baseScratch
+	}
+
+	outofplaceScratch := 0
+	if baseScratch > length {
+		outofplaceScratch = baseScratch
+	}
+
+	return &RadixN32{
+		length:            length,
+		direction:         direction,
+		baseFft:           baseFft,
+		baseLen:           baseLen,
+		factors:           factors,
+		transposeFactors:  transposeFactors,
+		butterflies:       butterflies,
+		twiddles:          twiddles,
+		inplaceScratch:    inplaceScratch,
+		outofplaceScratch: outofplaceScratch,
+		scale:             1,
+	}
+}
+
+// WithScale returns a copy of r whose output is multiplied by scale
+// As in Radix4_32, the factor is folded into the last cross-FFT layer.
+func (r *RadixN32) WithScale(scale float64) *RadixN32 {
+	s := complex(float32(scale), 0)
+	scaled := *r
+	scaled.scale = r.scale * s
+	scaled.twiddles = make([]complex64, len(r.twiddles))
+	copy(scaled.twiddles, r.twiddles)
+	if len(r.factors) > 0 {
+		radix := int(r.factors[len(r.factors)-1])
+		lastLayer := scaled.twiddles[len(scaled.twiddles)-(r.length/radix)*(radix-1):]
+		for i := range lastLayer {
+			lastLayer[i] *= s
+		}
+	}
+	return &scaled
+}
+
+func (r *RadixN32) Len() int                  { return r.length }
+func (r *RadixN32) Direction() Direction      { return r.direction }
+func (r *RadixN32) InplaceScratchLen() int    { return r.inplaceScratch }
+func (r *RadixN32) OutOfPlaceScratchLen() int { return r.outofplaceScratch }
+func (r *RadixN32) ImmutableScratchLen() int  { return r.baseFft.InplaceScratchLen() }
+
+func (r *RadixN32) ProcessWithScratch(buffer, scratch []complex64) {
+	checkInplace(len(buffer), r.Len(), len(scratch), r.InplaceScratchLen())
+
+	// Process each chunk
+	for i := 0; i < len(buffer); i += r.length {
+		chunk := buffer[i : i+r.length]
+		selfScratch := scratch[:r.length]
+		r.performFftOutOfPlace(chunk, selfScratch, scratch[r.length:])
+		copy(chunk, selfScratch)
+	}
+}
+
+func (r *RadixN32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftOutOfPlace(input[i:i+r.length], output[i:i+r.length], scratch)
+	}
+}
+
+func (r *RadixN32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	checkOutOfPlace(len(input), len(output), r.Len(), len(scratch), r.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += r.length {
+		r.performFftImmutable(input[i:i+r.length], output[i:i+r.length], scratch)
+	}
+}
+
+func (r *RadixN32) performFftImmutable(input, output, scratch []complex64) {
+	// Step 1: Factor transpose (reorders data based on factors)
+	factorTranspose32(r.baseLen, input, output, r.transposeFactors)
+
+	// Step 2: Base FFTs
+	r.baseFft.ProcessWithScratch(output, scratch)
+
+	// Step 3: Cross-FFTs
+	r.performCrossFfts(output)
+}
+
+func (r *RadixN32) performFftOutOfPlace(input, output, scratch []complex64) {
+	// Step 1: Factor transpose (reorders data based on factors)
+	factorTranspose32(r.baseLen, input, output, r.transposeFactors)
+
+	// Step 2: Base FFTs, borrowing the input as scratch when none was provided
+	baseScratch := scratch
+	if len(scratch) < r.baseFft.InplaceScratchLen() {
+		baseScratch = input
+	}
+	r.baseFft.ProcessWithScratch(output, baseScratch)
+
+	// Step 3: Cross-FFTs
+	r.performCrossFfts(output)
+}
+
+// performCrossFfts applies the cross-FFT butterflies with twiddles for each factor layer
+func (r *RadixN32) performCrossFfts(output []complex64) {
+	crossFftLen := r.baseLen
+	twiddleOffset := 0
+
+	for i, butterfly := range r.butterflies {
+		radix := int(r.factors[i])
+		crossFftColumns := crossFftLen
+		crossFftLen *= radix
+
+		// Apply cross-FFT butterflies on chunks
+		layerTwiddles := r.twiddles[twiddleOffset : twiddleOffset+crossFftColumns*(radix-1)]
+
+		// The last layer also applies the output scale
+		scale := complex64(1)
+		if crossFftLen == r.length {
+			scale = r.scale
+		}
+
+		for chunkStart := 0; chunkStart < r.length; chunkStart += crossFftLen {
+			chunk := output[chunkStart : chunkStart+crossFftLen]
+			applyCrossFft32(chunk, layerTwiddles, crossFftColumns, radix, scale, butterfly)
+		}
+
+		twiddleOffset += crossFftColumns * (radix - 1)
+	}
+
+	if len(r.butterflies) == 0 {
+		// No cross-FFT layer to fold the scale into
+		scaleBuffer32(output, r.scale)
+	}
+}
+
+// factorTranspose32 performs a transpose with remainder-reversal on column indices
+// This is like bit-reversal but generalized to mixed radices
+func factorTranspose32(height int, input, output []complex64, factors []TransposeFactor) {
+	width := len(input) / height
+
+	// Simple transpose with remainder reversal
+	for x := 0; x < width; x++ {
+		xRev := reverseRemainders(x, factors)
+		for y := 0; y < height; y++ {
+			inputIdx := x + y*width
+			outputIdx := y + xRev*height
+			output[outputIdx] = input[inputIdx]
+		}
+	}
+}
+
+// applyCrossFft32 applies a cross-FFT butterfly with twiddles
+// This performs radix-point butterflies on strided data. The first row is
+// multiplied by scale; the other rows expect it in their twiddles.
+func applyCrossFft32(data []complex64, twiddles []complex64, columns, radix int, scale complex64, butterfly FftInterface32) {
+	var column [maxRadixFactor]complex64
+
+	// For each column
+	for col := 0; col < columns; col++ {
+		// Extract radix elements (strided by columns)
+		chunk := column[:radix]
+
+		// First element (no twiddle)
+		chunk[0] = data[col]
+		if scale != 1 {
+			chunk[0] *= scale
+		}
+
+		// Remaining elements with twiddles
+		// Twiddles are laid out: [col0_tw1, col0_tw2, ..., col1_tw1, col1_tw2, ...]
+		for r := 1; r < radix; r++ {
+			idx := col + r*columns
+			twiddleIdx := col*(radix-1) + (r - 1)
+			chunk[r] = data[idx] * twiddles[twiddleIdx]
+		}
+
+		// Apply butterfly
+		performColumnFft32(butterfly, chunk)
+
+		// Write back
+		for r := 0; r < radix; r++ {
+			idx := col + r*columns
+			data[idx] = chunk[r]
+		}
+	}
+}
+
+// performColumnFft32 runs a factor butterfly on one gathered column
+// Calling through the concrete type lets the column stay on the stack,
+// where an interface call would move it to the heap on every column
+func performColumnFft32(butterfly FftInterface32, chunk []complex64) {
+	switch b := butterfly.(type) {
+	case *Butterfly2_32:
+		b.performFft(chunk)
+	case *Butterfly3_32:
+		b.performFft(chunk)
+	case *Butterfly4_32:
+		b.performFft(chunk)
+	case *Butterfly5_32:
+		b.performFft(chunk)
+	case *Butterfly6_32:
+		b.performFft(chunk)
+	case *Butterfly7_32:
+		b.performFft(chunk)
+	default:
+		panic("unsupported radix factor")
+	}
+}
@@ -0,0 +1,57 @@
+package algorithm
+
+// Scaled wraps an FFT and multiplies its output by a constant
+// It is the fallback for algorithms that can't fold a scale into their own
+// passes, such as the butterflies, and costs one extra sweep over the output.
+type Scaled struct {
+	inner FftInterface
+	scale complex128
+}
+
+// NewScaled creates an FFT that computes fft and multiplies its output by scale
+func NewScaled(fft FftInterface, scale float64) *Scaled {
+	return &Scaled{inner: fft, scale: complex(scale, 0)}
+}
+
+func (s *Scaled) Len() int                  { return s.inner.Len() }
+func (s *Scaled) Direction() Direction      { return s.inner.Direction() }
+func (s *Scaled) InplaceScratchLen() int    { return s.inner.InplaceScratchLen() }
+func (s *Scaled) OutOfPlaceScratchLen() int { return s.inner.OutOfPlaceScratchLen() }
+func (s *Scaled) ImmutableScratchLen() int  { return s.inner.ImmutableScratchLen() }
+
+func (s *Scaled) ProcessWithScratch(buffer, scratch []complex128) {
+	s.inner.ProcessWithScratch(buffer, scratch)
+	scaleBuffer(buffer, s.scale)
+}
+
+func (s *Scaled) ProcessOutOfPlace(input, output, scratch []complex128) {
+	s.inner.ProcessOutOfPlace(input, output, scratch)
+	scaleBuffer(output, s.scale)
+}
+
+func (s *Scaled) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	s.inner.ProcessImmutable(input, output, scratch)
+	scaleBuffer(output, s.scale)
+}
+
+// scaleBuffer multiplies every element of buffer by scale
+func scaleBuffer(buffer []complex128, scale complex128) {
+	if scale == 1 {
+		return
+	}
+	for i := range buffer {
+		buffer[i] *= scale
+	}
+}
+
+// pointwiseMultiply sets dst[i] = a[i] * b[i] for every element of dst
+func pointwiseMultiply(dst, a, b []complex128) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	if useAVX2 {
+		multiplyAVX2(dst, a, b)
+		return
+	}
+	for i := range dst {
+		dst[i] = a[i] * b[i]
+	}
+}
@@ -0,0 +1,51 @@
+package algorithm
+
+// Scaled32 is the complex64 counterpart of Scaled
+type Scaled32 struct {
+	inner FftInterface32
+	scale complex64
+}
+
+// NewScaled32 creates a complex64 FFT that computes fft and multiplies its output by scale
+func NewScaled32(fft FftInterface32, scale float64) *Scaled32 {
+	return &Scaled32{inner: fft, scale: complex(float32(scale), 0)}
+}
+
+func (s *Scaled32) Len() int                  { return s.inner.Len() }
+func (s *Scaled32) Direction() Direction      { return s.inner.Direction() }
+func (s *Scaled32) InplaceScratchLen() int    { return s.inner.InplaceScratchLen() }
+func (s *Scaled32) OutOfPlaceScratchLen() int { return s.inner.OutOfPlaceScratchLen() }
+func (s *Scaled32) ImmutableScratchLen() int  { return s.inner.ImmutableScratchLen() }
+
+func (s *Scaled32) ProcessWithScratch(buffer, scratch []complex64) {
+	s.inner.ProcessWithScratch(buffer, scratch)
+	scaleBuffer32(buffer, s.scale)
+}
+
+func (s *Scaled32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	s.inner.ProcessOutOfPlace(input, output, scratch)
+	scaleBuffer32(output, s.scale)
+}
+
+func (s *Scaled32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	s.inner.ProcessImmutable(input, output, scratch)
+	scaleBuffer32(output, s.scale)
+}
+
+// scaleBuffer32 multiplies every element of buffer by scale
+func scaleBuffer32(buffer []complex64, scale complex64) {
+	if scale == 1 {
+		return
+	}
+	for i := range buffer {
+		buffer[i] *= scale
+	}
+}
+
+// pointwiseMultiply32 sets dst[i] = a[i] * b[i] for every element of dst
+func pointwiseMultiply32(dst, a, b []complex64) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for i := range dst {
+		dst[i] = a[i] * b[i]
+	}
+}
@@ -0,0 +1,86 @@
+package algorithm
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+// TestWithScaleMatchesManualScaling checks that folding a scale into an
+// algorithm gives the same result as scaling its output afterwards
+func TestWithScaleMatchesManualScaling(t *testing.T) {
+	const scale = 0.125
+	for _, dir := range []Direction{Forward, Inverse} {
+		testCases := []struct {
+			name   string
+			fft    FftInterface
+			scaled FftInterface
+		}{
+			{"Dft", NewDft(12, dir), NewDft(12, dir).WithScale(scale)},
+			{"Radix4/64", NewRadix4(64, dir), NewRadix4(64, dir).WithScale(scale)},
+			{"Radix4/2048", NewRadix4(2048, dir), NewRadix4(2048, dir).WithScale(scale)},
+			{"Radix4/BaseOnly", NewRadix4WithBase(0, NewButterfly8(dir)), NewRadix4WithBase(0, NewButterfly8(dir)).WithScale(scale)},
+			{"Radix4/Parallel", NewRadix4(4096, dir), NewRadix4(4096, dir).WithScale(scale).WithWorkers(4)},
+			{"Radix8/512", NewRadix8(512, dir), NewRadix8(512, dir).WithScale(scale)},
+			{"Radix8/BaseOnly", NewRadix8WithBase(0, NewButterfly16(dir)), NewRadix8WithBase(0, NewButterfly16(dir)).WithScale(scale)},
+			{"Radix8/Parallel", NewRadix8(32768, dir), NewRadix8(32768, dir).WithScale(scale).WithWorkers(4)},
+			{"Stockham/1024", NewStockham(1024, dir), NewStockham(1024, dir).WithScale(scale)},
+			{"Stockham/2048", NewStockham(2048, dir), NewStockham(2048, dir).WithScale(scale)},
+			{"Stockham/Parallel", NewStockham(4096, dir), NewStockham(4096, dir).WithScale(scale).WithWorkers(4)},
+			{"FourStep/4096", NewFourStep(NewRadix4(64, dir), NewRadix8(64, dir)),
+				NewFourStep(NewRadix4(64, dir), NewRadix8(64, dir)).WithScale(scale)},
+			{"FourStep/Parallel", NewFourStep(NewRadix4(128, dir), NewRadix8(64, dir)),
+				NewFourStep(NewRadix4(128, dir), NewRadix8(64, dir)).WithScale(scale).WithWorkers(4)},
+			{"RadixN/60", NewRadixN([]RadixFactor{Factor5, Factor3, Factor4}, NewDft(1, dir)),
+				NewRadixN([]RadixFactor{Factor5, Factor3, Factor4}, NewDft(1, dir)).WithScale(scale)},
+			{"RadixN/BaseOnly", NewRadixN(nil, NewDft(7, dir)), NewRadixN(nil, NewDft(7, dir)).WithScale(scale)},
+			{"Raders/37", NewRaders(NewDft(36, dir)), NewRaders(NewDft(36, dir)).WithScale(scale)},
+			{"Bluestein/101", NewBluestein(101, dir), NewBluestein(101, dir).WithScale(scale)},
+			{"MixedRadix/35", NewMixedRadix(NewButterfly5(dir), NewButterfly7(dir)),
+				NewMixedRadix(NewButterfly5(dir), NewButterfly7(dir)).WithScale(scale)},
+			{"GoodThomas/143", NewGoodThomas(NewButterfly11(dir), NewButterfly13(dir)),
+				NewGoodThomas(NewButterfly11(dir), NewButterfly13(dir)).WithScale(scale)},
+			{"GoodThomas/Parallel", NewGoodThomas(NewRadix4(64, dir), NewButterfly9(dir)),
+				NewGoodThomas(NewRadix4(64, dir), NewButterfly9(dir)).WithScale(scale).WithWorkers(4)},
+			{"Scaled/Butterfly16", NewButterfly16(dir), NewScaled(NewButterfly16(dir), scale)},
+			{"Twice", NewRadix4(256, dir), NewRadix4(256, dir).WithScale(0.5).WithScale(2 * scale)},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				n := tc.fft.Len()
+				input := make([]complex128, 2*n)
+				for i := range input {
+					input[i] = complex(float64(i%11)-5, float64(i%5)*0.5)
+				}
+
+				expected := make([]complex128, len(input))
+				copy(expected, input)
+				tc.fft.ProcessWithScratch(expected, make([]complex128, tc.fft.InplaceScratchLen()))
+				for i := range expected {
+					expected[i] *= scale
+				}
+
+				check := func(method string, got []complex128) {
+					for i := range got {
+						if cmplx.Abs(got[i]-expected[i]) > 1e-9 {
+							t.Fatalf("%s [%d] got %v, want %v", method, i, got[i], expected[i])
+						}
+					}
+				}
+
+				inplace := make([]complex128, len(input))
+				copy(inplace, input)
+				tc.scaled.ProcessWithScratch(inplace, make([]complex128, tc.scaled.InplaceScratchLen()))
+				check("ProcessWithScratch", inplace)
+
+				immutable := make([]complex128, len(input))
+				tc.scaled.ProcessImmutable(input, immutable, make([]complex128, tc.scaled.ImmutableScratchLen()))
+				check("ProcessImmutable", immutable)
+
+				outOfPlace := make([]complex128, len(input))
+				tc.scaled.ProcessOutOfPlace(input, outOfPlace, make([]complex128, tc.scaled.OutOfPlaceScratchLen()))
+				check("ProcessOutOfPlace", outOfPlace)
+			})
+		}
+	}
+}
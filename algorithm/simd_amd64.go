@@ -0,0 +1,113 @@
+//go:build amd64 && !purego
+
+package algorithm
+
+// useAVX2 reports whether the CPU and OS support the AVX2 and FMA kernels in
+// simd_amd64.s. The complex128 algorithms check it on their hot paths and fall
+// back to the portable Go loops when it's false.
+var useAVX2 = detectAVX2FMA()
+
+// AVX2Enabled reports whether the complex128 algorithms use the AVX2 kernels
+func AVX2Enabled() bool { return useAVX2 }
+
+// avx2Table holds the per-direction constants the AVX2 kernels load
+// The offsets are hard-coded in simd_amd64.s.
+type avx2Table struct {
+	rotate     [4]uint64      // Sign mask that turns swapped (im, re) pairs into x·(∓i)
+	twiddles8  [6]complex128  // W8^(n2·k1) for rows k1 = 1..3 and columns n2 = 0..1
+	twiddles16 [12]complex128 // W16^(n2·k1) for rows k1 = 1..3 and columns n2 = 0..3
+	twiddles32 [24]complex128 // W32^(n2·k1) for rows k1 = 1..3 and columns n2 = 0..7
+}
+
+// avx2Tables is indexed by Direction
+var avx2Tables [2]avx2Table
+
+func init() {
+	const signBit = 1 << 63
+	avx2Tables[Forward].rotate = [4]uint64{0, signBit, 0, signBit}
+	avx2Tables[Inverse].rotate = [4]uint64{signBit, 0, signBit, 0}
+
+	for _, direction := range []Direction{Forward, Inverse} {
+		table := &avx2Tables[direction]
+		for k1 := 1; k1 < 4; k1++ {
+			for n2 := 0; n2 < 2; n2++ {
+				table.twiddles8[(k1-1)*2+n2] = twiddleFactor(n2*k1, 8, direction)
+			}
+			for n2 := 0; n2 < 4; n2++ {
+				table.twiddles16[(k1-1)*4+n2] = twiddleFactor(n2*k1, 16, direction)
+			}
+			for n2 := 0; n2 < 8; n2++ {
+				table.twiddles32[(k1-1)*8+n2] = twiddleFactor(n2*k1, 32, direction)
+			}
+		}
+	}
+}
+
+// detectAVX2FMA checks CPUID for AVX2 and FMA, and XCR0 for OS support of the
+// YMM registers, the same way golang.org/x/sys/cpu does
+func detectAVX2FMA() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+
+	const fma, osxsave, avx = 1 << 12, 1 << 27, 1 << 28
+	_, _, ecx1, _ := cpuid(1, 0)
+	if ecx1&(fma|osxsave|avx) != fma|osxsave|avx {
+		return false
+	}
+	// The OS must save the XMM and YMM state on context switches
+	if xcr0, _ := xgetbv(); xcr0&6 != 6 {
+		return false
+	}
+
+	const avx2 = 1 << 5
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&avx2 != 0
+}
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+func xgetbv() (eax, edx uint32)
+
+// The butterfly kernels run an FFT on every chunk of src, writing to dst.
+// dst and src may be the same slice.
+
+//go:noescape
+func butterfly2AVX2(dst, src []complex128)
+
+//go:noescape
+func butterfly4AVX2(dst, src []complex128, direction Direction)
+
+//go:noescape
+func butterfly8AVX2(dst, src []complex128, direction Direction)
+
+//go:noescape
+func butterfly16AVX2(dst, src []complex128, direction Direction)
+
+//go:noescape
+func butterfly32AVX2(dst, src []complex128, direction Direction)
+
+// radix2ColumnsAVX2, radix4ColumnsAVX2 and radix8ColumnsAVX2 apply the
+// twiddles and butterflies of columns [start, end) of a cross-FFT layer, as
+// applyCrossFft and butterfly8Columns do with a scale of 1. end-start must be
+// even.
+
+//go:noescape
+func radix2ColumnsAVX2(data, twiddles []complex128, columns, start, end int)
+
+//go:noescape
+func radix4ColumnsAVX2(data, twiddles []complex128, columns, start, end int, direction Direction)
+
+//go:noescape
+func radix8ColumnsAVX2(data, twiddles []complex128, columns, start, end int, direction Direction)
+
+// stockhamColumnsAVX2 runs elements [0, count) of one twiddle group of a
+// Stockham stage, as stockhamStage and stockhamLast4 do. count must be even.
+//
+//go:noescape
+func stockhamColumnsAVX2(dst, src, twiddles []complex128, quarter, stride, count int, direction Direction)
+
+// multiplyAVX2 sets dst[k] = a[k]·b[k] for every k in dst
+//
+//go:noescape
+func multiplyAVX2(dst, a, b []complex128)
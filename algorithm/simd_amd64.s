@@ -0,0 +1,564 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// Offsets into avx2Table, see simd_amd64.go
+#define TABLE_SIZE 704
+#define ROTATE 0
+#define TWIDDLES8 32
+#define TWIDDLES16 128
+#define TWIDDLES32 320
+
+// Each YMM register holds two complex128 values as (re, im, re, im).
+
+// CMUL multiplies the complex pairs in a by those in b, leaving the product in a.
+// b may be a register or a memory operand; t1 and t2 are clobbered.
+#define CMUL(b, a, t1, t2) \
+	VPERMILPD      $15, b, t1; \
+	VPERMILPD      $5, a, t2;  \
+	VMULPD         t1, t2, t2; \
+	VMOVDDUP       b, t1;      \
+	VFMADDSUB213PD t2, t1, a
+
+// ROT multiplies x by -i (forward) or +i (inverse) as selected by the sign mask
+#define ROT(x, mask) \
+	VPERMILPD $5, x, x; \
+	VXORPD    mask, x, x
+
+// BF2 computes (a, b) = (a + b, a - b), clobbering t
+#define BF2(a, b, t) \
+	VSUBPD  b, a, t; \
+	VADDPD  b, a, a; \
+	VMOVAPD t, b
+
+// BF4 computes 4-point FFTs down the columns a, b, c, d, leaving X0..X3 in
+// a, b, c, d and clobbering t. It matches Butterfly4.performFftOutOfPlace.
+#define BF4(a, b, c, d, t, mask) \
+	VSUBPD  c, a, t; \
+	VADDPD  c, a, a; \
+	VADDPD  d, b, c; \
+	VSUBPD  d, b, d; \
+	ROT(d, mask);    \
+	VADDPD  d, t, b; \
+	VSUBPD  d, t, d; \
+	VSUBPD  c, a, t; \
+	VADDPD  c, a, a; \
+	VMOVAPD t, c
+
+// FFT8 computes 8-point FFTs down the columns Y0..Y7, leaving X0..X7 in
+// Y0, Y2, Y4, Y6, Y1, Y3, Y5, Y7. It expects the rotation mask in Y15 and
+// √½ in every element of Y14, and clobbers Y8.
+#define FFT8 \
+	BF4(Y0, Y2, Y4, Y6, Y8, Y15); \
+	BF4(Y1, Y3, Y5, Y7, Y8, Y15); \
+	VPERMILPD $5, Y3, Y8;         \
+	VXORPD    Y15, Y8, Y8;        \
+	VADDPD    Y3, Y8, Y3;         \
+	VMULPD    Y14, Y3, Y3;        \
+	ROT(Y5, Y15);                 \
+	VPERMILPD $5, Y7, Y8;         \
+	VXORPD    Y15, Y8, Y8;        \
+	VSUBPD    Y7, Y8, Y7;         \
+	VMULPD    Y14, Y7, Y7;        \
+	BF2(Y0, Y1, Y8);              \
+	BF2(Y2, Y3, Y8);              \
+	BF2(Y4, Y5, Y8);              \
+	BF2(Y6, Y7, Y8)
+
+DATA sqrtHalf<>+0(SB)/8, $0x3fe6a09e667f3bcd
+GLOBL sqrtHalf<>(SB), RODATA|NOPTR, $8
+
+// TABLE loads the address of avx2Tables[direction] into R8
+#define TABLE(direction) \
+	MOVQ  direction, AX;         \
+	IMULQ $TABLE_SIZE, AX;       \
+	LEAQ  ·avx2Tables(SB), R8;   \
+	ADDQ  AX, R8
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL   $0, CX
+	XGETBV
+	MOVL   AX, eax+0(FP)
+	MOVL   DX, edx+4(FP)
+	RET
+
+// func butterfly2AVX2(dst, src []complex128)
+TEXT ·butterfly2AVX2(SB), NOSPLIT, $0-48
+	MOVQ dst_base+0(FP), DI
+	MOVQ src_base+24(FP), SI
+	MOVQ src_len+32(FP), CX
+	SHRQ $1, CX
+	JZ   b2done
+
+b2loop:
+	VMOVUPD    (SI), Y0
+	VPERM2F128 $0x01, Y0, Y0, Y1
+	VADDPD     Y1, Y0, Y2
+	VSUBPD     Y0, Y1, Y3
+	VBLENDPD   $12, Y3, Y2, Y2
+	VMOVUPD    Y2, (DI)
+	ADDQ       $32, SI
+	ADDQ       $32, DI
+	DECQ       CX
+	JNZ        b2loop
+
+b2done:
+	VZEROUPPER
+	RET
+
+// func butterfly4AVX2(dst, src []complex128, direction Direction)
+TEXT ·butterfly4AVX2(SB), NOSPLIT, $0-56
+	TABLE(direction+48(FP))
+	VMOVUPD ROTATE(R8), Y15
+	MOVQ    dst_base+0(FP), DI
+	MOVQ    src_base+24(FP), SI
+	MOVQ    src_len+32(FP), CX
+	SHRQ    $2, CX
+	JZ      b4done
+
+b4loop:
+	VMOVUPD    (SI), Y0
+	VMOVUPD    32(SI), Y1
+	VADDPD     Y1, Y0, Y2
+	VSUBPD     Y1, Y0, Y3
+	VMOVAPD    Y3, Y4
+	ROT(Y4, Y15)
+	VBLENDPD   $12, Y4, Y3, Y3
+	VPERM2F128 $0x20, Y3, Y2, Y4
+	VPERM2F128 $0x31, Y3, Y2, Y5
+	VADDPD     Y5, Y4, Y0
+	VSUBPD     Y5, Y4, Y1
+	VMOVUPD    Y0, (DI)
+	VMOVUPD    Y1, 32(DI)
+	ADDQ       $64, SI
+	ADDQ       $64, DI
+	DECQ       CX
+	JNZ        b4loop
+
+b4done:
+	VZEROUPPER
+	RET
+
+// func butterfly8AVX2(dst, src []complex128, direction Direction)
+//
+// The chunk is read as 4 rows of 2 columns: 4-point FFTs down the columns,
+// twiddles, then 2-point FFTs across each row.
+TEXT ·butterfly8AVX2(SB), NOSPLIT, $0-56
+	TABLE(direction+48(FP))
+	VMOVUPD ROTATE(R8), Y15
+	MOVQ    dst_base+0(FP), DI
+	MOVQ    src_base+24(FP), SI
+	MOVQ    src_len+32(FP), CX
+	SHRQ    $3, CX
+	JZ      b8done
+
+b8loop:
+	VMOVUPD    (SI), Y0
+	VMOVUPD    32(SI), Y1
+	VMOVUPD    64(SI), Y2
+	VMOVUPD    96(SI), Y3
+	BF4(Y0, Y1, Y2, Y3, Y4, Y15)
+	CMUL(TWIDDLES8(R8), Y1, Y4, Y5)
+	CMUL(TWIDDLES8+32(R8), Y2, Y4, Y5)
+	CMUL(TWIDDLES8+64(R8), Y3, Y4, Y5)
+	VPERM2F128 $0x20, Y1, Y0, Y4
+	VPERM2F128 $0x31, Y1, Y0, Y5
+	VPERM2F128 $0x20, Y3, Y2, Y6
+	VPERM2F128 $0x31, Y3, Y2, Y7
+	VADDPD     Y5, Y4, Y0
+	VADDPD     Y7, Y6, Y1
+	VSUBPD     Y5, Y4, Y2
+	VSUBPD     Y7, Y6, Y3
+	VMOVUPD    Y0, (DI)
+	VMOVUPD    Y1, 32(DI)
+	VMOVUPD    Y2, 64(DI)
+	VMOVUPD    Y3, 96(DI)
+	ADDQ       $128, SI
+	ADDQ       $128, DI
+	DECQ       CX
+	JNZ        b8loop
+
+b8done:
+	VZEROUPPER
+	RET
+
+// func butterfly16AVX2(dst, src []complex128, direction Direction)
+//
+// The chunk is read as 4 rows of 4 columns: 4-point FFTs down the columns,
+// twiddles, a transpose, and 4-point FFTs down the new columns, which leaves
+// the output in natural order.
+TEXT ·butterfly16AVX2(SB), NOSPLIT, $0-56
+	TABLE(direction+48(FP))
+	VMOVUPD ROTATE(R8), Y15
+	MOVQ    dst_base+0(FP), DI
+	MOVQ    src_base+24(FP), SI
+	MOVQ    src_len+32(FP), CX
+	SHRQ    $4, CX
+	JZ      b16done
+
+b16loop:
+	VMOVUPD    (SI), Y0
+	VMOVUPD    32(SI), Y1
+	VMOVUPD    64(SI), Y2
+	VMOVUPD    96(SI), Y3
+	VMOVUPD    128(SI), Y4
+	VMOVUPD    160(SI), Y5
+	VMOVUPD    192(SI), Y6
+	VMOVUPD    224(SI), Y7
+	BF4(Y0, Y2, Y4, Y6, Y8, Y15)
+	BF4(Y1, Y3, Y5, Y7, Y8, Y15)
+	CMUL(TWIDDLES16(R8), Y2, Y8, Y9)
+	CMUL(TWIDDLES16+32(R8), Y3, Y8, Y9)
+	CMUL(TWIDDLES16+64(R8), Y4, Y8, Y9)
+	CMUL(TWIDDLES16+96(R8), Y5, Y8, Y9)
+	CMUL(TWIDDLES16+128(R8), Y6, Y8, Y9)
+	CMUL(TWIDDLES16+160(R8), Y7, Y8, Y9)
+	VPERM2F128 $0x20, Y2, Y0, Y8
+	VPERM2F128 $0x31, Y2, Y0, Y9
+	VPERM2F128 $0x20, Y6, Y4, Y10
+	VPERM2F128 $0x31, Y6, Y4, Y11
+	VPERM2F128 $0x20, Y3, Y1, Y12
+	VPERM2F128 $0x31, Y3, Y1, Y13
+	VPERM2F128 $0x20, Y7, Y5, Y0
+	VPERM2F128 $0x31, Y7, Y5, Y1
+	BF4(Y8, Y9, Y12, Y13, Y2, Y15)
+	BF4(Y10, Y11, Y0, Y1, Y2, Y15)
+	VMOVUPD    Y8, (DI)
+	VMOVUPD    Y10, 32(DI)
+	VMOVUPD    Y9, 64(DI)
+	VMOVUPD    Y11, 96(DI)
+	VMOVUPD    Y12, 128(DI)
+	VMOVUPD    Y0, 160(DI)
+	VMOVUPD    Y13, 192(DI)
+	VMOVUPD    Y1, 224(DI)
+	ADDQ       $256, SI
+	ADDQ       $256, DI
+	DECQ       CX
+	JNZ        b16loop
+
+b16done:
+	VZEROUPPER
+	RET
+
+// B32COLUMNS runs the first pass of butterfly32AVX2 on columns 2p and 2p+1,
+// where off = 32p, and stores them transposed to the frame
+#define B32COLUMNS(off) \
+	VMOVUPD    (off)(SI), Y0;                \
+	VMOVUPD    (off+128)(SI), Y1;            \
+	VMOVUPD    (off+256)(SI), Y2;            \
+	VMOVUPD    (off+384)(SI), Y3;            \
+	BF4(Y0, Y1, Y2, Y3, Y4, Y15);            \
+	CMUL((TWIDDLES32+off)(R8), Y1, Y4, Y5);     \
+	CMUL((TWIDDLES32+128+off)(R8), Y2, Y4, Y5); \
+	CMUL((TWIDDLES32+256+off)(R8), Y3, Y4, Y5); \
+	VPERM2F128 $0x20, Y1, Y0, Y4;            \
+	VPERM2F128 $0x20, Y3, Y2, Y5;            \
+	VPERM2F128 $0x31, Y1, Y0, Y6;            \
+	VPERM2F128 $0x31, Y3, Y2, Y7;            \
+	VMOVUPD    Y4, (4*off)(SP);              \
+	VMOVUPD    Y5, (4*off+32)(SP);           \
+	VMOVUPD    Y6, (4*off+64)(SP);           \
+	VMOVUPD    Y7, (4*off+96)(SP)
+
+// B32ROWS runs the second pass of butterfly32AVX2 on output columns 2q and
+// 2q+1, where off = 32q
+#define B32ROWS(off) \
+	VMOVUPD (off)(SP), Y0;     \
+	VMOVUPD (off+64)(SP), Y1;  \
+	VMOVUPD (off+128)(SP), Y2; \
+	VMOVUPD (off+192)(SP), Y3; \
+	VMOVUPD (off+256)(SP), Y4; \
+	VMOVUPD (off+320)(SP), Y5; \
+	VMOVUPD (off+384)(SP), Y6; \
+	VMOVUPD (off+448)(SP), Y7; \
+	FFT8;                      \
+	VMOVUPD Y0, (off)(DI);     \
+	VMOVUPD Y2, (off+64)(DI);  \
+	VMOVUPD Y4, (off+128)(DI); \
+	VMOVUPD Y6, (off+192)(DI); \
+	VMOVUPD Y1, (off+256)(DI); \
+	VMOVUPD Y3, (off+320)(DI); \
+	VMOVUPD Y5, (off+384)(DI); \
+	VMOVUPD Y7, (off+448)(DI)
+
+// func butterfly32AVX2(dst, src []complex128, direction Direction)
+//
+// The chunk is read as 4 rows of 8 columns: 4-point FFTs down the columns and
+// twiddles, stored transposed to the frame, then 8-point FFTs down the
+// columns of the transpose, which leaves the output in natural order.
+TEXT ·butterfly32AVX2(SB), $512-56
+	TABLE(direction+48(FP))
+	VMOVUPD      ROTATE(R8), Y15
+	VBROADCASTSD sqrtHalf<>(SB), Y14
+	MOVQ         dst_base+0(FP), DI
+	MOVQ         src_base+24(FP), SI
+	MOVQ         src_len+32(FP), CX
+	SHRQ         $5, CX
+	JZ           b32done
+
+b32loop:
+	B32COLUMNS(0)
+	B32COLUMNS(32)
+	B32COLUMNS(64)
+	B32COLUMNS(96)
+	B32ROWS(0)
+	B32ROWS(32)
+	ADDQ $512, SI
+	ADDQ $512, DI
+	DECQ CX
+	JNZ  b32loop
+
+b32done:
+	VZEROUPPER
+	RET
+
+// func radix2ColumnsAVX2(data, twiddles []complex128, columns, start, end int)
+TEXT ·radix2ColumnsAVX2(SB), NOSPLIT, $0-72
+	MOVQ data_base+0(FP), DI
+	MOVQ twiddles_base+24(FP), SI
+	MOVQ columns+48(FP), BX
+	MOVQ start+56(FP), CX
+	MOVQ end+64(FP), DX
+	SHLQ $4, BX
+	MOVQ CX, AX
+	SHLQ $4, AX
+	ADDQ AX, DI
+	ADDQ AX, SI
+	SUBQ CX, DX
+	SHRQ $1, DX
+	JZ   r2done
+
+r2loop:
+	VMOVUPD (DI), Y0
+	VMOVUPD (DI)(BX*1), Y1
+	CMUL((SI), Y1, Y2, Y3)
+	BF2(Y0, Y1, Y2)
+	VMOVUPD Y0, (DI)
+	VMOVUPD Y1, (DI)(BX*1)
+	ADDQ    $32, DI
+	ADDQ    $32, SI
+	DECQ    DX
+	JNZ     r2loop
+
+r2done:
+	VZEROUPPER
+	RET
+
+// func radix4ColumnsAVX2(data, twiddles []complex128, columns, start, end int, direction Direction)
+//
+// Each column's three twiddles are adjacent, so the twiddles for a pair of
+// columns are gathered from two 128-bit loads.
+TEXT ·radix4ColumnsAVX2(SB), NOSPLIT, $0-80
+	TABLE(direction+72(FP))
+	VMOVUPD ROTATE(R8), Y15
+	MOVQ    data_base+0(FP), DI
+	MOVQ    twiddles_base+24(FP), SI
+	MOVQ    columns+48(FP), BX
+	MOVQ    start+56(FP), CX
+	MOVQ    end+64(FP), DX
+	SHLQ    $4, BX
+	MOVQ    CX, AX
+	SHLQ    $4, AX
+	ADDQ    AX, DI
+	LEAQ    (AX)(AX*2), AX
+	ADDQ    AX, SI
+	SUBQ    CX, DX
+	SHRQ    $1, DX
+	JZ      r4done
+
+r4loop:
+	LEAQ        (DI)(BX*2), R9
+	VMOVUPD     (DI), Y0
+	VMOVUPD     (DI)(BX*1), Y1
+	VMOVUPD     (R9), Y2
+	VMOVUPD     (R9)(BX*1), Y3
+	VMOVUPD     (SI), X4
+	VINSERTF128 $1, 48(SI), Y4, Y4
+	VMOVUPD     16(SI), X5
+	VINSERTF128 $1, 64(SI), Y5, Y5
+	VMOVUPD     32(SI), X6
+	VINSERTF128 $1, 80(SI), Y6, Y6
+	CMUL(Y4, Y1, Y7, Y8)
+	CMUL(Y5, Y2, Y7, Y8)
+	CMUL(Y6, Y3, Y7, Y8)
+	BF4(Y0, Y1, Y2, Y3, Y7, Y15)
+	VMOVUPD     Y0, (DI)
+	VMOVUPD     Y1, (DI)(BX*1)
+	VMOVUPD     Y2, (R9)
+	VMOVUPD     Y3, (R9)(BX*1)
+	ADDQ        $32, DI
+	ADDQ        $96, SI
+	DECQ        DX
+	JNZ         r4loop
+
+r4done:
+	VZEROUPPER
+	RET
+
+// func radix8ColumnsAVX2(data, twiddles []complex128, columns, start, end int, direction Direction)
+//
+// Radix8 stores each layer's twiddles row by row, so the twiddles for a pair
+// of columns are a single 256-bit load per row.
+TEXT ·radix8ColumnsAVX2(SB), NOSPLIT, $0-80
+	TABLE(direction+72(FP))
+	VMOVUPD      ROTATE(R8), Y15
+	VBROADCASTSD sqrtHalf<>(SB), Y14
+	MOVQ         data_base+0(FP), DI
+	MOVQ         twiddles_base+24(FP), SI
+	MOVQ         columns+48(FP), BX
+	MOVQ         start+56(FP), CX
+	MOVQ         end+64(FP), DX
+	SHLQ         $4, BX
+	MOVQ         CX, AX
+	SHLQ         $4, AX
+	ADDQ         AX, DI
+	ADDQ         AX, SI
+	LEAQ         (BX)(BX*2), R10
+	SUBQ         CX, DX
+	SHRQ         $1, DX
+	JZ           r8done
+
+r8loop:
+	LEAQ    (DI)(BX*4), R9
+	LEAQ    (SI)(BX*4), R11
+	VMOVUPD (DI), Y0
+	VMOVUPD (DI)(BX*1), Y1
+	VMOVUPD (DI)(BX*2), Y2
+	VMOVUPD (DI)(R10*1), Y3
+	VMOVUPD (R9), Y4
+	VMOVUPD (R9)(BX*1), Y5
+	VMOVUPD (R9)(BX*2), Y6
+	VMOVUPD (R9)(R10*1), Y7
+	CMUL((SI), Y1, Y9, Y10)
+	CMUL((SI)(BX*1), Y2, Y9, Y10)
+	CMUL((SI)(BX*2), Y3, Y9, Y10)
+	CMUL((SI)(R10*1), Y4, Y9, Y10)
+	CMUL((R11), Y5, Y9, Y10)
+	CMUL((R11)(BX*1), Y6, Y9, Y10)
+	CMUL((R11)(BX*2), Y7, Y9, Y10)
+	FFT8
+	VMOVUPD Y0, (DI)
+	VMOVUPD Y2, (DI)(BX*1)
+	VMOVUPD Y4, (DI)(BX*2)
+	VMOVUPD Y6, (DI)(R10*1)
+	VMOVUPD Y1, (R9)
+	VMOVUPD Y3, (R9)(BX*1)
+	VMOVUPD Y5, (R9)(BX*2)
+	VMOVUPD Y7, (R9)(R10*1)
+	ADDQ    $32, DI
+	ADDQ    $32, SI
+	DECQ    DX
+	JNZ     r8loop
+
+r8done:
+	VZEROUPPER
+	RET
+
+// func stockhamColumnsAVX2(dst, src, twiddles []complex128, quarter, stride, count int, direction Direction)
+//
+// Runs count elements (an even number) of one twiddle group of a Stockham
+// stage: a 4-point FFT of src[q], src[q+quarter], src[q+2·quarter] and
+// src[q+3·quarter], whose outputs 1-3 are multiplied by twiddles[0..2] and
+// stored to dst[q+k·stride]. With no twiddles it is the stage's plain 4-point FFT.
+TEXT ·stockhamColumnsAVX2(SB), NOSPLIT, $0-104
+	TABLE(direction+96(FP))
+	VMOVUPD ROTATE(R8), Y15
+	MOVQ    dst_base+0(FP), DI
+	MOVQ    src_base+24(FP), SI
+	MOVQ    twiddles_base+48(FP), R11
+	MOVQ    twiddles_len+56(FP), R12
+	MOVQ    quarter+72(FP), BX
+	MOVQ    stride+80(FP), CX
+	MOVQ    count+88(FP), DX
+	SHLQ    $4, BX
+	SHLQ    $4, CX
+	LEAQ    (BX)(BX*2), R10
+	LEAQ    (CX)(CX*2), R9
+	SHRQ    $1, DX
+	JZ      shdone
+	TESTQ   R12, R12
+	JZ      shplain
+	VBROADCASTF128 (R11), Y12
+	VBROADCASTF128 16(R11), Y13
+	VBROADCASTF128 32(R11), Y14
+
+shloop:
+	VMOVUPD (SI), Y0
+	VMOVUPD (SI)(BX*1), Y1
+	VMOVUPD (SI)(BX*2), Y2
+	VMOVUPD (SI)(R10*1), Y3
+	BF4(Y0, Y1, Y2, Y3, Y4, Y15)
+	CMUL(Y12, Y1, Y4, Y5)
+	CMUL(Y13, Y2, Y4, Y5)
+	CMUL(Y14, Y3, Y4, Y5)
+	VMOVUPD Y0, (DI)
+	VMOVUPD Y1, (DI)(CX*1)
+	VMOVUPD Y2, (DI)(CX*2)
+	VMOVUPD Y3, (DI)(R9*1)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	DECQ    DX
+	JNZ     shloop
+	JMP     shdone
+
+shplain:
+	VMOVUPD (SI), Y0
+	VMOVUPD (SI)(BX*1), Y1
+	VMOVUPD (SI)(BX*2), Y2
+	VMOVUPD (SI)(R10*1), Y3
+	BF4(Y0, Y1, Y2, Y3, Y4, Y15)
+	VMOVUPD Y0, (DI)
+	VMOVUPD Y1, (DI)(CX*1)
+	VMOVUPD Y2, (DI)(CX*2)
+	VMOVUPD Y3, (DI)(R9*1)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	DECQ    DX
+	JNZ     shplain
+
+shdone:
+	VZEROUPPER
+	RET
+
+// func multiplyAVX2(dst, a, b []complex128)
+TEXT ·multiplyAVX2(SB), NOSPLIT, $0-72
+	MOVQ dst_base+0(FP), DI
+	MOVQ dst_len+8(FP), CX
+	MOVQ a_base+24(FP), SI
+	MOVQ b_base+48(FP), DX
+	MOVQ CX, BX
+	SHRQ $1, BX
+	JZ   multail
+
+mulloop:
+	VMOVUPD (SI), Y0
+	CMUL((DX), Y0, Y1, Y2)
+	VMOVUPD Y0, (DI)
+	ADDQ    $32, SI
+	ADDQ    $32, DX
+	ADDQ    $32, DI
+	DECQ    BX
+	JNZ     mulloop
+
+multail:
+	TESTQ $1, CX
+	JZ    muldone
+	VMOVUPD (SI), X0
+	CMUL((DX), X0, X1, X2)
+	VMOVUPD X0, (DI)
+
+muldone:
+	VZEROUPPER
+	RET
@@ -0,0 +1,168 @@
+//go:build amd64 && !purego
+
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+	"unsafe"
+)
+
+// runScalar runs fn with the AVX2 kernels switched off
+func runScalar(fn func()) {
+	saved := useAVX2
+	useAVX2 = false
+	defer func() { useAVX2 = saved }()
+	fn()
+}
+
+func simdTestInput(n int) []complex128 {
+	input := make([]complex128, n)
+	for i := range input {
+		input[i] = complex(math.Sin(float64(i)*0.71)+0.25, math.Cos(float64(i)*0.23)-float64(i%7)*0.1)
+	}
+	return input
+}
+
+// maxDifference returns the largest |a[i]-b[i]| relative to the largest |b[i]|
+func maxDifference(a, b []complex128) float64 {
+	maxErr, maxAbs := 0.0, 1.0
+	for i := range a {
+		maxErr = math.Max(maxErr, cmplx.Abs(a[i]-b[i]))
+		maxAbs = math.Max(maxAbs, cmplx.Abs(b[i]))
+	}
+	return maxErr / maxAbs
+}
+
+func TestAVX2TableLayout(t *testing.T) {
+	// Must match the offsets defined in simd_amd64.s
+	var table avx2Table
+	offsets := []struct {
+		name      string
+		got, want uintptr
+	}{
+		{"size", unsafe.Sizeof(table), 704},
+		{"rotate", unsafe.Offsetof(table.rotate), 0},
+		{"twiddles8", unsafe.Offsetof(table.twiddles8), 32},
+		{"twiddles16", unsafe.Offsetof(table.twiddles16), 128},
+		{"twiddles32", unsafe.Offsetof(table.twiddles32), 320},
+	}
+	for _, o := range offsets {
+		if o.got != o.want {
+			t.Errorf("%s: got %d, want %d", o.name, o.got, o.want)
+		}
+	}
+}
+
+// TestAVX2MatchesScalar runs each algorithm with an AVX2 kernel on its hot path
+// with and without the kernels and compares the outputs
+func TestAVX2MatchesScalar(t *testing.T) {
+	if !useAVX2 {
+		t.Skip("CPU doesn't support AVX2 and FMA")
+	}
+
+	for _, dir := range []Direction{Forward, Inverse} {
+		testCases := []struct {
+			name string
+			fft  FftInterface
+		}{
+			{"Butterfly2", NewButterfly2(dir)},
+			{"Butterfly4", NewButterfly4(dir)},
+			{"Butterfly8", NewButterfly8(dir)},
+			{"Butterfly16", NewButterfly16(dir)},
+			{"Butterfly32", NewButterfly32(dir)},
+			{"Radix4/4", NewRadix4(4, dir)},
+			{"Radix4/64", NewRadix4(64, dir)},
+			{"Radix4/2048", NewRadix4(2048, dir)},
+			{"Radix4/Scaled", NewRadix4(512, dir).WithScale(0.25)},
+			{"Radix4/Parallel", NewRadix4(4096, dir).WithWorkers(3)},
+			{"Radix4/OddColumns", NewRadix4WithBase(3, NewDft(1, dir))},
+			{"Radix8/8", NewRadix8(8, dir)},
+			{"Radix8/4096", NewRadix8(4096, dir)},
+			{"Radix8/Scaled", NewRadix8(2048, dir).WithScale(0.25)},
+			{"Radix8/Parallel", NewRadix8(1<<15, dir).WithWorkers(3)},
+			{"Radix8/OddColumns", NewRadix8WithBase(2, NewDft(3, dir))},
+			{"Stockham/2048", NewStockham(2048, dir)},
+			{"Stockham/4096", NewStockham(4096, dir)},
+			{"Stockham/Parallel", NewStockham(1<<15, dir).WithWorkers(3)},
+			{"Stockham/OddRuns", NewStockham(8, dir)},
+			{"RadixN/2", NewRadixN([]RadixFactor{Factor2, Factor3, Factor2}, NewDft(5, dir))},
+			{"RadixN/4", NewRadixN([]RadixFactor{Factor4, Factor5, Factor4}, NewButterfly3(dir))},
+			{"RadixN/OddColumns", NewRadixN([]RadixFactor{Factor2, Factor4}, NewDft(1, dir))},
+			{"RadixN/Scaled", NewRadixN([]RadixFactor{Factor4, Factor2}, NewButterfly3(dir)).WithScale(0.5)},
+			{"Bluestein/101", NewBluestein(101, dir)},
+			{"Bluestein/1000", NewBluestein(1000, dir)},
+			{"MixedRadix/35", NewMixedRadix(NewButterfly5(dir), NewButterfly7(dir))},
+		}
+
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("Dir%d/%s", dir, tc.name), func(t *testing.T) {
+				input := simdTestInput(3 * tc.fft.Len())
+
+				process := func() (inplace, outOfPlace []complex128) {
+					inplace = append([]complex128(nil), input...)
+					tc.fft.ProcessWithScratch(inplace, make([]complex128, tc.fft.InplaceScratchLen()))
+
+					in := append([]complex128(nil), input...)
+					outOfPlace = make([]complex128, len(input))
+					tc.fft.ProcessOutOfPlace(in, outOfPlace, make([]complex128, tc.fft.OutOfPlaceScratchLen()))
+					return inplace, outOfPlace
+				}
+
+				gotInplace, gotOutOfPlace := process()
+				var wantInplace, wantOutOfPlace []complex128
+				runScalar(func() { wantInplace, wantOutOfPlace = process() })
+
+				if err := maxDifference(gotInplace, wantInplace); err > 1e-13 {
+					t.Errorf("in-place: relative error %g", err)
+				}
+				if err := maxDifference(gotOutOfPlace, wantOutOfPlace); err > 1e-13 {
+					t.Errorf("out-of-place: relative error %g", err)
+				}
+			})
+		}
+	}
+}
+
+func TestMultiplyAVX2(t *testing.T) {
+	if !useAVX2 {
+		t.Skip("CPU doesn't support AVX2 and FMA")
+	}
+
+	for _, n := range []int{0, 1, 2, 7, 64} {
+		a := simdTestInput(n)
+		b := simdTestInput(n + 3)[3:]
+		got := make([]complex128, n)
+		pointwiseMultiply(got, a, b)
+		for i := range got {
+			if want := a[i] * b[i]; cmplx.Abs(got[i]-want) > 1e-15*cmplx.Abs(want) {
+				t.Errorf("n=%d [%d]: got %v, want %v", n, i, got[i], want)
+			}
+		}
+	}
+}
+
+func BenchmarkAVX2Butterflies(b *testing.B) {
+	for _, fft := range []FftInterface{
+		NewButterfly4(Forward), NewButterfly8(Forward), NewButterfly16(Forward), NewButterfly32(Forward),
+		NewRadix4(1024, Forward), NewBluestein(1000, Forward),
+	} {
+		buffer := simdTestInput(fft.Len() * (4096 / fft.Len()))
+		scratch := make([]complex128, fft.InplaceScratchLen())
+		for _, simd := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%T/%d/avx2=%v", fft, fft.Len(), simd), func(b *testing.B) {
+				if simd && !useAVX2 {
+					b.Skip("CPU doesn't support AVX2 and FMA")
+				}
+				saved := useAVX2
+				useAVX2 = simd
+				defer func() { useAVX2 = saved }()
+				for i := 0; i < b.N; i++ {
+					fft.ProcessWithScratch(buffer, scratch)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !amd64 || purego
+
+package algorithm
+
+// useAVX2 is always false without the amd64 assembly, so the kernels below
+// are never called
+const useAVX2 = false
+
+// AVX2Enabled reports whether the complex128 algorithms use the AVX2 kernels
+func AVX2Enabled() bool { return false }
+
+func butterfly2AVX2(dst, src []complex128)                       { panic("unreachable") }
+func butterfly4AVX2(dst, src []complex128, direction Direction)  { panic("unreachable") }
+func butterfly8AVX2(dst, src []complex128, direction Direction)  { panic("unreachable") }
+func butterfly16AVX2(dst, src []complex128, direction Direction) { panic("unreachable") }
+func butterfly32AVX2(dst, src []complex128, direction Direction) { panic("unreachable") }
+
+func radix2ColumnsAVX2(data, twiddles []complex128, columns, start, end int) {
+	panic("unreachable")
+}
+
+func radix4ColumnsAVX2(data, twiddles []complex128, columns, start, end int, direction Direction) {
+	panic("unreachable")
+}
+
+func radix8ColumnsAVX2(data, twiddles []complex128, columns, start, end int, direction Direction) {
+	panic("unreachable")
+}
+
+func stockhamColumnsAVX2(dst, src, twiddles []complex128, quarter, stride, count int, direction Direction) {
+	panic("unreachable")
+}
+
+func multiplyAVX2(dst, a, b []complex128) { panic("unreachable") }
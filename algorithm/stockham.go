@@ -0,0 +1,262 @@
+package algorithm
+
+// Stockham implements a Stockham autosort FFT for power-of-two sizes
+// Each radix-4 stage reads one buffer and writes the next in an order that
+// leaves the output sorted, so there is no bit-reversal pass, and every stage
+// streams through memory in runs of the current stride. The stages ping-pong
+// between the output and a second buffer.
+type Stockham struct {
+	length    int
+	direction Direction
+	twiddles  []complex128 // W^p, W^2p, W^3p for each p of each stage with twiddles
+	stages    int
+	workers   int
+	scale     complex128 // Output scale, applied by the last stage
+}
+
+// NewStockham creates a new Stockham FFT instance for the given power-of-two length
+func NewStockham(length int, direction Direction) *Stockham {
+	if !isPowerOfTwo(length) {
+		panic("Stockham algorithm requires a power-of-two input size")
+	}
+
+	// Radix-4 stages with twiddles down to a sub-length of 8, then a
+	// twiddle-free radix-4 or radix-2 stage
+	var twiddles []complex128
+	stages := 0
+	n := length
+	for ; n >= 8; n /= 4 {
+		for p := 0; p < n/4; p++ {
+			twiddles = append(twiddles,
+				twiddleFactor(p, n, direction),
+				twiddleFactor(2*p, n, direction),
+				twiddleFactor(3*p, n, direction))
+		}
+		stages++
+	}
+	if n > 1 {
+		stages++
+	}
+
+	return &Stockham{
+		length:    length,
+		direction: direction,
+		twiddles:  twiddles,
+		stages:    stages,
+		scale:     1,
+	}
+}
+
+func (s *Stockham) Len() int             { return s.length }
+func (s *Stockham) Direction() Direction { return s.direction }
+
+func (s *Stockham) InplaceScratchLen() int { return s.length }
+
+// OutOfPlaceScratchLen is zero for an odd number of stages, since the input
+// can then take the place of the second buffer
+func (s *Stockham) OutOfPlaceScratchLen() int {
+	if s.stages%2 == 1 {
+		return 0
+	}
+	return s.length
+}
+
+func (s *Stockham) ImmutableScratchLen() int {
+	if s.stages <= 1 {
+		return 0
+	}
+	return s.length
+}
+
+// WithWorkers returns a copy of s that splits each stage across up to workers
+// goroutines. Every call then allocates, so this only pays off for large
+// lengths.
+func (s *Stockham) WithWorkers(workers int) *Stockham {
+	parallel := *s
+	parallel.workers = workers
+	return &parallel
+}
+
+// WithScale returns a copy of s whose output is multiplied by scale
+// The last stage applies the factor as it writes the output.
+func (s *Stockham) WithScale(scale float64) *Stockham {
+	scaled := *s
+	scaled.scale = s.scale * complex(scale, 0)
+	return &scaled
+}
+
+func (s *Stockham) Process(buffer []complex128) {
+	s.ProcessWithScratch(buffer, make([]complex128, s.InplaceScratchLen()))
+}
+
+func (s *Stockham) ProcessWithScratch(buffer, scratch []complex128) {
+	checkInplace(len(buffer), s.Len(), len(scratch), s.InplaceScratchLen())
+
+	scratch = scratch[:s.length]
+	for i := 0; i < len(buffer); i += s.length {
+		chunk := buffer[i : i+s.length]
+		if s.stages%2 == 0 {
+			// The first stage moves the data to scratch and the last one back
+			s.run(chunk, chunk, scratch)
+		} else {
+			s.run(chunk, scratch, chunk)
+			copy(chunk, scratch)
+		}
+	}
+}
+
+func (s *Stockham) ProcessOutOfPlace(input, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), s.Len(), len(scratch), s.OutOfPlaceScratchLen())
+
+	for i := 0; i < len(input); i += s.length {
+		in := input[i : i+s.length]
+		other := in
+		if s.stages%2 == 0 {
+			other = scratch[:s.length]
+		}
+		s.run(in, output[i:i+s.length], other)
+	}
+}
+
+func (s *Stockham) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	checkOutOfPlace(len(input), len(output), s.Len(), len(scratch), s.ImmutableScratchLen())
+
+	for i := 0; i < len(input); i += s.length {
+		s.run(input[i:i+s.length], output[i:i+s.length], scratch)
+	}
+}
+
+// run computes the FFT of input into output, with other as the second buffer
+// of the ping-pong. The stages alternate so that the last one writes output;
+// input is only read by the first, so other may be input if that stage
+// writes output.
+func (s *Stockham) run(input, output, other []complex128) {
+	if s.length == 1 {
+		output[0] = input[0] * s.scale
+		return
+	}
+
+	src := input
+	twiddles := s.twiddles
+	n, stride := s.length, 1
+	for stage := 0; stage < s.stages; stage++ {
+		dst := output
+		if (s.stages-1-stage)%2 == 1 {
+			dst = other[:s.length]
+		}
+
+		// The last stage has a single twiddle group and no twiddles
+		groups, stageTwiddles := 1, twiddles[:0]
+		if n >= 8 {
+			groups, stageTwiddles = n/4, twiddles[:3*(n/4)]
+		}
+		switch {
+		case s.workers <= 1:
+			s.stage(src, dst, stageTwiddles, n, stride, 0, groups, 0, stride)
+		case groups >= s.workers:
+			ParallelFor(s.workers, groups, func(_, start, end int) {
+				s.stage(src, dst, stageTwiddles, n, stride, start, end, 0, stride)
+			})
+		default:
+			// Few twiddle groups, so split the runs instead
+			ParallelFor(s.workers, stride, func(_, start, end int) {
+				s.stage(src, dst, stageTwiddles, n, stride, 0, groups, start, end)
+			})
+		}
+
+		src = dst
+		twiddles = twiddles[len(stageTwiddles):]
+		n /= 4
+		stride *= 4
+	}
+}
+
+// stage runs twiddle groups [pStart, pEnd) of the stage for sub-length n, on
+// elements [qStart, qEnd) of each run of stride elements
+func (s *Stockham) stage(src, dst, twiddles []complex128, n, stride, pStart, pEnd, qStart, qEnd int) {
+	switch n {
+	case 2:
+		stockhamLast2(src, dst, stride, qStart, qEnd, s.scale)
+	case 4:
+		stockhamLast4(src, dst, stride, qStart, qEnd, s.scale, s.direction)
+	default:
+		stockhamStage(src, dst, twiddles, n, stride, pStart, pEnd, qStart, qEnd, s.direction)
+	}
+}
+
+// stockhamStage runs twiddle groups [pStart, pEnd) of the radix-4 stage for
+// sub-length n, on elements [qStart, qEnd) of each run of stride elements.
+// Input run p+k·n/4 feeds output run 4p+k, multiplied by W^(kp).
+func stockhamStage(src, dst, twiddles []complex128, n, stride, pStart, pEnd, qStart, qEnd int, direction Direction) {
+	quarter := n / 4 * stride
+	for p := pStart; p < pEnd; p++ {
+		w1, w2, w3 := twiddles[3*p], twiddles[3*p+1], twiddles[3*p+2]
+
+		in := src[p*stride:]
+		a, b, c, d := in[qStart:qEnd], in[quarter+qStart:quarter+qEnd], in[2*quarter+qStart:2*quarter+qEnd], in[3*quarter+qStart:3*quarter+qEnd]
+		out := dst[4*p*stride:]
+		y0, y1, y2, y3 := out[qStart:qEnd], out[stride+qStart:stride+qEnd], out[2*stride+qStart:2*stride+qEnd], out[3*stride+qStart:3*stride+qEnd]
+		b, c, d = b[:len(a)], c[:len(a)], d[:len(a)]
+		y0, y1, y2, y3 = y0[:len(a)], y1[:len(a)], y2[:len(a)], y3[:len(a)]
+
+		first := 0
+		if useAVX2 && len(a) >= 2 {
+			first = len(a) &^ 1
+			stockhamColumnsAVX2(out[qStart:], in[qStart:], twiddles[3*p:3*p+3], quarter, stride, first, direction)
+		}
+
+		for q := first; q < len(a); q++ {
+			apc, amc := a[q]+c[q], a[q]-c[q]
+			bpd, bmd := b[q]+d[q], rotate90(b[q]-d[q], direction)
+			y0[q] = apc + bpd
+			y1[q] = (amc + bmd) * w1
+			y2[q] = (apc - bpd) * w2
+			y3[q] = (amc - bmd) * w3
+		}
+	}
+}
+
+// stockhamLast4 is the final radix-4 stage, whose twiddles are all one
+func stockhamLast4(src, dst []complex128, stride, start, end int, scale complex128, direction Direction) {
+	a, b, c, d := src[start:end], src[stride+start:stride+end], src[2*stride+start:2*stride+end], src[3*stride+start:3*stride+end]
+	y0, y1, y2, y3 := dst[start:end], dst[stride+start:stride+end], dst[2*stride+start:2*stride+end], dst[3*stride+start:3*stride+end]
+	b, c, d = b[:len(a)], c[:len(a)], d[:len(a)]
+	y0, y1, y2, y3 = y0[:len(a)], y1[:len(a)], y2[:len(a)], y3[:len(a)]
+
+	if scale != 1 {
+		for q := range a {
+			apc, amc := (a[q]+c[q])*scale, (a[q]-c[q])*scale
+			bpd, bmd := (b[q]+d[q])*scale, rotate90(b[q]-d[q], direction)*scale
+			y0[q], y1[q], y2[q], y3[q] = apc+bpd, amc+bmd, apc-bpd, amc-bmd
+		}
+		return
+	}
+
+	first := 0
+	if useAVX2 && len(a) >= 2 {
+		first = len(a) &^ 1
+		stockhamColumnsAVX2(dst[start:], src[start:], nil, stride, stride, first, direction)
+	}
+	for q := first; q < len(a); q++ {
+		apc, amc := a[q]+c[q], a[q]-c[q]
+		bpd, bmd := b[q]+d[q], rotate90(b[q]-d[q], direction)
+		y0[q], y1[q], y2[q], y3[q] = apc+bpd, amc+bmd, apc-bpd, amc-bmd
+	}
+}
+
+// stockhamLast2 is the final radix-2 stage for odd powers of two
+func stockhamLast2(src, dst []complex128, stride, start, end int, scale complex128) {
+	a, b := src[start:end], src[stride+start:stride+end]
+	y0, y1 := dst[start:end], dst[stride+start:stride+end]
+	b, y0, y1 = b[:len(a)], y0[:len(a)], y1[:len(a)]
+
+	if scale != 1 {
+		for q := range a {
+			y0[q], y1[q] = (a[q]+b[q])*scale, (a[q]-b[q])*scale
+		}
+		return
+	}
+	for q := range a {
+		y0[q], y1[q] = a[q]+b[q], a[q]-b[q]
+	}
+}
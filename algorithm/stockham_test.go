@@ -0,0 +1,48 @@
+package algorithm
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStockhamMatchesDft(t *testing.T) {
+	for _, dir := range []Direction{Forward, Inverse} {
+		// Odd and even stage counts, with radix-2 and radix-4 last stages
+		for exponent := 0; exponent <= 13; exponent++ {
+			n := 1 << exponent
+			t.Run(fmt.Sprintf("Size%d/Dir%d", n, dir), func(t *testing.T) {
+				checkMatchesDft(t, NewStockham(n, dir))
+			})
+		}
+	}
+}
+
+// BenchmarkPowerOfTwo compares the power-of-two algorithms the planner picks between
+func BenchmarkPowerOfTwo(b *testing.B) {
+	for _, exponent := range []int{10, 14, 18, 20, 22} {
+		n := 1 << exponent
+		algorithms := []struct {
+			name string
+			fft  FftInterface
+		}{
+			{"Radix4", NewRadix4(n, Forward)},
+			{"Radix8", NewRadix8(n, Forward)},
+			{"Stockham", NewStockham(n, Forward)},
+			{"FourStep", NewFourStep(NewRadix8(n>>(exponent/2), Forward), NewRadix8(1<<(exponent/2), Forward))},
+		}
+		for _, alg := range algorithms {
+			b.Run(fmt.Sprintf("%s/Size%d", alg.name, n), func(b *testing.B) {
+				buffer := make([]complex128, n)
+				for i := range buffer {
+					buffer[i] = complex(float64(i%7), float64(i%3))
+				}
+				scratch := make([]complex128, alg.fft.InplaceScratchLen())
+				b.SetBytes(int64(n * 16))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					alg.fft.ProcessWithScratch(buffer, scratch)
+				}
+			})
+		}
+	}
+}
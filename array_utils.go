@@ -1,20 +1,37 @@
 package gofft
 
+// transposeBlockSize is the tile edge used by the blocked transposes
+// A 16x16 tile of complex128 is 4KB, so a source and destination tile fit in L1
+const transposeBlockSize = 16
+
 // Transpose performs an out-of-place matrix transpose
 // data is treated as a rows x cols matrix stored in row-major order
+// The matrix is walked in square tiles so both input and output stay cache-resident
 func Transpose(input, output []complex128, rows, cols int) {
-	for r := 0; r < rows; r++ {
-		for c := 0; c < cols; c++ {
-			output[c*rows+r] = input[r*cols+c]
+	for r0 := 0; r0 < rows; r0 += transposeBlockSize {
+		rEnd := min(r0+transposeBlockSize, rows)
+		for c0 := 0; c0 < cols; c0 += transposeBlockSize {
+			cEnd := min(c0+transposeBlockSize, cols)
+			for r := r0; r < rEnd; r++ {
+				for c := c0; c < cEnd; c++ {
+					output[c*rows+r] = input[r*cols+c]
+				}
+			}
 		}
 	}
 }
 
 // Transpose32 performs an out-of-place matrix transpose for complex64
 func Transpose32(input, output []complex64, rows, cols int) {
-	for r := 0; r < rows; r++ {
-		for c := 0; c < cols; c++ {
-			output[c*rows+r] = input[r*cols+c]
+	for r0 := 0; r0 < rows; r0 += transposeBlockSize {
+		rEnd := min(r0+transposeBlockSize, rows)
+		for c0 := 0; c0 < cols; c0 += transposeBlockSize {
+			cEnd := min(c0+transposeBlockSize, cols)
+			for r := r0; r < rEnd; r++ {
+				for c := c0; c < cEnd; c++ {
+					output[c*rows+r] = input[r*cols+c]
+				}
+			}
 		}
 	}
 }
@@ -0,0 +1,182 @@
+package gofft
+
+// CheckedFft wraps an Fft with TryProcess variants that return an error
+// instead of panicking when given malformed buffers. This makes it safe to
+// run transforms on untrusted sizes, e.g. in a server handling requests.
+//
+// The errors wrap ErrBufferTooSmall, ErrNotMultipleOfLen, ErrScratchTooSmall
+// or ErrLengthMismatch. The panicking methods of the wrapped Fft remain available.
+type CheckedFft struct {
+	Fft
+}
+
+// NewCheckedFft wraps fft with error-returning process methods
+func NewCheckedFft(fft Fft) CheckedFft {
+	return CheckedFft{Fft: fft}
+}
+
+// TryProcess is like Process but returns an error instead of panicking
+func (c CheckedFft) TryProcess(buffer []complex128) error {
+	if err := checkInplace(len(buffer), c.Len(), 0, 0); err != nil {
+		return err
+	}
+	c.Process(buffer)
+	return nil
+}
+
+// TryProcessWithScratch is like ProcessWithScratch but returns an error instead of panicking
+func (c CheckedFft) TryProcessWithScratch(buffer, scratch []complex128) error {
+	if err := checkInplace(len(buffer), c.Len(), len(scratch), c.InplaceScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessWithScratch(buffer, scratch)
+	return nil
+}
+
+// TryProcessOutOfPlace is like ProcessOutOfPlace but returns an error instead of panicking
+func (c CheckedFft) TryProcessOutOfPlace(input, output, scratch []complex128) error {
+	if err := checkOutOfPlace(len(input), len(output), c.Len(), len(scratch), c.OutOfPlaceScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessOutOfPlace(input, output, scratch)
+	return nil
+}
+
+// TryProcessImmutable is like ProcessImmutable but returns an error instead of panicking
+func (c CheckedFft) TryProcessImmutable(input []complex128, output, scratch []complex128) error {
+	if err := checkOutOfPlace(len(input), len(output), c.Len(), len(scratch), c.ImmutableScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessImmutable(input, output, scratch)
+	return nil
+}
+
+// CheckedFft32 is the complex64 counterpart of CheckedFft
+type CheckedFft32 struct {
+	Fft32
+}
+
+// NewCheckedFft32 wraps fft with error-returning process methods
+func NewCheckedFft32(fft Fft32) CheckedFft32 {
+	return CheckedFft32{Fft32: fft}
+}
+
+// TryProcess is like Process but returns an error instead of panicking
+func (c CheckedFft32) TryProcess(buffer []complex64) error {
+	if err := checkInplace(len(buffer), c.Len(), 0, 0); err != nil {
+		return err
+	}
+	c.Process(buffer)
+	return nil
+}
+
+// TryProcessWithScratch is like ProcessWithScratch but returns an error instead of panicking
+func (c CheckedFft32) TryProcessWithScratch(buffer, scratch []complex64) error {
+	if err := checkInplace(len(buffer), c.Len(), len(scratch), c.InplaceScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessWithScratch(buffer, scratch)
+	return nil
+}
+
+// TryProcessOutOfPlace is like ProcessOutOfPlace but returns an error instead of panicking
+func (c CheckedFft32) TryProcessOutOfPlace(input, output, scratch []complex64) error {
+	if err := checkOutOfPlace(len(input), len(output), c.Len(), len(scratch), c.OutOfPlaceScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessOutOfPlace(input, output, scratch)
+	return nil
+}
+
+// TryProcessImmutable is like ProcessImmutable but returns an error instead of panicking
+func (c CheckedFft32) TryProcessImmutable(input []complex64, output, scratch []complex64) error {
+	if err := checkOutOfPlace(len(input), len(output), c.Len(), len(scratch), c.ImmutableScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessImmutable(input, output, scratch)
+	return nil
+}
+
+// CheckedFftND wraps an FftND with error-returning process methods
+type CheckedFftND struct {
+	FftND
+}
+
+// NewCheckedFftND wraps fft with error-returning process methods
+func NewCheckedFftND(fft FftND) CheckedFftND {
+	return CheckedFftND{FftND: fft}
+}
+
+// TryProcess is like Process but returns an error instead of panicking
+func (c CheckedFftND) TryProcess(buffer []complex128) error {
+	if err := checkInplace(len(buffer), c.Len(), 0, 0); err != nil {
+		return err
+	}
+	c.Process(buffer)
+	return nil
+}
+
+// TryProcessWithScratch is like ProcessWithScratch but returns an error instead of panicking
+func (c CheckedFftND) TryProcessWithScratch(buffer, scratch []complex128) error {
+	if err := checkInplace(len(buffer), c.Len(), len(scratch), c.InplaceScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessWithScratch(buffer, scratch)
+	return nil
+}
+
+// CheckedRealToComplex wraps a RealToComplex with error-returning process methods
+type CheckedRealToComplex struct {
+	RealToComplex
+}
+
+// NewCheckedRealToComplex wraps fft with error-returning process methods
+func NewCheckedRealToComplex(fft RealToComplex) CheckedRealToComplex {
+	return CheckedRealToComplex{RealToComplex: fft}
+}
+
+// TryProcess is like Process but returns an error instead of panicking
+func (c CheckedRealToComplex) TryProcess(input []float64, output []complex128) error {
+	if err := checkRealToComplex(len(input), c.Len(), len(output), c.OutputLen(), 0, 0); err != nil {
+		return err
+	}
+	c.Process(input, output)
+	return nil
+}
+
+// TryProcessWithScratch is like ProcessWithScratch but returns an error instead of panicking
+func (c CheckedRealToComplex) TryProcessWithScratch(input []float64, output, scratch []complex128) error {
+	if err := checkRealToComplex(len(input), c.Len(), len(output), c.OutputLen(), len(scratch), c.ScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessWithScratch(input, output, scratch)
+	return nil
+}
+
+// CheckedComplexToReal wraps a ComplexToReal with error-returning process methods
+type CheckedComplexToReal struct {
+	ComplexToReal
+}
+
+// NewCheckedComplexToReal wraps fft with error-returning process methods
+func NewCheckedComplexToReal(fft ComplexToReal) CheckedComplexToReal {
+	return CheckedComplexToReal{ComplexToReal: fft}
+}
+
+// TryProcess is like Process but returns an error instead of panicking
+func (c CheckedComplexToReal) TryProcess(input []complex128, output []float64) error {
+	if err := checkComplexToReal(len(input), c.InputLen(), len(output), c.Len(), 0, 0); err != nil {
+		return err
+	}
+	c.Process(input, output)
+	return nil
+}
+
+// TryProcessWithScratch is like ProcessWithScratch but returns an error instead of panicking
+func (c CheckedComplexToReal) TryProcessWithScratch(input []complex128, output []float64, scratch []complex128) error {
+	if err := checkComplexToReal(len(input), c.InputLen(), len(output), c.Len(), len(scratch), c.ScratchLen()); err != nil {
+		return err
+	}
+	c.ProcessWithScratch(input, output, scratch)
+	return nil
+}
@@ -0,0 +1,127 @@
+package gofft
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckedFftErrors(t *testing.T) {
+	planner := NewPlanner()
+	// 1031 is prime and large enough to use Bluestein, which used to index
+	// out of range on short scratch instead of reporting it
+	for _, n := range []int{256, 60, 1031} {
+		fft := NewCheckedFft(planner.PlanForward(n))
+		buffer := make([]complex128, n)
+		scratch := make([]complex128, fft.InplaceScratchLen())
+
+		tests := []struct {
+			name string
+			err  error
+			want error
+		}{
+			{"Empty", fft.TryProcess(nil), ErrBufferTooSmall},
+			{"Short", fft.TryProcess(buffer[:n-1]), ErrBufferTooSmall},
+			{"NotMultiple", fft.TryProcess(make([]complex128, n+1)), ErrNotMultipleOfLen},
+			{"ShortScratch", fft.TryProcessWithScratch(buffer, scratch[:len(scratch)/2]), ErrScratchTooSmall},
+			{"Mismatch", fft.TryProcessOutOfPlace(buffer, make([]complex128, 2*n), nil), ErrLengthMismatch},
+			{"ImmutableShort", fft.TryProcessImmutable(buffer[:1], buffer[:1], nil), ErrBufferTooSmall},
+			{"Valid", fft.TryProcessWithScratch(make([]complex128, 2*n), scratch), nil},
+		}
+		for _, tt := range tests {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("size %d, %s: got %v, want %v", n, tt.name, tt.err, tt.want)
+			}
+		}
+	}
+}
+
+func TestCheckedFftMatchesProcess(t *testing.T) {
+	n := 30
+	planner := NewPlanner()
+	fft := NewCheckedFft(planner.PlanForward(n))
+
+	input := make([]complex128, n)
+	for i := range input {
+		input[i] = complex(float64(i), float64(n-i))
+	}
+	expected := naiveDFT(input, true)
+
+	output := make([]complex128, n)
+	if err := fft.TryProcessImmutable(input, output, make([]complex128, fft.ImmutableScratchLen())); err != nil {
+		t.Fatalf("TryProcessImmutable: %v", err)
+	}
+	if !complexSlicesEqual(output, expected, 1e-9) {
+		t.Errorf("TryProcessImmutable output doesn't match DFT")
+	}
+
+	if err := fft.TryProcess(input); err != nil {
+		t.Fatalf("TryProcess: %v", err)
+	}
+	if !complexSlicesEqual(input, expected, 1e-9) {
+		t.Errorf("TryProcess output doesn't match DFT")
+	}
+}
+
+func TestCheckedFftZeroLength(t *testing.T) {
+	// The modulo in the check used to divide by zero for a length 0 plan
+	fft := NewCheckedFft(NewPlanner().PlanForward(0))
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty", fft.TryProcess(nil), nil},
+		{"EmptyOutOfPlace", fft.TryProcessOutOfPlace(nil, nil, nil), nil},
+		{"NonEmpty", fft.TryProcess(make([]complex128, 4)), ErrNotMultipleOfLen},
+		{"NonEmptyOutOfPlace", fft.TryProcessOutOfPlace(make([]complex128, 4), make([]complex128, 4), nil), ErrNotMultipleOfLen},
+		{"Mismatch", fft.TryProcessOutOfPlace(nil, make([]complex128, 4), nil), ErrLengthMismatch},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func TestCheckedRealAndNDErrors(t *testing.T) {
+	realPlanner := NewRealFftPlanner()
+	r2c := NewCheckedRealToComplex(realPlanner.PlanForward(16))
+	c2r := NewCheckedComplexToReal(realPlanner.PlanInverse(16))
+	c2rOdd := NewCheckedComplexToReal(realPlanner.PlanInverse(15))
+	nd := NewCheckedFftND(NewPlanner().Plan2D(4, 6, Forward))
+	fft32 := NewCheckedFft32(NewPlanner32().PlanForward(12))
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"R2CShortInput", r2c.TryProcess(make([]float64, 8), make([]complex128, 9)), ErrBufferTooSmall},
+		{"R2CWrongOutput", r2c.TryProcess(make([]float64, 16), make([]complex128, 8)), ErrLengthMismatch},
+		{"R2CValid", r2c.TryProcess(make([]float64, 32), make([]complex128, 18)), nil},
+		{"C2RNotMultiple", c2r.TryProcess(make([]complex128, 9), make([]float64, 20)), ErrNotMultipleOfLen},
+		{"C2RShortScratch", c2rOdd.TryProcessWithScratch(make([]complex128, 8), make([]float64, 15), nil), ErrScratchTooSmall},
+		{"NDNotMultiple", nd.TryProcess(make([]complex128, 30)), ErrNotMultipleOfLen},
+		{"NDValid", nd.TryProcess(make([]complex128, 48)), nil},
+		{"Fft32Short", fft32.TryProcess(make([]complex64, 6)), ErrBufferTooSmall},
+		{"Fft32Valid", fft32.TryProcess(make([]complex64, 12)), nil},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func TestProcessPanicsWithTypedError(t *testing.T) {
+	fft := NewPlanner().PlanForward(1031)
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrScratchTooSmall) {
+			t.Errorf("expected panic wrapping ErrScratchTooSmall, got %v", err)
+		}
+	}()
+	fft.ProcessWithScratch(make([]complex128, 1031), nil)
+}
@@ -0,0 +1,449 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxLineLen is where long sums are wrapped, matching the hand-written butterflies
+const maxLineLen = 100
+
+// fraction is the twiddle W_n^k = exp(∓2πik/n), with k/n in lowest terms
+type fraction struct{ k, n int }
+
+// butterfly accumulates the straight-line code of one butterfly
+type butterfly struct {
+	n           int
+	typeName    string
+	elem        string // complex128 or complex64
+	twiddleFunc string
+	rotateFunc  string
+	plan        string // Factorization used at the top level, for doc comments
+
+	body         []string
+	vars         int
+	depth        int
+	twiddles     []fraction
+	twiddleIndex map[fraction]int
+	parts        map[int]bool // Twiddles whose real and imaginary parts are used
+}
+
+func newButterfly(n int, complex64 bool) *butterfly {
+	b := &butterfly{
+		n:            n,
+		typeName:     fmt.Sprintf("Butterfly%d", n),
+		elem:         "complex128",
+		twiddleFunc:  "twiddleFactor",
+		rotateFunc:   "rotate90",
+		twiddleIndex: make(map[fraction]int),
+		parts:        make(map[int]bool),
+	}
+	if complex64 {
+		b.typeName += "_32"
+		b.elem = "complex64"
+		b.twiddleFunc = "twiddleFactor32"
+		b.rotateFunc = "rotate90_32"
+	}
+
+	switch n1, n2 := split(n); {
+	case n == 2 || n == 4:
+		b.plan = fmt.Sprintf("radix-%d", n)
+	case isPrime(n):
+		b.plan = "prime size"
+	case gcd(n1, n2) == 1:
+		b.plan = fmt.Sprintf("Good-Thomas %dx%d", n1, n2)
+	default:
+		b.plan = fmt.Sprintf("mixed radix %dx%d", n1, n2)
+	}
+
+	inputs := make([]string, n)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("x%d", i)
+	}
+	outputs := b.fft(inputs)
+
+	// Loads first, then the twiddle parts the prime kernels need, so that
+	// performFft works in place
+	var head []string
+	for i := 0; i < n; i += 4 {
+		end := min(i+4, n)
+		var names, loads []string
+		for j := i; j < end; j++ {
+			names = append(names, inputs[j])
+			loads = append(loads, fmt.Sprintf("buffer[%d]", j))
+		}
+		head = append(head, strings.Join(names, ", ")+" := "+strings.Join(loads, ", "))
+	}
+	if len(b.parts) > 0 {
+		head = append(head, "")
+		for i := range b.twiddles {
+			if b.parts[i] {
+				head = append(head, fmt.Sprintf("t%dr, t%di := real(b.twiddles[%d]), imag(b.twiddles[%d])", i, i, i, i))
+			}
+		}
+	}
+	b.body = append(head, b.body...)
+
+	b.body = append(b.body, "")
+	for k, out := range outputs {
+		b.body = append(b.body, fmt.Sprintf("buffer[%d] = %s", k, out))
+	}
+	return b
+}
+
+func (b *butterfly) fresh() string {
+	b.vars++
+	return fmt.Sprintf("v%d", b.vars-1)
+}
+
+func (b *butterfly) emit(format string, args ...any) {
+	b.body = append(b.body, wrap(fmt.Sprintf(format, args...)))
+}
+
+// comment emits a comment for the top-level stages only
+func (b *butterfly) comment(format string, args ...any) {
+	if b.depth == 0 {
+		b.body = append(b.body, "", "// "+fmt.Sprintf(format, args...))
+	}
+}
+
+// twiddle returns the index of W_n^k in the twiddles array
+func (b *butterfly) twiddle(k, n int) int {
+	g := gcd(k, n)
+	f := fraction{k / g, n / g}
+	if i, ok := b.twiddleIndex[f]; ok {
+		return i
+	}
+	b.twiddles = append(b.twiddles, f)
+	b.twiddleIndex[f] = len(b.twiddles) - 1
+	return len(b.twiddles) - 1
+}
+
+// fft emits the DFT of the named inputs and returns the names of the outputs
+func (b *butterfly) fft(in []string) []string {
+	n := len(in)
+	switch {
+	case n == 1:
+		return in
+	case n == 2:
+		o0, o1 := b.fresh(), b.fresh()
+		b.emit("%s, %s := %s+%s, %s-%s", o0, o1, in[0], in[1], in[0], in[1])
+		return []string{o0, o1}
+	case n == 4:
+		return b.radix4(in)
+	case isPrime(n):
+		return b.prime(in)
+	}
+
+	n1, n2 := split(n)
+	if gcd(n1, n2) == 1 {
+		return b.goodThomas(in, n1, n2)
+	}
+	return b.mixedRadix(in, n1, n2)
+}
+
+// subFft emits an FFT nested in a factorization, without stage comments
+func (b *butterfly) subFft(in []string) []string {
+	b.depth++
+	defer func() { b.depth-- }()
+	return b.fft(in)
+}
+
+func (b *butterfly) radix4(in []string) []string {
+	s0, s1, s2, s3 := b.fresh(), b.fresh(), b.fresh(), b.fresh()
+	b.emit("%s, %s := %s+%s, %s-%s", s0, s1, in[0], in[2], in[0], in[2])
+	b.emit("%s, %s := %s+%s, %s(%s-%s, b.direction)", s2, s3, in[1], in[3], b.rotateFunc, in[1], in[3])
+	out := []string{b.fresh(), b.fresh(), b.fresh(), b.fresh()}
+	b.emit("%s, %s, %s, %s := %s+%s, %s+%s, %s-%s, %s-%s", out[0], out[1], out[2], out[3],
+		s0, s2, s1, s3, s0, s2, s1, s3)
+	return out
+}
+
+// prime emits a prime-sized DFT with symmetric pairs
+// With p = x[j] + x[n-j] and q = x[j] - x[n-j], X[k] and X[n-k] share the sums
+// A = x[0] + Σ Re(W^jk)·p and B = Σ Im(W^jk)·q, and are A ± iB.
+func (b *butterfly) prime(in []string) []string {
+	n := len(in)
+	half := (n - 1) / 2
+
+	p, q := make([]string, half+1), make([]string, half+1)
+	for j := 1; j <= half; j++ {
+		p[j], q[j] = b.fresh(), b.fresh()
+		b.emit("%s, %s := %s+%s, %s-%s", p[j], q[j], in[j], in[n-j], in[j], in[n-j])
+	}
+
+	// W^j for j = 1..half; the other twiddles are their conjugates
+	tw := make([]int, half+1)
+	for j := 1; j <= half; j++ {
+		tw[j] = b.twiddle(j, n)
+		b.parts[tw[j]] = true
+	}
+
+	out := make([]string, n)
+	out[0] = b.fresh()
+	b.emit("%s := %s + %s", out[0], in[0], strings.Join(p[1:], " + "))
+
+	for k := 1; k <= half; k++ {
+		ar := []string{"real(" + in[0] + ")"}
+		ai := []string{"imag(" + in[0] + ")"}
+		var br, bi []string
+		for j := 1; j <= half; j++ {
+			r, sign := j*k%n, "+"
+			if r > half {
+				r, sign = n-r, "-"
+			}
+			ar = append(ar, fmt.Sprintf("+ t%dr*real(%s)", tw[r], p[j]))
+			ai = append(ai, fmt.Sprintf("+ t%dr*imag(%s)", tw[r], p[j]))
+			br = append(br, fmt.Sprintf("%s t%di*real(%s)", sign, tw[r], q[j]))
+			bi = append(bi, fmt.Sprintf("%s t%di*imag(%s)", sign, tw[r], q[j]))
+		}
+		// The first imaginary term takes its sign as a unary operator
+		for _, terms := range [][]string{br, bi} {
+			terms[0] = strings.Replace(strings.TrimPrefix(terms[0], "+ "), "- ", "-", 1)
+		}
+
+		id := b.vars
+		b.vars++
+		b.emit("ar%d := %s", id, strings.Join(ar, " "))
+		b.emit("ai%d := %s", id, strings.Join(ai, " "))
+		b.emit("br%d := %s", id, strings.Join(br, " "))
+		b.emit("bi%d := %s", id, strings.Join(bi, " "))
+		out[k], out[n-k] = b.fresh(), b.fresh()
+		b.emit("%s, %s := complex(ar%d-bi%d, ai%d+br%d), complex(ar%d+bi%d, ai%d-br%d)",
+			out[k], out[n-k], id, id, id, id, id, id, id, id)
+	}
+	return out
+}
+
+// mixedRadix emits n2 FFTs of size n1 over the columns x[c + n2·r], multiplies
+// output k1 of column c by W^(c·k1), then emits n1 FFTs of size n2 over the rows
+func (b *butterfly) mixedRadix(in []string, n1, n2 int) []string {
+	n := len(in)
+
+	b.comment("%d FFTs of size %d over the columns, then twiddles", n2, n1)
+	columns := make([][]string, n2)
+	for c := range columns {
+		column := make([]string, n1)
+		for r := range column {
+			column[r] = in[c+n2*r]
+		}
+		columns[c] = b.subFft(column)
+		for k1 := 1; k1 < n1; k1++ {
+			columns[c][k1] = b.multiplyTwiddle(columns[c][k1], c*k1, n)
+		}
+	}
+
+	b.comment("%d FFTs of size %d over the rows", n1, n2)
+	out := make([]string, n)
+	for k1 := 0; k1 < n1; k1++ {
+		row := make([]string, n2)
+		for c := range row {
+			row[c] = columns[c][k1]
+		}
+		for k2, v := range b.subFft(row) {
+			out[k1+n1*k2] = v
+		}
+	}
+	return out
+}
+
+// goodThomas emits the prime-factor algorithm for coprime n1 and n2
+// Input index (n2·a + n1·c) mod n and the output index from the Chinese
+// remainder theorem turn the DFT into a 2D one with no twiddles.
+func (b *butterfly) goodThomas(in []string, n1, n2 int) []string {
+	n := len(in)
+
+	b.comment("%d FFTs of size %d over the columns", n2, n1)
+	columns := make([][]string, n2)
+	for c := range columns {
+		column := make([]string, n1)
+		for a := range column {
+			column[a] = in[(n2*a+n1*c)%n]
+		}
+		columns[c] = b.subFft(column)
+	}
+
+	b.comment("%d FFTs of size %d over the rows", n1, n2)
+	out := make([]string, n)
+	for k1 := 0; k1 < n1; k1++ {
+		row := make([]string, n2)
+		for c := range row {
+			row[c] = columns[c][k1]
+		}
+		for k2, v := range b.subFft(row) {
+			out[crt(k1, n1, k2, n2)] = v
+		}
+	}
+	return out
+}
+
+// multiplyTwiddle emits v·W_n^e, using a negation or rotation where possible
+func (b *butterfly) multiplyTwiddle(v string, e, n int) string {
+	e %= n
+	if e == 0 {
+		return v
+	}
+	g := gcd(e, n)
+	k, m := e/g, n/g
+
+	w := b.fresh()
+	switch {
+	case m == 2:
+		b.emit("%s := -%s", w, v)
+	case m == 4 && k == 1:
+		b.emit("%s := %s(%s, b.direction)", w, b.rotateFunc, v)
+	case m == 4 && k == 3:
+		b.emit("%s := -%s(%s, b.direction)", w, b.rotateFunc, v)
+	default:
+		b.emit("%s := %s * b.twiddles[%d]", w, v, b.twiddle(k, m))
+	}
+	return w
+}
+
+// write appends the type, constructor and methods of the butterfly to buf
+func (b *butterfly) write(buf *strings.Builder) {
+	name, elem, n := b.typeName, b.elem, b.n
+	description := fmt.Sprintf("a size-%d FFT (%s)", n, b.plan)
+	if elem == "complex64" {
+		description = fmt.Sprintf("a size-%d FFT for complex64 (%s)", n, b.plan)
+	}
+
+	fmt.Fprintf(buf, "\n// %s implements %s\n", name, description)
+	fmt.Fprintf(buf, "type %s struct {\n\tdirection Direction\n", name)
+	if len(b.twiddles) > 0 {
+		fmt.Fprintf(buf, "\ttwiddles [%d]%s\n", len(b.twiddles), elem)
+	}
+	buf.WriteString("}\n")
+
+	fmt.Fprintf(buf, "\n// New%s creates a new %s instance\n", name, name)
+	fmt.Fprintf(buf, "func New%s(direction Direction) *%s {\n", name, name)
+	fmt.Fprintf(buf, "\treturn &%s{\n\t\tdirection: direction,\n", name)
+	if len(b.twiddles) > 0 {
+		fmt.Fprintf(buf, "\t\ttwiddles: [%d]%s{\n", len(b.twiddles), elem)
+		for _, f := range b.twiddles {
+			fmt.Fprintf(buf, "\t\t\t%s(%d, %d, direction),\n", b.twiddleFunc, f.k, f.n)
+		}
+		buf.WriteString("\t\t},\n")
+	}
+	buf.WriteString("\t}\n}\n\n")
+
+	fmt.Fprintf(buf, "func (b *%s) Len() int { return %d }\n", name, n)
+	fmt.Fprintf(buf, "func (b *%s) Direction() Direction { return b.direction }\n", name)
+	fmt.Fprintf(buf, "func (b *%s) InplaceScratchLen() int { return 0 }\n", name)
+	fmt.Fprintf(buf, "func (b *%s) OutOfPlaceScratchLen() int { return 0 }\n", name)
+	fmt.Fprintf(buf, "func (b *%s) ImmutableScratchLen() int { return 0 }\n", name)
+
+	fmt.Fprintf(buf, "\nfunc (b *%s) Process(buffer []%s) {\n\tb.ProcessWithScratch(buffer, nil)\n}\n", name, elem)
+	fmt.Fprintf(buf, "\nfunc (b *%s) ProcessWithScratch(buffer, scratch []%s) {\n", name, elem)
+	buf.WriteString("\tcheckInplace(len(buffer), b.Len(), len(scratch), b.InplaceScratchLen())\n\n")
+	fmt.Fprintf(buf, "\tfor i := 0; i < len(buffer); i += %d {\n\t\tb.performFft(buffer[i : i+%d])\n\t}\n}\n", n, n)
+	fmt.Fprintf(buf, "\nfunc (b *%s) ProcessOutOfPlace(input, output, scratch []%s) {\n", name, elem)
+	buf.WriteString("\tcheckOutOfPlace(len(input), len(output), b.Len(), len(scratch), b.OutOfPlaceScratchLen())\n\n")
+	fmt.Fprintf(buf, "\tfor i := 0; i < len(input); i += %d {\n\t\tb.performFftOutOfPlace(input[i:i+%d], output[i:i+%d])\n\t}\n}\n", n, n, n)
+	fmt.Fprintf(buf, "\nfunc (b *%s) ProcessImmutable(input []%s, output, scratch []%s) {\n", name, elem, elem)
+	buf.WriteString("\tb.ProcessOutOfPlace(input, output, scratch)\n}\n")
+
+	fmt.Fprintf(buf, "\nfunc (b *%s) performFft(buffer []%s) {\n", name, elem)
+	for _, line := range b.body {
+		if line == "" {
+			buf.WriteString("\n")
+			continue
+		}
+		buf.WriteString("\t" + strings.ReplaceAll(line, "\n", "\n\t") + "\n")
+	}
+	buf.WriteString("}\n")
+
+	fmt.Fprintf(buf, "\nfunc (b *%s) performFftOutOfPlace(input, output []%s) {\n", name, elem)
+	buf.WriteString("\tcopy(output, input)\n\tb.performFft(output)\n}\n")
+}
+
+// wrap breaks a long sum after a top-level + or - so it fits in maxLineLen
+func wrap(line string) string {
+	const indent = 4 // performFft's own indentation
+	if len(line)+indent <= maxLineLen {
+		return line
+	}
+
+	var lines []string
+	current := ""
+	for _, term := range splitTerms(line) {
+		limit := maxLineLen - indent
+		if len(lines) > 0 {
+			limit -= indent
+		}
+		if current != "" && len(current)+len(term)+2 > limit {
+			lines = append(lines, current)
+			current = strings.TrimPrefix(term, " ")
+			continue
+		}
+		current += term
+	}
+	lines = append(lines, current)
+	return strings.Join(lines, "\n\t")
+}
+
+// splitTerms splits line before each " + " and " - ", keeping the operator
+// at the end of the previous piece
+func splitTerms(line string) []string {
+	var terms []string
+	start := 0
+	for i := 0; i+3 <= len(line); i++ {
+		if op := line[i : i+3]; op == " + " || op == " - " {
+			terms = append(terms, line[start:i+2])
+			start = i + 2
+		}
+	}
+	return append(terms, line[start:])
+}
+
+// split chooses the factors n1·n2 = n of a composite size
+// Coprime factors come first; prime powers are split as evenly as possible.
+func split(n int) (n1, n2 int) {
+	p := smallestPrimeFactor(n)
+	power := 1
+	for n%(power*p) == 0 {
+		power *= p
+	}
+	if power != n {
+		return power, n / power
+	}
+
+	exponent := 0
+	for m := n; m > 1; m /= p {
+		exponent++
+	}
+	n1 = 1
+	for i := 0; i < exponent/2; i++ {
+		n1 *= p
+	}
+	return n1, n / n1
+}
+
+func smallestPrimeFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
+
+func isPrime(n int) bool {
+	return n > 1 && smallestPrimeFactor(n) == n
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// crt returns the k in [0, n1·n2) with k ≡ k1 mod n1 and k ≡ k2 mod n2
+func crt(k1, n1, k2, n2 int) int {
+	for k := k1; ; k += n1 {
+		if k%n2 == k2 {
+			return k
+		}
+	}
+}
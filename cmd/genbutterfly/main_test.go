@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedFilesUpToDate reruns the go:generate directives of the
+// algorithm package and compares the output with the checked-in files
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "algorithm")
+	directives, err := os.ReadFile(filepath.Join(dir, "generate.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const prefix = "//go:generate go run ../cmd/genbutterfly "
+	found := 0
+	for _, line := range strings.Split(string(directives), "\n") {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		found++
+
+		args := strings.TrimPrefix(line, prefix)
+		lengths, single, output, err := parseArgs(strings.Fields(args))
+		if err != nil {
+			t.Fatalf("%s: %v", args, err)
+		}
+		want, err := generate(lengths, single, args)
+		if err != nil {
+			t.Fatalf("%s: %v", args, err)
+		}
+		got, err := os.ReadFile(filepath.Join(dir, output))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s is out of date; run go generate ./algorithm", output)
+		}
+	}
+	if found == 0 {
+		t.Fatal("no genbutterfly directives in generate.go")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct{ n, n1, n2 int }{
+		{6, 2, 3}, {8, 2, 4}, {12, 4, 3}, {25, 5, 5}, {27, 3, 9}, {36, 4, 9}, {64, 8, 8}, {90, 2, 45},
+	}
+	for _, tt := range tests {
+		if n1, n2 := split(tt.n); n1 != tt.n1 || n2 != tt.n2 {
+			t.Errorf("split(%d) = %d, %d; want %d, %d", tt.n, n1, n2, tt.n1, tt.n2)
+		}
+	}
+}
+
+func TestCrt(t *testing.T) {
+	for _, f := range [][2]int{{2, 5}, {4, 9}, {3, 8}} {
+		n1, n2 := f[0], f[1]
+		for k := 0; k < n1*n2; k++ {
+			if got := crt(k%n1, n1, k%n2, n2); got != k {
+				t.Errorf("crt(%d, %d, %d, %d) = %d, want %d", k%n1, n1, k%n2, n2, got, k)
+			}
+		}
+	}
+}
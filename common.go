@@ -10,32 +10,110 @@ type FftNum interface {
 	~float32 | ~float64
 }
 
-// validateBuffers validates buffer sizes for in-place FFT operations
-func validateInplace(bufferLen, expectedLen, scratchLen, expectedScratch int) {
+// checkInplace validates buffer sizes for in-place FFT operations
+func checkInplace(bufferLen, expectedLen, scratchLen, expectedScratch int) error {
 	if bufferLen < expectedLen {
-		panic(fmt.Sprintf("Provided FFT buffer was too small. Expected len = %d, got len = %d", expectedLen, bufferLen))
+		return fmt.Errorf("%w: expected len = %d, got len = %d", ErrBufferTooSmall, expectedLen, bufferLen)
 	}
-	if bufferLen%expectedLen != 0 {
-		panic(fmt.Sprintf("Input FFT buffer must be a multiple of FFT length. Expected multiple of %d, got len = %d", expectedLen, bufferLen))
+	// A length 0 transform only accepts an empty buffer, and the modulo below would divide by zero
+	if expectedLen == 0 {
+		if bufferLen != 0 {
+			return fmt.Errorf("%w: expected len = 0, got len = %d", ErrNotMultipleOfLen, bufferLen)
+		}
+	} else if bufferLen%expectedLen != 0 {
+		return fmt.Errorf("%w: expected multiple of %d, got len = %d", ErrNotMultipleOfLen, expectedLen, bufferLen)
 	}
 	if scratchLen < expectedScratch {
-		panic(fmt.Sprintf("Not enough scratch space was provided. Expected scratch len >= %d, got scratch len = %d", expectedScratch, scratchLen))
+		return fmt.Errorf("%w: expected scratch len >= %d, got scratch len = %d", ErrScratchTooSmall, expectedScratch, scratchLen)
 	}
+	return nil
 }
 
-// validateOutOfPlace validates buffer sizes for out-of-place FFT operations
-func validateOutOfPlace(inputLen, outputLen, expectedLen, scratchLen, expectedScratch int) {
+// checkOutOfPlace validates buffer sizes for out-of-place FFT operations
+func checkOutOfPlace(inputLen, outputLen, expectedLen, scratchLen, expectedScratch int) error {
 	if inputLen != outputLen {
-		panic(fmt.Sprintf("Provided FFT input buffer and output buffer must have the same length. Got input.len() = %d, output.len() = %d", inputLen, outputLen))
+		return fmt.Errorf("%w: got input len = %d, output len = %d", ErrLengthMismatch, inputLen, outputLen)
+	}
+	return checkInplace(inputLen, expectedLen, scratchLen, expectedScratch)
+}
+
+// checkRealToComplex validates buffer sizes for real-to-complex FFT operations
+func checkRealToComplex(inputLen, expectedLen, outputLen, expectedOutputLen, scratchLen, expectedScratch int) error {
+	if err := checkInplace(inputLen, expectedLen, scratchLen, expectedScratch); err != nil {
+		return err
+	}
+	if want := numChunks(inputLen, expectedLen) * expectedOutputLen; outputLen != want {
+		return fmt.Errorf("%w: expected output len = %d, got output len = %d", ErrLengthMismatch, want, outputLen)
+	}
+	return nil
+}
+
+// checkComplexToReal validates buffer sizes for complex-to-real FFT operations
+func checkComplexToReal(inputLen, expectedInputLen, outputLen, expectedLen, scratchLen, expectedScratch int) error {
+	if err := checkInplace(outputLen, expectedLen, scratchLen, expectedScratch); err != nil {
+		return err
+	}
+	if want := numChunks(outputLen, expectedLen) * expectedInputLen; inputLen != want {
+		return fmt.Errorf("%w: expected input len = %d, got input len = %d", ErrLengthMismatch, want, inputLen)
+	}
+	return nil
+}
+
+// numChunks returns how many transforms of size expectedLen fit in bufferLen,
+// which checkInplace has already accepted
+func numChunks(bufferLen, expectedLen int) int {
+	if expectedLen == 0 {
+		return 0
 	}
-	if inputLen < expectedLen {
-		panic(fmt.Sprintf("Provided FFT buffer was too small. Expected len = %d, got len = %d", expectedLen, inputLen))
+	return bufferLen / expectedLen
+}
+
+// checkFixedLen validates buffer sizes for operations with one input and one output of fixed lengths
+func checkFixedLen(inputLen, expectedInputLen, outputLen, expectedOutputLen, scratchLen, expectedScratch int) error {
+	if inputLen != expectedInputLen {
+		return fmt.Errorf("%w: expected input len = %d, got input len = %d", ErrLengthMismatch, expectedInputLen, inputLen)
 	}
-	if inputLen%expectedLen != 0 {
-		panic(fmt.Sprintf("Input FFT buffer must be a multiple of FFT length. Expected multiple of %d, got len = %d", expectedLen, inputLen))
+	if outputLen != expectedOutputLen {
+		return fmt.Errorf("%w: expected output len = %d, got output len = %d", ErrLengthMismatch, expectedOutputLen, outputLen)
 	}
 	if scratchLen < expectedScratch {
-		panic(fmt.Sprintf("Not enough scratch space was provided. Expected scratch len >= %d, got scratch len = %d", expectedScratch, scratchLen))
+		return fmt.Errorf("%w: expected scratch len >= %d, got scratch len = %d", ErrScratchTooSmall, expectedScratch, scratchLen)
+	}
+	return nil
+}
+
+// validateInplace panics if checkInplace fails
+func validateInplace(bufferLen, expectedLen, scratchLen, expectedScratch int) {
+	if err := checkInplace(bufferLen, expectedLen, scratchLen, expectedScratch); err != nil {
+		panic(err)
+	}
+}
+
+// validateOutOfPlace panics if checkOutOfPlace fails
+func validateOutOfPlace(inputLen, outputLen, expectedLen, scratchLen, expectedScratch int) {
+	if err := checkOutOfPlace(inputLen, outputLen, expectedLen, scratchLen, expectedScratch); err != nil {
+		panic(err)
+	}
+}
+
+// validateRealToComplex panics if checkRealToComplex fails
+func validateRealToComplex(inputLen, expectedLen, outputLen, expectedOutputLen, scratchLen, expectedScratch int) {
+	if err := checkRealToComplex(inputLen, expectedLen, outputLen, expectedOutputLen, scratchLen, expectedScratch); err != nil {
+		panic(err)
+	}
+}
+
+// validateComplexToReal panics if checkComplexToReal fails
+func validateComplexToReal(inputLen, expectedInputLen, outputLen, expectedLen, scratchLen, expectedScratch int) {
+	if err := checkComplexToReal(inputLen, expectedInputLen, outputLen, expectedLen, scratchLen, expectedScratch); err != nil {
+		panic(err)
+	}
+}
+
+// validateFixedLen panics if checkFixedLen fails
+func validateFixedLen(inputLen, expectedInputLen, outputLen, expectedOutputLen, scratchLen, expectedScratch int) {
+	if err := checkFixedLen(inputLen, expectedInputLen, outputLen, expectedOutputLen, scratchLen, expectedScratch); err != nil {
+		panic(err)
 	}
 }
 
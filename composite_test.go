@@ -1,6 +1,8 @@
 package gofft
 
 import (
+	"fmt"
+	"math"
 	"math/cmplx"
 	"testing"
 )
@@ -56,3 +58,65 @@ func TestCompositeSizes(t *testing.T) {
 		})
 	}
 }
+
+// TestLargeFactorComposites checks sizes with prime factors above 7, which
+// are split into MixedRadix/GoodThomas trees instead of going to Bluestein's
+func TestLargeFactorComposites(t *testing.T) {
+	sizes := []int{
+		22, 143, 352, 1001, 2431, // coprime factors: GoodThomas
+		121, 289, 1331, // prime powers: MixedRadix
+		2 * 1031, 11 * 37 * 4, // Bluestein's and Rader's leaves
+	}
+
+	planner := NewPlanner()
+
+	for _, n := range sizes {
+		t.Run(fmt.Sprintf("Size%d", n), func(t *testing.T) {
+			input := make([]complex128, n)
+			for i := range input {
+				input[i] = complex(math.Sin(float64(i)*0.3), math.Cos(float64(i)*0.7))
+			}
+
+			for _, forward := range []bool{true, false} {
+				expected := naiveDFT(input, forward)
+
+				direction := Forward
+				if !forward {
+					direction = Inverse
+				}
+				buffer := make([]complex128, n)
+				copy(buffer, input)
+				planner.Plan(n, direction).Process(buffer)
+
+				if !complexSlicesEqual(buffer, expected, 1e-8*float64(n)) {
+					t.Errorf("Size %d (forward=%v) doesn't match DFT", n, forward)
+				}
+			}
+		})
+	}
+}
+
+// TestCompositeRecipes checks which algorithm the planner picks for
+// composite sizes with a prime factor above 7
+func TestCompositeRecipes(t *testing.T) {
+	tests := []struct {
+		n    int
+		want recipeKind
+	}{
+		{2431, recipeGoodThomas}, // 11 * 13 * 17
+		{352, recipeGoodThomas},  // 32 * 11
+		{121, recipeMixedRadix},  // 11 * 11
+		{2 * 1031, recipeGoodThomas},
+	}
+
+	planner := NewPlanner()
+	for _, tt := range tests {
+		r := planner.designFft(tt.n)
+		if r.kind != tt.want {
+			t.Errorf("Size %d: got recipe kind %v, want %v", tt.n, r.kind, tt.want)
+		}
+		if r.width.length*r.height.length != tt.n {
+			t.Errorf("Size %d: factors %d x %d don't multiply back", tt.n, r.width.length, r.height.length)
+		}
+	}
+}
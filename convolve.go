@@ -0,0 +1,410 @@
+package gofft
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ConvolveMode selects which outputs a convolution or correlation produces
+// The linear modes follow numpy.convolve; ConvolveCircular wraps around instead.
+type ConvolveMode int
+
+const (
+	// ConvolveFull returns every output of the linear convolution,
+	// len(input)+len(kernel)-1 values
+	ConvolveFull ConvolveMode = iota
+
+	// ConvolveSame returns max(len(input), len(kernel)) values from the
+	// middle of the full output
+	ConvolveSame
+
+	// ConvolveValid returns only the outputs where input and kernel overlap
+	// completely, max-min+1 values
+	ConvolveValid
+
+	// ConvolveCircular returns the circular convolution of length len(input)
+	// A kernel longer than the input wraps around.
+	ConvolveCircular
+)
+
+// Convolver convolves (or correlates) inputs of a fixed length with a fixed kernel
+//
+// The kernel spectrum is computed once when the Convolver is planned, so every
+// call costs one forward and one inverse FFT of the padded length. The 1/n
+// normalization is folded into the kernel spectrum as well.
+// Like the FFTs it is built from, a Convolver is safe for concurrent use.
+type Convolver struct {
+	shape          convolveShape
+	forward        Fft
+	inverse        Fft
+	kernelSpectrum []complex128
+	pool           scratchPool[complex128]
+}
+
+// PlanConvolver creates a Convolver that convolves inputs of length inputLen with kernel
+func (p *Planner) PlanConvolver(kernel []complex128, inputLen int, mode ConvolveMode) *Convolver {
+	return p.planConvolver(kernel, inputLen, mode, false)
+}
+
+// PlanCorrelator creates a Convolver that cross-correlates inputs of length inputLen with kernel
+// Output k of a circular correlation is the sum of input[n+k] * conj(kernel[n]);
+// the linear modes return the lags from -(len(kernel)-1) up, like numpy.correlate.
+func (p *Planner) PlanCorrelator(kernel []complex128, inputLen int, mode ConvolveMode) *Convolver {
+	return p.planConvolver(kernel, inputLen, mode, true)
+}
+
+func (p *Planner) planConvolver(kernel []complex128, inputLen int, mode ConvolveMode, correlate bool) *Convolver {
+	shape := newConvolveShape(inputLen, len(kernel), mode)
+	c := &Convolver{
+		shape:   shape,
+		forward: p.PlanForward(shape.fftLen),
+		inverse: p.PlanInverse(shape.fftLen),
+	}
+	c.kernelSpectrum = kernelSpectrum(c.forward, kernel, shape, correlate)
+	return c
+}
+
+// InputLen returns the input length this instance processes
+func (c *Convolver) InputLen() int { return c.shape.inputLen }
+
+// KernelLen returns the length of the kernel
+func (c *Convolver) KernelLen() int { return c.shape.kernelLen }
+
+// OutputLen returns the output length, which depends on the mode
+func (c *Convolver) OutputLen() int { return c.shape.outputLen }
+
+// FftLen returns the padded length of the FFTs the convolution runs on
+func (c *Convolver) FftLen() int { return c.shape.fftLen }
+
+// ScratchLen returns the required scratch buffer size for ProcessWithScratch
+func (c *Convolver) ScratchLen() int {
+	fftScratch := max(c.forward.InplaceScratchLen(), c.inverse.InplaceScratchLen())
+	if c.shape.mode == ConvolveCircular {
+		return max(fftScratch, c.forward.ImmutableScratchLen())
+	}
+	return c.shape.fftLen + fftScratch
+}
+
+// Process convolves input with the kernel into output
+// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+func (c *Convolver) Process(input, output []complex128) {
+	scratch := c.pool.get(c.ScratchLen())
+	c.ProcessWithScratch(input, output, *scratch)
+	c.pool.put(scratch)
+}
+
+// ProcessWithScratch convolves input with the kernel into output using the provided scratch buffer
+// The contents of input are left unchanged.
+func (c *Convolver) ProcessWithScratch(input, output, scratch []complex128) {
+	validateFixedLen(len(input), c.shape.inputLen, len(output), c.shape.outputLen, len(scratch), c.ScratchLen())
+
+	if c.shape.mode == ConvolveCircular {
+		// No padding, so the FFTs can run directly on output
+		c.forward.ProcessImmutable(input, output, scratch)
+		multiplySpectrum(output, c.kernelSpectrum)
+		c.inverse.ProcessWithScratch(output, scratch)
+		return
+	}
+
+	buffer := scratch[:c.shape.fftLen]
+	fftScratch := scratch[c.shape.fftLen:]
+	copy(buffer, input)
+	clear(buffer[len(input):])
+
+	c.forward.ProcessWithScratch(buffer, fftScratch)
+	multiplySpectrum(buffer, c.kernelSpectrum)
+	c.inverse.ProcessWithScratch(buffer, fftScratch)
+
+	copy(output, buffer[c.shape.start:])
+}
+
+// RealConvolver convolves (or correlates) real inputs of a fixed length with a fixed real kernel
+// It works like Convolver, but runs on real FFTs of half the size.
+type RealConvolver struct {
+	shape          convolveShape
+	forward        RealToComplex
+	inverse        ComplexToReal
+	packedForward  *realToComplexEven // Linear modes: the padded FFTs read and write packed samples
+	packedInverse  *complexToRealEven
+	kernelSpectrum []complex128
+	pool           scratchPool[complex128]
+}
+
+// PlanConvolver creates a RealConvolver that convolves inputs of length inputLen with kernel
+func (p *RealFftPlanner) PlanConvolver(kernel []float64, inputLen int, mode ConvolveMode) *RealConvolver {
+	return p.planConvolver(kernel, inputLen, mode, false)
+}
+
+// PlanCorrelator creates a RealConvolver that cross-correlates inputs of length inputLen with kernel
+func (p *RealFftPlanner) PlanCorrelator(kernel []float64, inputLen int, mode ConvolveMode) *RealConvolver {
+	return p.planConvolver(kernel, inputLen, mode, true)
+}
+
+func (p *RealFftPlanner) planConvolver(kernel []float64, inputLen int, mode ConvolveMode, correlate bool) *RealConvolver {
+	shape := newConvolveShape(inputLen, len(kernel), mode)
+	c := &RealConvolver{
+		shape:   shape,
+		forward: p.PlanForward(shape.fftLen),
+		inverse: p.PlanInverse(shape.fftLen),
+	}
+	if mode != ConvolveCircular {
+		// Padded lengths are always even
+		c.packedForward = c.forward.(*realToComplexEven)
+		c.packedInverse = c.inverse.(*complexToRealEven)
+	}
+
+	c.kernelSpectrum = p.realKernelSpectrum(kernel, shape, correlate)
+	return c
+}
+
+// realKernelSpectrum is kernelSpectrum for a real kernel
+// The spectrum of a real kernel is Hermitian, so only the first n/2+1 bins are returned.
+func (p *RealFftPlanner) realKernelSpectrum(kernel []float64, shape convolveShape, correlate bool) []complex128 {
+	complexKernel := make([]complex128, len(kernel))
+	for i, k := range kernel {
+		complexKernel[i] = complex(k, 0)
+	}
+	spectrum := kernelSpectrum(p.planner.PlanForward(shape.fftLen), complexKernel, shape, correlate)
+	return spectrum[:shape.fftLen/2+1]
+}
+
+// InputLen returns the input length this instance processes
+func (c *RealConvolver) InputLen() int { return c.shape.inputLen }
+
+// KernelLen returns the length of the kernel
+func (c *RealConvolver) KernelLen() int { return c.shape.kernelLen }
+
+// OutputLen returns the output length, which depends on the mode
+func (c *RealConvolver) OutputLen() int { return c.shape.outputLen }
+
+// FftLen returns the padded length of the real FFTs the convolution runs on
+func (c *RealConvolver) FftLen() int { return c.shape.fftLen }
+
+// ScratchLen returns the required scratch buffer size for ProcessWithScratch
+func (c *RealConvolver) ScratchLen() int {
+	return c.shape.fftLen/2 + 1 + max(c.forward.ScratchLen(), c.inverse.ScratchLen())
+}
+
+// Process convolves input with the kernel into output
+// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+func (c *RealConvolver) Process(input, output []float64) {
+	scratch := c.pool.get(c.ScratchLen())
+	c.ProcessWithScratch(input, output, *scratch)
+	c.pool.put(scratch)
+}
+
+// ProcessWithScratch convolves input with the kernel into output using the provided scratch buffer
+// The contents of input are left unchanged.
+func (c *RealConvolver) ProcessWithScratch(input, output []float64, scratch []complex128) {
+	validateFixedLen(len(input), c.shape.inputLen, len(output), c.shape.outputLen, len(scratch), c.ScratchLen())
+
+	half := c.shape.fftLen / 2
+	spectrum := scratch[:half+1]
+	fftScratch := scratch[half+1:]
+
+	if c.shape.mode == ConvolveCircular {
+		c.forward.ProcessWithScratch(input, spectrum, fftScratch)
+		multiplySpectrum(spectrum, c.kernelSpectrum)
+		c.inverse.ProcessWithScratch(spectrum, output, fftScratch)
+		return
+	}
+
+	// Pack the zero-padded input two samples per complex value, as the even
+	// real FFT does, so no padded copy of the input is needed
+	pairs := len(input) / 2
+	for k := 0; k < pairs; k++ {
+		spectrum[k] = complex(input[2*k], input[2*k+1])
+	}
+	k := pairs
+	if len(input)%2 == 1 {
+		spectrum[k] = complex(input[len(input)-1], 0)
+		k++
+	}
+	clear(spectrum[k:half])
+
+	c.packedForward.processPacked(spectrum, fftScratch)
+	multiplySpectrum(spectrum, c.kernelSpectrum)
+	c.packedInverse.processPacked(spectrum, fftScratch)
+
+	for i := range output {
+		j := c.shape.start + i
+		if j%2 == 0 {
+			output[i] = real(spectrum[j/2])
+		} else {
+			output[i] = imag(spectrum[j/2])
+		}
+	}
+}
+
+// convolveShape holds the sizes of a planned convolution
+type convolveShape struct {
+	mode      ConvolveMode
+	inputLen  int
+	kernelLen int
+	start     int // First output taken from the full linear convolution
+	outputLen int
+	fftLen    int
+}
+
+func newConvolveShape(inputLen, kernelLen int, mode ConvolveMode) convolveShape {
+	if inputLen < 1 || kernelLen < 1 {
+		panic(fmt.Sprintf("convolution needs a non-empty input and kernel, got lengths %d and %d", inputLen, kernelLen))
+	}
+
+	s := convolveShape{mode: mode, inputLen: inputLen, kernelLen: kernelLen}
+	shorter, longer := min(inputLen, kernelLen), max(inputLen, kernelLen)
+	fullLen := inputLen + kernelLen - 1
+
+	switch mode {
+	case ConvolveFull:
+		s.outputLen = fullLen
+	case ConvolveSame:
+		s.start = (shorter - 1) / 2
+		s.outputLen = longer
+	case ConvolveValid:
+		s.start = shorter - 1
+		s.outputLen = longer - shorter + 1
+	case ConvolveCircular:
+		s.outputLen = inputLen
+		s.fftLen = inputLen
+		return s
+	default:
+		panic(fmt.Sprintf("unknown convolution mode %d", mode))
+	}
+
+	// A circular convolution of length n adds output j+n of the full
+	// convolution onto output j. Outputs before start are discarded, so n only
+	// has to push the wrapped-around outputs past the ones we keep.
+	s.fftLen = fastConvolutionLen(max(s.start+s.outputLen, fullLen-s.start))
+	return s
+}
+
+// fastConvolutionLen returns the smallest even length of at least n whose
+// factors are all 2, 3, 5 or 7, so the padded FFTs run on RadixN or Radix4
+func fastConvolutionLen(n int) int {
+	for m := max(n, 2); ; m++ {
+		if m%2 == 0 && canUseRadixN(m) {
+			return m
+		}
+	}
+}
+
+// kernelSpectrum computes the FFT of the kernel laid out for shape, scaled by
+// 1/n so the inverse FFT of the product needs no separate normalization
+// For correlation the kernel is conjugated and reversed, which turns the
+// convolution into a correlation.
+func kernelSpectrum(forward Fft, kernel []complex128, shape convolveShape, correlate bool) []complex128 {
+	n := shape.fftLen
+	spectrum := make([]complex128, n)
+	for i, k := range kernel {
+		idx := i
+		if correlate {
+			k = ComplexConj(k)
+			if shape.mode == ConvolveCircular {
+				idx = n - i%n
+			} else {
+				idx = len(kernel) - 1 - i
+			}
+		}
+		// Circular kernels longer than the input wrap around
+		spectrum[idx%n] += k
+	}
+
+	forward.Process(spectrum)
+	scale := complex(1/float64(n), 0)
+	for i := range spectrum {
+		spectrum[i] *= scale
+	}
+	return spectrum
+}
+
+// multiplySpectrum multiplies data by spectrum element-wise
+func multiplySpectrum(data, spectrum []complex128) {
+	for i := range data {
+		data[i] *= spectrum[i]
+	}
+}
+
+// convolvePlanCacheLen is how many distinct FFT lengths the planner behind
+// Convolve and friends serves before it's replaced by an empty one
+const convolvePlanCacheLen = 16
+
+// convolvePlans hands out the planner for Convolve, Correlate and their real
+// counterparts, so repeated calls with the same sizes reuse the plans
+// Planners cache every size they plan, so after convolvePlanCacheLen distinct
+// lengths it starts over with a fresh planner, which bounds the memory held on
+// behalf of callers that convolve many different sizes.
+var convolvePlans = struct {
+	mu      sync.Mutex
+	planner *RealFftPlanner
+	lengths map[int]struct{}
+}{planner: NewRealFftPlanner(), lengths: make(map[int]struct{})}
+
+// convolvePlanner returns the planner for a convolution of inputs of length
+// inputLen with kernels of length kernelLen
+func convolvePlanner(inputLen, kernelLen int, mode ConvolveMode) *RealFftPlanner {
+	fftLen := newConvolveShape(inputLen, kernelLen, mode).fftLen
+
+	convolvePlans.mu.Lock()
+	defer convolvePlans.mu.Unlock()
+	if _, ok := convolvePlans.lengths[fftLen]; !ok {
+		if len(convolvePlans.lengths) >= convolvePlanCacheLen {
+			convolvePlans.planner = NewRealFftPlanner()
+			clear(convolvePlans.lengths)
+		}
+		convolvePlans.lengths[fftLen] = struct{}{}
+	}
+	return convolvePlans.planner
+}
+
+// Convolve returns the convolution of a and b
+// It returns nil if either is empty. The FFT plans are cached for up to 16
+// distinct padded lengths, after which the cache starts over, so a few
+// recurring sizes are planned once while ever-changing sizes don't pile up
+// plans. For repeated convolutions with the same kernel,
+// Planner.PlanConvolver also reuses the kernel spectrum.
+func Convolve(a, b []complex128, mode ConvolveMode) []complex128 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	c := convolvePlanner(len(a), len(b), mode).planner.PlanConvolver(b, len(a), mode)
+	output := make([]complex128, c.OutputLen())
+	c.Process(a, output)
+	return output
+}
+
+// Correlate returns the cross-correlation of a with b, conjugating b
+// It returns nil if either is empty. Plans are cached as for Convolve.
+func Correlate(a, b []complex128, mode ConvolveMode) []complex128 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	c := convolvePlanner(len(a), len(b), mode).planner.PlanCorrelator(b, len(a), mode)
+	output := make([]complex128, c.OutputLen())
+	c.Process(a, output)
+	return output
+}
+
+// ConvolveReal returns the convolution of the real signals a and b
+// It returns nil if either is empty. Plans are cached as for Convolve.
+func ConvolveReal(a, b []float64, mode ConvolveMode) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	c := convolvePlanner(len(a), len(b), mode).PlanConvolver(b, len(a), mode)
+	output := make([]float64, c.OutputLen())
+	c.Process(a, output)
+	return output
+}
+
+// CorrelateReal returns the cross-correlation of the real signals a and b
+// It returns nil if either is empty. Plans are cached as for Convolve.
+func CorrelateReal(a, b []float64, mode ConvolveMode) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	c := convolvePlanner(len(a), len(b), mode).PlanCorrelator(b, len(a), mode)
+	output := make([]float64, c.OutputLen())
+	c.Process(a, output)
+	return output
+}
@@ -0,0 +1,220 @@
+package gofft
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// naiveConvolve computes the full linear convolution, or the cross-correlation
+// with lags from -(len(b)-1) up, directly from the definition
+func naiveConvolve(a, b []complex128, correlate bool) []complex128 {
+	full := make([]complex128, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			if correlate {
+				full[i+len(b)-1-j] += x * cmplx.Conj(y)
+			} else {
+				full[i+j] += x * y
+			}
+		}
+	}
+	return full
+}
+
+// naiveCircular computes the circular convolution or correlation of length len(a)
+func naiveCircular(a, b []complex128, correlate bool) []complex128 {
+	n := len(a)
+	out := make([]complex128, n)
+	for k := range out {
+		for j, y := range b {
+			if correlate {
+				out[k] += a[(j+k)%n] * cmplx.Conj(y)
+			} else {
+				out[(k+j)%n] += a[k] * y
+			}
+		}
+	}
+	return out
+}
+
+// expectedConvolution slices the naive result the way mode does
+func expectedConvolution(a, b []complex128, mode ConvolveMode, correlate bool) []complex128 {
+	if mode == ConvolveCircular {
+		return naiveCircular(a, b, correlate)
+	}
+	full := naiveConvolve(a, b, correlate)
+	shorter, longer := min(len(a), len(b)), max(len(a), len(b))
+	switch mode {
+	case ConvolveSame:
+		start := (shorter - 1) / 2
+		return full[start : start+longer]
+	case ConvolveValid:
+		return full[shorter-1 : longer]
+	}
+	return full
+}
+
+func testSignal(n int, seed float64) []complex128 {
+	s := make([]complex128, n)
+	for i := range s {
+		s[i] = complex(math.Sin(float64(i)*seed+1), math.Cos(float64(i)*seed*0.7))
+	}
+	return s
+}
+
+func TestConvolveMatchesNaive(t *testing.T) {
+	pairs := [][2]int{{1, 1}, {5, 3}, {3, 5}, {16, 16}, {100, 7}, {64, 33}, {7, 100}, {257, 31}, {10, 1}}
+	modes := []struct {
+		name string
+		mode ConvolveMode
+	}{{"Full", ConvolveFull}, {"Same", ConvolveSame}, {"Valid", ConvolveValid}, {"Circular", ConvolveCircular}}
+
+	for _, m := range modes {
+		for _, pair := range pairs {
+			for _, correlate := range []bool{false, true} {
+				name := fmt.Sprintf("%s/%dx%d/Correlate=%v", m.name, pair[0], pair[1], correlate)
+				t.Run(name, func(t *testing.T) {
+					a := testSignal(pair[0], 0.37)
+					b := testSignal(pair[1], 1.13)
+					expected := expectedConvolution(a, b, m.mode, correlate)
+
+					var got []complex128
+					if correlate {
+						got = Correlate(a, b, m.mode)
+					} else {
+						got = Convolve(a, b, m.mode)
+					}
+					if len(got) != len(expected) {
+						t.Fatalf("got %d outputs, want %d", len(got), len(expected))
+					}
+					if !complexSlicesEqual(got, expected, 1e-9*float64(len(a)+len(b))) {
+						t.Errorf("complex result doesn't match direct convolution")
+					}
+
+					// Real inputs through the real FFT path
+					ra := make([]float64, len(a))
+					rb := make([]float64, len(b))
+					for i := range a {
+						ra[i] = real(a[i])
+					}
+					for i := range b {
+						rb[i] = real(b[i])
+					}
+					expected = expectedConvolution(toComplex(ra), toComplex(rb), m.mode, correlate)
+
+					var gotReal []float64
+					if correlate {
+						gotReal = CorrelateReal(ra, rb, m.mode)
+					} else {
+						gotReal = ConvolveReal(ra, rb, m.mode)
+					}
+					if len(gotReal) != len(expected) {
+						t.Fatalf("real: got %d outputs, want %d", len(gotReal), len(expected))
+					}
+					for i := range gotReal {
+						if math.Abs(gotReal[i]-real(expected[i])) > 1e-9*float64(len(a)+len(b)) {
+							t.Fatalf("real [%d] got %v, want %v", i, gotReal[i], real(expected[i]))
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func toComplex(x []float64) []complex128 {
+	c := make([]complex128, len(x))
+	for i, v := range x {
+		c[i] = complex(v, 0)
+	}
+	return c
+}
+
+// TestConvolverReuse checks that a planned Convolver gives the same result on every call
+func TestConvolverReuse(t *testing.T) {
+	kernel := testSignal(31, 0.5)
+	c := NewPlanner().PlanConvolver(kernel, 200, ConvolveSame)
+	if c.FftLen() < 200 || c.FftLen()%2 != 0 {
+		t.Errorf("unexpected FFT length %d", c.FftLen())
+	}
+
+	scratch := make([]complex128, c.ScratchLen())
+	for call := 0; call < 3; call++ {
+		input := testSignal(200, 0.1*float64(call+1))
+		inputCopy := append([]complex128(nil), input...)
+		output := make([]complex128, c.OutputLen())
+		c.ProcessWithScratch(input, output, scratch)
+
+		if !complexSlicesEqual(input, inputCopy, 0) {
+			t.Fatalf("call %d modified the input", call)
+		}
+		expected := expectedConvolution(input, kernel, ConvolveSame, false)
+		if !complexSlicesEqual(output, expected, 1e-8) {
+			t.Errorf("call %d doesn't match direct convolution", call)
+		}
+	}
+}
+
+func TestRealConvolverAllocations(t *testing.T) {
+	kernel := make([]float64, 64)
+	for i := range kernel {
+		kernel[i] = math.Exp(-float64(i) / 8)
+	}
+	c := NewRealFftPlanner().PlanConvolver(kernel, 1000, ConvolveFull)
+	input := make([]float64, 1000)
+	output := make([]float64, c.OutputLen())
+	scratch := make([]complex128, c.ScratchLen())
+
+	if allocs := testing.AllocsPerRun(10, func() { c.ProcessWithScratch(input, output, scratch) }); allocs != 0 {
+		t.Errorf("ProcessWithScratch allocated %v times, want 0", allocs)
+	}
+}
+
+func TestConvolverBadSizes(t *testing.T) {
+	c := NewPlanner().PlanConvolver(make([]complex128, 4), 16, ConvolveFull)
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrLengthMismatch) {
+			t.Errorf("expected a panic wrapping ErrLengthMismatch, got %v", err)
+		}
+	}()
+	c.Process(make([]complex128, 15), make([]complex128, c.OutputLen()))
+}
+
+func TestConvolveEmpty(t *testing.T) {
+	if got := Convolve(nil, []complex128{1}, ConvolveFull); got != nil {
+		t.Errorf("got %v for an empty input, want nil", got)
+	}
+	if got := CorrelateReal([]float64{1, 2}, nil, ConvolveValid); got != nil {
+		t.Errorf("got %v for an empty kernel, want nil", got)
+	}
+}
+
+// TestConvolvePlanCacheBounded checks that Convolve and friends don't keep a
+// plan for every size they've seen
+func TestConvolvePlanCacheBounded(t *testing.T) {
+	for n := 1; n <= 3*convolvePlanCacheLen; n++ {
+		ConvolveReal(make([]float64, 100+n), []float64{1}, ConvolveCircular)
+		if got := len(convolvePlans.lengths); got > convolvePlanCacheLen {
+			t.Fatalf("after %d sizes the cache holds %d lengths, want at most %d", n, got, convolvePlanCacheLen)
+		}
+	}
+
+	// A recurring size keeps its plans
+	planner := convolvePlanner(100, 1, ConvolveCircular)
+	if again := convolvePlanner(100, 1, ConvolveCircular); again != planner {
+		t.Errorf("a repeated size got a different planner")
+	}
+}
+
+func TestFastConvolutionLen(t *testing.T) {
+	for n := 1; n < 2000; n++ {
+		m := fastConvolutionLen(n)
+		if m < n || m%2 != 0 || !canUseRadixN(m) {
+			t.Fatalf("fastConvolutionLen(%d) = %d", n, m)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package gofft
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/10d9e/gofft/algorithm"
+)
+
+// Czt computes the chirp-z transform, the z-transform of Len() samples
+// evaluated at OutputLen() points along the spiral A·W^-k:
+//
+//	X[k] = Σ x[n] A^-n W^(nk)
+//
+// With A = 1, W = e^(-2πi/N) and N outputs it is the DFT. Points on an arc of
+// the unit circle give a zoom FFT, which NewZoomFft sets up. The transform
+// runs on the convolution behind Bluestein's algorithm, so it costs a few
+// power-of-two FFTs of at least Len()+OutputLen()-1 points.
+// Like the FFTs it is built from, a Czt is safe for concurrent use.
+type Czt struct {
+	inner *algorithm.ChirpZ
+	pool  scratchPool[complex128]
+}
+
+// NewCzt creates a chirp-z transform of inputLen samples at the outputLen points A·W^-k
+// Powers of W and A are taken on the principal branch of the logarithm.
+func NewCzt(inputLen, outputLen int, w, a complex128) *Czt {
+	if inputLen < 1 || outputLen < 1 {
+		panic(fmt.Sprintf("chirp-z transform needs positive lengths, got %d inputs and %d outputs", inputLen, outputLen))
+	}
+	return &Czt{inner: algorithm.NewChirpZ(inputLen, outputLen, w, a)}
+}
+
+// NewZoomFft creates a Czt that evaluates the spectrum of inputLen samples
+// taken at sampleRate at the outputLen frequencies f1 + k(f2-f1)/outputLen
+//
+// The frequencies run from f1 up to but excluding f2, as in
+// scipy.signal.ZoomFFT, so the bins can be much narrower than those of an FFT
+// of the same input. With f1 = 0, f2 = sampleRate and outputLen = inputLen,
+// the output is the DFT.
+func NewZoomFft(inputLen int, f1, f2 float64, outputLen int, sampleRate float64) *Czt {
+	step := (f2 - f1) / float64(outputLen) / sampleRate
+	start := f1 / sampleRate
+	w := complex(math.Cos(2*math.Pi*step), -math.Sin(2*math.Pi*step))
+	a := complex(math.Cos(2*math.Pi*start), math.Sin(2*math.Pi*start))
+	return NewCzt(inputLen, outputLen, w, a)
+}
+
+// Len returns the number of input samples
+func (c *Czt) Len() int { return c.inner.Len() }
+
+// OutputLen returns the number of points the transform is evaluated at
+func (c *Czt) OutputLen() int { return c.inner.OutputLen() }
+
+// ScratchLen returns the required scratch buffer size for ProcessWithScratch
+func (c *Czt) ScratchLen() int { return c.inner.ScratchLen() }
+
+// Process computes the transform of Len() input samples into OutputLen() outputs
+// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+func (c *Czt) Process(input, output []complex128) {
+	scratch := c.pool.get(c.ScratchLen())
+	c.ProcessWithScratch(input, output, *scratch)
+	c.pool.put(scratch)
+}
+
+// ProcessWithScratch computes the transform using the provided scratch buffer
+// The scratch buffer must have length >= ScratchLen(). The contents of input are left unchanged.
+func (c *Czt) ProcessWithScratch(input, output, scratch []complex128) {
+	validateFixedLen(len(input), c.Len(), len(output), c.OutputLen(), len(scratch), c.ScratchLen())
+	c.inner.Process(input, output, scratch)
+}
@@ -0,0 +1,97 @@
+package gofft
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func naiveCzt(x []complex128, m int, w, a complex128) []complex128 {
+	out := make([]complex128, m)
+	for k := range out {
+		z := a * cmplx.Pow(w, complex(-float64(k), 0))
+		for n, v := range x {
+			out[k] += v * cmplx.Pow(z, complex(-float64(n), 0))
+		}
+	}
+	return out
+}
+
+func TestCztMatchesDefinition(t *testing.T) {
+	tests := []struct {
+		n, m int
+		w, a complex128
+	}{
+		{16, 16, cmplx.Rect(1, -2*math.Pi/16), 1},
+		{13, 40, cmplx.Rect(1, -0.05), cmplx.Rect(1, 0.3)},
+		{50, 7, cmplx.Rect(0.995, -0.1), cmplx.Rect(1.02, -0.7)},
+		{1, 5, cmplx.Rect(1.01, 0.2), 0.9},
+		{33, 1, 1i, 2},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("N%d/M%d", tt.n, tt.m), func(t *testing.T) {
+			x := testSignal(tt.n, 0.6)
+			want := naiveCzt(x, tt.m, tt.w, tt.a)
+
+			c := NewCzt(tt.n, tt.m, tt.w, tt.a)
+			if c.Len() != tt.n || c.OutputLen() != tt.m {
+				t.Fatalf("got Len %d and OutputLen %d", c.Len(), c.OutputLen())
+			}
+			got := make([]complex128, tt.m)
+			c.Process(x, got)
+			if !complexSlicesEqual(got, want, 1e-9*float64(tt.n+tt.m)) {
+				t.Errorf("got %v\nwant %v", got, want)
+			}
+		})
+	}
+}
+
+func TestZoomFft(t *testing.T) {
+	// The full band at the input length is the DFT
+	x := testSignal(24, 0.35)
+	dft := make([]complex128, 24)
+	NewZoomFft(24, 0, 1000, 24, 1000).Process(x, dft)
+	if want := naiveDFT(x, true); !complexSlicesEqual(dft, want, 1e-9) {
+		t.Errorf("full-band zoom FFT: got %v\nwant %v", dft, want)
+	}
+
+	// Two tones 2 Hz apart, closer than the 7.8 Hz bins of the plain FFT
+	const fs, n = 1000.0, 128
+	signal := make([]complex128, n)
+	for i := range signal {
+		tm := float64(i) / fs
+		signal[i] = complex(math.Cos(2*math.Pi*100*tm)+math.Cos(2*math.Pi*102*tm), 0)
+	}
+	zoom := NewZoomFft(n, 95, 107, 120, fs)
+	spectrum := make([]complex128, zoom.OutputLen())
+	zoom.Process(signal, spectrum)
+	for k, v := range spectrum {
+		f := 95 + float64(k)*0.1
+		var want complex128
+		for i, s := range signal {
+			want += s * cmplx.Rect(1, -2*math.Pi*f*float64(i)/fs)
+		}
+		if cmplx.Abs(v-want) > 1e-8 {
+			t.Fatalf("%.1f Hz: got %v, want %v", f, v, want)
+		}
+	}
+}
+
+func TestCztScratchAndSizes(t *testing.T) {
+	c := NewZoomFft(100, 10, 20, 64, 100)
+	input := testSignal(100, 0.2)
+	output := make([]complex128, 64)
+	scratch := make([]complex128, c.ScratchLen())
+	if allocs := testing.AllocsPerRun(10, func() { c.ProcessWithScratch(input, output, scratch) }); allocs != 0 {
+		t.Errorf("ProcessWithScratch allocated %v times", allocs)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrLengthMismatch) {
+			t.Errorf("expected a panic wrapping ErrLengthMismatch, got %v", err)
+		}
+	}()
+	c.Process(input, make([]complex128, 100))
+}
@@ -0,0 +1,500 @@
+package gofft
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DctType selects one of the discrete cosine and sine transforms
+//
+// The transforms are unnormalized and follow RustDCT's definitions. For a
+// buffer x of length N:
+//
+//	DCT-I:   X[k] = (x[0] + (-1)^k x[N-1])/2 + Σ_{n=1}^{N-2} x[n] cos(πnk/(N-1))
+//	DCT-II:  X[k] = Σ x[n] cos(π(2n+1)k/2N)
+//	DCT-III: X[k] = x[0]/2 + Σ_{n=1}^{N-1} x[n] cos(πn(2k+1)/2N)
+//	DCT-IV:  X[k] = Σ x[n] cos(π(2n+1)(2k+1)/4N)
+//	DST-I:   X[k] = Σ x[n] sin(π(n+1)(k+1)/(N+1))
+//	DST-II:  X[k] = Σ x[n] sin(π(2n+1)(k+1)/2N)
+//	DST-III: X[k] = (-1)^k x[N-1]/2 + Σ_{n=0}^{N-2} x[n] sin(π(n+1)(2k+1)/2N)
+//	DST-IV:  X[k] = Σ x[n] sin(π(2n+1)(2k+1)/4N)
+//
+// Types II and III are each other's inverse, and types I and IV are their own,
+// up to a factor of 2/(N-1) for DCT-I, 2/(N+1) for DST-I and 2/N otherwise.
+type DctType int
+
+const (
+	// Dct1 is the DCT-I, which needs at least two samples
+	Dct1 DctType = iota
+
+	// Dct2 is the DCT-II, usually just called "the DCT"
+	Dct2
+
+	// Dct3 is the DCT-III, the inverse of the DCT-II
+	Dct3
+
+	// Dct4 is the DCT-IV, the building block of the MDCT
+	Dct4
+
+	// Dst1 is the DST-I
+	Dst1
+
+	// Dst2 is the DST-II
+	Dst2
+
+	// Dst3 is the DST-III, the inverse of the DST-II
+	Dst3
+
+	// Dst4 is the DST-IV
+	Dst4
+)
+
+func (t DctType) String() string {
+	names := [...]string{"DCT-I", "DCT-II", "DCT-III", "DCT-IV", "DST-I", "DST-II", "DST-III", "DST-IV"}
+	if t < 0 || int(t) >= len(names) {
+		return fmt.Sprintf("DctType(%d)", int(t))
+	}
+	return names[t]
+}
+
+// Dct computes one type of discrete cosine or sine transform in place
+//
+// All Dct implementations are thread-safe and can be used concurrently.
+type Dct interface {
+	// Process computes the transform in-place on the provided buffer.
+	// The buffer length must be a multiple of Len().
+	// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+	Process(buffer []float64)
+
+	// ProcessWithScratch computes the transform in-place using the provided scratch buffer.
+	// The scratch buffer must have length >= ScratchLen().
+	// The buffer length must be a multiple of Len().
+	ProcessWithScratch(buffer []float64, scratch []complex128)
+
+	// Len returns the transform size that this instance processes
+	Len() int
+
+	// Type returns which transform this instance computes
+	Type() DctType
+
+	// ScratchLen returns the required scratch buffer size for ProcessWithScratch
+	ScratchLen() int
+}
+
+// DctPlanner creates DCT and DST instances
+// Each transform is mapped onto a complex FFT planned with a Planner, so every size is O(n log n).
+type DctPlanner struct {
+	mu      sync.Mutex
+	planner *Planner
+	cache   map[dctKey]Dct
+}
+
+type dctKey struct {
+	dctType DctType
+	length  int
+}
+
+// NewDctPlanner creates a new DCT planner
+func NewDctPlanner() *DctPlanner {
+	return NewDctPlannerWith(NewPlanner())
+}
+
+// NewDctPlannerWith creates a DCT planner that plans its FFTs with the given planner
+func NewDctPlannerWith(planner *Planner) *DctPlanner {
+	return &DctPlanner{
+		planner: planner,
+		cache:   make(map[dctKey]Dct),
+	}
+}
+
+// Plan creates an instance computing transforms of the given type and size
+func (p *DctPlanner) Plan(dctType DctType, length int) Dct {
+	minLen := 1
+	if dctType == Dct1 {
+		minLen = 2
+	}
+	if length < minLen {
+		panic(fmt.Sprintf("%v needs a length of at least %d, got %d", dctType, minLen, length))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := dctKey{dctType: dctType, length: length}
+	if dct, ok := p.cache[key]; ok {
+		return dct
+	}
+
+	var dct Dct
+	switch dctType {
+	case Dct1:
+		dct = &dct1{length: length, fft: p.planner.PlanForward(2 * (length - 1))}
+	case Dst1:
+		dct = &dst1{length: length, fft: p.planner.PlanForward(2 * (length + 1))}
+	case Dct2, Dst2:
+		dct = newDct2(length, p.planner.PlanForward(length))
+	case Dct3, Dst3:
+		dct = newDct3(length, p.planner.PlanForward(length))
+	case Dct4, Dst4:
+		if length%2 == 0 {
+			dct = newDct4(length, p.planner.PlanForward(length/2))
+		} else {
+			dct = newDct4(length, p.planner.PlanForward(2*length))
+		}
+	default:
+		panic(fmt.Sprintf("unknown DCT type %d", dctType))
+	}
+	if dctType == Dst2 || dctType == Dst3 || dctType == Dst4 {
+		dct = &dstFromDct{inner: dct, dctType: dctType}
+	}
+
+	p.cache[key] = dct
+	return dct
+}
+
+// dctHelper validates the buffers and processes every transform in a batch
+func dctHelper(buffer []float64, scratch []complex128, expectedLen, expectedScratch int, process func(chunk []float64, scratch []complex128)) {
+	validateInplace(len(buffer), expectedLen, len(scratch), expectedScratch)
+
+	for i := 0; i < len(buffer); i += expectedLen {
+		process(buffer[i:i+expectedLen], scratch)
+	}
+}
+
+// dct1 computes the DCT-I as the FFT of the even extension of length 2(N-1)
+type dct1 struct {
+	length int
+	fft    Fft
+	pool   scratchPool[complex128]
+}
+
+func (d *dct1) Len() int        { return d.length }
+func (d *dct1) Type() DctType   { return Dct1 }
+func (d *dct1) ScratchLen() int { return d.fft.Len() + d.fft.InplaceScratchLen() }
+
+func (d *dct1) Process(buffer []float64) {
+	scratch := d.pool.get(d.ScratchLen())
+	d.ProcessWithScratch(buffer, *scratch)
+	d.pool.put(scratch)
+}
+
+func (d *dct1) ProcessWithScratch(buffer []float64, scratch []complex128) {
+	dctHelper(buffer, scratch, d.length, d.ScratchLen(), d.processOne)
+}
+
+func (d *dct1) processOne(x []float64, scratch []complex128) {
+	m := d.fft.Len()
+	buf := scratch[:m]
+
+	// x[0], ..., x[N-1], x[N-2], ..., x[1]
+	for i, v := range x {
+		buf[i] = complex(v, 0)
+	}
+	for i := 1; i < d.length-1; i++ {
+		buf[m-i] = complex(x[i], 0)
+	}
+
+	d.fft.ProcessWithScratch(buf, scratch[m:])
+
+	// The extension counts the interior samples twice
+	for k := range x {
+		x[k] = real(buf[k]) / 2
+	}
+}
+
+// dst1 computes the DST-I as the FFT of the odd extension of length 2(N+1)
+type dst1 struct {
+	length int
+	fft    Fft
+	pool   scratchPool[complex128]
+}
+
+func (d *dst1) Len() int        { return d.length }
+func (d *dst1) Type() DctType   { return Dst1 }
+func (d *dst1) ScratchLen() int { return d.fft.Len() + d.fft.InplaceScratchLen() }
+
+func (d *dst1) Process(buffer []float64) {
+	scratch := d.pool.get(d.ScratchLen())
+	d.ProcessWithScratch(buffer, *scratch)
+	d.pool.put(scratch)
+}
+
+func (d *dst1) ProcessWithScratch(buffer []float64, scratch []complex128) {
+	dctHelper(buffer, scratch, d.length, d.ScratchLen(), d.processOne)
+}
+
+func (d *dst1) processOne(x []float64, scratch []complex128) {
+	m := d.fft.Len()
+	buf := scratch[:m]
+
+	// 0, x[0], ..., x[N-1], 0, -x[N-1], ..., -x[0]
+	buf[0] = 0
+	buf[d.length+1] = 0
+	for i, v := range x {
+		buf[i+1] = complex(v, 0)
+		buf[m-1-i] = complex(-v, 0)
+	}
+
+	d.fft.ProcessWithScratch(buf, scratch[m:])
+
+	// Bin k+1 of the extension is -2i times output k
+	for k := range x {
+		x[k] = -imag(buf[k+1]) / 2
+	}
+}
+
+// dct2 computes the DCT-II with an FFT of the same length (Makhoul, 1980)
+// The even samples followed by the odd samples in reverse make a sequence
+// whose DFT, rotated by a quarter-sample twiddle, has the DCT as its real part.
+type dct2 struct {
+	length   int
+	fft      Fft
+	twiddles []complex128 // e^(-iπk/2N)
+	pool     scratchPool[complex128]
+}
+
+func newDct2(length int, fft Fft) *dct2 {
+	twiddles := make([]complex128, length)
+	for k := range twiddles {
+		twiddles[k] = TwiddleFactor(k, 4*length, Forward)
+	}
+	return &dct2{length: length, fft: fft, twiddles: twiddles}
+}
+
+func (d *dct2) Len() int        { return d.length }
+func (d *dct2) Type() DctType   { return Dct2 }
+func (d *dct2) ScratchLen() int { return d.length + d.fft.InplaceScratchLen() }
+
+func (d *dct2) Process(buffer []float64) {
+	scratch := d.pool.get(d.ScratchLen())
+	d.ProcessWithScratch(buffer, *scratch)
+	d.pool.put(scratch)
+}
+
+func (d *dct2) ProcessWithScratch(buffer []float64, scratch []complex128) {
+	dctHelper(buffer, scratch, d.length, d.ScratchLen(), d.processOne)
+}
+
+func (d *dct2) processOne(x []float64, scratch []complex128) {
+	n := d.length
+	buf := scratch[:n]
+
+	for i := 0; 2*i < n; i++ {
+		buf[i] = complex(x[2*i], 0)
+	}
+	for i := 0; 2*i+1 < n; i++ {
+		buf[n-1-i] = complex(x[2*i+1], 0)
+	}
+
+	d.fft.ProcessWithScratch(buf, scratch[n:])
+
+	for k := range x {
+		x[k] = real(buf[k] * d.twiddles[k])
+	}
+}
+
+// dct3 computes the DCT-III by running the steps of dct2 transposed
+type dct3 struct {
+	length   int
+	fft      Fft
+	twiddles []complex128 // e^(-iπk/2N)
+	pool     scratchPool[complex128]
+}
+
+func newDct3(length int, fft Fft) *dct3 {
+	twiddles := make([]complex128, length)
+	for k := range twiddles {
+		twiddles[k] = TwiddleFactor(k, 4*length, Forward)
+	}
+	twiddles[0] = 0.5 // the halved x[0] term
+	return &dct3{length: length, fft: fft, twiddles: twiddles}
+}
+
+func (d *dct3) Len() int        { return d.length }
+func (d *dct3) Type() DctType   { return Dct3 }
+func (d *dct3) ScratchLen() int { return d.length + d.fft.InplaceScratchLen() }
+
+func (d *dct3) Process(buffer []float64) {
+	scratch := d.pool.get(d.ScratchLen())
+	d.ProcessWithScratch(buffer, *scratch)
+	d.pool.put(scratch)
+}
+
+func (d *dct3) ProcessWithScratch(buffer []float64, scratch []complex128) {
+	dctHelper(buffer, scratch, d.length, d.ScratchLen(), d.processOne)
+}
+
+func (d *dct3) processOne(x []float64, scratch []complex128) {
+	n := d.length
+	buf := scratch[:n]
+
+	for k, v := range x {
+		buf[k] = complex(v, 0) * d.twiddles[k]
+	}
+
+	d.fft.ProcessWithScratch(buf, scratch[n:])
+
+	// Undo the even/odd reordering of the DCT-II
+	for i := 0; 2*i < n; i++ {
+		x[2*i] = real(buf[i])
+	}
+	for i := 0; 2*i+1 < n; i++ {
+		x[2*i+1] = real(buf[n-1-i])
+	}
+}
+
+// dct4 computes the DCT-IV
+// Even lengths pack pairs of samples into one complex FFT of length N/2; odd
+// lengths zero-pad a twiddled copy of the input to an FFT of length 2N.
+type dct4 struct {
+	length       int
+	fft          Fft
+	preTwiddles  []complex128
+	postTwiddles []complex128
+	pool         scratchPool[complex128]
+}
+
+func newDct4(length int, fft Fft) *dct4 {
+	d := &dct4{length: length, fft: fft}
+	if length%2 == 0 {
+		half := length / 2
+		d.preTwiddles = make([]complex128, half)
+		d.postTwiddles = make([]complex128, half)
+		for i := 0; i < half; i++ {
+			d.preTwiddles[i] = TwiddleFactor(4*i+1, 8*length, Forward) // e^(-iπ(4n+1)/4N)
+			d.postTwiddles[i] = TwiddleFactor(i, 2*length, Forward)    // e^(-iπk/N)
+		}
+	} else {
+		d.preTwiddles = make([]complex128, length)
+		d.postTwiddles = make([]complex128, length)
+		for i := 0; i < length; i++ {
+			d.preTwiddles[i] = TwiddleFactor(i, 4*length, Forward)      // e^(-iπn/2N)
+			d.postTwiddles[i] = TwiddleFactor(2*i+1, 8*length, Forward) // e^(-iπ(2k+1)/4N)
+		}
+	}
+	return d
+}
+
+func (d *dct4) Len() int        { return d.length }
+func (d *dct4) Type() DctType   { return Dct4 }
+func (d *dct4) ScratchLen() int { return d.fft.Len() + d.fft.InplaceScratchLen() }
+
+func (d *dct4) Process(buffer []float64) {
+	scratch := d.pool.get(d.ScratchLen())
+	d.ProcessWithScratch(buffer, *scratch)
+	d.pool.put(scratch)
+}
+
+func (d *dct4) ProcessWithScratch(buffer []float64, scratch []complex128) {
+	if d.length%2 == 0 {
+		dctHelper(buffer, scratch, d.length, d.ScratchLen(), d.processEven)
+	} else {
+		dctHelper(buffer, scratch, d.length, d.ScratchLen(), d.processOdd)
+	}
+}
+
+func (d *dct4) processEven(x []float64, scratch []complex128) {
+	n := d.length
+	packed := d.transformEven(x, scratch)
+
+	// The real parts are the even outputs, the imaginary parts the odd ones in reverse
+	for k, c := range packed {
+		x[2*k] = real(c)
+		x[n-1-2*k] = -imag(c)
+	}
+}
+
+// transformEven computes the DCT-IV of x, leaving x unchanged, and returns it
+// packed into scratch[:N/2]: output 2k is the real part of element k and
+// output N-1-2k is minus its imaginary part
+func (d *dct4) transformEven(x []float64, scratch []complex128) []complex128 {
+	n := d.length
+	half := n / 2
+	buf := scratch[:half]
+
+	// Pair each even sample with the odd sample mirrored from the end
+	for i := 0; i < half; i++ {
+		buf[i] = complex(x[2*i], x[n-1-2*i]) * d.preTwiddles[i]
+	}
+
+	d.fft.ProcessWithScratch(buf, scratch[half:])
+
+	for k := range buf {
+		buf[k] *= d.postTwiddles[k]
+	}
+	return buf
+}
+
+func (d *dct4) processOdd(x []float64, scratch []complex128) {
+	n := d.length
+	buf := scratch[:2*n]
+
+	for i, v := range x {
+		buf[i] = complex(v, 0) * d.preTwiddles[i]
+	}
+	for i := n; i < 2*n; i++ {
+		buf[i] = 0
+	}
+
+	d.fft.ProcessWithScratch(buf, scratch[2*n:])
+
+	for k := range x {
+		x[k] = real(buf[k] * d.postTwiddles[k])
+	}
+}
+
+// dstFromDct computes the DST-II, DST-III and DST-IV with the DCT of the same type
+// Alternating the signs of the inputs of a DCT-II and reversing its outputs
+// gives the DST-II; the other two are the same steps transposed.
+type dstFromDct struct {
+	inner   Dct
+	dctType DctType
+	pool    scratchPool[complex128]
+}
+
+func (d *dstFromDct) Len() int        { return d.inner.Len() }
+func (d *dstFromDct) Type() DctType   { return d.dctType }
+func (d *dstFromDct) ScratchLen() int { return d.inner.ScratchLen() }
+
+func (d *dstFromDct) Process(buffer []float64) {
+	scratch := d.pool.get(d.ScratchLen())
+	d.ProcessWithScratch(buffer, *scratch)
+	d.pool.put(scratch)
+}
+
+func (d *dstFromDct) ProcessWithScratch(buffer []float64, scratch []complex128) {
+	n := d.Len()
+	validateInplace(len(buffer), n, len(scratch), d.ScratchLen())
+
+	for i := 0; i < len(buffer); i += n {
+		x := buffer[i : i+n]
+		if d.dctType == Dst2 {
+			negateOdd(x)
+		} else {
+			reverseFloats(x)
+		}
+	}
+
+	d.inner.ProcessWithScratch(buffer, scratch)
+
+	for i := 0; i < len(buffer); i += n {
+		x := buffer[i : i+n]
+		if d.dctType == Dst2 {
+			reverseFloats(x)
+		} else {
+			negateOdd(x)
+		}
+	}
+}
+
+func negateOdd(x []float64) {
+	for i := 1; i < len(x); i += 2 {
+		x[i] = -x[i]
+	}
+}
+
+func reverseFloats(x []float64) {
+	for i, j := 0, len(x)-1; i < j; i, j = i+1, j-1 {
+		x[i], x[j] = x[j], x[i]
+	}
+}
@@ -0,0 +1,179 @@
+package gofft
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+var allDctTypes = []DctType{Dct1, Dct2, Dct3, Dct4, Dst1, Dst2, Dst3, Dst4}
+
+// naiveDct evaluates the definitions listed on DctType directly
+func naiveDct(dctType DctType, x []float64) []float64 {
+	n := len(x)
+	out := make([]float64, n)
+	for k := range out {
+		sum := 0.0
+		for i, v := range x {
+			fi, fk, fn := float64(i), float64(k), float64(n)
+			switch dctType {
+			case Dct1:
+				if i == 0 || i == n-1 {
+					v /= 2
+				}
+				sum += v * math.Cos(math.Pi*fi*fk/(fn-1))
+			case Dct2:
+				sum += v * math.Cos(math.Pi*(2*fi+1)*fk/(2*fn))
+			case Dct3:
+				if i == 0 {
+					v /= 2
+				}
+				sum += v * math.Cos(math.Pi*fi*(2*fk+1)/(2*fn))
+			case Dct4:
+				sum += v * math.Cos(math.Pi*(2*fi+1)*(2*fk+1)/(4*fn))
+			case Dst1:
+				sum += v * math.Sin(math.Pi*(fi+1)*(fk+1)/(fn+1))
+			case Dst2:
+				sum += v * math.Sin(math.Pi*(2*fi+1)*(fk+1)/(2*fn))
+			case Dst3:
+				if i == n-1 {
+					v /= 2
+				}
+				sum += v * math.Sin(math.Pi*(fi+1)*(2*fk+1)/(2*fn))
+			case Dst4:
+				sum += v * math.Sin(math.Pi*(2*fi+1)*(2*fk+1)/(4*fn))
+			}
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+// realTestSignal returns the real parts of testSignal
+func realTestSignal(n int, seed float64) []float64 {
+	x := make([]float64, n)
+	for i, v := range testSignal(n, seed) {
+		x[i] = real(v)
+	}
+	return x
+}
+
+func floatSlicesEqual(a, b []float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDctMatchesDefinition(t *testing.T) {
+	planner := NewDctPlanner()
+	for _, dctType := range allDctTypes {
+		for _, n := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 15, 16, 31, 32, 100, 127, 256} {
+			if dctType == Dct1 && n < 2 {
+				continue
+			}
+			t.Run(fmt.Sprintf("%v/Size%d", dctType, n), func(t *testing.T) {
+				x := realTestSignal(n, float64(n))
+				want := naiveDct(dctType, x)
+
+				dct := planner.Plan(dctType, n)
+				if dct.Len() != n || dct.Type() != dctType {
+					t.Fatalf("got Len %d and Type %v", dct.Len(), dct.Type())
+				}
+				dct.Process(x)
+				if !floatSlicesEqual(x, want, 1e-9*float64(n)) {
+					t.Errorf("got %v\nwant %v", x, want)
+				}
+			})
+		}
+	}
+}
+
+// TestDctInverses checks the inverse pairs documented on DctType
+func TestDctInverses(t *testing.T) {
+	planner := NewDctPlanner()
+	pairs := []struct {
+		forward, inverse DctType
+		scale            func(n int) float64
+	}{
+		{Dct1, Dct1, func(n int) float64 { return 2 / float64(n-1) }},
+		{Dct2, Dct3, func(n int) float64 { return 2 / float64(n) }},
+		{Dct3, Dct2, func(n int) float64 { return 2 / float64(n) }},
+		{Dct4, Dct4, func(n int) float64 { return 2 / float64(n) }},
+		{Dst1, Dst1, func(n int) float64 { return 2 / float64(n+1) }},
+		{Dst2, Dst3, func(n int) float64 { return 2 / float64(n) }},
+		{Dst4, Dst4, func(n int) float64 { return 2 / float64(n) }},
+	}
+	for _, pair := range pairs {
+		for _, n := range []int{2, 17, 64, 1000} {
+			x := realTestSignal(n, 3)
+			y := append([]float64(nil), x...)
+			planner.Plan(pair.forward, n).Process(y)
+			planner.Plan(pair.inverse, n).Process(y)
+			for i := range y {
+				y[i] *= pair.scale(n)
+			}
+			if !floatSlicesEqual(x, y, 1e-10) {
+				t.Errorf("%v then %v of size %d doesn't round-trip", pair.forward, pair.inverse, n)
+			}
+		}
+	}
+}
+
+func TestDctBatchAndScratch(t *testing.T) {
+	planner := NewDctPlanner()
+	for _, dctType := range allDctTypes {
+		dct := planner.Plan(dctType, 12)
+		if planner.Plan(dctType, 12) != dct {
+			t.Errorf("%v: planner didn't cache the plan", dctType)
+		}
+
+		batch := realTestSignal(36, 5)
+		want := make([]float64, 0, 36)
+		for i := 0; i < 36; i += 12 {
+			want = append(want, naiveDct(dctType, batch[i:i+12])...)
+		}
+
+		scratch := make([]complex128, dct.ScratchLen())
+		dct.ProcessWithScratch(batch, scratch)
+		if !floatSlicesEqual(batch, want, 1e-10) {
+			t.Errorf("%v: batched output differs from one transform per row", dctType)
+		}
+
+		if allocs := testing.AllocsPerRun(10, func() { dct.ProcessWithScratch(batch, scratch) }); allocs != 0 {
+			t.Errorf("%v: ProcessWithScratch allocated %v times", dctType, allocs)
+		}
+
+		func() {
+			defer func() {
+				if err, ok := recover().(error); !ok || !errors.Is(err, ErrNotMultipleOfLen) {
+					t.Errorf("%v: expected a panic wrapping ErrNotMultipleOfLen, got %v", dctType, err)
+				}
+			}()
+			dct.Process(make([]float64, 18))
+		}()
+	}
+}
+
+func BenchmarkDct(b *testing.B) {
+	planner := NewDctPlanner()
+	for _, dctType := range []DctType{Dct2, Dct3, Dct4} {
+		for _, n := range []int{256, 1024, 4096} {
+			b.Run(fmt.Sprintf("%v/Size%d", dctType, n), func(b *testing.B) {
+				dct := planner.Plan(dctType, n)
+				x := realTestSignal(n, 1)
+				scratch := make([]complex128, dct.ScratchLen())
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					dct.ProcessWithScratch(x, scratch)
+				}
+			})
+		}
+	}
+}
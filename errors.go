@@ -0,0 +1,30 @@
+package gofft
+
+import "errors"
+
+// Errors reported for malformed buffers. Process methods panic with, and
+// TryProcess methods return, an error wrapping one of these together with
+// the expected and actual sizes, so callers should match them with errors.Is.
+var (
+	// ErrBufferTooSmall means a buffer is shorter than the FFT length
+	ErrBufferTooSmall = errors.New("provided FFT buffer was too small")
+
+	// ErrNotMultipleOfLen means a buffer length is not a multiple of the FFT length
+	ErrNotMultipleOfLen = errors.New("FFT buffer length must be a multiple of FFT length")
+
+	// ErrScratchTooSmall means the scratch buffer is shorter than the required scratch length
+	ErrScratchTooSmall = errors.New("not enough scratch space was provided")
+
+	// ErrLengthMismatch means an input and output buffer have incompatible lengths
+	ErrLengthMismatch = errors.New("FFT input and output buffer lengths don't match")
+)
+
+// Errors reported by ImportWisdom
+var (
+	// ErrWisdomMismatch means the wisdom was exported on a different OS,
+	// architecture, precision or set of SIMD kernels, so its timings don't apply here
+	ErrWisdomMismatch = errors.New("wisdom was measured on a different platform")
+
+	// ErrInvalidWisdom means the wisdom is malformed or describes an impossible FFT
+	ErrInvalidWisdom = errors.New("invalid FFT wisdom")
+)
@@ -1,7 +1,8 @@
 // Package gofft provides a high-performance FFT library for Go, inspired by RustFFT.
 //
-// gofft supports SIMD acceleration on x86_64 (SSE4.1, AVX) and ARM64 (NEON) architectures
-// with automatic runtime CPU feature detection.
+// On amd64, the complex128 hot paths run AVX2/FMA assembly when runtime CPU
+// detection finds support for it. Other architectures, and builds with the
+// purego tag, use the portable Go implementations.
 //
 // Usage:
 //
@@ -18,9 +19,11 @@
 //	// Compute FFT in-place
 //	fft.Process(buffer)
 //
-// The library does not normalize outputs. Callers must manually normalize results
-// by scaling each element by 1/sqrt(len). When doing a forward FFT followed by
-// an inverse FFT, you can normalize once by scaling by 1/len.
+// FFTs from Plan are unnormalized, so a forward FFT followed by an inverse FFT
+// multiplies the data by len. PlanWith takes a Normalization that scales the
+// output as numpy's "norm" argument does, folded into the algorithm's final pass:
+//
+//	inverse := planner.PlanWith(1234, gofft.Inverse, gofft.PlanOptions{Normalize: gofft.NormBackward})
 package gofft
 
 // Fft is the main interface for computing FFTs.
@@ -29,7 +32,7 @@ package gofft
 type Fft interface {
 	// Process computes an FFT in-place on the provided buffer.
 	// The buffer length must be a multiple of Len().
-	// Allocates scratch space internally.
+	// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
 	Process(buffer []complex128)
 
 	// ProcessWithScratch computes an FFT in-place using the provided scratch buffer.
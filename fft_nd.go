@@ -0,0 +1,132 @@
+package gofft
+
+// FftND computes multi-dimensional FFTs over row-major data.
+//
+// The transform is separable: a 1D FFT is applied along every axis in turn.
+// All FftND implementations are thread-safe and can be used concurrently.
+type FftND interface {
+	// Process computes an N-D FFT in-place on the provided buffer.
+	// The buffer length must be a multiple of Len().
+	// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+	Process(buffer []complex128)
+
+	// ProcessWithScratch computes an N-D FFT in-place using the provided scratch buffer.
+	// The scratch buffer must have length >= InplaceScratchLen().
+	// The buffer length must be a multiple of Len().
+	ProcessWithScratch(buffer, scratch []complex128)
+
+	// Shape returns the size of each dimension, outermost first
+	Shape() []int
+
+	// Len returns the total number of elements, the product of Shape()
+	Len() int
+
+	// Direction returns whether this instance computes forward or inverse FFTs
+	Direction() Direction
+
+	// InplaceScratchLen returns the required scratch buffer size for ProcessWithScratch
+	InplaceScratchLen() int
+}
+
+// Plan2D creates a 2D FFT instance for a rows x cols matrix stored in row-major order
+func (p *Planner) Plan2D(rows, cols int, direction Direction) FftND {
+	return p.PlanND([]int{rows, cols}, direction)
+}
+
+// PlanND creates an N-D FFT instance for data of the given shape stored in row-major order
+func (p *Planner) PlanND(shape []int, direction Direction) FftND {
+	if len(shape) == 0 {
+		panic("FFT shape must have at least one dimension")
+	}
+
+	length := 1
+	for _, n := range shape {
+		if n < 1 {
+			panic("FFT dimensions must be positive")
+		}
+		length *= n
+	}
+
+	// Plan one FFT per axis, innermost axis first, since that is the order they run in
+	axes := make([]Fft, len(shape))
+	innerScratch := 0
+	for i := range shape {
+		n := shape[len(shape)-1-i]
+		axes[i] = p.Plan(n, direction)
+		if s := axes[i].InplaceScratchLen(); s > innerScratch {
+			innerScratch = s
+		}
+	}
+
+	// The axis FFTs borrow whichever half of the ping-pong buffers is idle,
+	// and only need dedicated scratch when that isn't big enough
+	inplaceScratch := length
+	if innerScratch > length {
+		inplaceScratch += innerScratch
+	}
+
+	return &fftND{
+		shape:          append([]int(nil), shape...),
+		length:         length,
+		direction:      direction,
+		axes:           axes,
+		innerScratch:   innerScratch,
+		inplaceScratch: inplaceScratch,
+	}
+}
+
+// fftND implements FftND by alternating batched 1D FFTs with transposes
+type fftND struct {
+	shape          []int
+	length         int
+	direction      Direction
+	axes           []Fft // One FFT per axis, innermost axis first
+	innerScratch   int
+	inplaceScratch int
+	pool           scratchPool[complex128]
+}
+
+func (f *fftND) Shape() []int           { return append([]int(nil), f.shape...) }
+func (f *fftND) Len() int               { return f.length }
+func (f *fftND) Direction() Direction   { return f.direction }
+func (f *fftND) InplaceScratchLen() int { return f.inplaceScratch }
+
+func (f *fftND) Process(buffer []complex128) {
+	scratch := f.pool.get(f.inplaceScratch)
+	f.ProcessWithScratch(buffer, *scratch)
+	f.pool.put(scratch)
+}
+
+func (f *fftND) ProcessWithScratch(buffer, scratch []complex128) {
+	fftHelperInplace(buffer, scratch, f.length, f.inplaceScratch, f.processOne)
+}
+
+func (f *fftND) processOne(buffer, scratch []complex128) {
+	// Each pass transforms the innermost axis with contiguous row FFTs, then
+	// transposes so the next axis becomes innermost. After one pass per axis
+	// the axes have rotated all the way around to their original order.
+	data := buffer
+	spare := scratch[:f.length]
+	extra := scratch[f.length:]
+
+	for _, axis := range f.axes {
+		n := axis.Len()
+		if n == 1 {
+			// A length-1 axis needs no FFT, and moving it is a no-op in memory
+			continue
+		}
+
+		axisScratch := spare
+		if f.innerScratch > f.length {
+			axisScratch = extra
+		}
+		axis.ProcessWithScratch(data, axisScratch)
+
+		Transpose(data, spare, f.length/n, n)
+		data, spare = spare, data
+	}
+
+	if &data[0] != &buffer[0] {
+		copy(buffer, data)
+	}
+}
@@ -0,0 +1,172 @@
+package gofft
+
+import (
+	"math"
+	"testing"
+)
+
+// naiveDFTND computes an N-D DFT by applying naiveDFT along every axis
+func naiveDFTND(input []complex128, shape []int, forward bool) []complex128 {
+	output := make([]complex128, len(input))
+	copy(output, input)
+
+	stride := 1
+	for axis := len(shape) - 1; axis >= 0; axis-- {
+		n := shape[axis]
+		line := make([]complex128, n)
+		for start := 0; start < len(output); start++ {
+			// Only visit the first element of each line along this axis
+			if (start/stride)%n != 0 {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				line[k] = output[start+k*stride]
+			}
+			result := naiveDFT(line, forward)
+			for k := 0; k < n; k++ {
+				output[start+k*stride] = result[k]
+			}
+		}
+		stride *= n
+	}
+	return output
+}
+
+func TestFftNDMatchesDFT(t *testing.T) {
+	shapes := [][]int{
+		{4, 4},
+		{3, 5},
+		{8, 6},
+		{1, 7},
+		{7, 1},
+		{17, 32},
+		{2, 3, 4},
+		{5, 1, 3},
+		{4, 3, 2, 5},
+		{12},
+	}
+
+	planner := NewPlanner()
+
+	for _, shape := range shapes {
+		for _, direction := range []Direction{Forward, Inverse} {
+			fft := planner.PlanND(shape, direction)
+
+			input := make([]complex128, fft.Len())
+			for i := range input {
+				input[i] = complex(math.Sin(float64(i)*0.9), float64(i%4)-1.5)
+			}
+			expected := naiveDFTND(input, shape, direction == Forward)
+
+			buffer := make([]complex128, len(input))
+			copy(buffer, input)
+			fft.ProcessWithScratch(buffer, make([]complex128, fft.InplaceScratchLen()))
+
+			if !complexSlicesEqual(buffer, expected, 1e-9) {
+				t.Errorf("Shape %v %v: output doesn't match naive DFT", shape, direction)
+			}
+		}
+	}
+}
+
+func TestFft2DRoundTrip(t *testing.T) {
+	rows, cols := 48, 37
+	planner := NewPlanner()
+	forward := planner.Plan2D(rows, cols, Forward)
+	inverse := planner.Plan2D(rows, cols, Inverse)
+
+	if forward.Len() != rows*cols {
+		t.Fatalf("Len = %d, want %d", forward.Len(), rows*cols)
+	}
+	if shape := forward.Shape(); len(shape) != 2 || shape[0] != rows || shape[1] != cols {
+		t.Fatalf("Shape = %v, want [%d %d]", shape, rows, cols)
+	}
+
+	original := make([]complex128, rows*cols)
+	for i := range original {
+		original[i] = complex(float64(i%11), float64(i%13)*0.25)
+	}
+	buffer := make([]complex128, len(original))
+	copy(buffer, original)
+
+	forward.Process(buffer)
+	inverse.Process(buffer)
+
+	scale := complex(1/float64(rows*cols), 0)
+	for i := range buffer {
+		buffer[i] *= scale
+	}
+
+	if !complexSlicesEqual(buffer, original, 1e-10) {
+		t.Errorf("2D round trip didn't recover the input")
+	}
+}
+
+func TestFftNDBatched(t *testing.T) {
+	shape := []int{6, 10}
+	fft := NewPlanner().PlanND(shape, Forward)
+	n := fft.Len()
+
+	buffer := make([]complex128, 3*n)
+	for i := range buffer {
+		buffer[i] = complex(float64(i%9), 0)
+	}
+	expected := make([]complex128, 0, len(buffer))
+	for b := 0; b < 3; b++ {
+		expected = append(expected, naiveDFTND(buffer[b*n:(b+1)*n], shape, true)...)
+	}
+
+	fft.ProcessWithScratch(buffer, make([]complex128, fft.InplaceScratchLen()))
+
+	if !complexSlicesEqual(buffer, expected, 1e-9) {
+		t.Errorf("Batched 2D FFT doesn't match naive DFT")
+	}
+}
+
+func TestFftNDZeroAllocations(t *testing.T) {
+	fft := NewPlanner().Plan2D(64, 64, Forward)
+	buffer := make([]complex128, fft.Len())
+	scratch := make([]complex128, fft.InplaceScratchLen())
+
+	allocs := testing.AllocsPerRun(10, func() {
+		fft.ProcessWithScratch(buffer, scratch)
+	})
+	if allocs != 0 {
+		t.Errorf("ProcessWithScratch allocated %.0f times per run", allocs)
+	}
+}
+
+func TestTransposeBlocked(t *testing.T) {
+	for _, dims := range [][2]int{{1, 1}, {3, 5}, {16, 16}, {17, 33}, {40, 7}} {
+		rows, cols := dims[0], dims[1]
+		input := make([]complex128, rows*cols)
+		for i := range input {
+			input[i] = complex(float64(i), 0)
+		}
+		output := make([]complex128, rows*cols)
+		Transpose(input, output, rows, cols)
+
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if output[c*rows+r] != input[r*cols+c] {
+					t.Fatalf("%dx%d: element (%d, %d) misplaced", rows, cols, r, c)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkFft2D(b *testing.B) {
+	for _, n := range []int{64, 256, 512} {
+		b.Run("Size"+string(rune(n+'0')), func(b *testing.B) {
+			fft := NewPlanner().Plan2D(n, n, Forward)
+			buffer := make([]complex128, fft.Len())
+			scratch := make([]complex128, fft.InplaceScratchLen())
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fft.ProcessWithScratch(buffer, scratch)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package gofft
 
 import (
+	"fmt"
 	"math"
 	"math/cmplx"
 	"testing"
@@ -166,6 +167,71 @@ func TestFFT_ImpulseResponse(t *testing.T) {
 	}
 }
 
+func TestFFT_OutOfPlaceAndImmutable(t *testing.T) {
+	// One size per recipe: butterfly, Radix4, RadixN, Rader's, Bluestein
+	sizes := []int{7, 17, 256, 60, 97, 1031}
+	planner := NewPlanner()
+
+	for _, n := range sizes {
+		t.Run(fmt.Sprintf("Size%d", n), func(t *testing.T) {
+			input := make([]complex128, n)
+			for i := range input {
+				input[i] = complex(math.Sin(float64(i)*0.3), math.Cos(float64(i)*0.7))
+			}
+			fft := planner.PlanForward(n)
+
+			expected := append([]complex128(nil), input...)
+			fft.Process(expected)
+
+			original := append([]complex128(nil), input...)
+			output := make([]complex128, n)
+			fft.ProcessImmutable(input, output, make([]complex128, fft.ImmutableScratchLen()))
+			if !complexSlicesEqual(output, expected, 1e-9) {
+				t.Errorf("ProcessImmutable doesn't match Process for size %d", n)
+			}
+			if !complexSlicesEqual(input, original, 0) {
+				t.Errorf("ProcessImmutable modified its input for size %d", n)
+			}
+
+			output = make([]complex128, n)
+			fft.ProcessOutOfPlace(input, output, make([]complex128, fft.OutOfPlaceScratchLen()))
+			if !complexSlicesEqual(output, expected, 1e-9) {
+				t.Errorf("ProcessOutOfPlace doesn't match Process for size %d", n)
+			}
+		})
+	}
+}
+
+func TestFFT_ZeroAllocations(t *testing.T) {
+	// One size per recipe: butterfly, Radix4, RadixN, Rader's, Bluestein
+	sizes := []int{7, 4096, 1000, 97, 1031}
+	planner := NewPlanner()
+
+	for _, n := range sizes {
+		fft := planner.PlanForward(n)
+		buffer := make([]complex128, n)
+		output := make([]complex128, n)
+		scratch := make([]complex128, fft.InplaceScratchLen())
+		outOfPlaceScratch := make([]complex128, fft.OutOfPlaceScratchLen())
+		immutableScratch := make([]complex128, fft.ImmutableScratchLen())
+
+		// Warm up the plan's scratch pool
+		fft.Process(buffer)
+
+		checks := map[string]func(){
+			"Process":            func() { fft.Process(buffer) },
+			"ProcessWithScratch": func() { fft.ProcessWithScratch(buffer, scratch) },
+			"ProcessOutOfPlace":  func() { fft.ProcessOutOfPlace(buffer, output, outOfPlaceScratch) },
+			"ProcessImmutable":   func() { fft.ProcessImmutable(buffer, output, immutableScratch) },
+		}
+		for name, run := range checks {
+			if allocs := testing.AllocsPerRun(100, run); allocs != 0 {
+				t.Errorf("size %d: %s allocated %.0f times per run", n, name, allocs)
+			}
+		}
+	}
+}
+
 func TestPrimeFactors(t *testing.T) {
 	tests := []struct {
 		n        int
@@ -194,6 +260,35 @@ func TestPrimeFactors(t *testing.T) {
 	}
 }
 
+func TestPartitionFactors(t *testing.T) {
+	for _, n := range []int{6, 22, 121, 352, 1000, 2431, 2 * 1031, 4096, 3 * 5 * 7 * 11 * 13} {
+		t.Run(fmt.Sprintf("Size%d", n), func(t *testing.T) {
+			left, right := ComputePrimeFactors(n).PartitionFactors()
+			if left.GetProduct()*right.GetProduct() != n {
+				t.Fatalf("%d * %d != %d", left.GetProduct(), right.GetProduct(), n)
+			}
+			if left.GetProduct() == 1 || right.GetProduct() == 1 {
+				t.Errorf("trivial partition %d * %d", left.GetProduct(), right.GetProduct())
+			}
+			// Halves may only share a factor when n is a prime power
+			factors := ComputePrimeFactors(n)
+			distinct := len(factors.GetOtherFactors())
+			if factors.GetPowerOfTwo() > 0 {
+				distinct++
+			}
+			if factors.GetPowerOfThree() > 0 {
+				distinct++
+			}
+			if distinct > 1 && GCD(left.GetProduct(), right.GetProduct()) != 1 {
+				t.Errorf("%d and %d aren't coprime", left.GetProduct(), right.GetProduct())
+			}
+			if ComputePrimeFactors(left.GetProduct()).GetPowerOfTwo() != left.GetPowerOfTwo() {
+				t.Errorf("left power of 2 is %d, product is %d", left.GetPowerOfTwo(), left.GetProduct())
+			}
+		})
+	}
+}
+
 func TestTwiddleFactors(t *testing.T) {
 	n := 8
 	twiddles := ComputeTwiddles(n, Forward)
@@ -0,0 +1,59 @@
+package gofft
+
+import "fmt"
+
+// Dht computes the discrete Hartley transform of real data in place
+//
+//	H[k] = Σ x[n] cas(2πnk/N), where cas(θ) = cos(θ) + sin(θ)
+//
+// The DHT is its own inverse up to scaling: applying it twice multiplies the
+// data by N. It is computed with a real FFT, since H[k] = Re(X[k]) - Im(X[k])
+// and the Hermitian symmetry of X gives the mirrored bin H[N-k] = Re(X[k]) + Im(X[k]).
+// Like the FFTs it is built from, a Dht is safe for concurrent use.
+type Dht struct {
+	fft  RealToComplex
+	pool scratchPool[complex128]
+}
+
+// PlanDht creates a Dht of the given size
+func (p *RealFftPlanner) PlanDht(length int) *Dht {
+	if length < 1 {
+		panic(fmt.Sprintf("DHT needs a positive length, got %d", length))
+	}
+	return &Dht{fft: p.PlanForward(length)}
+}
+
+// Len returns the transform size that this instance processes
+func (d *Dht) Len() int { return d.fft.Len() }
+
+// ScratchLen returns the required scratch buffer size for ProcessWithScratch
+func (d *Dht) ScratchLen() int { return d.fft.OutputLen() + d.fft.ScratchLen() }
+
+// Process computes the DHT in-place on the provided buffer
+// The buffer length must be a multiple of Len().
+// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+func (d *Dht) Process(buffer []float64) {
+	scratch := d.pool.get(d.ScratchLen())
+	d.ProcessWithScratch(buffer, *scratch)
+	d.pool.put(scratch)
+}
+
+// ProcessWithScratch computes the DHT in-place using the provided scratch buffer
+// The scratch buffer must have length >= ScratchLen().
+// The buffer length must be a multiple of Len().
+func (d *Dht) ProcessWithScratch(buffer []float64, scratch []complex128) {
+	dctHelper(buffer, scratch, d.Len(), d.ScratchLen(), d.processOne)
+}
+
+func (d *Dht) processOne(x []float64, scratch []complex128) {
+	n := len(x)
+	spectrum := scratch[:d.fft.OutputLen()]
+	d.fft.ProcessWithScratch(x, spectrum, scratch[len(spectrum):])
+
+	for k, c := range spectrum {
+		x[k] = real(c) - imag(c)
+		if k > 0 && n-k > k {
+			x[n-k] = real(c) + imag(c)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package gofft
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func naiveDht(x []float64) []float64 {
+	n := len(x)
+	out := make([]float64, n)
+	for k := range out {
+		for i, v := range x {
+			theta := 2 * math.Pi * float64(i*k) / float64(n)
+			out[k] += v * (math.Cos(theta) + math.Sin(theta))
+		}
+	}
+	return out
+}
+
+func TestDhtMatchesDefinition(t *testing.T) {
+	planner := NewRealFftPlanner()
+	for _, n := range []int{1, 2, 3, 4, 5, 8, 15, 16, 31, 100, 128, 1000} {
+		t.Run(fmt.Sprintf("Size%d", n), func(t *testing.T) {
+			x := realTestSignal(n, 0.9)
+			want := naiveDht(x)
+
+			dht := planner.PlanDht(n)
+			if dht.Len() != n {
+				t.Fatalf("Len = %d, want %d", dht.Len(), n)
+			}
+			dht.Process(x)
+			if !floatSlicesEqual(x, want, 1e-9*float64(n)) {
+				t.Errorf("got %v\nwant %v", x, want)
+			}
+
+			// Applying the DHT again restores the input, scaled by n
+			dht.Process(x)
+			original := realTestSignal(n, 0.9)
+			for i := range x {
+				x[i] /= float64(n)
+			}
+			if !floatSlicesEqual(x, original, 1e-12*float64(n)) {
+				t.Errorf("DHT isn't its own inverse")
+			}
+		})
+	}
+}
+
+func TestDhtBatchAndScratch(t *testing.T) {
+	dht := NewRealFftPlanner().PlanDht(10)
+	batch := realTestSignal(40, 0.4)
+	want := make([]float64, 0, 40)
+	for i := 0; i < 40; i += 10 {
+		want = append(want, naiveDht(batch[i:i+10])...)
+	}
+
+	scratch := make([]complex128, dht.ScratchLen())
+	dht.ProcessWithScratch(batch, scratch)
+	if !floatSlicesEqual(batch, want, 1e-10) {
+		t.Errorf("batched output differs from one transform per row")
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() { dht.ProcessWithScratch(batch, scratch) }); allocs != 0 {
+		t.Errorf("ProcessWithScratch allocated %v times", allocs)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrNotMultipleOfLen) {
+			t.Errorf("expected a panic wrapping ErrNotMultipleOfLen, got %v", err)
+		}
+	}()
+	dht.Process(make([]float64, 15))
+}
@@ -2,6 +2,7 @@ package gofft
 
 import (
 	"math"
+	"sort"
 )
 
 // PrimeFactor represents a prime factor with its count
@@ -146,64 +147,87 @@ func (pf PrimeFactors) ProductAbove(threshold int) int {
 
 // PartitionFactors splits the prime factors into two coprime groups
 // Returns (left_factors, right_factors) where their product equals the original number
+// Each prime's full power goes to one side, largest powers first to whichever
+// side has the smaller product, so the two groups end up roughly balanced.
+// A prime power can't be split coprimely, so its exponent is halved instead.
 func (pf PrimeFactors) PartitionFactors() (PrimeFactors, PrimeFactors) {
-	// Strategy: try to split as evenly as possible
-	// Put larger factors in the left, smaller in the right
-	// This is a simplified version - RustFFT has more sophisticated logic
-
-	leftProduct := 1
-	rightProduct := 1
+	// Gather the prime powers, smallest prime first
+	var powers []PrimeFactor
+	if pf.powerOfTwo > 0 {
+		powers = append(powers, PrimeFactor{Value: 2, Count: pf.powerOfTwo})
+	}
+	if pf.powerOfThree > 0 {
+		powers = append(powers, PrimeFactor{Value: 3, Count: pf.powerOfThree})
+	}
+	powers = append(powers, pf.otherFactors...)
 
-	// Split power of 2
-	halfPow2 := pf.powerOfTwo / 2
-	leftPow2 := halfPow2
-	rightPow2 := pf.powerOfTwo - halfPow2
+	left := PrimeFactors{product: 1}
+	right := PrimeFactors{product: 1}
 
-	leftProduct *= 1 << leftPow2
-	rightProduct *= 1 << rightPow2
+	if len(powers) == 1 {
+		half := powers[0].Count / 2
+		left.addPower(powers[0].Value, half)
+		right.addPower(powers[0].Value, powers[0].Count-half)
+		return left, right
+	}
 
-	// Split power of 3
-	halfPow3 := pf.powerOfThree / 2
-	leftPow3 := halfPow3
-	rightPow3 := pf.powerOfThree - halfPow3
+	values := make([]int, len(powers))
+	for i, p := range powers {
+		values[i] = intPow(p.Value, p.Count)
+	}
 
-	for i := 0; i < leftPow3; i++ {
-		leftProduct *= 3
+	// Assign the largest prime powers first
+	order := make([]int, len(powers))
+	for i := range order {
+		order[i] = i
 	}
-	for i := 0; i < rightPow3; i++ {
-		rightProduct *= 3
+	sort.Slice(order, func(a, b int) bool { return values[order[a]] > values[order[b]] })
+
+	toLeft := make([]bool, len(powers))
+	leftProduct, rightProduct := 1, 1
+	for _, i := range order {
+		if leftProduct <= rightProduct {
+			toLeft[i] = true
+			leftProduct *= values[i]
+		} else {
+			rightProduct *= values[i]
+		}
 	}
 
-	// Split other factors by alternating
-	var leftOther, rightOther []PrimeFactor
-	addToLeft := leftProduct < rightProduct
-
-	for _, factor := range pf.otherFactors {
-		if addToLeft {
-			leftOther = append(leftOther, factor)
-			for i := 0; i < factor.Count; i++ {
-				leftProduct *= factor.Value
-			}
+	// Add in the original order so otherFactors stays sorted
+	for i, p := range powers {
+		if toLeft[i] {
+			left.addPower(p.Value, p.Count)
 		} else {
-			rightOther = append(rightOther, factor)
-			for i := 0; i < factor.Count; i++ {
-				rightProduct *= factor.Value
-			}
+			right.addPower(p.Value, p.Count)
 		}
-		addToLeft = leftProduct < rightProduct
 	}
+	return left, right
+}
 
-	return PrimeFactors{
-			powerOfTwo:   leftPow2,
-			powerOfThree: leftPow3,
-			otherFactors: leftOther,
-			product:      leftProduct,
-		}, PrimeFactors{
-			powerOfTwo:   rightPow2,
-			powerOfThree: rightPow3,
-			otherFactors: rightOther,
-			product:      rightProduct,
-		}
+// addPower multiplies prime^count into the factorization
+func (pf *PrimeFactors) addPower(prime, count int) {
+	if count == 0 {
+		return
+	}
+	switch prime {
+	case 2:
+		pf.powerOfTwo += count
+	case 3:
+		pf.powerOfThree += count
+	default:
+		pf.otherFactors = append(pf.otherFactors, PrimeFactor{Value: prime, Count: count})
+	}
+	pf.product *= intPow(prime, count)
+}
+
+// intPow computes base^exp for a non-negative exponent
+func intPow(base, exp int) int {
+	result := 1
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
 }
 
 // GCD computes the greatest common divisor of two numbers
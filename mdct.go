@@ -0,0 +1,167 @@
+package gofft
+
+import "fmt"
+
+// Mdct computes the modified discrete cosine transform of overlapping blocks and its inverse
+//
+// An Mdct of length N turns a block of 2N samples into N coefficients,
+//
+//	X[k] = Σ_{n=0}^{2N-1} w[n] x[n] cos(π/N (n + 1/2 + N/2)(k + 1/2))
+//
+// and ProcessInverse turns N coefficients back into 2N windowed samples,
+//
+//	y[n] = w[n] Σ_{k=0}^{N-1} X[k] cos(π/N (n + 1/2 + N/2)(k + 1/2))
+//
+// which it adds to its output. Consecutive blocks overlap by N samples. When the
+// window satisfies the Princen-Bradley condition w[n]² + w[n+N]² = 1, as the sine
+// and Kaiser-Bessel-derived windows do, the time-domain aliasing of neighboring
+// blocks cancels as their inverses are overlap-added, and scaling by 2/N recovers
+// the input (TDAC).
+//
+// Both directions fold the block onto a DCT-IV of length N, which runs on a
+// complex FFT of length N/2, a quarter of the block length.
+// Like the FFTs it is built from, an Mdct is safe for concurrent use.
+type Mdct struct {
+	length int
+	window []float64
+	dct    *dct4
+	pool   scratchPool[complex128]
+}
+
+// PlanMdct creates an Mdct producing length coefficients from blocks of 2*length samples
+// length must be even, and window must hold 2*length samples; a nil window is rectangular.
+func (p *DctPlanner) PlanMdct(length int, window []float64) *Mdct {
+	if length < 2 || length%2 != 0 {
+		panic(fmt.Sprintf("MDCT needs a positive even length, got %d", length))
+	}
+
+	m := &Mdct{
+		length: length,
+		window: make([]float64, 2*length),
+		dct:    p.Plan(Dct4, length).(*dct4),
+	}
+	if window == nil {
+		for i := range m.window {
+			m.window[i] = 1
+		}
+	} else if len(window) != 2*length {
+		panic(fmt.Sprintf("MDCT of length %d needs a window of %d samples, got %d", length, 2*length, len(window)))
+	} else {
+		copy(m.window, window)
+	}
+	return m
+}
+
+// Len returns the number of coefficients per block
+func (m *Mdct) Len() int { return m.length }
+
+// BlockLen returns the number of samples per block, 2*Len()
+func (m *Mdct) BlockLen() int { return 2 * m.length }
+
+// ScratchLen returns the required scratch buffer size for ProcessWithScratch and ProcessInverseWithScratch
+func (m *Mdct) ScratchLen() int { return m.dct.ScratchLen() }
+
+// Process computes the MDCT of a block of BlockLen() samples into Len() coefficients
+// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+func (m *Mdct) Process(input, output []float64) {
+	scratch := m.pool.get(m.ScratchLen())
+	m.ProcessWithScratch(input, output, *scratch)
+	m.pool.put(scratch)
+}
+
+// ProcessWithScratch computes the MDCT using the provided scratch buffer
+// The scratch buffer must have length >= ScratchLen(). The contents of input are left unchanged.
+func (m *Mdct) ProcessWithScratch(input, output []float64, scratch []complex128) {
+	validateFixedLen(len(input), 2*m.length, len(output), m.length, len(scratch), m.ScratchLen())
+
+	// Fold the windowed quarters (a, b, c, d) into (-c_r - d, a - b_r),
+	// whose DCT-IV is the MDCT
+	half := m.length / 2
+	w := m.window
+	for n := 0; n < half; n++ {
+		i, j := 3*half-1-n, 3*half+n
+		output[n] = -w[i]*input[i] - w[j]*input[j]
+	}
+	for n := half; n < m.length; n++ {
+		i, j := n-half, 3*half-1-n
+		output[n] = w[i]*input[i] - w[j]*input[j]
+	}
+
+	m.dct.processEven(output, scratch)
+}
+
+// ProcessInverse computes the IMDCT of Len() coefficients and adds the
+// BlockLen() windowed samples to output
+// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+func (m *Mdct) ProcessInverse(input, output []float64) {
+	scratch := m.pool.get(m.ScratchLen())
+	m.ProcessInverseWithScratch(input, output, *scratch)
+	m.pool.put(scratch)
+}
+
+// ProcessInverseWithScratch computes the IMDCT using the provided scratch buffer
+// The scratch buffer must have length >= ScratchLen(). The contents of input are left unchanged.
+func (m *Mdct) ProcessInverseWithScratch(input, output []float64, scratch []complex128) {
+	validateFixedLen(len(input), m.length, len(output), 2*m.length, len(scratch), m.ScratchLen())
+
+	packed := m.dct.transformEven(input, scratch)
+
+	// Unfold the DCT-IV output v, the transpose of the fold in ProcessWithScratch
+	half := m.length / 2
+	w := m.window
+	for n := 0; n < m.length; n++ {
+		var v float64
+		if n%2 == 0 {
+			v = real(packed[n/2])
+		} else {
+			v = -imag(packed[(m.length-1-n)/2])
+		}
+
+		if n < half {
+			i, j := 3*half-1-n, 3*half+n
+			output[i] -= w[i] * v
+			output[j] -= w[j] * v
+		} else {
+			i, j := n-half, 3*half-1-n
+			output[i] += w[i] * v
+			output[j] -= w[j] * v
+		}
+	}
+}
+
+// MdctTransitionWindow builds the window for a block of length coefficients
+// next to blocks of other lengths, for block switching
+//
+// The first half of the window rises with the first half of left and the
+// second half falls with the second half of right, each slope centered in its
+// half with zeros outside and ones inside. left and right are MDCT windows,
+// such as sine windows, for blocks of at most length coefficients. To switch
+// sizes, give each side the window of the smaller of the two neighboring
+// blocks, and start a block of N' coefficients (3N - N')/2 samples after a
+// block of N coefficients; the overlapping slopes then line up and TDAC holds
+// across the switch.
+func MdctTransitionWindow(length int, left, right []float64) []float64 {
+	for _, slope := range [][]float64{left, right} {
+		if len(slope)%2 != 0 || len(slope) > 2*length || (length-len(slope)/2)%2 != 0 {
+			panic(fmt.Sprintf("MDCT block of length %d can't hold the slope of a window of %d samples", length, len(slope)))
+		}
+	}
+
+	w := make([]float64, 2*length)
+
+	rise := len(left) / 2
+	start := (length - rise) / 2
+	copy(w[start:], left[:rise])
+	for i := start + rise; i < length; i++ {
+		w[i] = 1
+	}
+
+	fall := len(right) / 2
+	start = length + (length-fall)/2
+	for i := length; i < start; i++ {
+		w[i] = 1
+	}
+	copy(w[start:], right[fall:])
+
+	return w
+}
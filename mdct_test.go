@@ -0,0 +1,191 @@
+package gofft
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// sineWindow returns the MDCT sine window of n samples
+func sineWindow(n int) []float64 {
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = math.Sin(math.Pi * (float64(i) + 0.5) / float64(n))
+	}
+	return w
+}
+
+func mdctBasis(n, k, length int) float64 {
+	return math.Cos(math.Pi / float64(length) * (float64(n) + 0.5 + float64(length)/2) * (float64(k) + 0.5))
+}
+
+func TestMdctMatchesDefinition(t *testing.T) {
+	planner := NewDctPlanner()
+	for _, length := range []int{2, 6, 16, 64, 100} {
+		t.Run(fmt.Sprintf("Size%d", length), func(t *testing.T) {
+			window := sineWindow(2 * length)
+			m := planner.PlanMdct(length, window)
+			if m.Len() != length || m.BlockLen() != 2*length {
+				t.Fatalf("got Len %d and BlockLen %d", m.Len(), m.BlockLen())
+			}
+
+			block := realTestSignal(2*length, 0.3)
+			want := make([]float64, length)
+			for k := range want {
+				for n, x := range block {
+					want[k] += window[n] * x * mdctBasis(n, k, length)
+				}
+			}
+			got := make([]float64, length)
+			m.Process(block, got)
+			if !floatSlicesEqual(got, want, 1e-9*float64(length)) {
+				t.Errorf("MDCT: got %v\nwant %v", got, want)
+			}
+
+			// The inverse adds to whatever is in the output already
+			coefficients := realTestSignal(length, 0.8)
+			out := realTestSignal(2*length, 1.1)
+			wantOut := append([]float64(nil), out...)
+			for n := range wantOut {
+				for k, c := range coefficients {
+					wantOut[n] += window[n] * c * mdctBasis(n, k, length)
+				}
+			}
+			m.ProcessInverse(coefficients, out)
+			if !floatSlicesEqual(out, wantOut, 1e-9*float64(length)) {
+				t.Errorf("IMDCT: got %v\nwant %v", out, wantOut)
+			}
+		})
+	}
+}
+
+// mdctRoundTrip runs the MDCT and IMDCT of blocks with the given lengths and
+// windows, starting the first block at zero and spacing the rest as
+// MdctTransitionWindow describes, and returns the overlap-added output
+func mdctRoundTrip(planner *DctPlanner, signal []float64, lengths []int, windows [][]float64) (out []float64, starts []int) {
+	out = make([]float64, len(signal))
+	start := 0
+	for j, length := range lengths {
+		if j > 0 {
+			start += (3*lengths[j-1] - length) / 2
+		}
+		starts = append(starts, start)
+
+		m := planner.PlanMdct(length, windows[j])
+		coefficients := make([]float64, length)
+		m.Process(signal[start:start+2*length], coefficients)
+
+		block := make([]float64, 2*length)
+		m.ProcessInverse(coefficients, block)
+		for i, v := range block {
+			out[start+i] += v * 2 / float64(length)
+		}
+	}
+	return out, starts
+}
+
+// TestMdctTDAC checks that overlap-adding the inverses of consecutive blocks
+// reconstructs the input wherever two blocks overlap
+func TestMdctTDAC(t *testing.T) {
+	planner := NewDctPlanner()
+	const length = 32
+	lengths := make([]int, 10)
+	windows := make([][]float64, 10)
+	for j := range lengths {
+		lengths[j] = length
+		windows[j] = sineWindow(2 * length)
+	}
+
+	signal := realTestSignal(11*length, 0.37)
+	out, _ := mdctRoundTrip(planner, signal, lengths, windows)
+	for i := length; i < 10*length; i++ {
+		if math.Abs(out[i]-signal[i]) > 1e-10 {
+			t.Fatalf("[%d] got %v, want %v", i, out[i], signal[i])
+		}
+	}
+}
+
+// TestMdctBlockSwitching switches from long blocks to short ones and back
+func TestMdctBlockSwitching(t *testing.T) {
+	planner := NewDctPlanner()
+	lengths := []int{64, 64, 16, 16, 16, 16, 64, 64}
+
+	windows := make([][]float64, len(lengths))
+	for j, length := range lengths {
+		left, right := length, length
+		if j > 0 {
+			left = min(left, lengths[j-1])
+		}
+		if j+1 < len(lengths) {
+			right = min(right, lengths[j+1])
+		}
+		windows[j] = MdctTransitionWindow(length, sineWindow(2*left), sineWindow(2*right))
+	}
+
+	signal := realTestSignal(512, 0.21)
+	out, starts := mdctRoundTrip(planner, signal, lengths, windows)
+
+	// Everything between the middle of the first block and the middle of the last is covered twice
+	last := len(lengths) - 1
+	from, to := lengths[0], starts[last]+lengths[last]
+	if to > len(signal) || to-from < 256 {
+		t.Fatalf("blocks cover [%d, %d) of %d samples", from, to, len(signal))
+	}
+	for i := from; i < to; i++ {
+		if math.Abs(out[i]-signal[i]) > 1e-10 {
+			t.Fatalf("[%d] got %v, want %v", i, out[i], signal[i])
+		}
+	}
+}
+
+func TestMdctTransitionWindow(t *testing.T) {
+	w := MdctTransitionWindow(8, sineWindow(16), []float64{0.5, 1, 1, 0.5})
+	want := append(sineWindow(16)[:8], 1, 1, 1, 1, 0.5, 0, 0, 0)
+	if !floatSlicesEqual(w, want, 0) {
+		t.Errorf("got %v, want %v", w, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for a slope that doesn't center in its half")
+		}
+	}()
+	MdctTransitionWindow(8, sineWindow(16), sineWindow(6))
+}
+
+func TestMdctScratchAndSizes(t *testing.T) {
+	m := NewDctPlanner().PlanMdct(256, sineWindow(512))
+	block := realTestSignal(512, 0.5)
+	coefficients := make([]float64, 256)
+	scratch := make([]complex128, m.ScratchLen())
+
+	if allocs := testing.AllocsPerRun(10, func() {
+		m.ProcessWithScratch(block, coefficients, scratch)
+		m.ProcessInverseWithScratch(coefficients, block, scratch)
+	}); allocs != 0 {
+		t.Errorf("MDCT and IMDCT allocated %v times", allocs)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrLengthMismatch) {
+			t.Errorf("expected a panic wrapping ErrLengthMismatch, got %v", err)
+		}
+	}()
+	m.Process(block[:256], coefficients)
+}
+
+func BenchmarkMdct(b *testing.B) {
+	for _, length := range []int{128, 1024} {
+		b.Run(fmt.Sprintf("Size%d", length), func(b *testing.B) {
+			m := NewDctPlanner().PlanMdct(length, sineWindow(2*length))
+			block := realTestSignal(2*length, 0.5)
+			coefficients := make([]float64, length)
+			scratch := make([]complex128, m.ScratchLen())
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.ProcessWithScratch(block, coefficients, scratch)
+			}
+		})
+	}
+}
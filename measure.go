@@ -0,0 +1,212 @@
+package gofft
+
+import (
+	"math"
+	"time"
+
+	"github.com/10d9e/gofft/algorithm"
+)
+
+// PlannerMode controls how much effort a planner spends choosing algorithms
+type PlannerMode int
+
+const (
+	// Estimate picks algorithms with a fixed heuristic. Planning is cheap and
+	// deterministic, which makes it the right default for most uses.
+	Estimate PlannerMode = iota
+
+	// Measure times the candidate decompositions of every size on the current
+	// machine and keeps the fastest, like FFTW's PATIENT mode. Planning a new
+	// size takes milliseconds instead of microseconds, but the chosen plan
+	// is tuned to the hardware it runs on.
+	Measure
+)
+
+const (
+	// measureMinDuration is how long each timing trial runs at minimum
+	measureMinDuration = 100 * time.Microsecond
+	// measureTrials is how many trials are timed, keeping the fastest
+	measureTrials = 3
+	// maxSplitCandidates limits how many width × height splits are timed
+	maxSplitCandidates = 3
+)
+
+// recipeTimer reports how long one FFT built from a recipe takes to run
+type recipeTimer func(r *recipe) time.Duration
+
+// candidateRecipes lists the recipes worth timing for an FFT of the given length
+// The heuristic choice always comes first, followed by the alternatives:
+// Rader's and Bluestein's for primes; Radix4, Radix8, Stockham, RadixN, the most balanced
+// MixedRadix/GoodThomas splits, FourStep for large sizes and Bluestein's for composites.
+// Butterflies are hand-optimized and are never second-guessed.
+func candidateRecipes(recipeCache map[int]*recipe, length int, timer recipeTimer) []*recipe {
+	candidates := []*recipe{estimateRecipe(recipeCache, length, timer)}
+	if length < 2 || candidates[0].isButterfly() {
+		return candidates
+	}
+
+	add := func(r *recipe) {
+		for _, c := range candidates {
+			if c.sameAs(r) {
+				return
+			}
+		}
+		candidates = append(candidates, r)
+	}
+
+	factors := ComputePrimeFactors(length)
+	if factors.IsPrime() {
+		// Rader's runs an FFT of length-1, which is only fast when its factors
+		// are all 2-7. Otherwise measuring it would recursively measure length-1
+		// for a candidate Bluestein's beats anyway. The estimate already covers
+		// Rader's for the small primes.
+		if !ComputePrimeFactors(length - 1).HasFactorsGt(7) {
+			add(&recipe{kind: recipeRaders, length: length, inner: designRecipe(recipeCache, length-1, timer)})
+		}
+		add(&recipe{kind: recipeBluestein, length: length})
+		return candidates
+	}
+
+	if isPowerOfTwo(length) {
+		add(&recipe{kind: recipeRadix4, length: length})
+		add(&recipe{kind: recipeRadix8, length: length})
+		add(&recipe{kind: recipeStockham, length: length})
+	}
+	// A butterfly the estimate passed over, like Butterfly64 next to the AVX2 Radix4
+	for kind, n := range butterflyLens {
+		if n == length {
+			add(&recipe{kind: kind, length: length})
+		}
+	}
+	if canUseRadixN(length) {
+		add(&recipe{kind: recipeRadixN, length: length})
+	}
+	for _, width := range splitCandidates(length) {
+		// Try both orientations, since the two passes have different strides
+		for _, w := range []int{width, length / width} {
+			h := length / w
+			r := &recipe{
+				kind:   recipeMixedRadix,
+				length: length,
+				width:  designRecipe(recipeCache, w, timer),
+				height: designRecipe(recipeCache, h, timer),
+			}
+			if GCD(w, h) == 1 {
+				r.kind = recipeGoodThomas
+			}
+			add(r)
+		}
+	}
+	if length >= fourStepMinLen/16 {
+		// Where the four-step starts to pay off depends on the cache sizes
+		width, height := fourStepFactors(recipeCache, length, timer)
+		add(&recipe{kind: recipeFourStep, length: length, width: width, height: height})
+	}
+	if factors.HasFactorsGt(7) {
+		add(&recipe{kind: recipeBluestein, length: length})
+	}
+
+	return candidates
+}
+
+// splitCandidates returns up to maxSplitCandidates divisors of length, the
+// ones closest to its square root first, since balanced splits are usually fastest
+func splitCandidates(length int) []int {
+	var widths []int
+	for w := int(math.Sqrt(float64(length))); w > 1 && len(widths) < maxSplitCandidates; w-- {
+		if length%w == 0 {
+			widths = append(widths, w)
+		}
+	}
+	return widths
+}
+
+// fastestRecipe times every candidate and returns the fastest one
+func fastestRecipe(candidates []*recipe, timer recipeTimer) *recipe {
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	best := candidates[0]
+	bestTime := time.Duration(math.MaxInt64)
+	for _, r := range candidates {
+		if elapsed := timer(r); elapsed < bestTime {
+			best, bestTime = r, elapsed
+		}
+	}
+	return best
+}
+
+// isButterfly reports whether r is one of the hardcoded butterflies
+func (r *recipe) isButterfly() bool {
+	return r.kind >= recipeButterfly2 && r.kind <= recipeButterfly64
+}
+
+// sameAs reports whether r and other build the same FFT
+// Sub-recipes come from the same cache, so they can be compared by pointer
+func (r *recipe) sameAs(other *recipe) bool {
+	return r.kind == other.kind && r.length == other.length &&
+		r.inner == other.inner && r.width == other.width && r.height == other.height
+}
+
+// timeRecipe times a complex128 forward FFT built from r
+func timeRecipe(r *recipe) time.Duration {
+	fft := buildAlgorithm(r, algorithm.Forward)
+	input := make([]complex128, r.length)
+	for i := range input {
+		input[i] = complex(float64(i%7), float64(i%5))
+	}
+	buffer := make([]complex128, r.length)
+	scratch := make([]complex128, fft.InplaceScratchLen())
+
+	// Restoring the input keeps values from growing across runs; the copy
+	// costs the same for every candidate
+	return timeRuns(func() {
+		copy(buffer, input)
+		fft.ProcessWithScratch(buffer, scratch)
+	})
+}
+
+// timeRecipe32 times a complex64 forward FFT built from r
+func timeRecipe32(r *recipe) time.Duration {
+	fft := buildAlgorithm32(r, algorithm.Forward)
+	input := make([]complex64, r.length)
+	for i := range input {
+		input[i] = complex(float32(i%7), float32(i%5))
+	}
+	buffer := make([]complex64, r.length)
+	scratch := make([]complex64, fft.InplaceScratchLen())
+
+	return timeRuns(func() {
+		copy(buffer, input)
+		fft.ProcessWithScratch(buffer, scratch)
+	})
+}
+
+// timeRuns returns the time per call of run, as the best of several trials
+func timeRuns(run func()) time.Duration {
+	// Warm up caches, then double the iterations until a trial is long
+	// enough for the clock to time reliably
+	run()
+	iterations := 1
+	for timeIterations(run, iterations) < measureMinDuration {
+		iterations *= 2
+	}
+
+	best := time.Duration(math.MaxInt64)
+	for t := 0; t < measureTrials; t++ {
+		if elapsed := timeIterations(run, iterations); elapsed < best {
+			best = elapsed
+		}
+	}
+	return best / time.Duration(iterations)
+}
+
+// timeIterations times iterations calls of run
+func timeIterations(run func(), iterations int) time.Duration {
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		run()
+	}
+	return time.Since(start)
+}
@@ -0,0 +1,204 @@
+package gofft
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/10d9e/gofft/algorithm"
+)
+
+// TestMeasurePlannerMatchesDFT checks that whatever Measure picks is correct
+func TestMeasurePlannerMatchesDFT(t *testing.T) {
+	sizes := []int{16, 64, 60, 97, 121, 352, 1000, 1031}
+
+	planner := NewPlannerWithMode(Measure)
+	planner32 := NewPlanner32WithMode(Measure)
+
+	for _, n := range sizes {
+		t.Run(fmt.Sprintf("Size%d", n), func(t *testing.T) {
+			input := make([]complex128, n)
+			buffer32 := make([]complex64, n)
+			for i := range input {
+				input[i] = complex(math.Sin(float64(i)*0.3), math.Cos(float64(i)*0.7))
+				buffer32[i] = complex64(input[i])
+			}
+			expected := naiveDFT(input, true)
+
+			buffer := make([]complex128, n)
+			copy(buffer, input)
+			planner.PlanForward(n).Process(buffer)
+			if !complexSlicesEqual(buffer, expected, 1e-8*float64(n)) {
+				t.Errorf("Size %d: measured plan doesn't match DFT", n)
+			}
+
+			planner32.PlanForward(n).Process(buffer32)
+			tolerance := 1e-4 * math.Sqrt(float64(n)) * math.Log2(float64(n))
+			for i := range buffer32 {
+				if err := cmplx.Abs(complex128(buffer32[i]) - expected[i]); err > tolerance {
+					t.Fatalf("Size %d: complex64 [%d] got %v, want %v", n, i, buffer32[i], expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCandidateRecipes(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []recipeKind
+	}{
+		{16, []recipeKind{recipeButterfly16}},
+		{36, []recipeKind{recipeButterfly36}},
+		{97, []recipeKind{recipeRaders, recipeBluestein}},
+		{1031, []recipeKind{recipeBluestein}},
+		{1153, []recipeKind{recipeBluestein, recipeRaders}},
+		{4096, []recipeKind{recipeRadix4, recipeRadix8, recipeStockham, recipeRadixN, recipeMixedRadix}},
+		{2431, []recipeKind{recipeGoodThomas, recipeBluestein}},
+	}
+
+	for _, tt := range tests {
+		candidates := candidateRecipes(make(map[int]*recipe), tt.n, nil)
+		if candidates[0].kind != tt.want[0] {
+			t.Errorf("Size %d: first candidate is %v, want the estimate %v", tt.n, candidates[0].kind, tt.want[0])
+		}
+		for _, kind := range tt.want {
+			found := false
+			for _, c := range candidates {
+				found = found || c.kind == kind
+			}
+			if !found {
+				t.Errorf("Size %d: no candidate of kind %v", tt.n, kind)
+			}
+		}
+		for i, c := range candidates {
+			if c.length != tt.n {
+				t.Errorf("Size %d: candidate %d has length %d", tt.n, i, c.length)
+			}
+			for _, other := range candidates[:i] {
+				if c.sameAs(other) {
+					t.Errorf("Size %d: duplicate candidate of kind %v", tt.n, c.kind)
+				}
+			}
+		}
+	}
+}
+
+// TestCandidateRecipes64 checks that Measure times Butterfly64 against Radix4
+// when the estimate prefers the AVX2 Radix4
+func TestCandidateRecipes64(t *testing.T) {
+	candidates := candidateRecipes(make(map[int]*recipe), 64, nil)
+	want := []recipeKind{recipeButterfly64}
+	if algorithm.AVX2Enabled() {
+		want = []recipeKind{recipeRadix4, recipeButterfly64}
+	}
+	for _, kind := range want {
+		found := false
+		for _, c := range candidates {
+			found = found || c.kind == kind
+		}
+		if !found {
+			t.Errorf("no candidate of kind %v", kind)
+		}
+	}
+	if candidates[0].kind != want[0] {
+		t.Errorf("first candidate is %v, want the estimate %v", candidates[0].kind, want[0])
+	}
+}
+
+// TestEstimateLargePowersOfTwo checks that large powers of two use Radix8 with
+// the AVX2 kernels and Stockham without, and that Measure still times Radix4
+func TestEstimateLargePowersOfTwo(t *testing.T) {
+	want := recipeStockham
+	if algorithm.AVX2Enabled() {
+		want = recipeRadix8
+	}
+	if kind := estimateRecipe(make(map[int]*recipe), largePowerOfTwo/2, nil).kind; kind != recipeRadix4 {
+		t.Errorf("Size %d: got %v, want %v", largePowerOfTwo/2, kind, recipeRadix4)
+	}
+	for _, n := range []int{largePowerOfTwo, 1 << 20} {
+		candidates := candidateRecipes(make(map[int]*recipe), n, nil)
+		if candidates[0].kind != want {
+			t.Errorf("Size %d: got %v, want %v", n, candidates[0].kind, want)
+		}
+		found := false
+		for _, c := range candidates {
+			found = found || c.kind == recipeRadix4
+		}
+		if !found {
+			t.Errorf("Size %d: no candidate of kind %v", n, recipeRadix4)
+		}
+	}
+}
+
+// TestEstimateFourStep checks that FourStep takes over from fourStepMinLen
+// with a balanced split, and that Measure times it a while before that
+func TestEstimateFourStep(t *testing.T) {
+	recipeCache := make(map[int]*recipe)
+	for _, n := range []int{fourStepMinLen, 2 * fourStepMinLen, 3 * fourStepMinLen} {
+		r := estimateRecipe(recipeCache, n, nil)
+		if r.kind != recipeFourStep {
+			t.Fatalf("Size %d: got %v, want %v", n, r.kind, recipeFourStep)
+		}
+		if r.width.length*r.height.length != n || r.height.length > r.width.length || 2*r.height.length < r.width.length {
+			t.Errorf("Size %d: unbalanced split %d x %d", n, r.width.length, r.height.length)
+		}
+		if err := r.validate(); err != nil {
+			t.Errorf("Size %d: %v", n, err)
+		}
+	}
+	if kind := estimateRecipe(recipeCache, fourStepMinLen/2, nil).kind; kind == recipeFourStep {
+		t.Errorf("Size %d: got %v below the threshold", fourStepMinLen/2, kind)
+	}
+
+	found := false
+	for _, c := range candidateRecipes(recipeCache, fourStepMinLen/16, nil) {
+		found = found || c.kind == recipeFourStep
+	}
+	if !found {
+		t.Errorf("Size %d: no candidate of kind %v", fourStepMinLen/16, recipeFourStep)
+	}
+}
+
+// TestMeasureLargePrime checks that Measure doesn't time Rader's for a prime
+// whose length-1 has a large factor, which would measure length-1 as well
+func TestMeasureLargePrime(t *testing.T) {
+	var timed []*recipe
+	timer := func(r *recipe) time.Duration {
+		timed = append(timed, r)
+		return time.Millisecond
+	}
+
+	// 100002 = 2·3·7·2381
+	if r := designRecipe(make(map[int]*recipe), 100003, timer); r.kind != recipeBluestein {
+		t.Errorf("Size 100003: got recipe kind %v, want Bluestein's", r.kind)
+	}
+	if len(timed) > 1 {
+		t.Errorf("Size 100003: timed %d candidates, want at most 1", len(timed))
+	}
+	for _, r := range timed {
+		if r.kind == recipeRaders {
+			t.Errorf("Size 100003: timed Rader's")
+		}
+	}
+}
+
+// TestMeasureKeepsFastest uses a fake clock so the choice is deterministic
+func TestMeasureKeepsFastest(t *testing.T) {
+	timer := func(r *recipe) time.Duration {
+		if r.kind == recipeBluestein {
+			return time.Microsecond
+		}
+		return time.Millisecond
+	}
+
+	cache := make(map[int]*recipe)
+	if r := designRecipe(cache, 97, timer); r.kind != recipeBluestein {
+		t.Errorf("Size 97: got recipe kind %v, want Bluestein's", r.kind)
+	}
+	if cache[97].kind != recipeBluestein {
+		t.Errorf("Size 97: measured recipe wasn't cached")
+	}
+}
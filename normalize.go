@@ -0,0 +1,100 @@
+package gofft
+
+import (
+	"math"
+
+	"github.com/10d9e/gofft/algorithm"
+)
+
+// Normalization selects how a plan scales its output
+// NormBackward, NormOrtho and NormForward match numpy's "backward", "ortho"
+// and "forward" norm modes. Plan and PlanParallel use NormNone.
+type Normalization int
+
+const (
+	// NormNone leaves both directions unscaled, so a forward FFT followed by
+	// an inverse FFT multiplies the data by the length
+	NormNone Normalization = iota
+
+	// NormBackward scales inverse FFTs by 1/n and leaves forward FFTs
+	// unscaled. This is numpy's default.
+	NormBackward
+
+	// NormOrtho scales both directions by 1/sqrt(n), which makes the
+	// transform unitary: it preserves the energy of the signal.
+	NormOrtho
+
+	// NormForward scales forward FFTs by 1/n and leaves inverse FFTs unscaled
+	NormForward
+)
+
+// scale returns the factor an FFT of the given length and direction multiplies its output by
+func (n Normalization) scale(length int, direction Direction) float64 {
+	if length == 0 {
+		return 1
+	}
+	switch {
+	case n == NormOrtho:
+		return 1 / math.Sqrt(float64(length))
+	case n == NormBackward && direction == Inverse, n == NormForward && direction == Forward:
+		return 1 / float64(length)
+	}
+	return 1
+}
+
+// withScale returns a copy of fft whose output is multiplied by scale
+// Every algorithm that builds larger FFTs folds the factor into its final
+// pass; the butterflies fall back to a separate sweep, which for their
+// sizes never leaves the cache.
+func withScale(fft algorithm.FftInterface, scale float64) algorithm.FftInterface {
+	switch f := fft.(type) {
+	case *algorithm.Dft:
+		return f.WithScale(scale)
+	case *algorithm.Radix4:
+		return f.WithScale(scale)
+	case *algorithm.Radix8:
+		return f.WithScale(scale)
+	case *algorithm.Stockham:
+		return f.WithScale(scale)
+	case *algorithm.RadixN:
+		return f.WithScale(scale)
+	case *algorithm.Raders:
+		return f.WithScale(scale)
+	case *algorithm.Bluestein:
+		return f.WithScale(scale)
+	case *algorithm.MixedRadix:
+		return f.WithScale(scale)
+	case *algorithm.GoodThomas:
+		return f.WithScale(scale)
+	case *algorithm.FourStep:
+		return f.WithScale(scale)
+	}
+	return algorithm.NewScaled(fft, scale)
+}
+
+// withScale32 is the complex64 counterpart of withScale
+func withScale32(fft algorithm.FftInterface32, scale float64) algorithm.FftInterface32 {
+	switch f := fft.(type) {
+	case *algorithm.Dft32:
+		return f.WithScale(scale)
+	case *algorithm.Radix4_32:
+		return f.WithScale(scale)
+	case *algorithm.Radix8_32:
+		return f.WithScale(scale)
+	case *algorithm.Stockham32:
+		return f.WithScale(scale)
+	case *algorithm.RadixN32:
+		return f.WithScale(scale)
+	case *algorithm.Raders32:
+		return f.WithScale(scale)
+	case *algorithm.Bluestein32:
+		return f.WithScale(scale)
+	case *algorithm.MixedRadix32:
+		return f.WithScale(scale)
+	case *algorithm.GoodThomas32:
+		return f.WithScale(scale)
+	case *algorithm.FourStep32:
+		return f.WithScale(scale)
+	}
+	return algorithm.NewScaled32(fft, scale)
+}
@@ -0,0 +1,129 @@
+package gofft
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestPlanWithNormalization checks every mode against a scaled naive DFT
+func TestPlanWithNormalization(t *testing.T) {
+	sizes := []int{1, 8, 12, 60, 97, 121, 143, 256, 1031, 4096}
+	modes := []struct {
+		name             string
+		norm             Normalization
+		forward, inverse func(n float64) float64
+	}{
+		{"None", NormNone, func(float64) float64 { return 1 }, func(float64) float64 { return 1 }},
+		{"Backward", NormBackward, func(float64) float64 { return 1 }, func(n float64) float64 { return 1 / n }},
+		{"Ortho", NormOrtho, func(n float64) float64 { return 1 / math.Sqrt(n) }, func(n float64) float64 { return 1 / math.Sqrt(n) }},
+		{"Forward", NormForward, func(n float64) float64 { return 1 / n }, func(float64) float64 { return 1 }},
+	}
+
+	planner := NewPlanner()
+	planner32 := NewPlanner32()
+	for _, mode := range modes {
+		for _, n := range sizes {
+			t.Run(fmt.Sprintf("%s/Size%d", mode.name, n), func(t *testing.T) {
+				input := make([]complex128, n)
+				for i := range input {
+					input[i] = complex(math.Sin(float64(i)*0.3), math.Cos(float64(i)*0.7))
+				}
+
+				for _, direction := range []Direction{Forward, Inverse} {
+					scale := mode.forward(float64(n))
+					if direction == Inverse {
+						scale = mode.inverse(float64(n))
+					}
+					expected := naiveDFT(input, direction == Forward)
+					for i := range expected {
+						expected[i] *= complex(scale, 0)
+					}
+
+					opts := PlanOptions{Normalize: mode.norm}
+					buffer := make([]complex128, n)
+					copy(buffer, input)
+					planner.PlanWith(n, direction, opts).Process(buffer)
+					if !complexSlicesEqual(buffer, expected, 1e-9*float64(n)) {
+						t.Errorf("direction %v: output doesn't match scaled DFT", direction)
+					}
+
+					buffer32 := make([]complex64, n)
+					for i := range buffer32 {
+						buffer32[i] = complex64(input[i])
+					}
+					planner32.PlanWith(n, direction, opts).Process(buffer32)
+					tolerance := 1e-4 * math.Sqrt(float64(n)) * scale * math.Max(1, math.Log2(float64(n)))
+					for i := range buffer32 {
+						if err := cmplx.Abs(complex128(buffer32[i]) - expected[i]); err > tolerance {
+							t.Fatalf("direction %v: complex64 [%d] got %v, want %v", direction, i, buffer32[i], expected[i])
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestPlanWithRoundTrip checks that Backward, Ortho and Forward all invert exactly
+func TestPlanWithRoundTrip(t *testing.T) {
+	planner := NewPlanner()
+	for _, norm := range []Normalization{NormBackward, NormOrtho, NormForward} {
+		for _, n := range []int{16, 100, 97, 1000, 1 << 14} {
+			opts := PlanOptions{Normalize: norm}
+			forward := planner.PlanWith(n, Forward, opts)
+			inverse := planner.PlanWith(n, Inverse, PlanOptions{Normalize: norm, Workers: 2})
+
+			input := make([]complex128, 3*n)
+			for i := range input {
+				input[i] = complex(float64(i%17)-8, float64(i%5))
+			}
+			buffer := make([]complex128, len(input))
+			copy(buffer, input)
+			forward.Process(buffer)
+			inverse.Process(buffer)
+
+			if !complexSlicesEqual(buffer, input, 1e-9*float64(n)) {
+				t.Errorf("norm %d, size %d: round trip doesn't reproduce the input", norm, n)
+			}
+		}
+	}
+}
+
+// TestPlanWithOrthoPreservesEnergy checks Parseval's theorem for the unitary transform
+func TestPlanWithOrthoPreservesEnergy(t *testing.T) {
+	for _, n := range []int{64, 360, 1009} {
+		buffer := make([]complex128, n)
+		for i := range buffer {
+			buffer[i] = complex(math.Cos(float64(i)*1.3), float64(i%3))
+		}
+		energy := func() float64 {
+			sum := 0.0
+			for _, v := range buffer {
+				sum += real(v)*real(v) + imag(v)*imag(v)
+			}
+			return sum
+		}
+
+		before := energy()
+		NewPlanner().PlanWith(n, Forward, PlanOptions{Normalize: NormOrtho}).Process(buffer)
+		if after := energy(); math.Abs(after-before) > 1e-9*before {
+			t.Errorf("Size %d: energy changed from %g to %g", n, before, after)
+		}
+	}
+}
+
+func TestPlanWithCachesByOptions(t *testing.T) {
+	planner := NewPlanner()
+	plain := planner.Plan(64, Forward)
+	if planner.PlanWith(64, Forward, PlanOptions{}) != plain {
+		t.Errorf("PlanWith with zero options didn't return the cached Plan result")
+	}
+	if planner.PlanWith(64, Forward, PlanOptions{Workers: 1}) != plain {
+		t.Errorf("a single worker should plan the same FFT as Plan")
+	}
+	if planner.PlanWith(64, Forward, PlanOptions{Normalize: NormOrtho}) == plain {
+		t.Errorf("a normalized plan shared the cache entry of an unnormalized one")
+	}
+}
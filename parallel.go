@@ -0,0 +1,164 @@
+package gofft
+
+import "github.com/10d9e/gofft/algorithm"
+
+// parallelMinLen is the smallest length for which PlanParallel splits the
+// passes of a single transform across goroutines. Below it the handoff
+// costs more than it saves, so only batches of transforms are split.
+const parallelMinLen = 1 << 14
+
+// NewParallelFft wraps fft so that a buffer holding several transforms is
+// split across up to workers goroutines, each taking a contiguous run of them.
+// Every worker needs its own scratch, so the scratch lengths are workers
+// times those of fft. A single transform still runs on one goroutine; use
+// Planner.PlanParallel to split large transforms too.
+func NewParallelFft(fft Fft, workers int) Fft {
+	if workers < 1 {
+		workers = 1
+	}
+	return &parallelFft{inner: fft, workers: workers}
+}
+
+type parallelFft struct {
+	inner   Fft
+	workers int
+	pool    scratchPool[complex128]
+}
+
+func (f *parallelFft) Process(buffer []complex128) {
+	scratch := f.pool.get(f.InplaceScratchLen())
+	f.ProcessWithScratch(buffer, *scratch)
+	f.pool.put(scratch)
+}
+
+func (f *parallelFft) ProcessWithScratch(buffer, scratch []complex128) {
+	validateInplace(len(buffer), f.Len(), len(scratch), f.InplaceScratchLen())
+	n, s := f.Len(), f.inner.InplaceScratchLen()
+	algorithm.ParallelFor(f.workers, len(buffer)/n, func(w, start, end int) {
+		f.inner.ProcessWithScratch(buffer[start*n:end*n], scratch[w*s:(w+1)*s])
+	})
+}
+
+func (f *parallelFft) ProcessOutOfPlace(input, output, scratch []complex128) {
+	validateOutOfPlace(len(input), len(output), f.Len(), len(scratch), f.OutOfPlaceScratchLen())
+	n, s := f.Len(), f.inner.OutOfPlaceScratchLen()
+	algorithm.ParallelFor(f.workers, len(input)/n, func(w, start, end int) {
+		f.inner.ProcessOutOfPlace(input[start*n:end*n], output[start*n:end*n], scratch[w*s:(w+1)*s])
+	})
+}
+
+func (f *parallelFft) ProcessImmutable(input []complex128, output, scratch []complex128) {
+	validateOutOfPlace(len(input), len(output), f.Len(), len(scratch), f.ImmutableScratchLen())
+	n, s := f.Len(), f.inner.ImmutableScratchLen()
+	algorithm.ParallelFor(f.workers, len(input)/n, func(w, start, end int) {
+		f.inner.ProcessImmutable(input[start*n:end*n], output[start*n:end*n], scratch[w*s:(w+1)*s])
+	})
+}
+
+func (f *parallelFft) Len() int                  { return f.inner.Len() }
+func (f *parallelFft) Direction() Direction      { return f.inner.Direction() }
+func (f *parallelFft) InplaceScratchLen() int    { return f.workers * f.inner.InplaceScratchLen() }
+func (f *parallelFft) OutOfPlaceScratchLen() int { return f.workers * f.inner.OutOfPlaceScratchLen() }
+func (f *parallelFft) ImmutableScratchLen() int  { return f.workers * f.inner.ImmutableScratchLen() }
+
+// NewParallelFft32 is the complex64 counterpart of NewParallelFft
+func NewParallelFft32(fft Fft32, workers int) Fft32 {
+	if workers < 1 {
+		workers = 1
+	}
+	return &parallelFft32{inner: fft, workers: workers}
+}
+
+type parallelFft32 struct {
+	inner   Fft32
+	workers int
+	pool    scratchPool[complex64]
+}
+
+func (f *parallelFft32) Process(buffer []complex64) {
+	scratch := f.pool.get(f.InplaceScratchLen())
+	f.ProcessWithScratch(buffer, *scratch)
+	f.pool.put(scratch)
+}
+
+func (f *parallelFft32) ProcessWithScratch(buffer, scratch []complex64) {
+	validateInplace(len(buffer), f.Len(), len(scratch), f.InplaceScratchLen())
+	n, s := f.Len(), f.inner.InplaceScratchLen()
+	algorithm.ParallelFor(f.workers, len(buffer)/n, func(w, start, end int) {
+		f.inner.ProcessWithScratch(buffer[start*n:end*n], scratch[w*s:(w+1)*s])
+	})
+}
+
+func (f *parallelFft32) ProcessOutOfPlace(input, output, scratch []complex64) {
+	validateOutOfPlace(len(input), len(output), f.Len(), len(scratch), f.OutOfPlaceScratchLen())
+	n, s := f.Len(), f.inner.OutOfPlaceScratchLen()
+	algorithm.ParallelFor(f.workers, len(input)/n, func(w, start, end int) {
+		f.inner.ProcessOutOfPlace(input[start*n:end*n], output[start*n:end*n], scratch[w*s:(w+1)*s])
+	})
+}
+
+func (f *parallelFft32) ProcessImmutable(input []complex64, output, scratch []complex64) {
+	validateOutOfPlace(len(input), len(output), f.Len(), len(scratch), f.ImmutableScratchLen())
+	n, s := f.Len(), f.inner.ImmutableScratchLen()
+	algorithm.ParallelFor(f.workers, len(input)/n, func(w, start, end int) {
+		f.inner.ProcessImmutable(input[start*n:end*n], output[start*n:end*n], scratch[w*s:(w+1)*s])
+	})
+}
+
+func (f *parallelFft32) Len() int                  { return f.inner.Len() }
+func (f *parallelFft32) Direction() Direction      { return f.inner.Direction() }
+func (f *parallelFft32) InplaceScratchLen() int    { return f.workers * f.inner.InplaceScratchLen() }
+func (f *parallelFft32) OutOfPlaceScratchLen() int { return f.workers * f.inner.OutOfPlaceScratchLen() }
+func (f *parallelFft32) ImmutableScratchLen() int  { return f.workers * f.inner.ImmutableScratchLen() }
+
+// PlanParallel creates an FFT instance that uses up to workers goroutines
+// Transforms of at least 16384 points built by Radix4, Radix8, Stockham,
+// MixedRadix, GoodThomas or FourStep split their passes across the workers. Everything else splits
+// batches of transforms instead, as NewParallelFft does.
+func (p *Planner) PlanParallel(length int, direction Direction, workers int) Fft {
+	return p.PlanWith(length, direction, PlanOptions{Workers: workers})
+}
+
+// PlanParallel creates a complex64 FFT instance that uses up to workers goroutines
+func (p *Planner32) PlanParallel(length int, direction Direction, workers int) Fft32 {
+	return p.PlanWith(length, direction, PlanOptions{Workers: workers})
+}
+
+// withWorkers returns a copy of fft that splits its passes across workers,
+// or false if its algorithm runs on a single goroutine only
+func withWorkers(fft algorithm.FftInterface, workers int) (algorithm.FftInterface, bool) {
+	switch f := fft.(type) {
+	case *algorithm.Radix4:
+		return f.WithWorkers(workers), true
+	case *algorithm.Radix8:
+		return f.WithWorkers(workers), true
+	case *algorithm.Stockham:
+		return f.WithWorkers(workers), true
+	case *algorithm.MixedRadix:
+		return f.WithWorkers(workers), true
+	case *algorithm.GoodThomas:
+		return f.WithWorkers(workers), true
+	case *algorithm.FourStep:
+		return f.WithWorkers(workers), true
+	}
+	return fft, false
+}
+
+// withWorkers32 is the complex64 counterpart of withWorkers
+func withWorkers32(fft algorithm.FftInterface32, workers int) (algorithm.FftInterface32, bool) {
+	switch f := fft.(type) {
+	case *algorithm.Radix4_32:
+		return f.WithWorkers(workers), true
+	case *algorithm.Radix8_32:
+		return f.WithWorkers(workers), true
+	case *algorithm.Stockham32:
+		return f.WithWorkers(workers), true
+	case *algorithm.MixedRadix32:
+		return f.WithWorkers(workers), true
+	case *algorithm.GoodThomas32:
+		return f.WithWorkers(workers), true
+	case *algorithm.FourStep32:
+		return f.WithWorkers(workers), true
+	}
+	return fft, false
+}
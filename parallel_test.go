@@ -0,0 +1,104 @@
+package gofft
+
+import (
+	"fmt"
+	"math/cmplx"
+	"testing"
+)
+
+// TestPlanParallelMatchesPlan checks parallel plans against the regular ones,
+// both for large transforms split internally and for batches of small ones
+func TestPlanParallelMatchesPlan(t *testing.T) {
+	planner := NewPlanner()
+
+	tests := []struct {
+		n     int
+		batch int
+	}{
+		{1 << 16, 1},      // Radix4 split internally
+		{17 * 1024, 2},    // GoodThomas split internally
+		{3 * 5 * 1024, 1}, // RadixN: batches only
+		{1000, 7},         // small: batches only
+		{1031, 3},         // Bluestein: batches only
+	}
+
+	for _, tt := range tests {
+		for _, direction := range []Direction{Forward, Inverse} {
+			t.Run(fmt.Sprintf("Size%d/Batch%d/Dir%d", tt.n, tt.batch, direction), func(t *testing.T) {
+				input := make([]complex128, tt.n*tt.batch)
+				for i := range input {
+					input[i] = complex(float64(i%11)-5, float64(i%3))
+				}
+
+				expected := make([]complex128, len(input))
+				copy(expected, input)
+				planner.Plan(tt.n, direction).Process(expected)
+
+				parallel := planner.PlanParallel(tt.n, direction, 4)
+				got := make([]complex128, len(input))
+				copy(got, input)
+				parallel.Process(got)
+				for i := range got {
+					if cmplx.Abs(got[i]-expected[i]) > 1e-9*float64(tt.n) {
+						t.Fatalf("Process [%d] got %v, want %v", i, got[i], expected[i])
+					}
+				}
+
+				output := make([]complex128, len(input))
+				parallel.ProcessImmutable(input, output, make([]complex128, parallel.ImmutableScratchLen()))
+				if !complexSlicesEqual(output, expected, 1e-9*float64(tt.n)) {
+					t.Errorf("ProcessImmutable doesn't match Process")
+				}
+			})
+		}
+	}
+}
+
+func TestParallelFftBatches(t *testing.T) {
+	n := 60
+	fft := NewPlanner().PlanForward(n)
+	parallel := NewParallelFft(fft, 3)
+
+	if parallel.InplaceScratchLen() != 3*fft.InplaceScratchLen() {
+		t.Errorf("InplaceScratchLen: got %d, want %d", parallel.InplaceScratchLen(), 3*fft.InplaceScratchLen())
+	}
+
+	input := make([]complex128, 10*n)
+	for i := range input {
+		input[i] = complex(float64(i%7), float64(i%5)*0.3)
+	}
+	buffer32 := make([]complex64, len(input))
+	for i := range buffer32 {
+		buffer32[i] = complex64(input[i])
+	}
+	expected := make([]complex128, len(input))
+	copy(expected, input)
+	fft.Process(expected)
+
+	// ProcessOutOfPlace may clobber input, so it goes last
+	output := make([]complex128, len(input))
+	parallel.ProcessOutOfPlace(input, output, make([]complex128, parallel.OutOfPlaceScratchLen()))
+	if !complexSlicesEqual(output, expected, 1e-9) {
+		t.Errorf("ProcessOutOfPlace doesn't match sequential Process")
+	}
+
+	NewParallelFft32(NewPlanner32().PlanForward(n), 4).Process(buffer32)
+	for i := range buffer32 {
+		if cmplx.Abs(complex128(buffer32[i])-expected[i]) > 1e-3 {
+			t.Fatalf("complex64 [%d] got %v, want %v", i, buffer32[i], expected[i])
+		}
+	}
+}
+
+func TestPlanParallelSingleWorker(t *testing.T) {
+	planner := NewPlanner()
+	if planner.PlanParallel(256, Forward, 1) != planner.PlanForward(256) {
+		t.Errorf("one worker should reuse the regular plan")
+	}
+	if planner.PlanParallel(256, Forward, 4) != planner.PlanParallel(256, Forward, 4) {
+		t.Errorf("parallel plans aren't cached")
+	}
+	if _, ok := NewPlanner32().PlanParallel(1<<15, Forward, 2).(*fftAdapter32); !ok {
+		t.Errorf("large complex64 Radix4 plan isn't split internally")
+	}
+}
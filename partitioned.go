@@ -0,0 +1,211 @@
+package gofft
+
+import "fmt"
+
+// PartitionScheme selects how a PartitionedConvolver splits its kernel
+type PartitionScheme int
+
+const (
+	// UniformPartitions splits the kernel into partitions of the block length
+	// Every block costs the same, but the work per block grows linearly with
+	// the kernel length.
+	UniformPartitions PartitionScheme = iota
+
+	// NonUniformPartitions uses block-length partitions for the head of the
+	// kernel and doubles the partition size further into it, so the work per
+	// sample only grows logarithmically. The larger partitions are computed
+	// in full on the block that completes them, so some blocks cost more.
+	NonUniformPartitions
+)
+
+// minPartitionsPerLevel is how many partitions each size gets in a
+// non-uniform scheme before the next size takes over
+const minPartitionsPerLevel = 4
+
+// PartitionedConvolver filters a real stream with a long FIR kernel, such as
+// a room impulse response, with a latency of one block
+//
+// The kernel is split into partitions whose spectra are precomputed. Each
+// block of input is transformed once and kept in a frequency-domain delay
+// line, and every output block is the inverse FFT of the delay line
+// multiplied by the partition spectra, in the manner of uniformly
+// partitioned overlap-save.
+//
+// A PartitionedConvolver carries state between calls, so it is not safe for
+// concurrent use. It never allocates after it is planned.
+type PartitionedConvolver struct {
+	blockLen  int
+	kernelLen int
+	levels    []*partitionLevel
+}
+
+// partitionLevel runs uniformly partitioned overlap-save with one partition size
+//
+// It covers the kernel taps [offset, offset+len(partitions)*blockLen). When
+// offset is at least blockLen, the output of a block only depends on inputs
+// before it, so it is computed once the previous block is complete and
+// consumed base block by base block while the next one fills up.
+type partitionLevel struct {
+	blockLen   int
+	lag        int // How many delay line spectra older than the newest the first partition uses
+	forward    RealToComplex
+	inverse    ComplexToReal
+	partitions [][]complex128 // Kernel spectra, scaled by 1/(2*blockLen)
+
+	delayLine [][]complex128 // Input spectra, newest at head
+	head      int
+	window    []float64 // The previous block followed by the one being filled
+	filled    int
+	accum     []complex128
+	output    []float64 // Time-domain result of the last inverse FFT
+	pending   []float64 // Delayed levels: the outputs of the current block
+	consumed  int
+	scratch   []complex128
+}
+
+// PlanPartitionedConvolver creates a PartitionedConvolver for kernel that
+// processes blocks of blockLen samples
+func (p *RealFftPlanner) PlanPartitionedConvolver(kernel []float64, blockLen int, scheme PartitionScheme) *PartitionedConvolver {
+	if len(kernel) == 0 || blockLen < 1 {
+		panic(fmt.Sprintf("partitioned convolution needs a non-empty kernel and a positive block length, got %d and %d", len(kernel), blockLen))
+	}
+	if scheme != UniformPartitions && scheme != NonUniformPartitions {
+		panic(fmt.Sprintf("unknown partition scheme %d", scheme))
+	}
+
+	c := &PartitionedConvolver{blockLen: blockLen, kernelLen: len(kernel)}
+	offset, size := 0, blockLen
+	for offset < len(kernel) {
+		count := (len(kernel) - offset + size - 1) / size
+		if scheme == NonUniformPartitions {
+			// The next level doubles the size and must start at a multiple of it
+			next := minPartitionsPerLevel
+			for (offset+next*size)%(2*size) != 0 {
+				next++
+			}
+			count = min(count, next)
+		}
+
+		c.levels = append(c.levels, p.newPartitionLevel(kernel, offset, size, count))
+		offset += count * size
+		size *= 2
+	}
+	return c
+}
+
+func (p *RealFftPlanner) newPartitionLevel(kernel []float64, offset, blockLen, count int) *partitionLevel {
+	fftLen := 2 * blockLen
+	l := &partitionLevel{
+		blockLen:   blockLen,
+		forward:    p.PlanForward(fftLen),
+		inverse:    p.PlanInverse(fftLen),
+		partitions: make([][]complex128, count),
+		window:     make([]float64, fftLen),
+		accum:      make([]complex128, blockLen+1),
+		output:     make([]float64, fftLen),
+	}
+	if offset > 0 {
+		// Output block t uses input spectra up to t-offset/blockLen, and it is
+		// computed when block t-1 is the newest
+		l.lag = offset/blockLen - 1
+		l.pending = make([]float64, blockLen)
+	}
+
+	shape := convolveShape{fftLen: fftLen}
+	for i := range l.partitions {
+		start := offset + i*blockLen
+		end := min(start+blockLen, len(kernel))
+		l.partitions[i] = p.realKernelSpectrum(kernel[start:end], shape, false)
+	}
+
+	l.delayLine = make([][]complex128, l.lag+count)
+	for i := range l.delayLine {
+		l.delayLine[i] = make([]complex128, blockLen+1)
+	}
+	l.scratch = make([]complex128, max(l.forward.ScratchLen(), l.inverse.ScratchLen()))
+	return l
+}
+
+// BlockLen returns the block length, which is also the latency in samples
+func (c *PartitionedConvolver) BlockLen() int { return c.blockLen }
+
+// KernelLen returns the length of the kernel
+func (c *PartitionedConvolver) KernelLen() int { return c.kernelLen }
+
+// PartitionLens returns the size of every kernel partition, head first
+func (c *PartitionedConvolver) PartitionLens() []int {
+	var lens []int
+	for _, l := range c.levels {
+		for range l.partitions {
+			lens = append(lens, l.blockLen)
+		}
+	}
+	return lens
+}
+
+// Process filters the next blocks of the stream from in into out
+// in and out must have the same length, which must be a multiple of BlockLen.
+func (c *PartitionedConvolver) Process(in, out []float64) {
+	validateOutOfPlace(len(in), len(out), c.blockLen, 0, 0)
+
+	for start := 0; start < len(in); start += c.blockLen {
+		block := in[start : start+c.blockLen]
+		outBlock := out[start : start+c.blockLen]
+
+		// The first level always uses the block length and starts at tap 0,
+		// so it produces this block's outputs directly
+		first := c.levels[0]
+		first.push(block)
+		copy(outBlock, first.output[c.blockLen:])
+
+		for _, l := range c.levels[1:] {
+			for i, v := range l.pending[l.consumed : l.consumed+c.blockLen] {
+				outBlock[i] += v
+			}
+			l.consumed += c.blockLen
+			l.push(block)
+		}
+	}
+}
+
+// Reset clears the stream history, as if no input had been processed
+func (c *PartitionedConvolver) Reset() {
+	for _, l := range c.levels {
+		for _, spectrum := range l.delayLine {
+			clear(spectrum)
+		}
+		clear(l.window)
+		clear(l.pending)
+		l.filled = 0
+		l.consumed = 0
+	}
+}
+
+// push adds a base block of input and runs the level's FFTs once a whole block is buffered
+func (l *partitionLevel) push(block []float64) {
+	copy(l.window[l.blockLen+l.filled:], block)
+	l.filled += len(block)
+	if l.filled < l.blockLen {
+		return
+	}
+
+	// Transform the last two blocks into the newest delay line slot
+	l.head = (l.head + 1) % len(l.delayLine)
+	l.forward.ProcessWithScratch(l.window, l.delayLine[l.head], l.scratch)
+
+	clear(l.accum)
+	for p, partition := range l.partitions {
+		input := l.delayLine[(l.head-l.lag-p+len(l.delayLine))%len(l.delayLine)]
+		for k, h := range partition {
+			l.accum[k] += input[k] * h
+		}
+	}
+	l.inverse.ProcessWithScratch(l.accum, l.output, l.scratch)
+
+	if l.pending != nil {
+		copy(l.pending, l.output[l.blockLen:])
+		l.consumed = 0
+	}
+	copy(l.window, l.window[l.blockLen:])
+	l.filled = 0
+}
@@ -0,0 +1,101 @@
+package gofft
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPartitionedConvolverMatchesDirect(t *testing.T) {
+	planner := NewRealFftPlanner()
+	schemes := map[string]PartitionScheme{"Uniform": UniformPartitions, "NonUniform": NonUniformPartitions}
+	for name, scheme := range schemes {
+		for _, tc := range []struct{ kernelLen, blockLen int }{{1, 16}, {100, 16}, {5000, 32}, {3000, 64}, {7, 1}, {900, 12}} {
+			t.Run(fmt.Sprintf("%s/Kernel%d/Block%d", name, tc.kernelLen, tc.blockLen), func(t *testing.T) {
+				kernel := make([]float64, tc.kernelLen)
+				for i := range kernel {
+					kernel[i] = math.Exp(-float64(i)/700) * math.Sin(float64(i)*0.7+0.2)
+				}
+				c := planner.PlanPartitionedConvolver(kernel, tc.blockLen, scheme)
+
+				covered := 0
+				for _, n := range c.PartitionLens() {
+					covered += n
+				}
+				if covered < tc.kernelLen {
+					t.Fatalf("partitions %v don't cover %d taps", c.PartitionLens(), tc.kernelLen)
+				}
+
+				stream := make([]float64, 40*tc.blockLen+tc.kernelLen)
+				stream = stream[:len(stream)-len(stream)%tc.blockLen]
+				for i := range stream {
+					stream[i] = math.Cos(float64(i)*0.013) + 0.5*math.Sin(float64(i)*2.1)
+				}
+				expected := make([]float64, len(stream))
+				for i := range expected {
+					for j := 0; j < tc.kernelLen && j <= i; j++ {
+						expected[i] += kernel[j] * stream[i-j]
+					}
+				}
+
+				// Mix single blocks with several blocks per call
+				got := make([]float64, len(stream))
+				for start, calls := 0, 0; start < len(stream); calls++ {
+					end := min(start+(1+calls%3)*tc.blockLen, len(stream))
+					c.Process(stream[start:end], got[start:end])
+					start = end
+				}
+
+				for i := range got {
+					if math.Abs(got[i]-expected[i]) > 1e-9*float64(tc.kernelLen) {
+						t.Fatalf("[%d] got %v, want %v", i, got[i], expected[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPartitionedConvolverPartitionLens(t *testing.T) {
+	planner := NewRealFftPlanner()
+	kernel := make([]float64, 96000)
+
+	uniform := planner.PlanPartitionedConvolver(kernel, 128, UniformPartitions).PartitionLens()
+	if len(uniform) != 750 {
+		t.Errorf("uniform: got %d partitions, want 750", len(uniform))
+	}
+
+	nonUniform := planner.PlanPartitionedConvolver(kernel, 128, NonUniformPartitions).PartitionLens()
+	if len(nonUniform) >= 50 {
+		t.Errorf("non-uniform: got %d partitions, want far fewer than the uniform 750", len(nonUniform))
+	}
+	offset := 0
+	for i, n := range nonUniform {
+		if i > 0 && n < nonUniform[i-1] {
+			t.Fatalf("partition sizes %v shrink", nonUniform)
+		}
+		// A partition larger than a block needs a head start of its own length
+		if n > 128 && offset < n {
+			t.Fatalf("partition %d of size %d starts at tap %d", i, n, offset)
+		}
+		offset += n
+	}
+}
+
+func TestPartitionedConvolverAllocations(t *testing.T) {
+	kernel := make([]float64, 4000)
+	kernel[10] = 1
+	c := NewRealFftPlanner().PlanPartitionedConvolver(kernel, 64, NonUniformPartitions)
+	in := make([]float64, 64)
+	out := make([]float64, 64)
+	if allocs := testing.AllocsPerRun(100, func() { c.Process(in, out) }); allocs != 0 {
+		t.Errorf("Process allocated %v times, want 0", allocs)
+	}
+
+	c.Reset()
+	in[0] = 1
+	c.Process(in, out)
+	if math.Abs(out[10]-1) > 1e-12 || math.Abs(out[0]) > 1e-12 {
+		t.Errorf("after Reset, a delayed impulse came out as %v", out[:12])
+	}
+}
@@ -1,6 +1,7 @@
 package gofft
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/10d9e/gofft/algorithm"
@@ -10,6 +11,7 @@ import (
 // It automatically selects the best algorithm and caches created instances
 type Planner struct {
 	mu          sync.Mutex
+	mode        PlannerMode
 	cache       map[plannerKey]Fft
 	recipeCache map[int]*recipe
 }
@@ -17,11 +19,29 @@ type Planner struct {
 type plannerKey struct {
 	length    int
 	direction Direction
+	workers   int // 0 for single-goroutine plans
+	normalize Normalization
+}
+
+// PlanOptions configures PlanWith. The zero value plans the same FFT as Plan.
+type PlanOptions struct {
+	// Normalize selects how the output is scaled. The factor is folded into
+	// the final pass of the algorithm rather than applied as an extra sweep.
+	Normalize Normalization
+
+	// Workers is how many goroutines the FFT may use, as for PlanParallel
+	Workers int
 }
 
 // NewPlanner creates a new FFT planner
 func NewPlanner() *Planner {
+	return NewPlannerWithMode(Estimate)
+}
+
+// NewPlannerWithMode creates a new FFT planner that designs FFTs using the given mode
+func NewPlannerWithMode(mode PlannerMode) *Planner {
 	return &Planner{
+		mode:        mode,
 		cache:       make(map[plannerKey]Fft),
 		recipeCache: make(map[int]*recipe),
 	}
@@ -39,10 +59,19 @@ func (p *Planner) PlanInverse(length int) Fft {
 
 // Plan creates an FFT instance for the given size and direction
 func (p *Planner) Plan(length int, direction Direction) Fft {
+	return p.PlanWith(length, direction, PlanOptions{})
+}
+
+// PlanWith creates an FFT instance for the given size and direction with the given options
+func (p *Planner) PlanWith(length int, direction Direction, opts PlanOptions) Fft {
+	if opts.Workers <= 1 {
+		opts.Workers = 0
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	key := plannerKey{length: length, direction: direction}
+	key := plannerKey{length: length, direction: direction, workers: opts.Workers, normalize: opts.Normalize}
 
 	// Check cache
 	if fft, ok := p.cache[key]; ok {
@@ -50,10 +79,10 @@ func (p *Planner) Plan(length int, direction Direction) Fft {
 	}
 
 	// Create a recipe for this FFT
-	recipe, len := p.designFft(length)
+	recipe := p.designFft(length)
 
 	// Build the FFT from the recipe
-	fft := p.buildFft(recipe, len, direction)
+	fft := p.buildFft(recipe, direction, opts)
 
 	// Cache it
 	p.cache[key] = fft
@@ -62,10 +91,21 @@ func (p *Planner) Plan(length int, direction Direction) Fft {
 }
 
 // recipe describes how to construct an FFT without actually building it
-type recipe int
+// Algorithms built from smaller FFTs hold the recipes of those FFTs, so a
+// recipe is a tree whose leaves are butterflies, Radix4, RadixN, Bluestein or Dft
+type recipe struct {
+	kind   recipeKind
+	length int
+	inner  *recipe // Rader's: the FFT of length-1
+	width  *recipe // MixedRadix and GoodThomas: the two factor FFTs
+	height *recipe
+}
+
+// recipeKind identifies the algorithm a recipe builds
+type recipeKind int
 
 const (
-	recipeDft recipe = iota
+	recipeDft recipeKind = iota
 	recipeButterfly2
 	recipeButterfly3
 	recipeButterfly4
@@ -74,164 +114,336 @@ const (
 	recipeButterfly7
 	recipeButterfly8
 	recipeButterfly9
+	recipeButterfly10
 	recipeButterfly11
 	recipeButterfly12
 	recipeButterfly13
+	recipeButterfly14
+	recipeButterfly15
 	recipeButterfly16
 	recipeButterfly17
 	recipeButterfly19
+	recipeButterfly20
 	recipeButterfly23
 	recipeButterfly24
+	recipeButterfly25
 	recipeButterfly27
 	recipeButterfly29
 	recipeButterfly31
 	recipeButterfly32
+	recipeButterfly36
+	recipeButterfly64
 	recipeRadix4
+	recipeRadix8
+	recipeStockham
 	recipeRadixN
 	recipeRaders
 	recipeBluestein
+	recipeMixedRadix
+	recipeGoodThomas
+	recipeFourStep
 )
 
+var recipeKindNames = [...]string{
+	recipeDft:         "Dft",
+	recipeButterfly2:  "Butterfly2",
+	recipeButterfly3:  "Butterfly3",
+	recipeButterfly4:  "Butterfly4",
+	recipeButterfly5:  "Butterfly5",
+	recipeButterfly6:  "Butterfly6",
+	recipeButterfly7:  "Butterfly7",
+	recipeButterfly8:  "Butterfly8",
+	recipeButterfly9:  "Butterfly9",
+	recipeButterfly10: "Butterfly10",
+	recipeButterfly11: "Butterfly11",
+	recipeButterfly12: "Butterfly12",
+	recipeButterfly13: "Butterfly13",
+	recipeButterfly14: "Butterfly14",
+	recipeButterfly15: "Butterfly15",
+	recipeButterfly16: "Butterfly16",
+	recipeButterfly17: "Butterfly17",
+	recipeButterfly19: "Butterfly19",
+	recipeButterfly20: "Butterfly20",
+	recipeButterfly23: "Butterfly23",
+	recipeButterfly24: "Butterfly24",
+	recipeButterfly25: "Butterfly25",
+	recipeButterfly27: "Butterfly27",
+	recipeButterfly29: "Butterfly29",
+	recipeButterfly31: "Butterfly31",
+	recipeButterfly32: "Butterfly32",
+	recipeButterfly36: "Butterfly36",
+	recipeButterfly64: "Butterfly64",
+	recipeRadix4:      "Radix4",
+	recipeRadix8:      "Radix8",
+	recipeStockham:    "Stockham",
+	recipeRadixN:      "RadixN",
+	recipeRaders:      "Raders",
+	recipeBluestein:   "Bluestein",
+	recipeMixedRadix:  "MixedRadix",
+	recipeGoodThomas:  "GoodThomas",
+	recipeFourStep:    "FourStep",
+}
+
+// String returns the name of the algorithm, as used in wisdom files
+func (k recipeKind) String() string {
+	if k < 0 || int(k) >= len(recipeKindNames) {
+		return "recipeKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return recipeKindNames[k]
+}
+
 // designFft creates a recipe for an FFT of the given length
-func (p *Planner) designFft(length int) (*recipe, int) {
-	if r, ok := p.recipeCache[length]; ok {
-		return r, length
+func (p *Planner) designFft(length int) *recipe {
+	if p.mode == Measure {
+		return designRecipe(p.recipeCache, length, timeRecipe)
+	}
+	return designRecipe(p.recipeCache, length, nil)
+}
+
+// designRecipe chooses the recipe for an FFT of the given length
+// With a nil timer the choice is a fixed heuristic that only depends on the
+// length and the CPU, so it is shared by Planner and Planner32. Otherwise every candidate
+// recipe is timed and the fastest one wins.
+func designRecipe(recipeCache map[int]*recipe, length int, timer recipeTimer) *recipe {
+	if r, ok := recipeCache[length]; ok {
+		return r
 	}
 
-	var r recipe
+	var r *recipe
+	if timer == nil {
+		r = estimateRecipe(recipeCache, length, nil)
+	} else {
+		r = fastestRecipe(candidateRecipes(recipeCache, length, timer), timer)
+	}
+
+	recipeCache[length] = r
+	return r
+}
+
+// largePowerOfTwo is the length from which powers of two are built by Radix8
+// or Stockham rather than Radix4
+const largePowerOfTwo = 1 << 14
+
+// fourStepMinLen is the length from which smooth sizes are built by FourStep,
+// whose two passes over memory beat the many of the other algorithms once
+// the data no longer fits in cache
+const fourStepMinLen = 1 << 24
+
+// estimateRecipe picks a recipe for length without measuring anything
+// Sub-FFTs are designed through designRecipe, so they are timed if timer is set
+func estimateRecipe(recipeCache map[int]*recipe, length int, timer recipeTimer) *recipe {
+	r := &recipe{length: length}
 
 	// Choose algorithm based on length
 	switch length {
 	case 0, 1:
-		r = recipeDft
+		r.kind = recipeDft
 	case 2:
-		r = recipeButterfly2
+		r.kind = recipeButterfly2
 	case 3:
-		r = recipeButterfly3
+		r.kind = recipeButterfly3
 	case 4:
-		r = recipeButterfly4
+		r.kind = recipeButterfly4
 	case 5:
-		r = recipeButterfly5
+		r.kind = recipeButterfly5
 	case 6:
-		r = recipeButterfly6
+		r.kind = recipeButterfly6
 	case 7:
-		r = recipeButterfly7
+		r.kind = recipeButterfly7
 	case 8:
-		r = recipeButterfly8
+		r.kind = recipeButterfly8
 	case 9:
-		r = recipeButterfly9
+		r.kind = recipeButterfly9
+	case 10:
+		r.kind = recipeButterfly10
 	case 11:
-		r = recipeButterfly11
+		r.kind = recipeButterfly11
 	case 12:
-		r = recipeButterfly12
+		r.kind = recipeButterfly12
 	case 13:
-		r = recipeButterfly13
+		r.kind = recipeButterfly13
+	case 14:
+		r.kind = recipeButterfly14
+	case 15:
+		r.kind = recipeButterfly15
 	case 16:
-		r = recipeButterfly16
+		r.kind = recipeButterfly16
 	case 17:
-		r = recipeButterfly17
+		r.kind = recipeButterfly17
 	case 19:
-		r = recipeButterfly19
+		r.kind = recipeButterfly19
+	case 20:
+		r.kind = recipeButterfly20
 	case 23:
-		r = recipeButterfly23
+		r.kind = recipeButterfly23
 	case 24:
-		r = recipeButterfly24
+		r.kind = recipeButterfly24
+	case 25:
+		r.kind = recipeButterfly25
 	case 27:
-		r = recipeButterfly27
+		r.kind = recipeButterfly27
 	case 29:
-		r = recipeButterfly29
+		r.kind = recipeButterfly29
 	case 31:
-		r = recipeButterfly31
+		r.kind = recipeButterfly31
 	case 32:
-		r = recipeButterfly32
-	default:
-		// Check if it's a power of two
-		if isPowerOfTwo(length) && length > 32 {
-			r = recipeRadix4
+		r.kind = recipeButterfly32
+	case 36:
+		r.kind = recipeButterfly36
+	case 64:
+		// Radix4 with the AVX2 kernels beats the straight-line code
+		if algorithm.AVX2Enabled() {
+			r.kind = recipeRadix4
 		} else {
-			// Try RadixN for composite sizes (factors 2-7 only)
-			if canUseRadixN(length) {
-				r = recipeRadixN
+			r.kind = recipeButterfly64
+		}
+	default:
+		factors := ComputePrimeFactors(length)
+		switch {
+		case length >= fourStepMinLen && (isPowerOfTwo(length) || canUseRadixN(length)):
+			r.kind = recipeFourStep
+			r.width, r.height = fourStepFactors(recipeCache, length, timer)
+		case isPowerOfTwo(length) && length >= largePowerOfTwo:
+			// Radix8 makes the fewest passes and has AVX2 kernels; without
+			// them the Stockham stages stream through memory fastest
+			if algorithm.AVX2Enabled() {
+				r.kind = recipeRadix8
+			} else {
+				r.kind = recipeStockham
+			}
+		case isPowerOfTwo(length):
+			r.kind = recipeRadix4
+		case canUseRadixN(length):
+			// Composite sizes with factors 2-7 only
+			r.kind = recipeRadixN
+		case factors.IsPrime() && length <= 97:
+			// Use Rader's for small/medium primes (more efficient than Bluestein's)
+			r.kind = recipeRaders
+			r.inner = designRecipe(recipeCache, length-1, timer)
+		case factors.IsPrime():
+			// Use Bluestein's for large primes
+			r.kind = recipeBluestein
+		default:
+			// Composite sizes with a factor above 7: split into two smaller
+			// FFTs, which are coprime unless length is a prime power
+			left, right := factors.PartitionFactors()
+			r.width = designRecipe(recipeCache, left.GetProduct(), timer)
+			r.height = designRecipe(recipeCache, right.GetProduct(), timer)
+			if GCD(left.GetProduct(), right.GetProduct()) == 1 {
+				r.kind = recipeGoodThomas
 			} else {
-				// For non-factorizable sizes, check if it's prime
-				factors := ComputePrimeFactors(length)
-				if factors.IsPrime() && length <= 97 {
-					// Use Rader's for small/medium primes (more efficient than Bluestein's)
-					r = recipeRaders
-				} else {
-					// Use Bluestein's for large primes or sizes with factors >7
-					r = recipeBluestein
-				}
+				r.kind = recipeMixedRadix
 			}
 		}
 	}
 
-	p.recipeCache[length] = &r
-	return &r, length
+	return r
+}
+
+// fourStepFactors designs the width and height FFTs of a FourStep recipe,
+// the factors of length closest to its square root. The smaller one is the
+// height, so that each block of columns gathers more of them.
+func fourStepFactors(recipeCache map[int]*recipe, length int, timer recipeTimer) (width, height *recipe) {
+	h := splitCandidates(length)[0]
+	return designRecipe(recipeCache, length/h, timer), designRecipe(recipeCache, h, timer)
 }
 
 // buildFft constructs an FFT instance from a recipe
-func (p *Planner) buildFft(recipe *recipe, length int, direction Direction) Fft {
-	dir := toAlgoDirection(direction)
-	switch *recipe {
+func (p *Planner) buildFft(recipe *recipe, direction Direction, opts PlanOptions) Fft {
+	inner := buildAlgorithm(recipe, toAlgoDirection(direction))
+	if scale := opts.Normalize.scale(recipe.length, direction); scale != 1 {
+		inner = withScale(inner, scale)
+	}
+	if opts.Workers == 0 {
+		return &fftAdapter{inner: inner}
+	}
+	if parallel, ok := withWorkers(inner, opts.Workers); ok && recipe.length >= parallelMinLen {
+		return &fftAdapter{inner: parallel}
+	}
+	return NewParallelFft(&fftAdapter{inner: inner}, opts.Workers)
+}
+
+// buildAlgorithm recursively constructs the algorithm tree described by a recipe
+func buildAlgorithm(r *recipe, dir algorithm.Direction) algorithm.FftInterface {
+	switch r.kind {
 	case recipeDft:
-		return &fftAdapter{inner: algorithm.NewDft(length, dir)}
+		return algorithm.NewDft(r.length, dir)
 	case recipeButterfly2:
-		return &fftAdapter{inner: algorithm.NewButterfly2(dir)}
+		return algorithm.NewButterfly2(dir)
 	case recipeButterfly3:
-		return &fftAdapter{inner: algorithm.NewButterfly3(dir)}
+		return algorithm.NewButterfly3(dir)
 	case recipeButterfly4:
-		return &fftAdapter{inner: algorithm.NewButterfly4(dir)}
+		return algorithm.NewButterfly4(dir)
 	case recipeButterfly5:
-		return &fftAdapter{inner: algorithm.NewButterfly5(dir)}
+		return algorithm.NewButterfly5(dir)
 	case recipeButterfly6:
-		return &fftAdapter{inner: algorithm.NewButterfly6(dir)}
+		return algorithm.NewButterfly6(dir)
 	case recipeButterfly7:
-		return &fftAdapter{inner: algorithm.NewButterfly7(dir)}
+		return algorithm.NewButterfly7(dir)
 	case recipeButterfly8:
-		return &fftAdapter{inner: algorithm.NewButterfly8(dir)}
+		return algorithm.NewButterfly8(dir)
 	case recipeButterfly9:
-		return &fftAdapter{inner: algorithm.NewButterfly9(dir)}
+		return algorithm.NewButterfly9(dir)
+	case recipeButterfly10:
+		return algorithm.NewButterfly10(dir)
 	case recipeButterfly11:
-		return &fftAdapter{inner: algorithm.NewButterfly11(dir)}
+		return algorithm.NewButterfly11(dir)
 	case recipeButterfly12:
-		return &fftAdapter{inner: algorithm.NewButterfly12(dir)}
+		return algorithm.NewButterfly12(dir)
 	case recipeButterfly13:
-		return &fftAdapter{inner: algorithm.NewButterfly13(dir)}
+		return algorithm.NewButterfly13(dir)
+	case recipeButterfly14:
+		return algorithm.NewButterfly14(dir)
+	case recipeButterfly15:
+		return algorithm.NewButterfly15(dir)
 	case recipeButterfly16:
-		return &fftAdapter{inner: algorithm.NewButterfly16(dir)}
+		return algorithm.NewButterfly16(dir)
 	case recipeButterfly17:
-		return &fftAdapter{inner: algorithm.NewButterfly17(dir)}
+		return algorithm.NewButterfly17(dir)
 	case recipeButterfly19:
-		return &fftAdapter{inner: algorithm.NewButterfly19(dir)}
+		return algorithm.NewButterfly19(dir)
+	case recipeButterfly20:
+		return algorithm.NewButterfly20(dir)
 	case recipeButterfly23:
-		return &fftAdapter{inner: algorithm.NewButterfly23(dir)}
+		return algorithm.NewButterfly23(dir)
 	case recipeButterfly24:
-		return &fftAdapter{inner: algorithm.NewButterfly24(dir)}
+		return algorithm.NewButterfly24(dir)
+	case recipeButterfly25:
+		return algorithm.NewButterfly25(dir)
 	case recipeButterfly27:
-		return &fftAdapter{inner: algorithm.NewButterfly27(dir)}
+		return algorithm.NewButterfly27(dir)
 	case recipeButterfly29:
-		return &fftAdapter{inner: algorithm.NewButterfly29(dir)}
+		return algorithm.NewButterfly29(dir)
 	case recipeButterfly31:
-		return &fftAdapter{inner: algorithm.NewButterfly31(dir)}
+		return algorithm.NewButterfly31(dir)
 	case recipeButterfly32:
-		return &fftAdapter{inner: algorithm.NewButterfly32(dir)}
+		return algorithm.NewButterfly32(dir)
+	case recipeButterfly36:
+		return algorithm.NewButterfly36(dir)
+	case recipeButterfly64:
+		return algorithm.NewButterfly64(dir)
 	case recipeRadix4:
-		return &fftAdapter{inner: algorithm.NewRadix4(length, dir)}
+		return algorithm.NewRadix4(r.length, dir)
+	case recipeRadix8:
+		return algorithm.NewRadix8(r.length, dir)
+	case recipeStockham:
+		return algorithm.NewStockham(r.length, dir)
 	case recipeRadixN:
 		// Factor the length and create RadixN
-		factors := factorizeForRadixN(length)
+		factors := factorizeForRadixN(r.length)
 		baseFft := algorithm.NewDft(1, dir) // Base of size 1
-		return &fftAdapter{inner: algorithm.NewRadixN(factors, baseFft)}
+		return algorithm.NewRadixN(factors, baseFft)
 	case recipeRaders:
-		// Create FFT of size length-1 for Rader's algorithm
-		// Recursively plan the inner FFT
-		innerLength := length - 1
-		innerRecipe, _ := p.designFft(innerLength)
-		innerFftImpl := p.buildFft(innerRecipe, innerLength, direction)
-		return &fftAdapter{inner: algorithm.NewRaders(innerFftImpl.(*fftAdapter).inner)}
+		// Rader's reduces a prime FFT to an FFT of length-1
+		return algorithm.NewRaders(buildAlgorithm(r.inner, dir))
 	case recipeBluestein:
-		return &fftAdapter{inner: algorithm.NewBluestein(length, dir)}
+		return algorithm.NewBluestein(r.length, dir)
+	case recipeMixedRadix:
+		return algorithm.NewMixedRadix(buildAlgorithm(r.width, dir), buildAlgorithm(r.height, dir))
+	case recipeGoodThomas:
+		return algorithm.NewGoodThomas(buildAlgorithm(r.width, dir), buildAlgorithm(r.height, dir))
+	case recipeFourStep:
+		return algorithm.NewFourStep(buildAlgorithm(r.width, dir), buildAlgorithm(r.height, dir))
 	default:
 		panic("unknown recipe type")
 	}
@@ -246,27 +458,28 @@ func toAlgoDirection(d Direction) algorithm.Direction {
 }
 
 // fftAdapter adapts algorithm FFTs to the gofft.Fft interface
+// It is also where buffer sizes are validated, since the algorithms trust their callers
 type fftAdapter struct {
 	inner algorithm.FftInterface
+	pool  scratchPool[complex128]
 }
 
 func (f *fftAdapter) Process(buffer []complex128) {
-	f.inner.ProcessWithScratch(buffer, make([]complex128, f.inner.InplaceScratchLen()))
+	scratch := f.pool.get(f.inner.InplaceScratchLen())
+	f.ProcessWithScratch(buffer, *scratch)
+	f.pool.put(scratch)
 }
 
 func (f *fftAdapter) ProcessWithScratch(buffer, scratch []complex128) {
-	f.inner.ProcessWithScratch(buffer, scratch)
+	fftHelperInplace(buffer, scratch, f.inner.Len(), f.inner.InplaceScratchLen(), f.inner.ProcessWithScratch)
 }
 
 func (f *fftAdapter) ProcessOutOfPlace(input, output, scratch []complex128) {
-	// For now, do it via copy
-	copy(output, input)
-	f.inner.ProcessWithScratch(output, scratch)
+	fftHelperOutOfPlace(input, output, scratch, f.inner.Len(), f.inner.OutOfPlaceScratchLen(), f.inner.ProcessOutOfPlace)
 }
 
 func (f *fftAdapter) ProcessImmutable(input []complex128, output, scratch []complex128) {
-	copy(output, input)
-	f.inner.ProcessWithScratch(output, scratch)
+	fftHelperImmutable(input, output, scratch, f.inner.Len(), f.inner.ImmutableScratchLen(), f.inner.ProcessImmutable)
 }
 
 func (f *fftAdapter) Len() int {
@@ -285,11 +498,11 @@ func (f *fftAdapter) InplaceScratchLen() int {
 }
 
 func (f *fftAdapter) OutOfPlaceScratchLen() int {
-	return 0
+	return f.inner.OutOfPlaceScratchLen()
 }
 
 func (f *fftAdapter) ImmutableScratchLen() int {
-	return f.inner.InplaceScratchLen()
+	return f.inner.ImmutableScratchLen()
 }
 
 // isPowerOfTwo checks if n is a power of two
@@ -300,13 +513,20 @@ func isPowerOfTwo(n int) bool {
 // Plan32 is a planner for complex64 FFTs
 type Planner32 struct {
 	mu          sync.Mutex
+	mode        PlannerMode
 	cache       map[plannerKey]Fft32
 	recipeCache map[int]*recipe
 }
 
 // NewPlanner32 creates a new FFT planner for complex64
 func NewPlanner32() *Planner32 {
+	return NewPlanner32WithMode(Estimate)
+}
+
+// NewPlanner32WithMode creates a new complex64 FFT planner that designs FFTs using the given mode
+func NewPlanner32WithMode(mode PlannerMode) *Planner32 {
 	return &Planner32{
+		mode:        mode,
 		cache:       make(map[plannerKey]Fft32),
 		recipeCache: make(map[int]*recipe),
 	}
@@ -324,19 +544,30 @@ func (p *Planner32) PlanInverse(length int) Fft32 {
 
 // Plan creates an FFT instance for the given size and direction
 func (p *Planner32) Plan(length int, direction Direction) Fft32 {
+	return p.PlanWith(length, direction, PlanOptions{})
+}
+
+// PlanWith creates an FFT instance for the given size and direction with the given options
+func (p *Planner32) PlanWith(length int, direction Direction, opts PlanOptions) Fft32 {
+	if opts.Workers <= 1 {
+		opts.Workers = 0
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	key := plannerKey{length: length, direction: direction}
+	key := plannerKey{length: length, direction: direction, workers: opts.Workers, normalize: opts.Normalize}
 
 	// Check cache
 	if fft, ok := p.cache[key]; ok {
 		return fft
 	}
 
-	// For now, use DFT for all sizes
-	// TODO: port all algorithms to complex64
-	fft := &fftAdapter32{inner: algorithm.NewDft32(length, toAlgoDirection(direction))}
+	// Create a recipe for this FFT
+	recipe := p.designFft(length)
+
+	// Build the FFT from the recipe
+	fft := p.buildFft(recipe, direction, opts)
 
 	// Cache it
 	p.cache[key] = fft
@@ -344,32 +575,137 @@ func (p *Planner32) Plan(length int, direction Direction) Fft32 {
 	return fft
 }
 
+// designFft creates a recipe for an FFT of the given length
+func (p *Planner32) designFft(length int) *recipe {
+	if p.mode == Measure {
+		return designRecipe(p.recipeCache, length, timeRecipe32)
+	}
+	return designRecipe(p.recipeCache, length, nil)
+}
+
+// buildFft constructs a complex64 FFT instance from a recipe
+func (p *Planner32) buildFft(recipe *recipe, direction Direction, opts PlanOptions) Fft32 {
+	inner := buildAlgorithm32(recipe, toAlgoDirection(direction))
+	if scale := opts.Normalize.scale(recipe.length, direction); scale != 1 {
+		inner = withScale32(inner, scale)
+	}
+	if opts.Workers == 0 {
+		return &fftAdapter32{inner: inner}
+	}
+	if parallel, ok := withWorkers32(inner, opts.Workers); ok && recipe.length >= parallelMinLen {
+		return &fftAdapter32{inner: parallel}
+	}
+	return NewParallelFft32(&fftAdapter32{inner: inner}, opts.Workers)
+}
+
+// buildAlgorithm32 recursively constructs the complex64 algorithm tree described by a recipe
+func buildAlgorithm32(r *recipe, dir algorithm.Direction) algorithm.FftInterface32 {
+	switch r.kind {
+	case recipeDft:
+		return algorithm.NewDft32(r.length, dir)
+	case recipeButterfly2:
+		return algorithm.NewButterfly2_32(dir)
+	case recipeButterfly3:
+		return algorithm.NewButterfly3_32(dir)
+	case recipeButterfly4:
+		return algorithm.NewButterfly4_32(dir)
+	case recipeButterfly5:
+		return algorithm.NewButterfly5_32(dir)
+	case recipeButterfly6:
+		return algorithm.NewButterfly6_32(dir)
+	case recipeButterfly7:
+		return algorithm.NewButterfly7_32(dir)
+	case recipeButterfly8:
+		return algorithm.NewButterfly8_32(dir)
+	case recipeButterfly9:
+		return algorithm.NewButterfly9_32(dir)
+	case recipeButterfly10:
+		return algorithm.NewButterfly10_32(dir)
+	case recipeButterfly11:
+		return algorithm.NewButterfly11_32(dir)
+	case recipeButterfly12:
+		return algorithm.NewButterfly12_32(dir)
+	case recipeButterfly13:
+		return algorithm.NewButterfly13_32(dir)
+	case recipeButterfly14:
+		return algorithm.NewButterfly14_32(dir)
+	case recipeButterfly15:
+		return algorithm.NewButterfly15_32(dir)
+	case recipeButterfly16:
+		return algorithm.NewButterfly16_32(dir)
+	case recipeButterfly17:
+		return algorithm.NewButterfly17_32(dir)
+	case recipeButterfly19:
+		return algorithm.NewButterfly19_32(dir)
+	case recipeButterfly20:
+		return algorithm.NewButterfly20_32(dir)
+	case recipeButterfly23:
+		return algorithm.NewButterfly23_32(dir)
+	case recipeButterfly24:
+		return algorithm.NewButterfly24_32(dir)
+	case recipeButterfly25:
+		return algorithm.NewButterfly25_32(dir)
+	case recipeButterfly27:
+		return algorithm.NewButterfly27_32(dir)
+	case recipeButterfly29:
+		return algorithm.NewButterfly29_32(dir)
+	case recipeButterfly31:
+		return algorithm.NewButterfly31_32(dir)
+	case recipeButterfly32:
+		return algorithm.NewButterfly32_32(dir)
+	case recipeButterfly36:
+		return algorithm.NewButterfly36_32(dir)
+	case recipeButterfly64:
+		return algorithm.NewButterfly64_32(dir)
+	case recipeRadix4:
+		return algorithm.NewRadix4_32(r.length, dir)
+	case recipeRadix8:
+		return algorithm.NewRadix8_32(r.length, dir)
+	case recipeStockham:
+		return algorithm.NewStockham32(r.length, dir)
+	case recipeRadixN:
+		factors := factorizeForRadixN(r.length)
+		baseFft := algorithm.NewDft32(1, dir)
+		return algorithm.NewRadixN32(factors, baseFft)
+	case recipeRaders:
+		// Rader's reduces a prime FFT to an FFT of length-1
+		return algorithm.NewRaders32(buildAlgorithm32(r.inner, dir))
+	case recipeBluestein:
+		return algorithm.NewBluestein32(r.length, dir)
+	case recipeMixedRadix:
+		return algorithm.NewMixedRadix32(buildAlgorithm32(r.width, dir), buildAlgorithm32(r.height, dir))
+	case recipeGoodThomas:
+		return algorithm.NewGoodThomas32(buildAlgorithm32(r.width, dir), buildAlgorithm32(r.height, dir))
+	case recipeFourStep:
+		return algorithm.NewFourStep32(buildAlgorithm32(r.width, dir), buildAlgorithm32(r.height, dir))
+	default:
+		panic("unknown recipe type")
+	}
+}
+
 // fftAdapter32 adapts algorithm FFTs to the gofft.Fft32 interface
+// It is also where buffer sizes are validated, since the algorithms trust their callers
 type fftAdapter32 struct {
-	inner interface {
-		Len() int
-		Direction() algorithm.Direction
-		InplaceScratchLen() int
-		ProcessWithScratch(buffer, scratch []complex64)
-	}
+	inner algorithm.FftInterface32
+	pool  scratchPool[complex64]
 }
 
 func (f *fftAdapter32) Process(buffer []complex64) {
-	f.inner.ProcessWithScratch(buffer, make([]complex64, f.inner.InplaceScratchLen()))
+	scratch := f.pool.get(f.inner.InplaceScratchLen())
+	f.ProcessWithScratch(buffer, *scratch)
+	f.pool.put(scratch)
 }
 
 func (f *fftAdapter32) ProcessWithScratch(buffer, scratch []complex64) {
-	f.inner.ProcessWithScratch(buffer, scratch)
+	fftHelperInplace32(buffer, scratch, f.inner.Len(), f.inner.InplaceScratchLen(), f.inner.ProcessWithScratch)
 }
 
 func (f *fftAdapter32) ProcessOutOfPlace(input, output, scratch []complex64) {
-	copy(output, input)
-	f.inner.ProcessWithScratch(output, scratch)
+	fftHelperOutOfPlace32(input, output, scratch, f.inner.Len(), f.inner.OutOfPlaceScratchLen(), f.inner.ProcessOutOfPlace)
 }
 
 func (f *fftAdapter32) ProcessImmutable(input []complex64, output, scratch []complex64) {
-	copy(output, input)
-	f.inner.ProcessWithScratch(output, scratch)
+	fftHelperImmutable32(input, output, scratch, f.inner.Len(), f.inner.ImmutableScratchLen(), f.inner.ProcessImmutable)
 }
 
 func (f *fftAdapter32) Len() int {
@@ -388,9 +724,9 @@ func (f *fftAdapter32) InplaceScratchLen() int {
 }
 
 func (f *fftAdapter32) OutOfPlaceScratchLen() int {
-	return 0
+	return f.inner.OutOfPlaceScratchLen()
 }
 
 func (f *fftAdapter32) ImmutableScratchLen() int {
-	return f.inner.InplaceScratchLen()
+	return f.inner.ImmutableScratchLen()
 }
@@ -0,0 +1,112 @@
+package gofft
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/10d9e/gofft/algorithm"
+)
+
+// TestPlanner32MatchesDFT checks every recipe type on complex64 against a complex128 reference
+func TestPlanner32MatchesDFT(t *testing.T) {
+	sizes := []int{
+		// Butterflies
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 11, 12, 13, 16, 17, 19, 23, 24, 27, 29, 31, 32,
+		// Radix4
+		64, 128, 1024, 4096,
+		// RadixN
+		10, 30, 60, 120, 360,
+		// Rader's
+		37, 53, 97,
+		// Bluestein's
+		101, 1009, 1234,
+		// MixedRadix and GoodThomas
+		121, 352, 2431,
+	}
+
+	planner := NewPlanner32()
+
+	for _, n := range sizes {
+		t.Run("Size"+string(rune(n+'0')), func(t *testing.T) {
+			input := make([]complex128, n)
+			buffer := make([]complex64, n)
+			for i := range input {
+				input[i] = complex(math.Sin(float64(i)*0.3), math.Cos(float64(i)*0.7))
+				buffer[i] = complex64(input[i])
+			}
+
+			expected := naiveDFT(input, true)
+
+			fft := planner.PlanForward(n)
+			fft.Process(buffer)
+
+			// Scale tolerance with the magnitude of the spectrum
+			tolerance := 1e-4 * math.Sqrt(float64(n)) * math.Max(1, math.Log2(float64(n)))
+			for i := range buffer {
+				if err := cmplx.Abs(complex128(buffer[i]) - expected[i]); err > tolerance {
+					t.Fatalf("Size %d: [%d] got %v, want %v (error %.3e)", n, i, buffer[i], expected[i], err)
+				}
+			}
+		})
+	}
+}
+
+// TestPlanner32RoundTrip checks forward + inverse recovers the input
+func TestPlanner32RoundTrip(t *testing.T) {
+	sizes := []int{16, 60, 97, 256, 1000, 4096}
+
+	planner := NewPlanner32()
+
+	for _, n := range sizes {
+		t.Run("Size"+string(rune(n+'0')), func(t *testing.T) {
+			original := make([]complex64, n)
+			for i := range original {
+				original[i] = complex(float32(i%7), float32(i%5)*0.3)
+			}
+			buffer := make([]complex64, n)
+			copy(buffer, original)
+
+			planner.PlanForward(n).Process(buffer)
+			planner.PlanInverse(n).Process(buffer)
+
+			scale := 1 / float32(n)
+			for i := range buffer {
+				got := ComplexScale32(buffer[i], scale)
+				if cmplx.Abs(complex128(got-original[i])) > 1e-4 {
+					t.Fatalf("Size %d: [%d] got %v, want %v", n, i, got, original[i])
+				}
+			}
+		})
+	}
+}
+
+// TestPlanner32UsesFastAlgorithms makes sure Planner32 no longer falls back to the O(n²) DFT
+func TestPlanner32UsesFastAlgorithms(t *testing.T) {
+	planner := NewPlanner32()
+
+	for _, n := range []int{64, 4096, 60, 97, 1009} {
+		fft := planner.PlanForward(n).(*fftAdapter32)
+		if _, isDft := fft.inner.(*algorithm.Dft32); isDft {
+			t.Errorf("Size %d: Planner32 chose the naive DFT", n)
+		}
+	}
+}
+
+func BenchmarkFFT32(b *testing.B) {
+	sizes := []int{64, 256, 1024, 4096}
+
+	for _, n := range sizes {
+		b.Run("Size"+string(rune(n+'0')), func(b *testing.B) {
+			planner := NewPlanner32()
+			fft := planner.PlanForward(n)
+			buffer := make([]complex64, n)
+			scratch := make([]complex64, fft.InplaceScratchLen())
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fft.ProcessWithScratch(buffer, scratch)
+			}
+		})
+	}
+}
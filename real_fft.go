@@ -0,0 +1,369 @@
+package gofft
+
+import "sync"
+
+// RealToComplex computes forward FFTs of real-valued input.
+//
+// A real signal of length n has a Hermitian spectrum, so only the first
+// n/2+1 complex outputs are produced; the rest are their complex conjugates.
+type RealToComplex interface {
+	// Process computes the FFT of input into output.
+	// The input length must be a multiple of Len(), and the output length must be
+	// the same multiple of OutputLen().
+	// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+	Process(input []float64, output []complex128)
+
+	// ProcessWithScratch computes the FFT of input into output using the provided scratch buffer.
+	// The scratch buffer must have length >= ScratchLen().
+	// The contents of input are left unchanged.
+	ProcessWithScratch(input []float64, output, scratch []complex128)
+
+	// Len returns the length of the real input this instance processes
+	Len() int
+
+	// OutputLen returns the length of the complex half-spectrum, Len()/2+1
+	OutputLen() int
+
+	// Direction always returns Forward
+	Direction() Direction
+
+	// ScratchLen returns the required scratch buffer size for ProcessWithScratch
+	ScratchLen() int
+}
+
+// ComplexToReal computes inverse FFTs of a Hermitian half-spectrum back to real values.
+//
+// The imaginary parts of the first bin (and of the last bin for even lengths)
+// are ignored, since they are always zero in the spectrum of a real signal.
+// Like the other inverse FFTs in this package, the output is not normalized.
+type ComplexToReal interface {
+	// Process computes the inverse FFT of input into output.
+	// The input length must be a multiple of InputLen(), and the output length must be
+	// the same multiple of Len().
+	// Scratch space comes from a per-plan pool, so steady-state calls don't allocate.
+	Process(input []complex128, output []float64)
+
+	// ProcessWithScratch computes the inverse FFT of input into output using the provided scratch buffer.
+	// The scratch buffer must have length >= ScratchLen().
+	// The contents of input are destroyed.
+	ProcessWithScratch(input []complex128, output []float64, scratch []complex128)
+
+	// Len returns the length of the real output this instance produces
+	Len() int
+
+	// InputLen returns the length of the complex half-spectrum, Len()/2+1
+	InputLen() int
+
+	// Direction always returns Inverse
+	Direction() Direction
+
+	// ScratchLen returns the required scratch buffer size for ProcessWithScratch
+	ScratchLen() int
+}
+
+// RealFftPlanner creates real-to-complex and complex-to-real FFT instances
+// It plans the underlying complex FFTs with a Planner and caches created instances
+type RealFftPlanner struct {
+	mu           sync.Mutex
+	planner      *Planner
+	forwardCache map[int]RealToComplex
+	inverseCache map[int]ComplexToReal
+}
+
+// NewRealFftPlanner creates a new real FFT planner
+func NewRealFftPlanner() *RealFftPlanner {
+	return NewRealFftPlannerWith(NewPlanner())
+}
+
+// NewRealFftPlannerWith creates a real FFT planner that plans its complex FFTs with the given planner
+func NewRealFftPlannerWith(planner *Planner) *RealFftPlanner {
+	return &RealFftPlanner{
+		planner:      planner,
+		forwardCache: make(map[int]RealToComplex),
+		inverseCache: make(map[int]ComplexToReal),
+	}
+}
+
+// PlanForward creates an FFT instance for computing real-to-complex FFTs of the given size
+func (p *RealFftPlanner) PlanForward(length int) RealToComplex {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if fft, ok := p.forwardCache[length]; ok {
+		return fft
+	}
+
+	var fft RealToComplex
+	if length%2 == 0 {
+		fft = newRealToComplexEven(length, p.planner.PlanForward(length/2))
+	} else {
+		fft = &realToComplexOdd{inner: p.planner.PlanForward(length)}
+	}
+
+	p.forwardCache[length] = fft
+	return fft
+}
+
+// PlanInverse creates an FFT instance for computing complex-to-real FFTs of the given size
+func (p *RealFftPlanner) PlanInverse(length int) ComplexToReal {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if fft, ok := p.inverseCache[length]; ok {
+		return fft
+	}
+
+	var fft ComplexToReal
+	if length%2 == 0 {
+		fft = newComplexToRealEven(length, p.planner.PlanInverse(length/2))
+	} else {
+		fft = &complexToRealOdd{inner: p.planner.PlanInverse(length)}
+	}
+
+	p.inverseCache[length] = fft
+	return fft
+}
+
+// computeRealTwiddles precomputes the twiddles used to split a packed half-length
+// FFT into the spectrum of a real signal: exp(∓2πik/n) for k in [0, n/2)
+func computeRealTwiddles(length int, direction Direction) []complex128 {
+	twiddles := make([]complex128, length/2)
+	for k := range twiddles {
+		twiddles[k] = TwiddleFactor(k, length, direction)
+	}
+	return twiddles
+}
+
+// realToComplexEven computes real FFTs of even length n by packing pairs of
+// samples into a complex FFT of length n/2
+type realToComplexEven struct {
+	length   int
+	inner    Fft
+	twiddles []complex128
+	pool     scratchPool[complex128]
+}
+
+func newRealToComplexEven(length int, inner Fft) *realToComplexEven {
+	return &realToComplexEven{
+		length:   length,
+		inner:    inner,
+		twiddles: computeRealTwiddles(length, Forward),
+	}
+}
+
+func (r *realToComplexEven) Len() int             { return r.length }
+func (r *realToComplexEven) OutputLen() int       { return r.length/2 + 1 }
+func (r *realToComplexEven) Direction() Direction { return Forward }
+func (r *realToComplexEven) ScratchLen() int      { return r.inner.InplaceScratchLen() }
+
+func (r *realToComplexEven) Process(input []float64, output []complex128) {
+	scratch := r.pool.get(r.ScratchLen())
+	r.ProcessWithScratch(input, output, *scratch)
+	r.pool.put(scratch)
+}
+
+func (r *realToComplexEven) ProcessWithScratch(input []float64, output, scratch []complex128) {
+	validateRealToComplex(len(input), r.length, len(output), r.OutputLen(), len(scratch), r.ScratchLen())
+
+	outLen := r.OutputLen()
+	for i, o := 0, 0; i < len(input); i, o = i+r.length, o+outLen {
+		r.processOne(input[i:i+r.length], output[o:o+outLen], scratch)
+	}
+}
+
+func (r *realToComplexEven) processOne(input []float64, output, scratch []complex128) {
+	half := r.length / 2
+
+	// Pack even samples into the real parts and odd samples into the imaginary parts
+	for k := 0; k < half; k++ {
+		output[k] = complex(input[2*k], input[2*k+1])
+	}
+
+	r.processPacked(output, scratch)
+}
+
+// processPacked computes the half-spectrum into output from samples that are
+// already packed into output[:n/2], even ones in the real parts
+func (r *realToComplexEven) processPacked(output, scratch []complex128) {
+	half := r.length / 2
+
+	r.inner.ProcessWithScratch(output[:half], scratch)
+
+	// Split the packed spectrum Z into the spectra of the even and odd samples,
+	// E[k] = (Z[k] + conj(Z[half-k])) / 2 and O[k] = (Z[k] - conj(Z[half-k])) / 2i,
+	// then combine them as X[k] = E[k] + W^k * O[k]
+	z0 := output[0]
+	output[0] = complex(real(z0)+imag(z0), 0)
+	output[half] = complex(real(z0)-imag(z0), 0)
+
+	for k := 1; k <= half/2; k++ {
+		zk := output[k]
+		zn := ComplexConj(output[half-k])
+
+		even := (zk + zn) * 0.5
+		odd := (zk - zn) * complex(0, -0.5)
+		output[k] = even + r.twiddles[k]*odd
+
+		if k != half-k {
+			// Mirrored bin: E[half-k] = conj(E[k]) and O[half-k] = conj(O[k])
+			output[half-k] = ComplexConj(even) + r.twiddles[half-k]*ComplexConj(odd)
+		}
+	}
+}
+
+// realToComplexOdd computes real FFTs of odd length with a full complex FFT
+type realToComplexOdd struct {
+	inner Fft
+	pool  scratchPool[complex128]
+}
+
+func (r *realToComplexOdd) Len() int             { return r.inner.Len() }
+func (r *realToComplexOdd) OutputLen() int       { return r.inner.Len()/2 + 1 }
+func (r *realToComplexOdd) Direction() Direction { return Forward }
+func (r *realToComplexOdd) ScratchLen() int      { return r.inner.Len() + r.inner.InplaceScratchLen() }
+
+func (r *realToComplexOdd) Process(input []float64, output []complex128) {
+	scratch := r.pool.get(r.ScratchLen())
+	r.ProcessWithScratch(input, output, *scratch)
+	r.pool.put(scratch)
+}
+
+func (r *realToComplexOdd) ProcessWithScratch(input []float64, output, scratch []complex128) {
+	length := r.Len()
+	outLen := r.OutputLen()
+	validateRealToComplex(len(input), length, len(output), outLen, len(scratch), r.ScratchLen())
+
+	buffer := scratch[:length]
+	innerScratch := scratch[length:]
+
+	for i, o := 0, 0; i < len(input); i, o = i+length, o+outLen {
+		for k, x := range input[i : i+length] {
+			buffer[k] = complex(x, 0)
+		}
+		r.inner.ProcessWithScratch(buffer, innerScratch)
+		copy(output[o:o+outLen], buffer)
+	}
+}
+
+// complexToRealEven computes inverse real FFTs of even length n with a complex FFT of length n/2
+type complexToRealEven struct {
+	length   int
+	inner    Fft
+	twiddles []complex128
+	pool     scratchPool[complex128]
+}
+
+func newComplexToRealEven(length int, inner Fft) *complexToRealEven {
+	return &complexToRealEven{
+		length:   length,
+		inner:    inner,
+		twiddles: computeRealTwiddles(length, Inverse),
+	}
+}
+
+func (c *complexToRealEven) Len() int             { return c.length }
+func (c *complexToRealEven) InputLen() int        { return c.length/2 + 1 }
+func (c *complexToRealEven) Direction() Direction { return Inverse }
+func (c *complexToRealEven) ScratchLen() int      { return c.inner.InplaceScratchLen() }
+
+func (c *complexToRealEven) Process(input []complex128, output []float64) {
+	scratch := c.pool.get(c.ScratchLen())
+	c.ProcessWithScratch(input, output, *scratch)
+	c.pool.put(scratch)
+}
+
+func (c *complexToRealEven) ProcessWithScratch(input []complex128, output []float64, scratch []complex128) {
+	validateComplexToReal(len(input), c.InputLen(), len(output), c.length, len(scratch), c.ScratchLen())
+
+	inLen := c.InputLen()
+	for i, o := 0, 0; i < len(input); i, o = i+inLen, o+c.length {
+		c.processOne(input[i:i+inLen], output[o:o+c.length], scratch)
+	}
+}
+
+func (c *complexToRealEven) processOne(input []complex128, output []float64, scratch []complex128) {
+	half := c.length / 2
+
+	c.processPacked(input, scratch)
+
+	// Unpack even samples from the real parts and odd samples from the imaginary parts
+	for k := 0; k < half; k++ {
+		output[2*k] = real(input[k])
+		output[2*k+1] = imag(input[k])
+	}
+}
+
+// processPacked computes the inverse FFT of the half-spectrum in input, leaving
+// the real output packed into input[:n/2], even samples in the real parts
+func (c *complexToRealEven) processPacked(input, scratch []complex128) {
+	half := c.length / 2
+
+	// Rebuild the packed spectrum Z[k] = E[k] + i*O[k] in place, where
+	// E[k] = X[k] + conj(X[half-k]) and O[k] = (X[k] - conj(X[half-k])) * W^-k.
+	// Both are twice their forward counterparts, which makes the output
+	// match an unnormalized inverse FFT of length n.
+	x0 := real(input[0])
+	xn := real(input[half])
+	input[0] = complex(x0+xn, x0-xn)
+
+	for k := 1; k <= half/2; k++ {
+		xk := input[k]
+		xm := ComplexConj(input[half-k])
+
+		even := xk + xm
+		odd := (xk - xm) * c.twiddles[k]
+		input[k] = even + complex(-imag(odd), real(odd))
+
+		if k != half-k {
+			// Mirrored bin: E[half-k] = conj(E[k]) and O[half-k] = conj(O[k])
+			even = ComplexConj(even)
+			odd = ComplexConj(odd)
+			input[half-k] = even + complex(-imag(odd), real(odd))
+		}
+	}
+
+	c.inner.ProcessWithScratch(input[:half], scratch)
+}
+
+// complexToRealOdd computes inverse real FFTs of odd length with a full complex FFT
+type complexToRealOdd struct {
+	inner Fft
+	pool  scratchPool[complex128]
+}
+
+func (c *complexToRealOdd) Len() int             { return c.inner.Len() }
+func (c *complexToRealOdd) InputLen() int        { return c.inner.Len()/2 + 1 }
+func (c *complexToRealOdd) Direction() Direction { return Inverse }
+func (c *complexToRealOdd) ScratchLen() int      { return c.inner.Len() + c.inner.InplaceScratchLen() }
+
+func (c *complexToRealOdd) Process(input []complex128, output []float64) {
+	scratch := c.pool.get(c.ScratchLen())
+	c.ProcessWithScratch(input, output, *scratch)
+	c.pool.put(scratch)
+}
+
+func (c *complexToRealOdd) ProcessWithScratch(input []complex128, output []float64, scratch []complex128) {
+	length := c.Len()
+	inLen := c.InputLen()
+	validateComplexToReal(len(input), inLen, len(output), length, len(scratch), c.ScratchLen())
+
+	buffer := scratch[:length]
+	innerScratch := scratch[length:]
+
+	for i, o := 0, 0; i < len(input); i, o = i+inLen, o+length {
+		spectrum := input[i : i+inLen]
+
+		// Expand the half-spectrum using Hermitian symmetry
+		buffer[0] = complex(real(spectrum[0]), 0)
+		for k := 1; k < inLen; k++ {
+			buffer[k] = spectrum[k]
+			buffer[length-k] = ComplexConj(spectrum[k])
+		}
+
+		c.inner.ProcessWithScratch(buffer, innerScratch)
+
+		for k := range output[o : o+length] {
+			output[o+k] = real(buffer[k])
+		}
+	}
+}
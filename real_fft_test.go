@@ -0,0 +1,193 @@
+package gofft
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestRealFftForward compares the real FFT half-spectrum against a naive DFT
+func TestRealFftForward(t *testing.T) {
+	sizes := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 12, 15, 16, 30, 31, 64, 97, 100, 128, 1000, 1024}
+
+	planner := NewRealFftPlanner()
+
+	for _, n := range sizes {
+		t.Run("Size"+string(rune(n+'0')), func(t *testing.T) {
+			input := make([]float64, n)
+			complexInput := make([]complex128, n)
+			for i := range input {
+				input[i] = math.Sin(float64(i)*0.7) + float64(i%3)
+				complexInput[i] = complex(input[i], 0)
+			}
+			original := make([]float64, n)
+			copy(original, input)
+
+			expected := naiveDFT(complexInput, true)
+
+			fft := planner.PlanForward(n)
+			if fft.OutputLen() != n/2+1 {
+				t.Fatalf("OutputLen = %d, want %d", fft.OutputLen(), n/2+1)
+			}
+			output := make([]complex128, fft.OutputLen())
+			scratch := make([]complex128, fft.ScratchLen())
+			fft.ProcessWithScratch(input, output, scratch)
+
+			if !complexSlicesEqual(output, expected[:n/2+1], 1e-9*float64(n)) {
+				for i := range output {
+					if cmplx.Abs(output[i]-expected[i]) > 1e-9*float64(n) {
+						t.Errorf("  [%d] got %v, want %v", i, output[i], expected[i])
+					}
+				}
+			}
+
+			for i := range input {
+				if input[i] != original[i] {
+					t.Fatalf("Input was modified at [%d]", i)
+				}
+			}
+		})
+	}
+}
+
+// TestRealFftRoundTrip checks forward + inverse recovers the input
+func TestRealFftRoundTrip(t *testing.T) {
+	sizes := []int{1, 2, 3, 4, 6, 9, 10, 17, 32, 63, 64, 250, 1001, 4096}
+
+	planner := NewRealFftPlanner()
+
+	for _, n := range sizes {
+		t.Run("Size"+string(rune(n+'0')), func(t *testing.T) {
+			input := make([]float64, n)
+			for i := range input {
+				input[i] = math.Cos(float64(i)*1.3) * float64(i%5)
+			}
+
+			forward := planner.PlanForward(n)
+			inverse := planner.PlanInverse(n)
+			if inverse.InputLen() != forward.OutputLen() {
+				t.Fatalf("InputLen = %d, want %d", inverse.InputLen(), forward.OutputLen())
+			}
+
+			spectrum := make([]complex128, forward.OutputLen())
+			forward.Process(input, spectrum)
+
+			output := make([]float64, n)
+			inverse.Process(spectrum, output)
+
+			for i := range output {
+				got := output[i] / float64(n)
+				if math.Abs(got-input[i]) > 1e-10 {
+					t.Errorf("  [%d] got %v, want %v", i, got, input[i])
+				}
+			}
+		})
+	}
+}
+
+// TestRealFftInverseMatchesComplex checks the inverse real FFT against a full complex inverse FFT
+func TestRealFftInverseMatchesComplex(t *testing.T) {
+	for _, n := range []int{8, 12, 15, 100} {
+		// Build a Hermitian spectrum
+		half := make([]complex128, n/2+1)
+		full := make([]complex128, n)
+		for k := range half {
+			half[k] = complex(float64(k%4), float64(k%3)-1)
+		}
+		half[0] = complex(real(half[0]), 0)
+		if n%2 == 0 {
+			half[n/2] = complex(real(half[n/2]), 0)
+		}
+		for k := range half {
+			full[k] = half[k]
+			if k > 0 {
+				full[n-k] = cmplx.Conj(half[k])
+			}
+		}
+
+		expected := naiveDFT(full, false)
+
+		output := make([]float64, n)
+		NewRealFftPlanner().PlanInverse(n).Process(half, output)
+
+		for i := range output {
+			if math.Abs(output[i]-real(expected[i])) > 1e-9 {
+				t.Errorf("Size %d: [%d] got %v, want %v", n, i, output[i], real(expected[i]))
+			}
+		}
+	}
+}
+
+// TestRealFftBatched checks that multiple transforms can be processed in one call
+func TestRealFftBatched(t *testing.T) {
+	for _, n := range []int{16, 15} {
+		const batches = 3
+		planner := NewRealFftPlanner()
+		forward := planner.PlanForward(n)
+		outLen := forward.OutputLen()
+
+		input := make([]float64, n*batches)
+		for i := range input {
+			input[i] = float64((i * 7) % 11)
+		}
+
+		batched := make([]complex128, outLen*batches)
+		forward.Process(input, batched)
+
+		for b := 0; b < batches; b++ {
+			single := make([]complex128, outLen)
+			forward.Process(input[b*n:(b+1)*n], single)
+			if !complexSlicesEqual(single, batched[b*outLen:(b+1)*outLen], 1e-12) {
+				t.Errorf("Size %d: batch %d differs from a single transform", n, b)
+			}
+		}
+
+		roundTrip := make([]float64, n*batches)
+		planner.PlanInverse(n).Process(batched, roundTrip)
+		for i := range roundTrip {
+			if math.Abs(roundTrip[i]/float64(n)-input[i]) > 1e-10 {
+				t.Fatalf("Size %d: batched round trip failed at [%d]", n, i)
+			}
+		}
+	}
+}
+
+func TestRealFftZeroAllocations(t *testing.T) {
+	planner := NewRealFftPlanner()
+	for _, n := range []int{64, 63} {
+		forward := planner.PlanForward(n)
+		inverse := planner.PlanInverse(n)
+		signal := make([]float64, n)
+		spectrum := make([]complex128, forward.OutputLen())
+
+		// Warm up the plans' scratch pools
+		forward.Process(signal, spectrum)
+		inverse.Process(spectrum, signal)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			forward.Process(signal, spectrum)
+			inverse.Process(spectrum, signal)
+		})
+		if allocs != 0 {
+			t.Errorf("size %d: Process allocated %.0f times per run", n, allocs)
+		}
+	}
+}
+
+func BenchmarkRealFft(b *testing.B) {
+	sizes := []int{256, 1024, 4096}
+
+	for _, n := range sizes {
+		b.Run("Size"+string(rune(n+'0')), func(b *testing.B) {
+			fft := NewRealFftPlanner().PlanForward(n)
+			input := make([]float64, n)
+			output := make([]complex128, fft.OutputLen())
+			scratch := make([]complex128, fft.ScratchLen())
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fft.ProcessWithScratch(input, output, scratch)
+			}
+		})
+	}
+}
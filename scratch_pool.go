@@ -0,0 +1,26 @@
+package gofft
+
+import "sync"
+
+// scratchPool recycles the scratch buffers that Process allocates, so the
+// convenience API doesn't allocate once a plan has warmed up.
+//
+// Each plan owns its own pool, so all buffers in it have the same length.
+// The zero value is ready to use.
+type scratchPool[T complex64 | complex128] struct {
+	pool sync.Pool
+}
+
+// get returns a scratch buffer of length n, reusing a pooled one if available
+func (p *scratchPool[T]) get(n int) *[]T {
+	if s, ok := p.pool.Get().(*[]T); ok {
+		return s
+	}
+	s := make([]T, n)
+	return &s
+}
+
+// put returns a buffer obtained from get to the pool
+func (p *scratchPool[T]) put(s *[]T) {
+	p.pool.Put(s)
+}
@@ -0,0 +1,235 @@
+// Package stft computes short-time Fourier transforms of real signals and
+// reconstructs signals from them.
+//
+// Frames are windowed and transformed with real FFTs from a gofft
+// RealFftPlanner, all frames of a signal in one batched call. The inverse
+// overlap-adds the frames and divides by the summed squared window, which
+// reconstructs the signal exactly for windows satisfying the COLA condition.
+package stft
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/10d9e/gofft"
+)
+
+// PadMode selects how the signal is extended before framing
+type PadMode int
+
+const (
+	// PadNone frames the signal as is. Frame f starts at sample f*hop, and
+	// trailing samples that don't fill a whole frame are left out.
+	PadNone PadMode = iota
+
+	// PadZero centers frame f on sample f*hop by padding frameLen/2 zeros on
+	// both sides, so every sample is covered by as many frames as any other
+	PadZero
+
+	// PadReflect centers frames like PadZero but pads with the signal
+	// reflected about its first and last samples
+	PadReflect
+)
+
+// STFT computes the short-time Fourier transform for one frame length, hop
+// length, window and padding mode
+//
+// A spectrogram is stored frame by frame: frame f occupies
+// [f*Bins(), (f+1)*Bins()) and holds the non-negative frequency bins of
+// that frame. An STFT is safe for concurrent use.
+type STFT struct {
+	frameLen  int
+	hopLen    int
+	window    []float64
+	synthesis []float64 // window / frameLen, so the inverse FFT needs no separate scaling
+	pad       PadMode
+	forward   gofft.RealToComplex
+	inverse   gofft.ComplexToReal
+}
+
+// New creates an STFT with its own FFT planner
+// A nil window is rectangular; otherwise it must have frameLen samples.
+func New(frameLen, hopLen int, window []float64, pad PadMode) *STFT {
+	return NewWithPlanner(gofft.NewRealFftPlanner(), frameLen, hopLen, window, pad)
+}
+
+// NewWithPlanner creates an STFT that plans its FFTs with the given planner
+func NewWithPlanner(planner *gofft.RealFftPlanner, frameLen, hopLen int, window []float64, pad PadMode) *STFT {
+	if frameLen < 1 || hopLen < 1 {
+		panic(fmt.Sprintf("STFT frame and hop lengths must be positive, got %d and %d", frameLen, hopLen))
+	}
+	if window == nil {
+		window = make([]float64, frameLen)
+		for i := range window {
+			window[i] = 1
+		}
+	}
+	if len(window) != frameLen {
+		panic(fmt.Sprintf("STFT window has %d samples, want the frame length %d", len(window), frameLen))
+	}
+	if pad != PadNone && pad != PadZero && pad != PadReflect {
+		panic(fmt.Sprintf("unknown STFT padding mode %d", pad))
+	}
+
+	s := &STFT{
+		frameLen:  frameLen,
+		hopLen:    hopLen,
+		window:    append([]float64(nil), window...),
+		synthesis: make([]float64, frameLen),
+		pad:       pad,
+		forward:   planner.PlanForward(frameLen),
+		inverse:   planner.PlanInverse(frameLen),
+	}
+	for i, w := range window {
+		s.synthesis[i] = w / float64(frameLen)
+	}
+	return s
+}
+
+// FrameLen returns the number of samples in each frame
+func (s *STFT) FrameLen() int { return s.frameLen }
+
+// HopLen returns the number of samples between the starts of consecutive frames
+func (s *STFT) HopLen() int { return s.hopLen }
+
+// Bins returns the number of frequency bins per frame, FrameLen()/2+1
+func (s *STFT) Bins() int { return s.frameLen/2 + 1 }
+
+// NumFrames returns the number of frames in the STFT of a signal of the given length
+func (s *STFT) NumFrames(signalLen int) int {
+	padded := signalLen + 2*s.padLen()
+	if signalLen < 1 || padded < s.frameLen {
+		return 0
+	}
+	return 1 + (padded-s.frameLen)/s.hopLen
+}
+
+// padLen returns the number of samples added to each side of the signal
+func (s *STFT) padLen() int {
+	if s.pad == PadNone {
+		return 0
+	}
+	return s.frameLen / 2
+}
+
+// Forward returns the spectrogram of signal
+func (s *STFT) Forward(signal []float64) []complex128 {
+	spectrogram := make([]complex128, s.NumFrames(len(signal))*s.Bins())
+	s.ForwardInto(signal, spectrogram)
+	return spectrogram
+}
+
+// ForwardInto computes the spectrogram of signal into spectrogram, which
+// must hold NumFrames(len(signal))*Bins() values
+func (s *STFT) ForwardInto(signal []float64, spectrogram []complex128) {
+	frames := s.NumFrames(len(signal))
+	if len(spectrogram) != frames*s.Bins() {
+		panic(fmt.Errorf("%w: expected spectrogram len = %d, got len = %d", gofft.ErrLengthMismatch, frames*s.Bins(), len(spectrogram)))
+	}
+	if frames == 0 {
+		return
+	}
+
+	// Window every frame into one buffer, so a single batched call transforms them all
+	buffer := make([]float64, frames*s.frameLen)
+	padLen := s.padLen()
+	for f := 0; f < frames; f++ {
+		frame := buffer[f*s.frameLen : (f+1)*s.frameLen]
+		start := f*s.hopLen - padLen
+		for i, w := range s.window {
+			frame[i] = w * s.sample(signal, start+i)
+		}
+	}
+	s.forward.Process(buffer, spectrogram)
+}
+
+// sample returns signal[i], extended past either end according to the padding mode
+func (s *STFT) sample(signal []float64, i int) float64 {
+	switch {
+	case i >= 0 && i < len(signal):
+		return signal[i]
+	case s.pad != PadReflect:
+		return 0
+	case len(signal) == 1:
+		return signal[0]
+	}
+
+	// Reflect about the end samples without repeating them, as numpy.pad does
+	period := 2 * (len(signal) - 1)
+	i %= period
+	if i < 0 {
+		i += period
+	}
+	if i >= len(signal) {
+		i = period - i
+	}
+	return signal[i]
+}
+
+// Inverse reconstructs a signal of length signalLen from its spectrogram
+// The frames are overlap-added with the window applied again, then divided by
+// the sum of the squared windows covering each sample. Samples that no frame
+// covers, or that only window zeros cover, come out as zero.
+func (s *STFT) Inverse(spectrogram []complex128, signalLen int) []float64 {
+	frames := s.NumFrames(signalLen)
+	if len(spectrogram) != frames*s.Bins() {
+		panic(fmt.Errorf("%w: expected spectrogram len = %d, got len = %d", gofft.ErrLengthMismatch, frames*s.Bins(), len(spectrogram)))
+	}
+	signal := make([]float64, signalLen)
+	if frames == 0 {
+		return signal
+	}
+
+	// The inverse FFT destroys its input, so work on a copy
+	spectra := append([]complex128(nil), spectrogram...)
+	buffer := make([]float64, frames*s.frameLen)
+	s.inverse.Process(spectra, buffer)
+
+	padLen := s.padLen()
+	padded := make([]float64, (frames-1)*s.hopLen+s.frameLen)
+	windowSum := make([]float64, len(padded))
+	for f := 0; f < frames; f++ {
+		frame := buffer[f*s.frameLen : (f+1)*s.frameLen]
+		start := f * s.hopLen
+		for i, w := range s.synthesis {
+			padded[start+i] += w * frame[i]
+			windowSum[start+i] += s.window[i] * s.window[i]
+		}
+	}
+
+	// Ignore sums that are zero up to rounding, rather than amplifying noise
+	threshold := 0.0
+	for _, w := range s.window {
+		threshold = math.Max(threshold, w*w)
+	}
+	threshold *= 1e-10
+
+	for i := range signal {
+		j := i + padLen
+		if j < len(padded) && windowSum[j] > threshold {
+			signal[i] = padded[j] / windowSum[j]
+		}
+	}
+	return signal
+}
+
+// IsCOLA reports whether window satisfies the constant overlap-add condition
+// for the given hop: copies of it shifted by multiples of hopLen sum to the
+// same nonzero value at every sample
+func IsCOLA(window []float64, hopLen int) bool {
+	if len(window) == 0 || hopLen < 1 {
+		return false
+	}
+	sums := make([]float64, hopLen)
+	maxAbs := 0.0
+	for i, w := range window {
+		sums[i%hopLen] += w
+		maxAbs = math.Max(maxAbs, math.Abs(w))
+	}
+	for _, sum := range sums[1:] {
+		if math.Abs(sum-sums[0]) > 1e-10*maxAbs*float64(len(window)) {
+			return false
+		}
+	}
+	return sums[0] != 0
+}
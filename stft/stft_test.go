@@ -0,0 +1,159 @@
+package stft
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// periodicHann returns a periodic Hann window, which is COLA at hops of len/2 and len/4
+func periodicHann(n int) []float64 {
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+	}
+	return w
+}
+
+func testSignal(n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Sin(float64(i)*0.05) + 0.25*math.Cos(float64(i)*0.91) + float64(i%7)*0.01
+	}
+	return x
+}
+
+func TestForwardMatchesFrameDFT(t *testing.T) {
+	for _, pad := range []PadMode{PadNone, PadZero, PadReflect} {
+		s := New(64, 16, periodicHann(64), pad)
+		signal := testSignal(300)
+		spectrogram := s.Forward(signal)
+		if len(spectrogram) != s.NumFrames(len(signal))*s.Bins() {
+			t.Fatalf("pad %d: got %d values for %d frames", pad, len(spectrogram), s.NumFrames(len(signal)))
+		}
+
+		for _, f := range []int{0, 1, s.NumFrames(len(signal)) - 1} {
+			frame := make([]float64, 64)
+			for i := range frame {
+				frame[i] = s.window[i] * s.sample(signal, f*16-s.padLen()+i)
+			}
+			for k := 0; k < s.Bins(); k++ {
+				var want complex128
+				for i, x := range frame {
+					want += complex(x, 0) * cmplx.Exp(complex(0, -2*math.Pi*float64(i*k)/64))
+				}
+				if got := spectrogram[f*s.Bins()+k]; cmplx.Abs(got-want) > 1e-9 {
+					t.Fatalf("pad %d, frame %d, bin %d: got %v, want %v", pad, f, k, got, want)
+				}
+			}
+		}
+	}
+}
+
+// TestPerfectReconstruction checks that Inverse undoes Forward for COLA windows
+func TestPerfectReconstruction(t *testing.T) {
+	tests := []struct {
+		frameLen, hopLen int
+		window           []float64
+	}{
+		{512, 128, periodicHann(512)},
+		{512, 256, periodicHann(512)},
+		{256, 64, periodicHann(256)},
+		{100, 25, periodicHann(100)},
+		{64, 64, nil},
+		{63, 21, nil},
+	}
+
+	for _, tt := range tests {
+		for _, pad := range []PadMode{PadZero, PadReflect} {
+			t.Run(fmt.Sprintf("Frame%d/Hop%d/Pad%d", tt.frameLen, tt.hopLen, pad), func(t *testing.T) {
+				s := New(tt.frameLen, tt.hopLen, tt.window, pad)
+				for _, n := range []int{5000, 4097, tt.frameLen} {
+					signal := testSignal(n)
+					got := s.Inverse(s.Forward(signal), n)
+					for i := range got {
+						if math.Abs(got[i]-signal[i]) > 1e-9 {
+							t.Fatalf("length %d: [%d] got %v, want %v", n, i, got[i], signal[i])
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestReconstructionWithoutPadding only covers samples inside whole frames
+func TestReconstructionWithoutPadding(t *testing.T) {
+	s := New(128, 32, periodicHann(128), PadNone)
+	signal := testSignal(1000)
+	got := s.Inverse(s.Forward(signal), len(signal))
+
+	// 28 frames cover [0, 27*32+128) = [0, 992), but Hann is zero at sample 0
+	covered := (s.NumFrames(len(signal))-1)*32 + 128
+	if covered != 992 {
+		t.Fatalf("frames cover %d samples, want 992", covered)
+	}
+	if got[0] != 0 {
+		t.Errorf("sample 0 is only covered by a window zero, got %v", got[0])
+	}
+	for i := 1; i < len(signal); i++ {
+		want := signal[i]
+		if i >= covered {
+			want = 0
+		}
+		if math.Abs(got[i]-want) > 1e-9 {
+			t.Fatalf("[%d] got %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+func TestNumFrames(t *testing.T) {
+	tests := []struct {
+		pad                  PadMode
+		signalLen, numFrames int
+	}{
+		{PadNone, 1000, 1 + (1000-256)/64},
+		{PadNone, 255, 0},
+		{PadZero, 1000, 1 + 1000/64},
+		{PadReflect, 1, 1},
+		{PadZero, 0, 0},
+	}
+	for _, tt := range tests {
+		s := New(256, 64, nil, tt.pad)
+		if got := s.NumFrames(tt.signalLen); got != tt.numFrames {
+			t.Errorf("pad %d, length %d: got %d frames, want %d", tt.pad, tt.signalLen, got, tt.numFrames)
+		}
+	}
+}
+
+func TestReflectPadding(t *testing.T) {
+	s := New(8, 4, nil, PadReflect)
+	signal := []float64{1, 2, 3, 4, 5}
+	want := map[int]float64{-3: 4, -2: 3, -1: 2, 5: 4, 6: 3, 7: 2}
+	for i, v := range want {
+		if got := s.sample(signal, i); got != v {
+			t.Errorf("sample %d: got %v, want %v", i, got, v)
+		}
+	}
+}
+
+func TestIsCOLA(t *testing.T) {
+	tests := []struct {
+		window []float64
+		hop    int
+		want   bool
+	}{
+		{periodicHann(512), 256, true},
+		{periodicHann(512), 128, true},
+		{periodicHann(512), 200, false},
+		{[]float64{1, 1, 1, 1}, 4, true},
+		{[]float64{1, 1, 1, 1}, 3, false},
+		{nil, 1, false},
+	}
+	for _, tt := range tests {
+		if got := IsCOLA(tt.window, tt.hop); got != tt.want {
+			t.Errorf("window of %d, hop %d: got %v, want %v", len(tt.window), tt.hop, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gofft
+
+import "fmt"
+
+// StreamingMethod selects how a StreamingConvolver splits a stream into FFT blocks
+type StreamingMethod int
+
+const (
+	// OverlapSave keeps the last FFT-length input samples and discards the
+	// outputs that wrapped around. It needs no output accumulator, which makes
+	// it the cheaper of the two.
+	OverlapSave StreamingMethod = iota
+
+	// OverlapAdd convolves each zero-padded block on its own and adds the
+	// tail that spills past the block onto the following outputs
+	OverlapAdd
+)
+
+// streamingMinFftLen keeps the FFTs for short kernels large enough to amortize their overhead
+const streamingMinFftLen = 128
+
+// StreamingConvolver filters an unbounded real stream with a fixed FIR kernel
+//
+// Each Process call consumes a block of input and produces the same number of
+// outputs, with no added latency: output i of the stream is the convolution
+// of the kernel with the inputs up to i. Blocks may have any length, but
+// blocks of HopLen samples make the best use of each FFT.
+//
+// A StreamingConvolver carries state between calls, so unlike the FFTs it is
+// not safe for concurrent use. It never allocates after it is planned.
+type StreamingConvolver struct {
+	method         StreamingMethod
+	kernelLen      int
+	fftLen         int
+	hopLen         int
+	forward        RealToComplex
+	inverse        ComplexToReal
+	kernelSpectrum []complex128
+
+	history  []float64 // OverlapSave: the last fftLen inputs, oldest first
+	overlap  []float64 // OverlapAdd: outputs still waiting for later tails
+	block    []float64
+	spectrum []complex128
+	scratch  []complex128
+}
+
+// PlanStreamingConvolver creates a StreamingConvolver for kernel
+// The FFT length is chosen from the kernel length, trading the cost of each
+// FFT against the number of outputs it yields. Each call returns a new
+// StreamingConvolver with its own state; only the FFTs are shared.
+func (p *RealFftPlanner) PlanStreamingConvolver(kernel []float64, method StreamingMethod) *StreamingConvolver {
+	if len(kernel) == 0 {
+		panic("streaming convolution needs a non-empty kernel")
+	}
+	if method != OverlapSave && method != OverlapAdd {
+		panic(fmt.Sprintf("unknown streaming method %d", method))
+	}
+
+	// An FFT of about four times the kernel length keeps the cost per output
+	// close to its minimum while bounding the work done per block
+	fftLen := fastConvolutionLen(max(4*len(kernel), streamingMinFftLen))
+	c := &StreamingConvolver{
+		method:    method,
+		kernelLen: len(kernel),
+		fftLen:    fftLen,
+		hopLen:    fftLen - len(kernel) + 1,
+		forward:   p.PlanForward(fftLen),
+		inverse:   p.PlanInverse(fftLen),
+		block:     make([]float64, fftLen),
+		spectrum:  make([]complex128, fftLen/2+1),
+	}
+	c.kernelSpectrum = p.realKernelSpectrum(kernel, convolveShape{fftLen: fftLen}, false)
+	c.scratch = make([]complex128, max(c.forward.ScratchLen(), c.inverse.ScratchLen()))
+	if method == OverlapSave {
+		c.history = make([]float64, fftLen)
+	} else {
+		c.overlap = make([]float64, fftLen)
+	}
+	return c
+}
+
+// KernelLen returns the length of the kernel
+func (c *StreamingConvolver) KernelLen() int { return c.kernelLen }
+
+// FftLen returns the length of the real FFTs each block runs on
+func (c *StreamingConvolver) FftLen() int { return c.fftLen }
+
+// HopLen returns the largest number of samples one FFT can process
+// Longer blocks are split into hops; shorter ones still cost a full FFT.
+func (c *StreamingConvolver) HopLen() int { return c.hopLen }
+
+// Process filters the next block of the stream from in into out
+// in and out must have the same length, which may differ between calls.
+func (c *StreamingConvolver) Process(in, out []float64) {
+	if len(in) != len(out) {
+		panic(fmt.Errorf("%w: got input len = %d, output len = %d", ErrLengthMismatch, len(in), len(out)))
+	}
+
+	for start := 0; start < len(in); start += c.hopLen {
+		end := min(start+c.hopLen, len(in))
+		if c.method == OverlapSave {
+			c.overlapSave(in[start:end], out[start:end])
+		} else {
+			c.overlapAdd(in[start:end], out[start:end])
+		}
+	}
+}
+
+// Reset clears the stream history, as if no input had been processed
+func (c *StreamingConvolver) Reset() {
+	clear(c.history)
+	clear(c.overlap)
+}
+
+// overlapSave filters one hop of at most hopLen samples
+// The circular convolution of the history wraps the last kernelLen-1 outputs
+// onto the first ones, so the newest hopLen outputs are exact.
+func (c *StreamingConvolver) overlapSave(in, out []float64) {
+	n := len(in)
+	copy(c.history, c.history[n:])
+	copy(c.history[c.fftLen-n:], in)
+
+	c.forward.ProcessWithScratch(c.history, c.spectrum, c.scratch)
+	multiplySpectrum(c.spectrum, c.kernelSpectrum)
+	c.inverse.ProcessWithScratch(c.spectrum, c.block, c.scratch)
+
+	copy(out, c.block[c.fftLen-n:])
+}
+
+// overlapAdd filters one hop of at most hopLen samples
+// The hop and its tail fit in one FFT without wrapping around.
+func (c *StreamingConvolver) overlapAdd(in, out []float64) {
+	n := len(in)
+	copy(c.block, in)
+	clear(c.block[n:])
+
+	c.forward.ProcessWithScratch(c.block, c.spectrum, c.scratch)
+	multiplySpectrum(c.spectrum, c.kernelSpectrum)
+	c.inverse.ProcessWithScratch(c.spectrum, c.block, c.scratch)
+
+	for i, v := range c.block[:n+c.kernelLen-1] {
+		c.overlap[i] += v
+	}
+	copy(out, c.overlap[:n])
+	copy(c.overlap, c.overlap[n:])
+	clear(c.overlap[c.fftLen-n:])
+}
@@ -0,0 +1,84 @@
+package gofft
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestStreamingConvolverMatchesDirect feeds a stream through in blocks of
+// changing sizes and compares it with the direct convolution of the whole stream
+func TestStreamingConvolverMatchesDirect(t *testing.T) {
+	planner := NewRealFftPlanner()
+	methods := map[string]StreamingMethod{"OverlapSave": OverlapSave, "OverlapAdd": OverlapAdd}
+	for name, method := range methods {
+		for _, kernelLen := range []int{1, 17, 300, 1000} {
+			t.Run(fmt.Sprintf("%s/Kernel%d", name, kernelLen), func(t *testing.T) {
+				kernel := make([]float64, kernelLen)
+				for i := range kernel {
+					kernel[i] = math.Exp(-float64(i)/50) * math.Cos(float64(i)*0.3)
+				}
+				c := planner.PlanStreamingConvolver(kernel, method)
+				if c.HopLen() != c.FftLen()-kernelLen+1 || c.HopLen() < 1 {
+					t.Fatalf("hop %d doesn't fit FFT length %d", c.HopLen(), c.FftLen())
+				}
+
+				stream := make([]float64, 6000)
+				for i := range stream {
+					stream[i] = math.Sin(float64(i)*0.05) + 0.3*math.Sin(float64(i)*1.7)
+				}
+				expected := make([]float64, len(stream))
+				for i := range expected {
+					for j := 0; j < kernelLen && j <= i; j++ {
+						expected[i] += kernel[j] * stream[i-j]
+					}
+				}
+
+				got := make([]float64, len(stream))
+				blockLens := []int{1, 7, c.HopLen(), c.HopLen() + 5, 64, 2500}
+				for start, b := 0, 0; start < len(stream); b++ {
+					end := min(start+blockLens[b%len(blockLens)], len(stream))
+					c.Process(stream[start:end], got[start:end])
+					start = end
+				}
+
+				for i := range got {
+					if math.Abs(got[i]-expected[i]) > 1e-9*float64(kernelLen) {
+						t.Fatalf("[%d] got %v, want %v", i, got[i], expected[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestStreamingConvolverReset(t *testing.T) {
+	for _, method := range []StreamingMethod{OverlapSave, OverlapAdd} {
+		c := NewRealFftPlanner().PlanStreamingConvolver([]float64{1, 0.5, 0.25}, method)
+		first := make([]float64, 4)
+		c.Process([]float64{1, 2, 3, 4}, first)
+
+		c.Reset()
+		again := make([]float64, 4)
+		c.Process([]float64{1, 2, 3, 4}, again)
+		for i := range first {
+			if math.Abs(first[i]-again[i]) > 1e-12 {
+				t.Errorf("Method %d: after Reset got %v, want %v", method, again, first)
+				break
+			}
+		}
+	}
+}
+
+func TestStreamingConvolverAllocations(t *testing.T) {
+	kernel := make([]float64, 512)
+	kernel[0] = 1
+	for _, method := range []StreamingMethod{OverlapSave, OverlapAdd} {
+		c := NewRealFftPlanner().PlanStreamingConvolver(kernel, method)
+		in := make([]float64, 1000)
+		out := make([]float64, 1000)
+		if allocs := testing.AllocsPerRun(10, func() { c.Process(in, out) }); allocs != 0 {
+			t.Errorf("Method %d: Process allocated %v times, want 0", method, allocs)
+		}
+	}
+}
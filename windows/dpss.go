@@ -0,0 +1,230 @@
+package windows
+
+import (
+	"fmt"
+	"math"
+)
+
+// DPSS returns the first k discrete prolate spheroidal (Slepian) sequences
+// of length n with time-halfbandwidth product nw
+//
+// Taper i is the sequence with the i-th highest energy concentration in the
+// band |f| < nw/n, which makes the tapers the basis of multitaper spectral
+// estimation; about 2*nw of them are well concentrated. Each taper has unit
+// energy. Even tapers are symmetric with a positive sum, odd tapers are
+// antisymmetric and start positive, matching scipy.signal.windows.dpss.
+func DPSS(n int, nw float64, k int, sym Symmetry) []Window {
+	if n < 1 || k < 1 || k > n || nw <= 0 || nw >= float64(n)/2 {
+		panic(fmt.Sprintf("DPSS needs 1 <= k <= n and 0 < nw < n/2, got n = %d, nw = %g, k = %d", n, nw, k))
+	}
+	if sym == Periodic {
+		tapers := DPSS(n+1, nw, k, Symmetric)
+		for i := range tapers {
+			tapers[i] = tapers[i][:n]
+		}
+		return tapers
+	}
+
+	// The sequences are the eigenvectors of a symmetric tridiagonal matrix
+	// that commutes with the concentration problem (Slepian, 1978)
+	w := nw / float64(n)
+	diag := make([]float64, n)
+	off := make([]float64, n) // off[i] couples i-1 and i
+	for i := range diag {
+		c := (float64(n-1) - 2*float64(i)) / 2
+		diag[i] = c * c * math.Cos(2*math.Pi*w)
+		if i > 0 {
+			off[i] = float64(i*(n-i)) / 2
+		}
+	}
+
+	tapers := make([]Window, k)
+	for i := range tapers {
+		// The i-th taper belongs to the i-th largest eigenvalue
+		lambda := tridiagonalEigenvalue(diag, off, n-1-i)
+		tapers[i] = inverseIteration(diag, off, lambda, tapers[:i])
+		fixSign(tapers[i], i)
+	}
+	return tapers
+}
+
+// tridiagonalEigenvalue returns the eigenvalue with the given index, counting
+// from the smallest, of the symmetric tridiagonal matrix with diagonal diag and
+// off-diagonal off. It bisects on the Sturm sequence count.
+func tridiagonalEigenvalue(diag, off []float64, index int) float64 {
+	// Gershgorin bounds enclose every eigenvalue
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, d := range diag {
+		r := math.Abs(off[i])
+		if i+1 < len(off) {
+			r += math.Abs(off[i+1])
+		}
+		lo = math.Min(lo, d-r)
+		hi = math.Max(hi, d+r)
+	}
+
+	for iter := 0; iter < 200 && hi-lo > 1e-15*math.Max(1, math.Abs(lo)+math.Abs(hi)); iter++ {
+		mid := (lo + hi) / 2
+		if countBelow(diag, off, mid) > index {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// countBelow returns the number of eigenvalues smaller than x
+func countBelow(diag, off []float64, x float64) int {
+	count := 0
+	q := 1.0
+	for i, d := range diag {
+		if i == 0 {
+			q = d - x
+		} else {
+			q = d - x - off[i]*off[i]/q
+		}
+		if q == 0 {
+			q = 1e-300
+		}
+		if q < 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// inverseIteration returns the unit eigenvector for the eigenvalue lambda,
+// kept orthogonal to the eigenvectors found before it
+func inverseIteration(diag, off []float64, lambda float64, previous []Window) Window {
+	n := len(diag)
+	v := make(Window, n)
+	for i := range v {
+		v[i] = 1 + math.Sin(float64(i)+1)
+	}
+
+	// Shift slightly off the eigenvalue so the system stays solvable
+	shift := lambda + 1e-10*math.Max(1, math.Abs(lambda))
+	solver := newTridiagonalLU(diag, off, shift)
+	for iter := 0; iter < 8; iter++ {
+		solver.solve(v)
+		for _, p := range previous {
+			dot := 0.0
+			for i := range v {
+				dot += v[i] * p[i]
+			}
+			for i := range v {
+				v[i] -= dot * p[i]
+			}
+		}
+		norm := 0.0
+		for _, x := range v {
+			norm += x * x
+		}
+		norm = math.Sqrt(norm)
+		for i := range v {
+			v[i] /= norm
+		}
+	}
+	return v
+}
+
+// fixSign applies scipy's sign convention to taper number i
+func fixSign(taper Window, i int) {
+	if i%2 == 0 {
+		if sum(taper) < 0 {
+			negate(taper)
+		}
+		return
+	}
+	threshold := math.Max(1e-7, 1/float64(len(taper)))
+	for _, x := range taper {
+		if x*x > threshold {
+			if x < 0 {
+				negate(taper)
+			}
+			return
+		}
+	}
+}
+
+func negate(w Window) {
+	for i := range w {
+		w[i] = -w[i]
+	}
+}
+
+// tridiagonalLU is the LU factorization with partial pivoting of a shifted
+// symmetric tridiagonal matrix, as computed by LAPACK's dgttrf
+type tridiagonalLU struct {
+	d, du, du2, dl []float64
+	swapped        []bool
+}
+
+func newTridiagonalLU(diag, off []float64, shift float64) *tridiagonalLU {
+	n := len(diag)
+	lu := &tridiagonalLU{
+		d:       make([]float64, n),
+		du:      make([]float64, n),
+		du2:     make([]float64, n),
+		dl:      make([]float64, n),
+		swapped: make([]bool, n),
+	}
+	for i := range diag {
+		lu.d[i] = diag[i] - shift
+		if i+1 < n {
+			lu.du[i] = off[i+1]
+			lu.dl[i] = off[i+1]
+		}
+	}
+
+	for i := 0; i+1 < n; i++ {
+		if math.Abs(lu.d[i]) >= math.Abs(lu.dl[i]) {
+			// No row interchange
+			if lu.d[i] == 0 {
+				lu.d[i] = 1e-300
+			}
+			factor := lu.dl[i] / lu.d[i]
+			lu.dl[i] = factor
+			lu.d[i+1] -= factor * lu.du[i]
+		} else {
+			// Swap rows i and i+1
+			factor := lu.d[i] / lu.dl[i]
+			lu.d[i] = lu.dl[i]
+			lu.dl[i] = factor
+			temp := lu.du[i]
+			lu.du[i] = lu.d[i+1]
+			lu.d[i+1] = temp - factor*lu.d[i+1]
+			if i+2 < n {
+				lu.du2[i] = lu.du[i+1]
+				lu.du[i+1] = -factor * lu.du[i+1]
+			}
+			lu.swapped[i] = true
+		}
+	}
+	if lu.d[n-1] == 0 {
+		lu.d[n-1] = 1e-300
+	}
+	return lu
+}
+
+// solve overwrites b with the solution of the factored system
+func (lu *tridiagonalLU) solve(b []float64) {
+	n := len(b)
+	// Forward substitution with L, replaying the row interchanges
+	for i := 0; i+1 < n; i++ {
+		if lu.swapped[i] {
+			b[i], b[i+1] = b[i+1], b[i]-lu.dl[i]*b[i+1]
+		} else {
+			b[i+1] -= lu.dl[i] * b[i]
+		}
+	}
+	// Back substitution with U, which has two superdiagonals
+	b[n-1] /= lu.d[n-1]
+	if n > 1 {
+		b[n-2] = (b[n-2] - lu.du[n-2]*b[n-1]) / lu.d[n-2]
+	}
+	for i := n - 3; i >= 0; i-- {
+		b[i] = (b[i] - lu.du[i]*b[i+1] - lu.du2[i]*b[i+2]) / lu.d[i]
+	}
+}
@@ -0,0 +1,278 @@
+// Package windows generates window functions for spectral analysis and
+// filter design, and applies them to buffers before an FFT.
+//
+// Every window comes in a symmetric variant, for filter design, and a
+// periodic variant, for spectral analysis and STFTs. A periodic window of
+// length n is a symmetric window of length n+1 without its last sample, so
+// it tiles without a doubled endpoint when frames overlap. The exception is
+// KaiserBesselDerived, an MDCT window, which is always symmetric.
+package windows
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/10d9e/gofft"
+)
+
+// Symmetry selects between the symmetric and periodic variants of a window
+type Symmetry int
+
+const (
+	// Symmetric windows satisfy w[i] == w[n-1-i]
+	Symmetric Symmetry = iota
+
+	// Periodic windows are one period of a window repeating every n samples
+	Periodic
+)
+
+// Window holds the samples of a window function
+// It converts to []float64, so it can be passed anywhere a slice of samples is expected.
+type Window []float64
+
+// Hann returns the Hann (raised cosine) window
+func Hann(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.5, 0.5)
+}
+
+// Hamming returns the Hamming window, a raised cosine that cancels the first sidelobe
+func Hamming(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.54, 0.46)
+}
+
+// BlackmanHarris returns the minimum 4-term Blackman-Harris window, whose
+// sidelobes are below -92 dB
+func BlackmanHarris(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.35875, 0.48829, 0.14128, 0.01168)
+}
+
+// FlatTop returns a flat-top window, whose nearly flat main lobe makes the
+// amplitude of a sinusoid between bins accurate to about 0.01 dB
+// The coefficients are the ones used by scipy.signal.windows.flattop.
+func FlatTop(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.21557895, 0.41663158, 0.277263158, 0.083578947, 0.006947368)
+}
+
+// Kaiser returns the Kaiser window with shape parameter beta
+// Larger beta lowers the sidelobes and widens the main lobe; beta = 0 is
+// rectangular, and beta around 8.6 resembles Blackman-Harris.
+func Kaiser(n int, beta float64, sym Symmetry) Window {
+	w := make(Window, n)
+	m := span(n, sym)
+	if m == 0 {
+		fillOnes(w)
+		return w
+	}
+	norm := besselI0(beta)
+	for i := range w {
+		r := 2*float64(i)/float64(m) - 1
+		w[i] = besselI0(beta*math.Sqrt(math.Max(0, 1-r*r))) / norm
+	}
+	return w
+}
+
+// Tukey returns the Tukey (tapered cosine) window
+// alpha is the fraction of the window inside the cosine tapers: 0 gives a
+// rectangular window and 1 gives a Hann window.
+func Tukey(n int, alpha float64, sym Symmetry) Window {
+	w := make(Window, n)
+	m := span(n, sym)
+	if m == 0 || alpha <= 0 {
+		fillOnes(w)
+		return w
+	}
+	alpha = math.Min(alpha, 1)
+
+	// Each taper covers alpha*m/2 of the m intervals
+	taper := alpha * float64(m) / 2
+	for i := range w {
+		x := math.Min(float64(i), float64(m-i))
+		if x < taper {
+			w[i] = 0.5 - 0.5*math.Cos(math.Pi*x/taper)
+		} else {
+			w[i] = 1
+		}
+	}
+	return w
+}
+
+// Sine returns the sine window sin(π(i+1/2)/n), which scipy calls "cosine"
+// The symmetric variant satisfies the Princen-Bradley condition, so it is the
+// standard MDCT window of a block of n samples.
+func Sine(n int, sym Symmetry) Window {
+	if sym == Periodic {
+		return Sine(n+1, Symmetric)[:n]
+	}
+	w := make(Window, n)
+	for i := range w {
+		w[i] = math.Sin(math.Pi * (float64(i) + 0.5) / float64(n))
+	}
+	return w
+}
+
+// KaiserBesselDerived returns the Kaiser-Bessel-derived window of even length n
+// It is built from the running sum of a Kaiser window of length n/2+1 and
+// satisfies the Princen-Bradley condition, making it an MDCT window with
+// better stopband rejection than Sine. beta is π times the α of the AAC
+// and Vorbis specifications; AAC uses α = 4 for long blocks and 6 for short ones.
+func KaiserBesselDerived(n int, beta float64) Window {
+	if n%2 != 0 {
+		panic(fmt.Sprintf("KaiserBesselDerived needs an even length, got %d", n))
+	}
+	half := n / 2
+	kaiser := Kaiser(half+1, beta, Symmetric)
+
+	w := make(Window, n)
+	total := sum(kaiser)
+	running := 0.0
+	for i := 0; i < half; i++ {
+		running += kaiser[i]
+		w[i] = math.Sqrt(running / total)
+		w[n-1-i] = w[i]
+	}
+	return w
+}
+
+// cosineSum returns the window sum_k (-1)^k a_k cos(2πki/m) shared by the
+// Hann, Hamming, Blackman-Harris and flat-top windows
+func cosineSum(n int, sym Symmetry, coeffs ...float64) Window {
+	w := make(Window, n)
+	m := span(n, sym)
+	if m == 0 {
+		fillOnes(w)
+		return w
+	}
+	for i := range w {
+		sign := 1.0
+		for k, a := range coeffs {
+			w[i] += sign * a * math.Cos(2*math.Pi*float64(k*i)/float64(m))
+			sign = -sign
+		}
+	}
+	return w
+}
+
+// span returns the number of intervals the window's period is divided into:
+// n-1 for symmetric windows and n for periodic ones. It is 0 for windows of
+// fewer than two samples, which are all ones.
+func span(n int, sym Symmetry) int {
+	if n < 2 {
+		return 0
+	}
+	if sym == Periodic {
+		return n
+	}
+	return n - 1
+}
+
+func fillOnes(w Window) {
+	for i := range w {
+		w[i] = 1
+	}
+}
+
+// besselI0 evaluates the modified Bessel function of the first kind of order 0
+// The power series converges for every x; terms are added until they stop
+// changing the sum.
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	q := x * x / 4
+	for k := 1; k < 500; k++ {
+		term *= q / float64(k*k)
+		sum += term
+		if term < sum*1e-17 {
+			break
+		}
+	}
+	return sum
+}
+
+// CoherentGain returns the mean of the window, the factor by which it scales
+// the amplitude of a sinusoid centered on a bin
+func (w Window) CoherentGain() float64 {
+	if len(w) == 0 {
+		return 0
+	}
+	return sum(w) / float64(len(w))
+}
+
+// ENBW returns the equivalent noise bandwidth in bins: the width of the
+// rectangular filter that passes the same white noise power as the window
+// It is 1 for the rectangular window and 1.5 for a periodic Hann window.
+func (w Window) ENBW() float64 {
+	s := sum(w)
+	if s == 0 {
+		return math.Inf(1)
+	}
+	squares := 0.0
+	for _, v := range w {
+		squares += v * v
+	}
+	return float64(len(w)) * squares / (s * s)
+}
+
+func sum(w Window) float64 {
+	s := 0.0
+	for _, v := range w {
+		s += v
+	}
+	return s
+}
+
+// Float32 returns the window converted to float32
+func (w Window) Float32() []float32 {
+	w32 := make([]float32, len(w))
+	for i, v := range w {
+		w32[i] = float32(v)
+	}
+	return w32
+}
+
+// Apply multiplies x by the window in place
+// The length of x must be a multiple of the window length; every frame of
+// that length is windowed, matching the batches Fft.Process accepts.
+func (w Window) Apply(x []float64) {
+	w.validate(len(x))
+	for start := 0; start < len(x); start += len(w) {
+		for i, v := range w {
+			x[start+i] *= v
+		}
+	}
+}
+
+// ApplyComplex multiplies x by the window in place, frame by frame like Apply
+func (w Window) ApplyComplex(x []complex128) {
+	w.validate(len(x))
+	for start := 0; start < len(x); start += len(w) {
+		for i, v := range w {
+			x[start+i] *= complex(v, 0)
+		}
+	}
+}
+
+// Apply32 is the float32 counterpart of Apply
+func (w Window) Apply32(x []float32) {
+	w.validate(len(x))
+	for start := 0; start < len(x); start += len(w) {
+		for i, v := range w {
+			x[start+i] *= float32(v)
+		}
+	}
+}
+
+// ApplyComplex64 is the complex64 counterpart of ApplyComplex
+func (w Window) ApplyComplex64(x []complex64) {
+	w.validate(len(x))
+	for start := 0; start < len(x); start += len(w) {
+		for i, v := range w {
+			x[start+i] *= complex(float32(v), 0)
+		}
+	}
+}
+
+// validate panics unless a buffer of length n splits into whole frames
+func (w Window) validate(n int) {
+	if len(w) == 0 || n%len(w) != 0 {
+		panic(fmt.Errorf("%w: expected multiple of %d, got len = %d", gofft.ErrNotMultipleOfLen, len(w), n))
+	}
+}
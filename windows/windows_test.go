@@ -0,0 +1,223 @@
+package windows
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/10d9e/gofft"
+)
+
+func windowsEqual(a, b []float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKnownValues(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Window
+		want []float64
+	}{
+		{"Hann/Symmetric", Hann(5, Symmetric), []float64{0, 0.5, 1, 0.5, 0}},
+		{"Hann/Periodic", Hann(4, Periodic), []float64{0, 0.5, 1, 0.5}},
+		{"Hamming/Symmetric", Hamming(5, Symmetric), []float64{0.08, 0.54, 1, 0.54, 0.08}},
+		{"BlackmanHarris/Symmetric", BlackmanHarris(3, Symmetric), []float64{6e-5, 1, 6e-5}},
+		{"FlatTop/Symmetric", FlatTop(3, Symmetric), []float64{-0.000421051, 1.000000003, -0.000421051}},
+		{"Kaiser/Beta0", Kaiser(4, 0, Symmetric), []float64{1, 1, 1, 1}},
+		{"Kaiser/Beta5", Kaiser(3, 5, Symmetric), []float64{1 / besselI0(5), 1, 1 / besselI0(5)}},
+		{"Tukey/Alpha0", Tukey(4, 0, Symmetric), []float64{1, 1, 1, 1}},
+		{"Tukey/Alpha0.5", Tukey(9, 0.5, Symmetric), []float64{0, 0.5, 1, 1, 1, 1, 1, 0.5, 0}},
+		{"Single", Hann(1, Periodic), []float64{1}},
+		{"Empty", Hamming(0, Symmetric), []float64{}},
+	}
+	for _, tt := range tests {
+		if !windowsEqual(tt.got, tt.want, 1e-9) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if !windowsEqual(Tukey(64, 1, Periodic), Hann(64, Periodic), 1e-12) {
+		t.Errorf("Tukey with alpha 1 differs from Hann")
+	}
+	if !windowsEqual(Hann(16, Periodic), Hann(17, Symmetric)[:16], 1e-12) {
+		t.Errorf("periodic Hann isn't a truncated symmetric Hann")
+	}
+}
+
+func TestSymmetry(t *testing.T) {
+	for _, n := range []int{7, 8, 255, 256} {
+		for name, w := range map[string]Window{
+			"Hann":           Hann(n, Symmetric),
+			"Hamming":        Hamming(n, Symmetric),
+			"BlackmanHarris": BlackmanHarris(n, Symmetric),
+			"FlatTop":        FlatTop(n, Symmetric),
+			"Kaiser":         Kaiser(n, 8.6, Symmetric),
+			"Tukey":          Tukey(n, 0.3, Symmetric),
+		} {
+			for i := range w {
+				if math.Abs(w[i]-w[n-1-i]) > 1e-12 {
+					t.Fatalf("%s(%d): w[%d] = %v but w[%d] = %v", name, n, i, w[i], n-1-i, w[n-1-i])
+				}
+			}
+		}
+	}
+}
+
+// TestGains checks the textbook ENBW and coherent gain of long periodic windows
+func TestGains(t *testing.T) {
+	const n = 4096
+	tests := []struct {
+		name               string
+		w                  Window
+		enbw, coherentGain float64
+	}{
+		{"Rectangular", Tukey(n, 0, Periodic), 1, 1},
+		{"Hann", Hann(n, Periodic), 1.5, 0.5},
+		{"Hamming", Hamming(n, Periodic), 1.3628, 0.54},
+		{"BlackmanHarris", BlackmanHarris(n, Periodic), 2.0044, 0.35875},
+		{"FlatTop", FlatTop(n, Periodic), 3.7702, 0.21557895},
+	}
+	for _, tt := range tests {
+		if got := tt.w.ENBW(); math.Abs(got-tt.enbw) > 1e-3 {
+			t.Errorf("%s: ENBW %v, want %v", tt.name, got, tt.enbw)
+		}
+		if got := tt.w.CoherentGain(); math.Abs(got-tt.coherentGain) > 1e-6 {
+			t.Errorf("%s: coherent gain %v, want %v", tt.name, got, tt.coherentGain)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	w := Hann(4, Periodic)
+	x := []float64{1, 2, 3, 4, 1, 1, 1, 1}
+	w.Apply(x)
+	if !windowsEqual(x, []float64{0, 1, 3, 2, 0, 0.5, 1, 0.5}, 1e-12) {
+		t.Errorf("Apply: got %v", x)
+	}
+
+	c := []complex128{1 + 1i, 1 + 1i, 1 + 1i, 1 + 1i}
+	w.ApplyComplex(c)
+	if cmplx.Abs(c[1]-(0.5+0.5i)) > 1e-12 || cmplx.Abs(c[2]-(1+1i)) > 1e-12 {
+		t.Errorf("ApplyComplex: got %v", c)
+	}
+
+	x32 := []float32{2, 2, 2, 2}
+	w.Apply32(x32)
+	c64 := []complex64{2i, 2i, 2i, 2i}
+	w.ApplyComplex64(c64)
+	for i, v := range w.Float32() {
+		if x32[i] != 2*v || c64[i] != complex(0, 2*v) {
+			t.Errorf("float32 [%d]: got %v and %v, want %v", i, x32[i], c64[i], 2*v)
+		}
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, gofft.ErrNotMultipleOfLen) {
+			t.Errorf("expected a panic wrapping ErrNotMultipleOfLen, got %v", err)
+		}
+	}()
+	w.Apply(make([]float64, 6))
+}
+
+// TestDPSS checks that the tapers are orthonormal, follow scipy's sign
+// convention, and concentrate their energy in the band as they should
+func TestDPSS(t *testing.T) {
+	const n, nw, k = 128, 4.0, 7
+	tapers := DPSS(n, nw, k, Symmetric)
+	if len(tapers) != k {
+		t.Fatalf("got %d tapers, want %d", len(tapers), k)
+	}
+
+	for i, a := range tapers {
+		for j, b := range tapers {
+			dot := 0.0
+			for m := range a {
+				dot += a[m] * b[m]
+			}
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(dot-want) > 1e-9 {
+				t.Errorf("tapers %d and %d: dot product %v, want %v", i, j, dot, want)
+			}
+		}
+
+		// Even tapers are symmetric, odd ones antisymmetric
+		sign := 1.0
+		if i%2 == 1 {
+			sign = -1
+		}
+		for m := range a {
+			if math.Abs(a[m]-sign*a[n-1-m]) > 1e-9 {
+				t.Fatalf("taper %d isn't (anti)symmetric at %d", i, m)
+			}
+		}
+		if i%2 == 0 && sum(a) <= 0 {
+			t.Errorf("taper %d has a negative sum", i)
+		}
+	}
+
+	// Fraction of energy within |f| < nw/n, from the sinc kernel of the band
+	w := nw / n
+	var previous float64 = 1
+	for i, taper := range tapers {
+		concentration := 0.0
+		for a := 0; a < n; a++ {
+			for b := 0; b < n; b++ {
+				kernel := 2 * w
+				if a != b {
+					kernel = math.Sin(2*math.Pi*w*float64(a-b)) / (math.Pi * float64(a-b))
+				}
+				concentration += taper[a] * kernel * taper[b]
+			}
+		}
+		if concentration > previous+1e-12 {
+			t.Errorf("taper %d is more concentrated (%v) than the one before (%v)", i, concentration, previous)
+		}
+		if i == 0 && concentration < 0.9999999 {
+			t.Errorf("first taper only has %v of its energy in band", concentration)
+		}
+		previous = concentration
+	}
+
+	periodic := DPSS(n, nw, 2, Periodic)
+	if len(periodic[0]) != n || !windowsEqual(periodic[1], DPSS(n+1, nw, 2, Symmetric)[1][:n], 1e-12) {
+		t.Errorf("periodic DPSS isn't a truncated symmetric DPSS")
+	}
+}
+
+// TestPrincenBradley checks the condition that makes a window usable for the MDCT
+func TestPrincenBradley(t *testing.T) {
+	for _, n := range []int{4, 64, 2048} {
+		for name, w := range map[string]Window{
+			"Sine":                Sine(n, Symmetric),
+			"KaiserBesselDerived": KaiserBesselDerived(n, 4*math.Pi),
+		} {
+			for i := 0; i < n/2; i++ {
+				if got := w[i]*w[i] + w[i+n/2]*w[i+n/2]; math.Abs(got-1) > 1e-12 {
+					t.Fatalf("%s(%d): w[%d]² + w[%d]² = %v", name, n, i, i+n/2, got)
+				}
+				if math.Abs(w[i]-w[n-1-i]) > 1e-12 {
+					t.Fatalf("%s(%d) isn't symmetric at %d", name, n, i)
+				}
+			}
+		}
+	}
+
+	if !windowsEqual(Sine(3, Symmetric), []float64{0.5, 1, 0.5}, 1e-12) {
+		t.Errorf("Sine(3): got %v", Sine(3, Symmetric))
+	}
+	if !windowsEqual(KaiserBesselDerived(4, 0), []float64{math.Sqrt(1.0 / 3), math.Sqrt(2.0 / 3), math.Sqrt(2.0 / 3), math.Sqrt(1.0 / 3)}, 1e-12) {
+		t.Errorf("KaiserBesselDerived(4, 0): got %v", KaiserBesselDerived(4, 0))
+	}
+}
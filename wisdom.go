@@ -0,0 +1,290 @@
+package gofft
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+
+	"github.com/10d9e/gofft/algorithm"
+)
+
+// wisdomVersion is bumped whenever the wisdom format changes incompatibly
+const wisdomVersion = 1
+
+// wisdomFile is the JSON layout written by ExportWisdom
+type wisdomFile struct {
+	Version     int               `json:"version"`
+	Fingerprint wisdomFingerprint `json:"fingerprint"`
+	Plans       []wisdomRecipe    `json:"plans"`
+}
+
+// wisdomFingerprint identifies the platform the wisdom was measured on
+// OS, Arch, Precision and Kernels must match on import. Host is informational
+// only, so wisdom measured on one machine can be shipped to identical ones.
+type wisdomFingerprint struct {
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Precision string `json:"precision"`
+	Kernels   string `json:"kernels"`
+	Host      string `json:"host,omitempty"`
+}
+
+// wisdomRecipe is the serialized form of a recipe tree
+type wisdomRecipe struct {
+	Kind   string        `json:"kind"`
+	Length int           `json:"length"`
+	Inner  *wisdomRecipe `json:"inner,omitempty"`
+	Width  *wisdomRecipe `json:"width,omitempty"`
+	Height *wisdomRecipe `json:"height,omitempty"`
+}
+
+// ExportWisdom writes every recipe the planner has chosen so far as JSON
+// Together with a Measure planner this lets services ship pre-tuned plans:
+// measure once, export, and ImportWisdom at startup instead of re-measuring.
+func (p *Planner) ExportWisdom(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return exportWisdom(w, p.recipeCache, "complex128")
+}
+
+// ImportWisdom loads recipes written by ExportWisdom
+// Imported recipes replace any the planner designed itself, but FFTs that
+// were already planned keep their algorithm. Wisdom from a different OS,
+// architecture, precision or set of SIMD kernels is rejected with
+// ErrWisdomMismatch.
+func (p *Planner) ImportWisdom(r io.Reader) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return importWisdom(r, p.recipeCache, "complex128")
+}
+
+// ExportWisdom writes every recipe the planner has chosen so far as JSON
+func (p *Planner32) ExportWisdom(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return exportWisdom(w, p.recipeCache, "complex64")
+}
+
+// ImportWisdom loads recipes written by Planner32.ExportWisdom
+func (p *Planner32) ImportWisdom(r io.Reader) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return importWisdom(r, p.recipeCache, "complex64")
+}
+
+// currentFingerprint describes the platform this process runs on
+func currentFingerprint(precision string) wisdomFingerprint {
+	host, _ := os.Hostname()
+	return wisdomFingerprint{
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Precision: precision,
+		Kernels:   wisdomKernels(),
+		Host:      host,
+	}
+}
+
+// wisdomKernels names the butterfly kernels in use, "avx2" or "go"
+// The planner picks different algorithms for some sizes when the AVX2 kernels
+// are available, so wisdom measured with them doesn't apply without them.
+func wisdomKernels() string {
+	if algorithm.AVX2Enabled() {
+		return "avx2"
+	}
+	return "go"
+}
+
+func exportWisdom(w io.Writer, recipeCache map[int]*recipe, precision string) error {
+	// Length 0 is planned the same way everywhere, and ImportWisdom rejects it
+	lengths := make([]int, 0, len(recipeCache))
+	for length := range recipeCache {
+		if length > 0 {
+			lengths = append(lengths, length)
+		}
+	}
+	sort.Ints(lengths)
+
+	file := wisdomFile{
+		Version:     wisdomVersion,
+		Fingerprint: currentFingerprint(precision),
+		Plans:       make([]wisdomRecipe, len(lengths)),
+	}
+	for i, length := range lengths {
+		file.Plans[i] = *encodeRecipe(recipeCache[length])
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(file)
+}
+
+func importWisdom(r io.Reader, recipeCache map[int]*recipe, precision string) error {
+	var file wisdomFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWisdom, err)
+	}
+	if file.Version != wisdomVersion {
+		return fmt.Errorf("%w: version %d, want %d", ErrInvalidWisdom, file.Version, wisdomVersion)
+	}
+
+	want := currentFingerprint(precision)
+	got := file.Fingerprint
+	if got.OS != want.OS || got.Arch != want.Arch || got.Precision != want.Precision || got.Kernels != want.Kernels {
+		return fmt.Errorf("%w: wisdom is for %s/%s %s with %s kernels, this is %s/%s %s with %s kernels", ErrWisdomMismatch,
+			got.OS, got.Arch, got.Precision, got.Kernels, want.OS, want.Arch, want.Precision, want.Kernels)
+	}
+
+	// Decode everything before touching the cache, so bad wisdom leaves it unchanged
+	plans := make([]*recipe, len(file.Plans))
+	for i := range file.Plans {
+		r, err := decodeRecipe(&file.Plans[i])
+		if err != nil {
+			return err
+		}
+		plans[i] = r
+	}
+
+	for _, r := range plans {
+		recipeCache[r.length] = r
+	}
+	// Sub-recipes fill in sizes the wisdom has no top-level plan for
+	for _, r := range plans {
+		cacheSubRecipes(recipeCache, r)
+	}
+	return nil
+}
+
+// cacheSubRecipes adds the sub-recipes of r to the cache unless a recipe for their length exists
+func cacheSubRecipes(recipeCache map[int]*recipe, r *recipe) {
+	for _, sub := range []*recipe{r.inner, r.width, r.height} {
+		if sub == nil {
+			continue
+		}
+		if _, ok := recipeCache[sub.length]; !ok {
+			recipeCache[sub.length] = sub
+		}
+		cacheSubRecipes(recipeCache, sub)
+	}
+}
+
+func encodeRecipe(r *recipe) *wisdomRecipe {
+	if r == nil {
+		return nil
+	}
+	return &wisdomRecipe{
+		Kind:   r.kind.String(),
+		Length: r.length,
+		Inner:  encodeRecipe(r.inner),
+		Width:  encodeRecipe(r.width),
+		Height: encodeRecipe(r.height),
+	}
+}
+
+// decodeRecipe converts a serialized recipe back, rejecting any that would fail to build
+func decodeRecipe(w *wisdomRecipe) (*recipe, error) {
+	kind, ok := recipeKindByName(w.Kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown algorithm %q", ErrInvalidWisdom, w.Kind)
+	}
+	if w.Length < 0 {
+		return nil, fmt.Errorf("%w: %s has negative length %d", ErrInvalidWisdom, w.Kind, w.Length)
+	}
+
+	r := &recipe{kind: kind, length: w.Length}
+	var err error
+	if w.Inner != nil {
+		if r.inner, err = decodeRecipe(w.Inner); err != nil {
+			return nil, err
+		}
+	}
+	if w.Width != nil {
+		if r.width, err = decodeRecipe(w.Width); err != nil {
+			return nil, err
+		}
+	}
+	if w.Height != nil {
+		if r.height, err = decodeRecipe(w.Height); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s of length %d: %v", ErrInvalidWisdom, w.Kind, w.Length, err)
+	}
+	return r, nil
+}
+
+// recipeKindByName looks up a recipe kind by its String name
+func recipeKindByName(name string) (recipeKind, bool) {
+	for kind, n := range recipeKindNames {
+		if n == name {
+			return recipeKind(kind), true
+		}
+	}
+	return 0, false
+}
+
+// butterflyLens maps each butterfly recipe to the only length it supports
+var butterflyLens = map[recipeKind]int{
+	recipeButterfly2: 2, recipeButterfly3: 3, recipeButterfly4: 4, recipeButterfly5: 5,
+	recipeButterfly6: 6, recipeButterfly7: 7, recipeButterfly8: 8, recipeButterfly9: 9,
+	recipeButterfly10: 10, recipeButterfly11: 11, recipeButterfly12: 12, recipeButterfly13: 13,
+	recipeButterfly14: 14, recipeButterfly15: 15, recipeButterfly16: 16, recipeButterfly17: 17,
+	recipeButterfly19: 19, recipeButterfly20: 20, recipeButterfly23: 23, recipeButterfly24: 24,
+	recipeButterfly25: 25, recipeButterfly27: 27, recipeButterfly29: 29, recipeButterfly31: 31,
+	recipeButterfly32: 32, recipeButterfly36: 36, recipeButterfly64: 64,
+}
+
+// validate checks that r's algorithm supports its length and sub-recipes
+func (r *recipe) validate() error {
+	needsInner := r.kind == recipeRaders
+	needsFactors := r.kind == recipeMixedRadix || r.kind == recipeGoodThomas || r.kind == recipeFourStep
+	if (r.inner != nil) != needsInner || (r.width != nil) != needsFactors || (r.height != nil) != needsFactors {
+		return fmt.Errorf("wrong sub-recipes")
+	}
+
+	switch {
+	case r.isButterfly():
+		if r.length != butterflyLens[r.kind] {
+			return fmt.Errorf("butterfly only supports length %d", butterflyLens[r.kind])
+		}
+	case r.kind == recipeRadix4 || r.kind == recipeRadix8 || r.kind == recipeStockham:
+		if !isPowerOfTwo(r.length) {
+			return fmt.Errorf("length must be a power of two")
+		}
+	case r.kind == recipeDft:
+		if r.length < 1 {
+			return fmt.Errorf("length must be positive")
+		}
+	case r.kind == recipeRadixN:
+		if r.length < 1 {
+			return fmt.Errorf("length must be positive")
+		}
+		if !canUseRadixN(r.length) {
+			return fmt.Errorf("length must only have factors 2-7")
+		}
+	case r.kind == recipeRaders:
+		if !ComputePrimeFactors(r.length).IsPrime() || r.inner.length != r.length-1 {
+			return fmt.Errorf("length must be prime with an inner FFT of length-1")
+		}
+	case r.kind == recipeBluestein:
+		if r.length < 1 {
+			return fmt.Errorf("length must be positive")
+		}
+	case needsFactors:
+		// Zero-length factors multiply out fine but divide by zero when planned
+		if r.length < 1 || r.width.length < 1 || r.height.length < 1 {
+			return fmt.Errorf("length and factors must be positive")
+		}
+		if r.width.length*r.height.length != r.length {
+			return fmt.Errorf("factors %d x %d don't multiply to the length", r.width.length, r.height.length)
+		}
+		if r.kind == recipeGoodThomas && GCD(r.width.length, r.height.length) != 1 {
+			return fmt.Errorf("factors %d and %d aren't coprime", r.width.length, r.height.length)
+		}
+	}
+	return nil
+}
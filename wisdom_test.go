@@ -0,0 +1,142 @@
+package gofft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWisdomRoundTrip(t *testing.T) {
+	sizes := []int{16, 64, 97, 352, 1000, 1031, 2431}
+
+	// A fake clock makes the measured choices differ from the estimates,
+	// so the test can tell whether the wisdom was applied
+	timer := func(r *recipe) time.Duration {
+		if r.kind == recipeBluestein {
+			return time.Microsecond
+		}
+		return time.Millisecond
+	}
+	source := NewPlanner()
+	for _, n := range sizes {
+		designRecipe(source.recipeCache, n, timer)
+	}
+	// Length 0 isn't exported, since ImportWisdom would reject it
+	source.PlanForward(0)
+
+	var wisdom bytes.Buffer
+	if err := source.ExportWisdom(&wisdom); err != nil {
+		t.Fatalf("ExportWisdom: %v", err)
+	}
+
+	planner := NewPlanner()
+	if err := planner.ImportWisdom(bytes.NewReader(wisdom.Bytes())); err != nil {
+		t.Fatalf("ImportWisdom: %v", err)
+	}
+
+	for _, n := range sizes {
+		t.Run(fmt.Sprintf("Size%d", n), func(t *testing.T) {
+			want := source.recipeCache[n]
+			got := planner.recipeCache[n]
+			if got == nil || !sameRecipeTree(got, want) {
+				t.Fatalf("imported recipe doesn't match the exported one")
+			}
+
+			input := make([]complex128, n)
+			for i := range input {
+				input[i] = complex(float64(i%7), float64(i%5)*0.3)
+			}
+			expected := naiveDFT(input, true)
+			planner.PlanForward(n).Process(input)
+			if !complexSlicesEqual(input, expected, 1e-8*float64(n)) {
+				t.Errorf("plan built from wisdom doesn't match DFT")
+			}
+		})
+	}
+
+	// The format is stable, so exporting again gives the same bytes
+	var again bytes.Buffer
+	if err := planner.ExportWisdom(&again); err != nil {
+		t.Fatalf("ExportWisdom: %v", err)
+	}
+	if again.String() != wisdom.String() {
+		t.Errorf("re-exported wisdom differs:\n%s\nwant:\n%s", again.String(), wisdom.String())
+	}
+}
+
+func TestWisdomPlanner32(t *testing.T) {
+	source := NewPlanner32()
+	source.PlanForward(2431)
+
+	var wisdom bytes.Buffer
+	if err := source.ExportWisdom(&wisdom); err != nil {
+		t.Fatalf("ExportWisdom: %v", err)
+	}
+	if !strings.Contains(wisdom.String(), `"precision": "complex64"`) {
+		t.Errorf("complex64 wisdom isn't marked as such:\n%s", wisdom.String())
+	}
+
+	if err := NewPlanner32().ImportWisdom(bytes.NewReader(wisdom.Bytes())); err != nil {
+		t.Errorf("ImportWisdom: %v", err)
+	}
+	if err := NewPlanner().ImportWisdom(bytes.NewReader(wisdom.Bytes())); !errors.Is(err, ErrWisdomMismatch) {
+		t.Errorf("complex128 planner accepted complex64 wisdom: %v", err)
+	}
+}
+
+func TestImportWisdomErrors(t *testing.T) {
+	header := fmt.Sprintf(`{"version": 1, "fingerprint": {"os": %q, "arch": %q, "precision": "complex128", "kernels": %q}, "plans": `,
+		runtime.GOOS, runtime.GOARCH, wisdomKernels())
+	otherKernels := strings.Replace(header, fmt.Sprintf("%q}", wisdomKernels()), `"sse"}`, 1)
+
+	tests := []struct {
+		name   string
+		wisdom string
+		want   error
+	}{
+		{"NotJSON", "wisdom", ErrInvalidWisdom},
+		{"Version", `{"version": 99}`, ErrInvalidWisdom},
+		{"OtherArch", `{"version": 1, "fingerprint": {"os": "plan9", "arch": "mips", "precision": "complex128"}}`, ErrWisdomMismatch},
+		{"OtherKernels", otherKernels + `[]}`, ErrWisdomMismatch},
+		{"UnknownKind", header + `[{"kind": "Magic", "length": 8}]}`, ErrInvalidWisdom},
+		{"ButterflyLength", header + `[{"kind": "Butterfly8", "length": 9}]}`, ErrInvalidWisdom},
+		{"Radix4Length", header + `[{"kind": "Radix4", "length": 96}]}`, ErrInvalidWisdom},
+		{"RadersComposite", header + `[{"kind": "Raders", "length": 10, "inner": {"kind": "Butterfly9", "length": 9}}]}`, ErrInvalidWisdom},
+		{"MissingFactors", header + `[{"kind": "MixedRadix", "length": 121}]}`, ErrInvalidWisdom},
+		{"WrongProduct", header + `[{"kind": "MixedRadix", "length": 120, "width": {"kind": "Butterfly11", "length": 11},
+			"height": {"kind": "Butterfly11", "length": 11}}]}`, ErrInvalidWisdom},
+		{"NotCoprime", header + `[{"kind": "GoodThomas", "length": 121, "width": {"kind": "Butterfly11", "length": 11},
+			"height": {"kind": "Butterfly11", "length": 11}}]}`, ErrInvalidWisdom},
+		{"FourStepZero", header + `[{"kind": "FourStep", "length": 0, "width": {"kind": "Dft", "length": 0},
+			"height": {"kind": "Dft", "length": 0}}]}`, ErrInvalidWisdom},
+		{"GoodThomasZero", header + `[{"kind": "GoodThomas", "length": 0, "width": {"kind": "Dft", "length": 0},
+			"height": {"kind": "Dft", "length": 1}}]}`, ErrInvalidWisdom},
+		{"DftZero", header + `[{"kind": "Dft", "length": 0}]}`, ErrInvalidWisdom},
+		{"Valid", header + `[{"kind": "MixedRadix", "length": 121, "width": {"kind": "Butterfly11", "length": 11},
+			"height": {"kind": "Butterfly11", "length": 11}}]}`, nil},
+	}
+
+	for _, tt := range tests {
+		planner := NewPlanner()
+		err := planner.ImportWisdom(strings.NewReader(tt.wisdom))
+		if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+		if tt.want != nil && len(planner.recipeCache) != 0 {
+			t.Errorf("%s: rejected wisdom modified the planner", tt.name)
+		}
+	}
+}
+
+// sameRecipeTree compares two recipe trees structurally
+func sameRecipeTree(a, b *recipe) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.kind == b.kind && a.length == b.length &&
+		sameRecipeTree(a.inner, b.inner) && sameRecipeTree(a.width, b.width) && sameRecipeTree(a.height, b.height)
+}